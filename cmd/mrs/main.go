@@ -35,6 +35,12 @@ import (
 // AllLanguages to load all language models at once
 const AllLanguages = "ALL"
 
+// unbanSchedule is how often expired temporary bans are lifted and expired used action tokens are forgotten
+const unbanSchedule = "*/10 * * * *"
+
+// policiesSchedule is how often policy lists are pulled
+const policiesSchedule = "0 * * * *"
+
 var (
 	configPath string
 	runGenKey  bool
@@ -62,6 +68,7 @@ var (
 //	@securityDefinitions.basic	DiscoveryAuth
 //	@securityDefinitions.basic	ModerationAuth
 //	@securityDefinitions.basic	AdminAuth
+//	@securityDefinitions.basic	WorkersAuth
 
 // @securityDefinitions.apikey	FederationAuth
 // @in							header
@@ -70,12 +77,12 @@ var (
 func main() {
 	quit := make(chan struct{})
 	flag.StringVar(&configPath, "c", "config.yml", "Path to the config file")
-	flag.BoolVar(&runGenKey, "genkey", false, "Generate matrix signing key")
+	flag.BoolVar(&runGenKey, "genkey", false, "Generate matrix signing key and secret")
 	flag.Parse()
 
 	cfg, err := services.NewConfig(configPath)
 	if err != nil {
-		log.Fatal().Err(err).Msg("cannot read config")
+		apm.Log().Fatal().Err(err).Msg("cannot read config")
 	}
 
 	apm.SetName("MRS")
@@ -96,10 +103,21 @@ func main() {
 		if _, err := generateKey(); err != nil {
 			log.Fatal().Err(err).Msg("cannot generate key")
 		}
+		if _, err := generateSecret(); err != nil {
+			log.Fatal().Err(err).Msg("cannot generate secret")
+		}
 		return
 	}
 
 	msc1929.UserAgent = version.UserAgent
+	if flag.Arg(0) == "worker" {
+		runWorker(cfg)
+		return
+	}
+	if err := cfg.EnsureSecret(apm.NewContext()); err != nil {
+		log.Fatal().Err(err).Msg("cannot generate secret, set one in the config (see -genkey)")
+	}
+
 	dataRepo, err = data.New(cfg.Get().Path.Data)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot open data repo")
@@ -127,15 +145,19 @@ func main() {
 	crawlerSvc := services.NewCrawler(cfg, matrixSvc, validatorSvc, blockSvc, media, dataRepo, detector)
 	matrixSvc.SetDiscover(crawlerSvc.AddServer)
 	cacheSvc := services.NewCache(cfg, statsSvc)
-	dataSvc := services.NewDataFacade(crawlerSvc, indexSvc, statsSvc)
 	mailSvc := services.NewEmail(cfg)
-	modSvc := services.NewModeration(cfg, dataRepo, media, index, mailSvc, matrixSvc)
+	modSvc := services.NewModeration(cfg, dataRepo, media, index, blockSvc, mailSvc, matrixSvc)
+	dataSvc := services.NewDataFacade(crawlerSvc, indexSvc, statsSvc, modSvc)
+	if err := modSvc.LoadBanRules(apm.NewContext()); err != nil {
+		log.Error().Err(err).Msg("cannot load ban rules")
+	}
+	go modSvc.ImportPolicies(apm.NewContext())
 
 	e = echo.New()
 	e.Logger = lecho.From(*log)
 	controllers.ConfigureRouter(e, cfg, matrixSvc, dataSvc, cacheSvc, searchSvc, crawlerSvc, statsSvc, modSvc, plausibleSvc)
 
-	initCron(cfg, dataSvc)
+	initCron(cfg, dataSvc, modSvc)
 	initShutdown(quit)
 
 	if err := e.Start(cfg.Get().Address + ":" + cfg.Get().Port); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -156,6 +178,18 @@ func generateKey() (string, error) {
 	return key, nil
 }
 
+// generateSecret generates a random secret for reporter hashes and moderation links
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(secret)
+	log.Warn().Str("secret", encoded).Msg("ATTENTION! A new secret has been generated")
+
+	return encoded, nil
+}
+
 func getLanguageDetector(inputLangs []string) lingua.LanguageDetector {
 	builder := lingua.NewLanguageDetectorBuilder()
 	if len(inputLangs) > 0 && inputLangs[0] == AllLanguages {
@@ -193,7 +227,7 @@ func initShutdown(quit chan struct{}) {
 	}()
 }
 
-func initCron(cfg *services.Config, dataSvc *services.DataFacade) {
+func initCron(cfg *services.Config, dataSvc *services.DataFacade, modSvc *services.Moderation) {
 	ctx := apm.NewContext()
 	cron = crontab.New(crontab.WithPanicHandler(func(spec string, recovered any) {
 		log.Error().Str("spec", spec).Any("recover", recovered).Msg("cron job panicked")
@@ -218,6 +252,12 @@ func initCron(cfg *services.Config, dataSvc *services.DataFacade) {
 		dw, pw := cfg.Get().Workers.Discovery, cfg.Get().Workers.Parsing
 		cron.MustAddJob(schedule, func() { dataSvc.Full(ctx, dw, pw) })
 	}
+	// not configurable: temporary bans are a promise, keep it
+	cron.MustAddJob(unbanSchedule, func() {
+		modSvc.UnbanExpired(ctx)
+		modSvc.RemoveExpiredActionTokens(ctx)
+	})
+	cron.MustAddJob(policiesSchedule, func() { modSvc.ImportPolicies(ctx) })
 }
 
 func shutdown() {
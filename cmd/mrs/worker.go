@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/etkecc/go-apm"
+
+	"github.com/etkecc/mrs/internal/services"
+	"github.com/etkecc/mrs/internal/services/matrix"
+)
+
+// runWorker runs `mrs worker`: a stateless crawler processing batches of the coordinator,
+// no storage, no index, no http server
+func runWorker(cfg *services.Config) {
+	media, err := services.NewMedia(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot open media repo")
+	}
+	detector := getLanguageDetector(cfg.Get().Languages)
+	blockSvc := services.NewBlocklist(cfg)
+	// the worker only sends federation requests, nothing it answers needs the data repo or the search
+	matrixSvc, err := matrix.NewServer(cfg, nil, media, nil, blockSvc)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot start matrix service")
+	}
+	validatorSvc := services.NewValidator(cfg, blockSvc, matrixSvc)
+	workerSvc := services.NewWorker(cfg, matrixSvc, validatorSvc, blockSvc, media, detector)
+
+	ctx, cancel := signal.NotifyContext(apm.NewContext(), os.Interrupt, syscall.SIGABRT, syscall.SIGHUP, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	defer cancel()
+	workerSvc.Run(ctx)
+
+	log.Info().Msg("shutting down...")
+	apm.Flush()
+	if hc != nil {
+		hc.ExitStatus(0)
+	}
+}
@@ -91,6 +91,98 @@ const docTemplate = `{
                 }
             }
         },
+        "/-/refresh/room/{room_id_or_alias}": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Re-reads a single room with an MSC3266 room summary via its server (or the fallback servers for a room we do not know yet), stores it, and updates the search index right away. A room that must not be indexed anymore (a noindex room config in its topic, a blocked server, etc.) is removed instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Refresh a room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID or alias",
+                        "name": "room_id_or_alias",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Room refreshed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixRoom"
+                        }
+                    },
+                    "202": {
+                        "description": "Room refreshed, but quarantined (or held back by a flag rule), so kept out of the index",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixRoom"
+                        }
+                    },
+                    "404": {
+                        "description": "The room's summary is not available",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "410": {
+                        "description": "The room must not be indexed anymore, removed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
+        "/-/refresh/server/{name}": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Re-discovers a single server and re-parses its public rooms right away, then updates the search index with them (flag rules included, as in indexing), so a room admin's fix shows up in minutes instead of after the next full cycle. Unlike /-/parse, this waits for the crawl, so expect it to take a while for servers with thousands of rooms. Rooms that vanished from the server's directory are left to the retention policy.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Refresh a server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Server name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Server refreshed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RefreshServerResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Server is offline (or blocked), marked offline",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RefreshServerResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/-/reindex": {
             "post": {
                 "security": [
@@ -113,6 +205,71 @@ const docTemplate = `{
                 }
             }
         },
+        "/-/reset/backoff/{name}": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Forgets the failure streak of a federation destination, so the next outgoing call (version, publicRooms, directory, keys, thumbnails) knocks on it again instead of short-circuiting. Use it when a server you know is back keeps getting skipped.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reset destination backoff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination server name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Backoff reset"
+                    },
+                    "404": {
+                        "description": "The destination is not backing off",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
+        "/-/seeds": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "How the last discovery pass went for each configured seed source: how many server names it listed, and how many of them were new to us (not in the config, the db, or an earlier source). Empty until the first discovery pass after startup.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Seed sources stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.SeedStats"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/-/status": {
             "get": {
                 "security": [
@@ -481,6 +638,164 @@ const docTemplate = `{
                 }
             }
         },
+        "/_matrix/federation/unstable/cc.etke.mrs/peering/rooms": {
+            "get": {
+                "security": [
+                    {
+                        "FederationAuth": []
+                    }
+                ],
+                "description": "MRS-to-MRS peering feed: rooms we parsed after ` + "`" + `since` + "`" + `, in room ID order, so another instance can bootstrap its index without a full crawl and then keep up with the changes. Banned rooms and blocked servers are left out. Same auth rules as the server catalog.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matrix-s2s"
+                ],
+                "summary": "Peering: room deltas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp (ms), only rooms parsed after it. 0 for everything",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination token from a previous next_batch",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max rooms per page, 1000 at most",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of rooms",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.PeerRoomsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Federation auth failed (missing or invalid X-Matrix signature)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "403": {
+                        "description": "The origin is not allowed to peer with us",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
+        "/_matrix/federation/unstable/cc.etke.mrs/peering/servers": {
+            "get": {
+                "security": [
+                    {
+                        "FederationAuth": []
+                    }
+                ],
+                "description": "MRS-to-MRS peering feed: the servers we consider online, so another instance can seed its discovery from our crawl. Signed like any federation request, and only instances listed in our peering.allow config get an answer, everybody else a 403.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matrix-s2s"
+                ],
+                "summary": "Peering: server catalog",
+                "responses": {
+                    "200": {
+                        "description": "Online servers",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.PeerServersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Federation auth failed (missing or invalid X-Matrix signature)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "403": {
+                        "description": "The origin is not allowed to peer with us",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
+        "/_matrix/federation/v1/hierarchy/{roomID}": {
+            "get": {
+                "security": [
+                    {
+                        "FederationAuth": []
+                    }
+                ],
+                "description": "Expands an indexed public space into its children, from the relations our crawler collected. Authenticated federation endpoint, so a missing or invalid X-Matrix signature is a 401. Children we never indexed (or banned) land in inaccessible_children instead of being made up. The spec'd endpoint has no pagination, so limit and from are ours: a client that ignores next_batch still gets the first 100 children, which is more than most spaces have.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matrix-s2s"
+                ],
+                "summary": "Space hierarchy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Space room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only consider children marked as suggested",
+                        "name": "suggested_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max children per page, 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination token from a previous next_batch",
+                        "name": "from",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The space, its children, and the children we cannot vouch for",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.SpaceHierarchyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Federation auth failed (missing or invalid X-Matrix signature)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not an indexed public space",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
         "/_matrix/federation/v1/publicRooms": {
             "get": {
                 "security": [
@@ -908,6 +1223,34 @@ const docTemplate = `{
                 }
             }
         },
+        "/catalog/policies": {
+            "get": {
+                "security": [
+                    {
+                        "CatalogAuth": []
+                    }
+                ],
+                "description": "Banned rooms and server ban rules of this instance as m.policy.rule.room and m.policy.rule.server state events with the m.ban recommendation, the same shape as a policy room's state. Another MRS instance can import it as a policy list, a bot can copy it into a policy room. Alias and text ban rules are regexes, not globs, so they are not included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Bans as a policy list",
+                "responses": {
+                    "200": {
+                        "description": "Policy rule events",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.PolicyEvent"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/catalog/rooms": {
             "get": {
                 "security": [
@@ -992,6 +1335,95 @@ const docTemplate = `{
                 }
             }
         },
+        "/coordinator/lease": {
+            "post": {
+                "security": [
+                    {
+                        "WorkersAuth": []
+                    }
+                ],
+                "description": "Internal API for ` + "`" + `mrs worker` + "`" + ` processes. Hands out a batch of servers of the running discovery or parsing. The worker has ` + "`" + `coordinator.lease` + "`" + ` seconds to send the results back, after that the servers are handed out again. A server is out with one worker at a time. Only works with ` + "`" + `coordinator.enabled` + "`" + `, otherwise there is never anything to hand out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coordinator"
+                ],
+                "summary": "Lease a crawl batch",
+                "responses": {
+                    "200": {
+                        "description": "Batch to process",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.CrawlBatch"
+                        }
+                    },
+                    "204": {
+                        "description": "Nothing to do right now, ask again later"
+                    },
+                    "401": {
+                        "description": "Invalid Workers credentials"
+                    }
+                }
+            }
+        },
+        "/coordinator/results/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "WorkersAuth": []
+                    }
+                ],
+                "description": "Internal API for ` + "`" + `mrs worker` + "`" + ` processes. Stores results of a leased batch. Only the results of the batch's own servers are kept: their servers and the rooms their directories list, anything else is dropped. Late results of an expired lease are still accepted while the run lasts, except for servers another worker has returned in the meantime; once the run is over, they are refused.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coordinator"
+                ],
+                "summary": "Submit crawl batch results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Batch results",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.CrawlResults"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Results stored"
+                    },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid Workers credentials"
+                    },
+                    "404": {
+                        "description": "Unknown batch, or the run is over",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
         "/discover/bulk": {
             "post": {
                 "security": [
@@ -1113,117 +1545,132 @@ const docTemplate = `{
                 }
             }
         },
-        "/mod/ban/{room_id}": {
-            "get": {
+        "/mod/accept-appeal/{room_id}": {
+            "post": {
                 "security": [
                     {
                         "ModerationAuth": []
                     }
                 ],
-                "description": "Bans a room from the index. Yes, it is a GET that mutates state, we know, and no, we are not proud of it. And a User-Agent containing \"bot\" gets a 403 even with valid credentials, a scar from crawlers tripping this, not a feature. Both are real behavior, documented on purpose so they surprise you here and not in production.",
+                "description": "Accepts the room's pending appeal: the room is unbanned (the audit log records an unban), and the decision with the reason is emailed to the contact who filed the appeal. A POST, and a \"bot\" User-Agent gets a 403 even authenticated.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "moderation"
                 ],
-                "summary": "Ban a room",
+                "summary": "Accept an appeal",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Room ID to ban",
+                        "description": "Room ID of the appeal",
                         "name": "room_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason, sent to the contact and recorded in the audit log",
+                        "name": "reason",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Confirmation message",
+                        "description": "The decided appeal",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.Appeal"
                         }
                     },
                     "403": {
-                        "description": "User-Agent contains 'bot'"
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    },
+                    "409": {
+                        "description": "The room has no pending appeal",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
                     }
                 }
             }
         },
-        "/mod/list": {
+        "/mod/appeal/verify/{token}": {
             "get": {
-                "security": [
-                    {
-                        "ModerationAuth": []
-                    }
-                ],
-                "description": "Lists banned room IDs. Append /{server_name} to filter to a single server. An empty list is a 204, not an empty 200. As with the rest of the mod group, a \"bot\" User-Agent gets a 403 even authenticated.",
+                "description": "Opens the verification step of an appeal, the link from the email sent to the room's contact. Opening it changes nothing, mail scanners are welcome.",
                 "produces": [
-                    "application/json"
+                    "text/html"
                 ],
                 "tags": [
                     "moderation"
                 ],
-                "summary": "List banned rooms",
+                "summary": "Open an appeal verification link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed verification token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Banned room IDs",
+                        "description": "Verification form"
+                    },
+                    "403": {
+                        "description": "Invalid token",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
                         }
                     },
-                    "204": {
-                        "description": "No banned rooms"
-                    },
-                    "403": {
-                        "description": "User-Agent contains 'bot'"
+                    "410": {
+                        "description": "Expired or used token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
                     }
                 }
-            }
-        },
-        "/mod/list-reported": {
-            "get": {
-                "security": [
-                    {
-                        "ModerationAuth": []
-                    }
-                ],
-                "description": "Lists reported rooms as a room-ID to reason map. Append /{server_name} to filter to a single server. Empty is a 204. A \"bot\" User-Agent gets a 403 even authenticated.",
+            },
+            "post": {
+                "description": "Verifies the appeal of the token and sends it to moderators, through the same webhook and email as reports. A token works once. A \"bot\" User-Agent gets a 403.",
                 "produces": [
-                    "application/json"
+                    "text/html"
                 ],
                 "tags": [
                     "moderation"
                 ],
-                "summary": "List reported rooms",
+                "summary": "Verify an appeal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed verification token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Room ID to report reason",
+                        "description": "Appeal verified"
+                    },
+                    "403": {
+                        "description": "Invalid token, or User-Agent contains 'bot'",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
                         }
                     },
-                    "204": {
-                        "description": "No reported rooms"
-                    },
-                    "403": {
-                        "description": "User-Agent contains 'bot'"
+                    "410": {
+                        "description": "Expired or used token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
                     }
                 }
             }
         },
-        "/mod/report/{room_id}": {
+        "/mod/appeal/{room_id}": {
             "post": {
-                "description": "Report a room for moderation. Open on purpose, no auth: anyone can flag a room, that is the whole point. The JSON body carries ` + "`" + `reason` + "`" + ` (5 chars minimum, or you get a 400) and an optional ` + "`" + `no_msc1929` + "`" + ` flag. This is the one honest, no-auth POST in the moderation set.",
+                "description": "Appeals the ban of a room, for room admins who have no other way to reach moderators. No auth, the room's contact is: ` + "`" + `email` + "`" + ` must be the room's config email (as of the ban) or an MSC1929 email of the room's server, and the appeal goes nowhere until the verification link emailed there is opened and confirmed. The response is a 202 either way, so the endpoint tells nobody whether the room is banned or which addresses are its contacts. A room has one open appeal at a time, another one is quietly ignored until the first is decided or its link expires.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1233,37 +1680,37 @@ const docTemplate = `{
                 "tags": [
                     "moderation"
                 ],
-                "summary": "Report a room",
+                "summary": "Appeal a room ban",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Room ID being reported",
+                        "description": "Banned room ID",
                         "name": "room_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Report reason (5 chars minimum) and options",
+                        "description": "Contact email and reason",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.reportSubmission"
+                            "$ref": "#/definitions/internal_controllers.appealSubmission"
                         }
                     }
                 ],
                 "responses": {
                     "202": {
-                        "description": "Report accepted"
+                        "description": "Appeal accepted for verification"
                     },
                     "400": {
-                        "description": "Invalid room ID, or reason too short or missing",
+                        "description": "Invalid room ID or email, or reason too short, too long, or missing",
                         "schema": {
                             "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
                         }
                     },
                     "500": {
-                        "description": "Internal error while processing the report",
+                        "description": "Internal error while processing the appeal",
                         "schema": {
                             "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
                         }
@@ -1271,580 +1718,2307 @@ const docTemplate = `{
                 }
             }
         },
-        "/mod/unban/{room_id}": {
+        "/mod/audit": {
             "get": {
                 "security": [
                     {
                         "ModerationAuth": []
                     }
                 ],
-                "description": "Lifts a room ban. Same shape as ban: a state-changing GET, and a \"bot\" User-Agent gets a 403 even authenticated. Real behavior, owned.",
+                "description": "Who banned, unbanned, or cleared reports of what, when, and why, newest first. Every filter is optional and they combine. 100 entries by default, use /mod/audit/export for everything. A \"bot\" User-Agent gets a 403 even authenticated.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "moderation"
                 ],
-                "summary": "Unban a room",
+                "summary": "Moderation audit log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Room ID to unban",
-                        "name": "room_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Moderator login",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action: ban, unban, or unreport",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Room ID",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Server of the room",
+                        "name": "server",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max entries, 100 by default",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Confirmation message",
+                        "description": "Audit log entries",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.AuditEntry"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
                     "403": {
-                        "description": "User-Agent contains 'bot'"
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
                     }
                 }
             }
         },
-        "/mod/unreport/{room_id}": {
+        "/mod/audit/export": {
             "get": {
                 "security": [
                     {
                         "ModerationAuth": []
                     }
                 ],
-                "description": "Clears reports on a room. Two warts, both owned: it is a state-changing GET, and a User-Agent containing \"bot\" gets a 403 even with valid moderation credentials, a scar from crawlers tripping these endpoints, not a feature. There is also a no-room-id form (GET /mod/unreport) that hits the same handler with an empty ID.",
+                "description": "The whole audit log (or the filtered part of it) as a JSON Lines download, one entry per line, newest first. Takes the same filters as /mod/audit, except limit. A \"bot\" User-Agent gets a 403 even authenticated.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "moderation"
                 ],
-                "summary": "Clear a room's reports",
+                "summary": "Export the moderation audit log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Room ID to clear reports for",
-                        "name": "room_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "Reports cleared"
+                        "description": "Moderator login",
+                        "name": "actor",
+                        "in": "query"
                     },
-                    "403": {
-                        "description": "User-Agent contains 'bot'"
-                    }
-                }
-            }
-        },
-        "/room/{room_id_or_alias}": {
-            "get": {
-                "description": "Room preview by ID or alias: like the Matrix client-server room preview, but enriched with everything MRS knows about a room (language and so on). We try our own index first, then fall back to a live MSC3266 summary, and when that fallback fires we set the ` + "`" + `X-MRS-MSC3266: true` + "`" + ` response header so you can tell. Marked EXPERIMENT in the source, so treat the shape as not yet frozen.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "catalog"
-                ],
-                "summary": "Room preview",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Room ID or alias",
-                        "name": "room_id_or_alias",
-                        "in": "path",
-                        "required": true
+                        "description": "Action: ban, unban, or unreport",
+                        "name": "action",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Server to try for the MSC3266 fallback if we do not have the room indexed",
-                        "name": "via",
+                        "description": "Room ID",
+                        "name": "target",
                         "in": "query"
-                    }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Server of the room",
+                        "name": "server",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp",
+                        "name": "until",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Room data",
+                        "description": "One entry per line",
                         "schema": {
-                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixRoom"
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.AuditEntry"
                         }
                     },
-                    "404": {
-                        "description": "Room not found anywhere we looked",
+                    "400": {
+                        "description": "Invalid filter",
                         "schema": {
                             "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
                         }
                     },
-                    "500": {
-                        "description": "Internal error reading the room",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
-                        }
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
                     }
                 }
             }
         },
-        "/search": {
-            "get": {
-                "description": "Full-text room search. Pass the query and options as query params here: ?q= (query), ?l= (limit), ?o= (offset), ?s= (sort), ?rt= (room type). The same handler also answers a positional path form for convenience, /search/{q}, /search/{q}/{l}, and so on up to /search/{q}/{l}/{o}/{s}/{rt}, filling those five slots left to right. An empty result set is a 204, not an empty 200.",
+        "/mod/ban/{room_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Bans a room from the index, for good or, with ` + "`" + `duration` + "`" + `, until the ban expires and is lifted automatically. The category defaults to the most severe category of the room's reports. It used to be a GET, and link previewers used to ban rooms, so now it's a POST and report notifications link to /mod/confirm instead. A User-Agent containing \"bot\" still gets a 403 even with valid credentials, a scar from those days, documented on purpose so it surprises you here and not in production.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "moderation"
                 ],
-                "summary": "Search rooms",
+                "summary": "Ban a room",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search query",
-                        "name": "q",
-                        "in": "query"
+                        "description": "Room ID to ban",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "l",
+                        "type": "string",
+                        "description": "Reason, recorded in the ban and the audit log",
+                        "name": "reason",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "o",
+                        "type": "string",
+                        "description": "Category: spam, harassment, illegal, csam, or other",
+                        "name": "category",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Sort field",
-                        "name": "s",
+                        "description": "Temporary ban duration, e.g., 7d or 12h",
+                        "name": "duration",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Confirmation message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category or duration",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
                     },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            }
+        },
+        "/mod/confirm/{token}": {
+            "get": {
+                "description": "Opens the confirmation step of a moderation action link from a report notification. The link carries a signed, short-lived token of the action and the room, and opening it changes nothing, so link previewers and mail scanners can prefetch it all they want. The token is the auth, no moderation credentials needed.",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Confirm a moderation action",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Room type filter",
-                        "name": "rt",
-                        "in": "query"
+                        "description": "Signed action token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Matching rooms",
+                        "description": "Confirmation form"
+                    },
+                    "403": {
+                        "description": "Invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "410": {
+                        "description": "Expired or used token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Performs the action of the token: ban, unban, quarantine, release, or unreport (dismiss). A token works once, even across restarts. The audit log records the recipients of the link (the moderation webhook and email) and the confirming IP as the actor. A \"bot\" User-Agent gets a 403.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Perform a confirmed moderation action",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed action token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason, recorded in the audit log",
+                        "name": "reason",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Action performed"
+                    },
+                    "403": {
+                        "description": "Invalid token, or User-Agent contains 'bot'",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "410": {
+                        "description": "Expired or used token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
+        "/mod/flags": {
+            "get": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Stats of the flag rules from the config during the last indexing: how many rooms each rule matched and the first of them. Refreshes (/-/refresh) count into the totals only. Dry-run rules are counted the same way, that's the point of the dry run. Rules act on rooms when they are written to the index, by an indexing or a refresh (parsing alone does not run them): flag reports the room for review (see /mod/list-reported), quarantine hides it, reject removes it. A room a moderator has released or unreported is left alone. A \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Flag rules",
+                "responses": {
+                    "200": {
+                        "description": "Flag rules",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.Entry"
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.FlagStats"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            }
+        },
+        "/mod/list": {
+            "get": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Lists ban records of the banned rooms: reason, category, moderator, and expiry of temporary bans. Bans made before bans had records only carry the room ID. Append /{server_name} to filter to a single server. An empty list is a 204, not an empty 200. As with the rest of the mod group, a \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "List banned rooms",
+                "responses": {
+                    "200": {
+                        "description": "Ban records",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomBan"
                             }
                         }
                     },
                     "204": {
-                        "description": "No matches"
+                        "description": "No banned rooms"
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
                     }
                 }
             }
         },
-        "/stats": {
+        "/mod/list-appeals": {
             "get": {
-                "description": "Live index counts: servers found online and indexable, rooms indexed and parsed, plus the server software breakdown (how much of the federation is Synapse, basically). Timeline is present only once we have history behind us; on a fresh index the key is absent, not an empty array.",
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Lists the latest appeal of each room, pending first. Unverified appeals are listed too, last, they may be anyone's attempt; ` + "`" + `status` + "`" + ` narrows the list down. Append /{server_name} to filter to a single server. Empty is a 204. A \"bot\" User-Agent gets a 403 even authenticated.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Crawler statistics",
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "List appeals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status: unverified, pending, accepted, or rejected",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Appeals",
                         "schema": {
-                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.StatsResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.Appeal"
+                            }
                         }
+                    },
+                    "204": {
+                        "description": "No appeals"
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_etkecc_mrs_internal_model.ClientVersions": {
-            "type": "object",
-            "properties": {
-                "unstable_features": {
-                    "description": "unstable MSCs we actually honor",
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "boolean"
+        },
+        "/mod/list-quarantined": {
+            "get": {
+                "security": [
+                    {
+                        "ModerationAuth": []
                     }
-                },
-                "versions": {
-                    "description": "advertised client-server spec versions",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+                ],
+                "description": "Lists quarantine records of the quarantined rooms. Append /{server_name} to filter to a single server. Empty is a 204. A \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "List quarantined rooms",
+                "responses": {
+                    "200": {
+                        "description": "Quarantine records",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomQuarantine"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No quarantined rooms"
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
                     }
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.Entry": {
-            "type": "object",
-            "properties": {
-                "alias": {
-                    "type": "string"
-                },
-                "avatar": {
-                    "type": "string"
-                },
-                "avatar_url": {
-                    "type": "string"
+        "/mod/list-reported": {
+            "get": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Lists reported rooms with their reports (one per reporter), highest priority first: the priority is the sum of the reports' category weights, so a single CSAM report outranks a pile of spam ones. Append /{server_name} to filter to a single server. Empty is a 204. A \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "List reported rooms",
+                "responses": {
+                    "200": {
+                        "description": "Reported rooms, most urgent first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomReports"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No reported rooms"
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            }
+        },
+        "/mod/policies": {
+            "get": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Stats of the last import of each policy list from the config: how many events each had and how many of them are room and server bans MRS applies. A list that failed to import keeps its previous rules, the error says why. A \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Policy lists",
+                "responses": {
+                    "200": {
+                        "description": "Policy lists",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.PolicyStats"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            }
+        },
+        "/mod/quarantine/{room_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Hides a room from search and federation directory results, but keeps its data, so you can look at it calmly, then release it or escalate to a ban. The next parsing doesn't bring it back. Same shape as ban: a POST, and a \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Quarantine a room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID to quarantine",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason, recorded in the quarantine and the audit log",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Confirmation message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            }
+        },
+        "/mod/reject-appeal/{room_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Rejects the room's pending appeal, the room stays banned. The reason is required, it's emailed to the contact who filed the appeal. A POST, and a \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Reject an appeal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID of the appeal",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason, sent to the contact and recorded in the audit log",
+                        "name": "reason",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The decided appeal",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.Appeal"
+                        }
+                    },
+                    "400": {
+                        "description": "Reason is missing",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    },
+                    "409": {
+                        "description": "The room has no pending appeal",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
+        "/mod/release/{room_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Lifts the quarantine and puts the room back into the index right away. To escalate instead, ban it, the ban replaces the quarantine. A POST, and a \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Release a room from quarantine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID to release",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason, recorded in the audit log",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Confirmation message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            }
+        },
+        "/mod/report/{room_id}": {
+            "post": {
+                "description": "Report a room for moderation. Open on purpose, no auth: anyone can flag a room, that is the whole point. The JSON body carries ` + "`" + `reason` + "`" + ` (5 chars minimum, or you get a 400), an optional ` + "`" + `category` + "`" + ` (spam, harassment, illegal, csam, or other, the default) and an optional ` + "`" + `no_msc1929` + "`" + ` flag. Every reporter counts towards the room's priority, but only the first report, and a report of a more severe category than the room had so far, notify moderators. This is the one honest, no-auth POST in the moderation set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Report a room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID being reported",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report reason (5 chars minimum) and options",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.reportSubmission"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Report accepted"
+                    },
+                    "400": {
+                        "description": "Invalid room ID or category, or reason too short or missing",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error while processing the report",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
+        "/mod/rules": {
+            "get": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Lists moderators' ban rules: rooms of a server, rooms with aliases matching a regex, or rooms with names or topics matching a regex, banned at crawl and query time. Unlike blocklist.servers in the config, these need no file edit. A \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "List ban rules",
+                "responses": {
+                    "200": {
+                        "description": "Ban rules",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.BanRule"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Stores the rule and applies it right away: matching rooms are removed from the index now, and are not crawled or shown in the results from now on. Returns what was removed, same as the preview. A \"bot\" User-Agent gets a 403 even authenticated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Add a ban rule",
+                "parameters": [
+                    {
+                        "description": "Ban rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.banRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The rule (with its ID) and the rooms it removed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.BanRulePreview"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rule",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            }
+        },
+        "/mod/rules/preview": {
+            "post": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Shows how many indexed rooms the rule would remove (and the first of them) without applying it. Look before you leap: a text regex is one typo away from emptying the index. A \"bot\" User-Agent gets a 403 even authenticated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Preview a ban rule",
+                "parameters": [
+                    {
+                        "description": "Ban rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.banRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rooms the rule would remove",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.BanRulePreview"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rule",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            }
+        },
+        "/mod/rules/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Removes the rule. Rooms it removed are not restored right away, they come back with the next parsing. A \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Remove a ban rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason, recorded in the audit log",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The removed rule",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.BanRule"
+                        }
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    },
+                    "404": {
+                        "description": "No such rule",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
+        "/mod/unban/{room_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Lifts a room ban. Same shape as ban: a POST, and a \"bot\" User-Agent gets a 403 even authenticated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Unban a room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID to unban",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason, recorded in the audit log",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Confirmation message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            }
+        },
+        "/mod/unreport/{room_id}": {
+            "post": {
+                "security": [
+                    {
+                        "ModerationAuth": []
+                    }
+                ],
+                "description": "Clears reports on a room. A User-Agent containing \"bot\" gets a 403 even with valid moderation credentials, a scar from crawlers tripping these endpoints back when they were GETs, not a feature. There is also a no-room-id form (POST /mod/unreport) that hits the same handler with an empty ID and clears every report.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Clear a room's reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID to clear reports for",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason, recorded in the audit log",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Reports cleared"
+                    },
+                    "403": {
+                        "description": "User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+                    }
+                }
+            }
+        },
+        "/room-config/check": {
+            "post": {
+                "description": "Shows how MRS understands a room config block, e.g. ` + "`" + `(MRS-language:EN|email:admin@example.com|noindex:false-MRS)` + "`" + `, before you wait a day for the next crawl to find out. Send either a topic as is, or a room ID or alias to fetch its live topic with an MSC3266 room summary. You get the parsed config, the topic as it will be indexed, a warning for each part that is ignored (unknown keys, unknown languages, invalid emails, etc.), and the language MRS would assign to the room, from the config or detected (` + "`" + `-` + "`" + ` if detection is not sure). A noindex room gets no language, it will not be indexed at all.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Check a room config",
+                "parameters": [
+                    {
+                        "description": "Topic or room to check",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomConfigCheckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "How MRS understands the room config",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomConfigCheck"
+                        }
+                    },
+                    "400": {
+                        "description": "Neither topic nor room is set",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "404": {
+                        "description": "The room's summary is not available"
+                    },
+                    "429": {
+                        "description": "Rate limited"
+                    }
+                }
+            }
+        },
+        "/room/{room_id_or_alias}": {
+            "get": {
+                "description": "Room preview by ID or alias: like the Matrix client-server room preview, but enriched with everything MRS knows about a room (language, space relations, whether its alias really resolves to it, and so on). We try our own index first, then fall back to a live MSC3266 summary, and when that fallback fires we set the ` + "`" + `X-MRS-MSC3266: true` + "`" + ` response header so you can tell. Marked EXPERIMENT in the source, so treat the shape as not yet frozen.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Room preview",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID or alias",
+                        "name": "room_id_or_alias",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Server to try for the MSC3266 fallback if we do not have the room indexed",
+                        "name": "via",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Room data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixRoom"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found anywhere we looked",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error reading the room",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
+        "/room/{room_id_or_alias}/history": {
+            "get": {
+                "description": "What we have seen of a room over time: when it first showed up in the index, the last name, topic, and alias changes, and one member count per day. Only rooms we index have one, so no MSC3266 fallback here. The history starts when this feature was deployed, so first_seen of old rooms is a lower bound, not a birthday.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Room history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID or alias",
+                        "name": "room_id_or_alias",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Room history",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomHistory"
+                        }
+                    },
+                    "404": {
+                        "description": "Room is not indexed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error reading the history",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Full-text room search. Pass the query and options as query params here: ?q= (query), ?l= (limit), ?o= (offset), ?s= (sort), ?rt= (room type). The same handler also answers a positional path form for convenience, /search/{q}, /search/{q}/{l}, and so on up to /search/{q}/{l}/{o}/{s}/{rt}, filling those five slots left to right. An empty result set is a 204, not an empty 200.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search rooms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "l",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "o",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field, e.g. -members, or -first_seen for the newest rooms (works with an empty query too)",
+                        "name": "s",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Room type filter",
+                        "name": "rt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching rooms",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.Entry"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No matches"
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Live index counts: servers found online and indexable, rooms indexed and parsed, plus the server software breakdown (how much of the federation is Synapse, basically). Timeline is present only once we have history behind us; on a fresh index the key is absent, not an empty array.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Crawler statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.StatsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trending": {
+            "get": {
+                "description": "Rooms that gained the most members over a window, computed after each parsing from one member count sample per day. Only rooms that actually grew are listed, so a quiet week may be a 204.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Trending rooms",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Window in days, one of the configured ones (7 and 30 by default), the first configured one if omitted",
+                        "name": "w",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "l",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "o",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trending rooms",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.TrendingEntry"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No room grew over the window"
+                    },
+                    "400": {
+                        "description": "Window is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_etkecc_mrs_internal_model.Appeal": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "decision": {
+                    "description": "moderator's reason, sent to the contact",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "the contact who filed the appeal, the decision goes there",
+                    "type": "string"
+                },
+                "moderator": {
+                    "description": "actor of the decision, as in the audit log",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "room_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "unverified, pending, accepted, or rejected",
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "description": "login of the moderator, ip:address if the moderation auth has no login, or link:recipients (ip:address) of a confirmed action link",
+                    "type": "string"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "previous": {
+                    "description": "state of the target right before the action",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.AuditState"
+                        }
+                    ]
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "target": {
+                    "description": "room ID, or kind:pattern of a ban rule",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.AuditState": {
+            "type": "object",
+            "properties": {
+                "ban": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomBan"
+                },
+                "banned": {
+                    "type": "boolean"
+                },
+                "category": {
+                    "description": "the most severe category of the reports",
+                    "type": "string"
+                },
+                "quarantine": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomQuarantine"
+                },
+                "report": {
+                    "description": "reason of the latest report",
+                    "type": "string"
+                },
+                "reported": {
+                    "type": "boolean"
+                },
+                "reports": {
+                    "description": "number of reporters",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.BanRule": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "same categories as reports",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "moderator": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.BanRulePreview": {
+            "type": "object",
+            "properties": {
+                "rooms": {
+                    "description": "number of matching rooms",
+                    "type": "integer"
+                },
+                "rule": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.BanRule"
+                },
+                "sample": {
+                    "description": "IDs of the first matching rooms",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.ClientVersions": {
+            "type": "object",
+            "properties": {
+                "unstable_features": {
+                    "description": "unstable MSCs we actually honor",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "versions": {
+                    "description": "advertised client-server spec versions",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.CrawlBatch": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "discovery",
+                        "parsing"
+                    ]
+                },
+                "policies": {
+                    "description": "Policies of the batch servers that publish one, parsing only",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixServerPolicy"
+                    }
+                },
+                "servers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.CrawlResults": {
+            "type": "object",
+            "properties": {
+                "discovered": {
+                    "description": "parsing: servers the rooms are reachable via",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "leased": {
+                    "description": "discovery: the batch's name of each of servers, in the same order (the server may call itself differently)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rooms": {
+                    "description": "parsing: parsed rooms",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixRoom"
+                    }
+                },
+                "servers": {
+                    "description": "discovery: every server of the batch, online or not",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixServer"
+                    }
+                },
+                "spaces": {
+                    "description": "parsing: space ID =\u003e its children",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixSpaceChild"
+                        }
+                    }
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.Entry": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "avatar": {
+                    "type": "string"
+                },
+                "avatar_url": {
+                    "type": "string"
+                },
+                "first_seen": {
+                    "description": "FirstSeen is when the room was indexed for the first time, sort by -first_seen for new rooms",
+                    "type": "string"
+                },
+                "guest_can_join": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "join_rule": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "network": {
+                    "description": "\"matrix\" for native rooms, third-party network name for bridged ones",
+                    "type": "string"
+                },
+                "parents": {
+                    "description": "Parents are IDs of spaces listing the room, indexed for the space: filter only",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "room_type": {
+                    "type": "string"
+                },
+                "server": {
+                    "description": "server of origin",
+                    "type": "string"
+                },
+                "servers": {
+                    "description": "comma-separated list of servers",
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "world_readable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.FlagStats": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "at": {
+                    "description": "when the indexing has finished",
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "hits": {
+                    "description": "matching rooms",
+                    "type": "integer"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "sample": {
+                    "description": "IDs of the first matching rooms",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "skipped": {
+                    "description": "Skipped rule matched more rooms than retention.max_removal allows, nothing was done about them",
+                    "type": "boolean"
+                },
+                "total": {
+                    "description": "matching rooms of all indexings since MRS started",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.IndexStats": {
+            "type": "object",
+            "properties": {
+                "discovery": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.IndexStatsTime"
+                },
+                "indexing": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.IndexStatsTime"
+                },
+                "parsing": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.IndexStatsTime"
+                },
+                "rooms": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.IndexStatsRooms"
+                },
+                "servers": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.IndexStatsServers"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.IndexStatsRooms": {
+            "type": "object",
+            "properties": {
+                "banned": {
+                    "type": "integer"
+                },
+                "indexed": {
+                    "type": "integer"
                 },
-                "guest_can_join": {
-                    "type": "boolean"
+                "parsed": {
+                    "type": "integer"
                 },
-                "id": {
+                "reported": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.IndexStatsServers": {
+            "type": "object",
+            "properties": {
+                "blocked": {
+                    "type": "integer"
+                },
+                "indexable": {
+                    "type": "integer"
+                },
+                "online": {
+                    "type": "integer"
+                },
+                "software": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.IndexStatsTime": {
+            "type": "object",
+            "properties": {
+                "finished_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.MatrixError": {
+            "type": "object",
+            "properties": {
+                "errcode": {
+                    "description": "Matrix error code, e.g M_UNAUTHORIZED",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Matrix error message",
+                    "type": "string"
+                },
+                "retry_after_ms": {
+                    "description": "RetryAfterMs is set on M_LIMIT_EXCEEDED, either by the remote body or from the Retry-After header",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.MatrixRoom": {
+            "type": "object",
+            "properties": {
+                "alias_verification": {
+                    "description": "AliasVerification is the result of resolving the room's canonical alias when it was parsed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixRoomAlias"
+                        }
+                    ]
+                },
+                "avatar_url": {
+                    "type": "string"
+                },
+                "avatar_url_http": {
+                    "type": "string"
+                },
+                "canonical_alias": {
+                    "type": "string"
+                },
+                "children": {
+                    "description": "children of the space, if the room is one",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixSpaceChild"
+                    }
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_seen": {
+                    "description": "carried over from the stored room on every parse, see RoomHistory",
                     "type": "string"
                 },
+                "guest_can_join": {
+                    "type": "boolean"
+                },
                 "join_rule": {
                     "type": "string"
                 },
                 "language": {
                     "type": "string"
                 },
-                "members": {
+                "name": {
+                    "type": "string"
+                },
+                "network": {
+                    "description": "third-party network the room is bridged from, empty for native matrix rooms",
+                    "type": "string"
+                },
+                "num_joined_members": {
                     "type": "integer"
                 },
-                "name": {
+                "parents": {
+                    "description": "Space relations, not stored with the room but looked up from the crawled hierarchies",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "parsed_at": {
+                    "type": "string"
+                },
+                "room_id": {
                     "type": "string"
                 },
                 "room_type": {
                     "type": "string"
                 },
                 "server": {
-                    "description": "server of origin",
+                    "description": "Parsed (custom) fields",
                     "type": "string"
                 },
                 "servers": {
-                    "description": "comma-separated list of servers",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "world_readable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.MatrixRoomAlias": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "room_id": {
+                    "description": "what the alias resolved to, differs from the room's ID on mismatch",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "verified, mismatch, dead, or unverified",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.MatrixServer": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "contacts": {
+                    "description": "Contacts as per MSC1929",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixServerContacts"
+                        }
+                    ]
+                },
+                "indexable": {
+                    "description": "Is the server published the public room directory over federation?",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "ServerName, as per spec, e.g., \"example.com\"",
+                    "type": "string"
+                },
+                "online": {
+                    "description": "Is the server online and federating?",
+                    "type": "boolean"
+                },
+                "online_at": {
+                    "description": "OnlineAt is the prune clock: last seen online, the sole basis for the 30d offline delete. Never bumped on an offline dial.\nCheckedAt is the backoff clock: last dial attempt, bumped every dial. Merge them and dead servers reset the prune clock and go immortal.",
+                    "type": "string"
+                },
+                "policy": {
+                    "description": "Indexing policy as per /.well-known/matrix/mrs",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixServerPolicy"
+                        }
+                    ]
+                },
+                "policy_stale": {
+                    "description": "PolicyStale is set if the policy could not be fetched (timeout, 5xx), so the stored one is kept instead of a nil one",
+                    "type": "boolean"
+                },
+                "software": {
+                    "description": "Software running on the server, e.g., Synapse, Dendrite",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Server-Server API URL, e.g., \"https://example.com:8448\"",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version of the software, e.g., 1.0.0",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.MatrixServerContacts": {
+            "type": "object",
+            "properties": {
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mxids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.MatrixServerPolicy": {
+            "type": "object",
+            "properties": {
+                "crawl_interval": {
+                    "description": "hours, don't parse the server's rooms more often than that, e.g. 24 for daily; capped, see Interval",
+                    "type": "integer"
+                },
+                "language": {
+                    "description": "default language of the rooms, ISO 639-1, used instead of detection",
+                    "type": "string"
+                },
+                "noindex": {
+                    "description": "don't index any room of the server",
+                    "type": "boolean"
+                },
+                "noindex_avatars": {
+                    "description": "index the rooms, but not their avatars",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.MatrixSpaceChild": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "type": "string"
+                },
+                "origin_server_ts": {
+                    "type": "integer"
+                },
+                "room_id": {
+                    "type": "string"
+                },
+                "sender": {
+                    "type": "string"
+                },
+                "suggested": {
+                    "type": "boolean"
+                },
+                "via": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.PeerRoomsResponse": {
+            "type": "object",
+            "properties": {
+                "next_batch": {
+                    "type": "string"
+                },
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixRoom"
+                    }
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.PeerServersResponse": {
+            "type": "object",
+            "properties": {
+                "servers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.PolicyContent": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "description": "room ID, alias, or server name, globs allowed",
                     "type": "string"
                 },
-                "topic": {
+                "reason": {
                     "type": "string"
                 },
-                "type": {
+                "recommendation": {
                     "type": "string"
-                },
-                "world_readable": {
-                    "type": "boolean"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.IndexStats": {
+        "github_com_etkecc_mrs_internal_model.PolicyEvent": {
             "type": "object",
             "properties": {
-                "discovery": {
-                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.IndexStatsTime"
-                },
-                "indexing": {
-                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.IndexStatsTime"
+                "content": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.PolicyContent"
                 },
-                "parsing": {
-                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.IndexStatsTime"
-                },
-                "rooms": {
-                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.IndexStatsRooms"
+                "state_key": {
+                    "type": "string"
                 },
-                "servers": {
-                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.IndexStatsServers"
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.IndexStatsRooms": {
+        "github_com_etkecc_mrs_internal_model.PolicyStats": {
             "type": "object",
             "properties": {
-                "banned": {
-                    "type": "integer"
+                "at": {
+                    "type": "string"
                 },
-                "indexed": {
-                    "type": "integer"
+                "error": {
+                    "description": "why the import failed, the source keeps its previous rules then",
+                    "type": "string"
                 },
-                "parsed": {
+                "events": {
+                    "description": "events in the list",
                     "type": "integer"
                 },
-                "reported": {
+                "rules": {
+                    "description": "of them, room and server bans",
                     "type": "integer"
+                },
+                "skipped": {
+                    "description": "of the rules, type:entity of those matching too many rooms to apply, see ConfigRetention.MaxRemoval",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.IndexStatsServers": {
+        "github_com_etkecc_mrs_internal_model.QueryDirectoryResponse": {
             "type": "object",
             "properties": {
-                "blocked": {
-                    "type": "integer"
-                },
-                "indexable": {
-                    "type": "integer"
-                },
-                "online": {
-                    "type": "integer"
+                "room_id": {
+                    "type": "string"
                 },
-                "software": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "integer"
+                "servers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.IndexStatsTime": {
+        "github_com_etkecc_mrs_internal_model.QueryServerKeysRequest": {
             "type": "object",
             "properties": {
-                "finished_at": {
-                    "type": "string"
-                },
-                "started_at": {
-                    "type": "string"
+                "server_keys": {
+                    "type": "object",
+                    "additionalProperties": {}
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.MatrixError": {
+        "github_com_etkecc_mrs_internal_model.RefreshServerResponse": {
             "type": "object",
             "properties": {
-                "errcode": {
-                    "description": "Matrix error code, e.g M_UNAUTHORIZED",
-                    "type": "string"
+                "rooms": {
+                    "description": "IDs of the refreshed rooms, now in the index",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "error": {
-                    "description": "Matrix error message",
-                    "type": "string"
+                "server": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixServer"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.MatrixRoom": {
+        "github_com_etkecc_mrs_internal_model.RoomBan": {
             "type": "object",
             "properties": {
-                "avatar_url": {
+                "category": {
+                    "description": "same categories as reports",
                     "type": "string"
                 },
-                "avatar_url_http": {
-                    "type": "string"
-                },
-                "canonical_alias": {
+                "created_at": {
+                    "description": "zero for bans made before bans had records",
                     "type": "string"
                 },
                 "email": {
+                    "description": "the room's contact at the time of the ban, the room's data is gone after it, see Appeal",
                     "type": "string"
                 },
-                "guest_can_join": {
-                    "type": "boolean"
-                },
-                "join_rule": {
-                    "type": "string"
-                },
-                "language": {
+                "expires_at": {
                     "type": "string"
                 },
-                "name": {
+                "moderator": {
+                    "description": "actor of the ban, as in the audit log",
                     "type": "string"
                 },
-                "num_joined_members": {
-                    "type": "integer"
-                },
-                "parsed_at": {
+                "reason": {
                     "type": "string"
                 },
                 "room_id": {
                     "type": "string"
-                },
-                "room_type": {
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.RoomChange": {
+            "type": "object",
+            "properties": {
+                "at": {
                     "type": "string"
                 },
-                "server": {
-                    "description": "Parsed (custom) fields",
+                "field": {
+                    "description": "name, topic, or alias",
                     "type": "string"
                 },
-                "servers": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "topic": {
+                "from": {
                     "type": "string"
                 },
-                "world_readable": {
-                    "type": "boolean"
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.MatrixServer": {
+        "github_com_etkecc_mrs_internal_model.RoomConfig": {
             "type": "object",
             "properties": {
-                "checked_at": {
+                "email": {
+                    "description": "email of the room, e.g. \"yourname@example.com\"",
                     "type": "string"
                 },
-                "contacts": {
-                    "description": "Contacts as per MSC1929",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.MatrixServerContacts"
-                        }
-                    ]
+                "language": {
+                    "description": "language of the room, e.g. \"EN\", \"DE\", etc.",
+                    "type": "string"
                 },
-                "indexable": {
-                    "description": "Is the server published the public room directory over federation?",
+                "noindex": {
+                    "description": "if true, the room should not be indexed by MRS",
                     "type": "boolean"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.RoomConfigCheck": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomConfig"
                 },
-                "name": {
-                    "description": "ServerName, as per spec, e.g., \"example.com\"",
+                "language": {
+                    "description": "what MRS assigns to the room: from the config, or detected. Empty with noindex",
                     "type": "string"
                 },
-                "online": {
-                    "description": "Is the server online and federating?",
-                    "type": "boolean"
+                "room_id": {
+                    "description": "if the topic was fetched",
+                    "type": "string"
                 },
-                "online_at": {
-                    "description": "OnlineAt is the prune clock: last seen online, the sole basis for the 30d offline delete. Never bumped on an offline dial.\nCheckedAt is the backoff clock: last dial attempt, bumped every dial. Merge them and dead servers reset the prune clock and go immortal.",
+                "topic": {
+                    "description": "the topic as it will be indexed, without the config tag",
                     "type": "string"
                 },
-                "software": {
-                    "description": "Software running on the server, e.g., Synapse, Dendrite",
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomConfigWarning"
+                    }
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.RoomConfigCheckRequest": {
+            "type": "object",
+            "properties": {
+                "room": {
+                    "description": "room ID or alias, its topic is fetched with a room summary",
                     "type": "string"
                 },
-                "url": {
-                    "description": "Server-Server API URL, e.g., \"https://example.com:8448\"",
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.RoomConfigWarning": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "config key, empty if the problem is with the tag itself",
                     "type": "string"
                 },
-                "version": {
-                    "description": "Version of the software, e.g., 1.0.0",
+                "message": {
+                    "type": "string"
+                },
+                "value": {
                     "type": "string"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.MatrixServerContacts": {
+        "github_com_etkecc_mrs_internal_model.RoomDirectoryFilter": {
             "type": "object",
             "properties": {
-                "emails": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "generic_search_term": {
+                    "type": "string"
                 },
-                "mxids": {
+                "room_types": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
-                },
-                "url": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.QueryDirectoryResponse": {
+        "github_com_etkecc_mrs_internal_model.RoomDirectoryRequest": {
             "type": "object",
             "properties": {
-                "room_id": {
+                "filter": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomDirectoryFilter"
+                },
+                "include_all_networks": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "since": {
                     "type": "string"
                 },
-                "servers": {
+                "third_party_instance_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.RoomDirectoryResponse": {
+            "type": "object",
+            "properties": {
+                "chunk": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomDirectoryRoom"
                     }
+                },
+                "next_batch": {
+                    "type": "string"
+                },
+                "prev_batch": {
+                    "type": "string"
+                },
+                "total_room_count_estimate": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.QueryServerKeysRequest": {
+        "github_com_etkecc_mrs_internal_model.RoomDirectoryRoom": {
             "type": "object",
             "properties": {
-                "server_keys": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "avatar_url": {
+                    "type": "string"
+                },
+                "canonical_alias": {
+                    "type": "string"
+                },
+                "guest_can_join": {
+                    "type": "boolean"
+                },
+                "join_rule": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "num_joined_members": {
+                    "type": "integer"
+                },
+                "room_id": {
+                    "type": "string"
+                },
+                "room_type": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "world_readable": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.RoomDirectoryFilter": {
+        "github_com_etkecc_mrs_internal_model.RoomHistory": {
             "type": "object",
             "properties": {
-                "generic_search_term": {
+                "changes": {
+                    "description": "oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomChange"
+                    }
+                },
+                "first_seen": {
                     "type": "string"
                 },
-                "room_types": {
+                "last_changed": {
+                    "description": "last name, topic or alias change, FirstSeen if there were none",
+                    "type": "string"
+                },
+                "members": {
+                    "description": "oldest first",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomMembersSample"
                     }
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.RoomDirectoryRequest": {
+        "github_com_etkecc_mrs_internal_model.RoomMembersSample": {
             "type": "object",
             "properties": {
-                "filter": {
-                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomDirectoryFilter"
+                "at": {
+                    "type": "string"
                 },
-                "limit": {
+                "members": {
                     "type": "integer"
-                },
-                "since": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.RoomDirectoryResponse": {
+        "github_com_etkecc_mrs_internal_model.RoomQuarantine": {
             "type": "object",
             "properties": {
-                "chunk": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomDirectoryRoom"
-                    }
+                "created_at": {
+                    "type": "string"
                 },
-                "next_batch": {
+                "moderator": {
+                    "description": "actor of the quarantine, as in the audit log",
                     "type": "string"
                 },
-                "prev_batch": {
+                "reason": {
                     "type": "string"
                 },
-                "total_room_count_estimate": {
-                    "type": "integer"
+                "room_id": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_etkecc_mrs_internal_model.RoomDirectoryRoom": {
+        "github_com_etkecc_mrs_internal_model.RoomReport": {
             "type": "object",
             "properties": {
-                "avatar_url": {
+                "at": {
                     "type": "string"
                 },
-                "canonical_alias": {
+                "category": {
                     "type": "string"
                 },
-                "guest_can_join": {
-                    "type": "boolean"
-                },
-                "join_rule": {
+                "reason": {
                     "type": "string"
                 },
-                "name": {
+                "reporter": {
+                    "description": "hash, not the IP",
                     "type": "string"
-                },
-                "num_joined_members": {
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.RoomReports": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "number of reporters",
                     "type": "integer"
                 },
-                "room_id": {
-                    "type": "string"
+                "priority": {
+                    "description": "sum of category weights, triage the highest first",
+                    "type": "integer"
                 },
-                "room_type": {
-                    "type": "string"
+                "reports": {
+                    "description": "newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.RoomReport"
+                    }
                 },
-                "topic": {
+                "room_id": {
                     "type": "string"
-                },
-                "world_readable": {
-                    "type": "boolean"
                 }
             }
         },
@@ -1857,6 +4031,29 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_etkecc_mrs_internal_model.SeedStats": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "why the pull failed, the source contributed nothing then",
+                    "type": "string"
+                },
+                "fetched": {
+                    "description": "valid server names in the list",
+                    "type": "integer"
+                },
+                "new": {
+                    "description": "of them not known from the config, the db, or earlier sources",
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_etkecc_mrs_internal_model.ServerKeys": {
             "type": "object",
             "properties": {
@@ -1935,6 +4132,108 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_etkecc_mrs_internal_model.SpaceChildEvent": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.SpaceChildEventContent"
+                },
+                "origin_server_ts": {
+                    "type": "integer"
+                },
+                "sender": {
+                    "type": "string"
+                },
+                "state_key": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.SpaceChildEventContent": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "type": "string"
+                },
+                "suggested": {
+                    "type": "boolean"
+                },
+                "via": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.SpaceHierarchyResponse": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.SpaceHierarchyRoom"
+                    }
+                },
+                "inaccessible_children": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "next_batch": {
+                    "description": "NextBatch is MRS' own pagination over the children, the spec'd federation endpoint has none",
+                    "type": "string"
+                },
+                "room": {
+                    "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.SpaceHierarchyRoom"
+                }
+            }
+        },
+        "github_com_etkecc_mrs_internal_model.SpaceHierarchyRoom": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "canonical_alias": {
+                    "type": "string"
+                },
+                "children_state": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_etkecc_mrs_internal_model.SpaceChildEvent"
+                    }
+                },
+                "guest_can_join": {
+                    "type": "boolean"
+                },
+                "join_rule": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "num_joined_members": {
+                    "type": "integer"
+                },
+                "room_id": {
+                    "type": "string"
+                },
+                "room_type": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "world_readable": {
+                    "type": "boolean"
+                }
+            }
+        },
         "github_com_etkecc_mrs_internal_model.StatsDetails": {
             "type": "object",
             "properties": {
@@ -2005,6 +4304,83 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_etkecc_mrs_internal_model.TrendingEntry": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "avatar": {
+                    "type": "string"
+                },
+                "avatar_url": {
+                    "type": "string"
+                },
+                "delta": {
+                    "type": "integer"
+                },
+                "first_seen": {
+                    "description": "FirstSeen is when the room was indexed for the first time, sort by -first_seen for new rooms",
+                    "type": "string"
+                },
+                "growth": {
+                    "type": "number"
+                },
+                "guest_can_join": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "join_rule": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "network": {
+                    "description": "\"matrix\" for native rooms, third-party network name for bridged ones",
+                    "type": "string"
+                },
+                "parents": {
+                    "description": "Parents are IDs of spaces listing the room, indexed for the space: filter only",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "room_type": {
+                    "type": "string"
+                },
+                "server": {
+                    "description": "server of origin",
+                    "type": "string"
+                },
+                "servers": {
+                    "description": "comma-separated list of servers",
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "window": {
+                    "description": "in days",
+                    "type": "integer"
+                },
+                "world_readable": {
+                    "type": "boolean"
+                }
+            }
+        },
         "github_com_etkecc_mrs_internal_model.WellKnownClient": {
             "type": "object",
             "properties": {
@@ -2031,9 +4407,49 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.appealSubmission": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "one of the room's contacts: the room config email, or an MSC1929 email of its server",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "5 chars minimum",
+                    "type": "string"
+                },
+                "roomID": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.banRuleRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "spam, harassment, illegal, csam, or other (default)",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "server, alias, or text",
+                    "type": "string"
+                },
+                "pattern": {
+                    "description": "server name for server, regex for alias and text",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_controllers.reportSubmission": {
             "type": "object",
             "properties": {
+                "category": {
+                    "description": "spam, harassment, illegal, csam, or other (default)",
+                    "type": "string"
+                },
                 "no_msc1929": {
                     "type": "boolean"
                 },
@@ -2107,6 +4523,9 @@ const docTemplate = `{
         },
         "ModerationAuth": {
             "type": "basic"
+        },
+        "WorkersAuth": {
+            "type": "basic"
         }
     }
 }`
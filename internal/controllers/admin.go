@@ -17,7 +17,10 @@ type dataService interface {
 	Ingest(context.Context)
 	Full(context.Context, int, int)
 	GetRoom(ctx context.Context, roomID string) (*model.MatrixRoom, error)
+	GetRoomHistory(ctx context.Context, roomIDorAlias string) (*model.RoomHistory, error)
 	EachRoom(context.Context, func(string, *model.MatrixRoom) bool)
+	RefreshServer(context.Context, string) (int, *model.RefreshServerResponse)
+	RefreshRoom(context.Context, string) (int, *model.MatrixRoom)
 }
 
 // @Summary		Index status
@@ -33,6 +36,19 @@ func status(stats statsService) echo.HandlerFunc {
 	}
 }
 
+// @Summary		Seed sources stats
+// @Description	How the last discovery pass went for each configured seed source: how many server names it listed, and how many of them were new to us (not in the config, the db, or an earlier source). Empty until the first discovery pass after startup.
+// @Tags			admin
+// @Produce		json
+// @Security		AdminAuth
+// @Success		200	{array}	model.SeedStats
+// @Router			/-/seeds [get]
+func seeds(crawler crawlerService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, crawler.SeedStats())
+	}
+}
+
 // @Summary		Trigger discovery
 // @Description	Kicks off a discovery pass and returns 201 immediately. Fire-and-forget: the crawl runs in the background, this does not wait for it.
 // @Tags			admin
@@ -96,3 +112,62 @@ func full(data dataService, cfg configService) echo.HandlerFunc {
 		return c.NoContent(http.StatusCreated)
 	}
 }
+
+// @Summary		Refresh a server
+// @Description	Re-discovers a single server and re-parses its public rooms right away, then updates the search index with them (flag rules included, as in indexing), so a room admin's fix shows up in minutes instead of after the next full cycle. Unlike /-/parse, this waits for the crawl, so expect it to take a while for servers with thousands of rooms. Rooms that vanished from the server's directory are left to the retention policy.
+// @Tags			admin
+// @Produce		json
+// @Security		AdminAuth
+// @Param			name	path		string						true	"Server name"
+// @Success		200		{object}	model.RefreshServerResponse	"Server refreshed"
+// @Failure		422		{object}	model.RefreshServerResponse	"Server is offline (or blocked), marked offline"
+// @Router			/-/refresh/server/{name} [post]
+func refreshServer(data dataService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code, resp := data.RefreshServer(c.Request().Context(), c.Param("name"))
+		return c.JSON(code, resp)
+	}
+}
+
+// @Summary		Refresh a room
+// @Description	Re-reads a single room with an MSC3266 room summary via its server (or the fallback servers for a room we do not know yet), stores it, and updates the search index right away. A room that must not be indexed anymore (a noindex room config in its topic, a blocked server, etc.) is removed instead.
+// @Tags			admin
+// @Produce		json
+// @Security		AdminAuth
+// @Param			room_id_or_alias	path		string				true	"Room ID or alias"
+// @Success		200					{object}	model.MatrixRoom	"Room refreshed"
+// @Success		202					{object}	model.MatrixRoom	"Room refreshed, but quarantined (or held back by a flag rule), so kept out of the index"
+// @Failure		404					{object}	model.MatrixError	"The room's summary is not available"
+// @Failure		410					{object}	model.MatrixError	"The room must not be indexed anymore, removed"
+// @Router			/-/refresh/room/{room_id_or_alias} [post]
+func refreshRoom(data dataService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code, room := data.RefreshRoom(c.Request().Context(), c.Param("room_id_or_alias"))
+		switch code {
+		case http.StatusOK, http.StatusAccepted:
+			return c.JSON(code, room)
+		case http.StatusGone:
+			return c.JSON(code, &model.MatrixError{Code: "M_NOT_FOUND", Message: "room must not be indexed, removed"})
+		default:
+			return c.JSON(code, &model.MatrixError{Code: "M_NOT_FOUND", Message: "room summary is not available"})
+		}
+	}
+}
+
+// @Summary		Reset destination backoff
+// @Description	Forgets the failure streak of a federation destination, so the next outgoing call (version, publicRooms, directory, keys, thumbnails) knocks on it again instead of short-circuiting. Use it when a server you know is back keeps getting skipped.
+// @Tags			admin
+// @Produce		json
+// @Security		AdminAuth
+// @Param			name	path	string	true	"Destination server name"
+// @Success		204		"Backoff reset"
+// @Failure		404		{object}	model.MatrixError	"The destination is not backing off"
+// @Router			/-/reset/backoff/{name} [post]
+func resetBackoff(matrixSvc matrixService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !matrixSvc.ResetBackoff(c.Param("name")) {
+			return c.JSON(http.StatusNotFound, &model.MatrixError{Code: "M_NOT_FOUND", Message: "destination is not backing off"})
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,251 @@
+package controllers
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/etkecc/go-apm"
+	"github.com/labstack/echo/v4"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// appealTemplate is the verification step of an appeal, the form posts back to the same URL
+var appealTemplate = template.Must(template.New("appeal").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><meta name="robots" content="noindex"><title>MRS: appeal</title></head>
+<body>
+{{- if .Done}}
+<p>The appeal of <a href="https://matrix.to/#/{{.RoomID}}">{{.RoomID}}</a> has been sent to moderators. The decision will be emailed to {{.Email}}.</p>
+{{- else}}
+<form method="post">
+<p>Confirm the appeal of <a href="https://matrix.to/#/{{.RoomID}}">{{.RoomID}}</a>?</p>
+<blockquote>{{.Reason}}</blockquote>
+<p><button type="submit">Send to moderators</button></p>
+<p>The link works once, until {{.VerifyExpiresAt.Format "2006-01-02 15:04 MST"}}.</p>
+</form>
+{{- end}}
+</body>
+</html>
+`))
+
+type appealPage struct {
+	*model.Appeal
+	Done bool
+}
+
+// renderAppealPage renders the verification page
+func renderAppealPage(c echo.Context, page *appealPage) error {
+	var html strings.Builder
+	if err := appealTemplate.Execute(&html, page); err != nil {
+		return err
+	}
+	return c.HTML(http.StatusOK, html.String())
+}
+
+type appealSubmission struct {
+	RoomID string `param:"room_id"`
+	Email  string `json:"email"`  // one of the room's contacts: the room config email, or an MSC1929 email of its server
+	Reason string `json:"reason"` // 5 chars minimum
+}
+
+// @Summary		Appeal a room ban
+// @Description	Appeals the ban of a room, for room admins who have no other way to reach moderators. No auth, the room's contact is: `email` must be the room's config email (as of the ban) or an MSC1929 email of the room's server, and the appeal goes nowhere until the verification link emailed there is opened and confirmed. The response is a 202 either way, so the endpoint tells nobody whether the room is banned or which addresses are its contacts. A room has one open appeal at a time, another one is quietly ignored until the first is decided or its link expires.
+// @Tags			moderation
+// @Accept			json
+// @Produce		json
+// @Param			room_id	path	string				true	"Banned room ID"
+// @Param			request	body	appealSubmission	true	"Contact email and reason"
+// @Success		202		"Appeal accepted for verification"
+// @Failure		400		{object}	model.MatrixError	"Invalid room ID or email, or reason too short, too long, or missing"
+// @Failure		500		{object}	model.MatrixError	"Internal error while processing the appeal"
+// @Router			/mod/appeal/{room_id} [post]
+func fileAppeal(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		log := apm.Log(c.Request().Context())
+		var appeal appealSubmission
+		if err := c.Bind(&appeal); err != nil {
+			log.Error().Err(err).Msg("cannot bind appeal")
+			return err
+		}
+
+		if !utils.IsValidID(appeal.RoomID) {
+			return c.JSON(http.StatusBadRequest, &model.MatrixError{
+				Code:    "M_INVALID_PARAM",
+				Message: "Invalid room ID format.",
+			})
+		}
+		if !strings.Contains(appeal.Email, "@") {
+			return c.JSON(http.StatusBadRequest, &model.MatrixError{
+				Code:    "M_INVALID_PARAM",
+				Message: "Invalid email.",
+			})
+		}
+		if len(appeal.Reason) < 5 || len(appeal.Reason) > model.MaxAppealReason {
+			return c.JSON(http.StatusBadRequest, &model.MatrixError{
+				Code:    "M_INVALID_PARAM",
+				Message: "Appeal reason is too short, too long, or missing.",
+			})
+		}
+
+		if err := svc.Appeal(c.Request().Context(), appeal.RoomID, appeal.Email, appeal.Reason); err != nil {
+			log.Error().Err(err).Msg("cannot file appeal")
+			return c.JSON(http.StatusInternalServerError, &model.MatrixError{
+				Code:    "M_INTERNAL_ERROR",
+				Message: "An internal error occurred while processing your request.",
+			})
+		}
+
+		return c.NoContent(http.StatusAccepted)
+	}
+}
+
+// @Summary		Open an appeal verification link
+// @Description	Opens the verification step of an appeal, the link from the email sent to the room's contact. Opening it changes nothing, mail scanners are welcome.
+// @Tags			moderation
+// @Produce		html
+// @Param			token	path		string				true	"Signed verification token"
+// @Success		200		"Verification form"
+// @Failure		403		{object}	model.MatrixError	"Invalid token"
+// @Failure		410		{object}	model.MatrixError	"Expired or used token"
+// @Router			/mod/appeal/verify/{token} [get]
+func verifyAppealPage(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		appeal, err := svc.CheckAppealToken(c.Request().Context(), c.Param("token"))
+		if err != nil {
+			return actionTokenError(c, err)
+		}
+		return renderAppealPage(c, &appealPage{Appeal: appeal})
+	}
+}
+
+// @Summary		Verify an appeal
+// @Description	Verifies the appeal of the token and sends it to moderators, through the same webhook and email as reports. A token works once. A "bot" User-Agent gets a 403.
+// @Tags			moderation
+// @Produce		html
+// @Param			token	path		string				true	"Signed verification token"
+// @Success		200		"Appeal verified"
+// @Failure		403		{object}	model.MatrixError	"Invalid token, or User-Agent contains 'bot'"
+// @Failure		410		{object}	model.MatrixError	"Expired or used token"
+// @Router			/mod/appeal/verify/{token} [post]
+func verifyAppeal(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		appeal, err := svc.VerifyAppeal(c.Request().Context(), c.Param("token"))
+		if err != nil {
+			return actionTokenError(c, err)
+		}
+		return renderAppealPage(c, &appealPage{Appeal: appeal, Done: true})
+	}
+}
+
+// @Summary		List appeals
+// @Description	Lists the latest appeal of each room, pending first. Unverified appeals are listed too, last, they may be anyone's attempt; `status` narrows the list down. Append /{server_name} to filter to a single server. Empty is a 204. A "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Param			status	query	string			false	"Status: unverified, pending, accepted, or rejected"
+// @Success		200		{array}	model.Appeal	"Appeals"
+// @Success		204		"No appeals"
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/list-appeals [get]
+func listAppeals(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		list, ok, err := listScoped(c, svc.ListAppeals)
+		if !ok {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
+		}
+		if err != nil {
+			return err
+		}
+		if status := c.QueryParam("status"); status != "" {
+			filtered := make([]*model.Appeal, 0, len(list))
+			for _, appeal := range list {
+				if appeal.Status == status {
+					filtered = append(filtered, appeal)
+				}
+			}
+			list = filtered
+		}
+		model.SortAppeals(list) // merged from several servers of the moderator's scope
+
+		if len(list) == 0 {
+			return c.NoContent(http.StatusNoContent)
+		}
+		return c.JSON(http.StatusOK, list)
+	}
+}
+
+// @Summary		Accept an appeal
+// @Description	Accepts the room's pending appeal: the room is unbanned (the audit log records an unban), and the decision with the reason is emailed to the contact who filed the appeal. A POST, and a "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Param			room_id	path		string				true	"Room ID of the appeal"
+// @Param			reason	query		string				false	"Reason, sent to the contact and recorded in the audit log"
+// @Success		200		{object}	model.Appeal		"The decided appeal"
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Failure		409		{object}	model.MatrixError	"The room has no pending appeal"
+// @Router			/mod/accept-appeal/{room_id} [post]
+func acceptAppeal(svc moderationService) echo.HandlerFunc {
+	return decideAppeal(svc, true)
+}
+
+// @Summary		Reject an appeal
+// @Description	Rejects the room's pending appeal, the room stays banned. The reason is required, it's emailed to the contact who filed the appeal. A POST, and a "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Param			room_id	path		string				true	"Room ID of the appeal"
+// @Param			reason	query		string				true	"Reason, sent to the contact and recorded in the audit log"
+// @Success		200		{object}	model.Appeal		"The decided appeal"
+// @Failure		400		{object}	model.MatrixError	"Reason is missing"
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Failure		409		{object}	model.MatrixError	"The room has no pending appeal"
+// @Router			/mod/reject-appeal/{room_id} [post]
+func rejectAppeal(svc moderationService) echo.HandlerFunc {
+	return decideAppeal(svc, false)
+}
+
+// decideAppeal is the handler of both decisions, a rejection without a reason tells the contact nothing, so it needs one
+func decideAppeal(svc moderationService, accept bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		roomID := c.Param("room_id")
+		if !roomInScope(c, roomID) {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
+		}
+		reason := c.QueryParam("reason")
+		if !accept && strings.TrimSpace(reason) == "" {
+			return c.JSON(http.StatusBadRequest, &model.MatrixError{
+				Code:    "M_INVALID_PARAM",
+				Message: "Rejection reason is missing.",
+			})
+		}
+
+		appeal, err := svc.DecideAppeal(c.Request().Context(), moderator(c), roomID, accept, reason)
+		if errors.Is(err, model.ErrAppealNotPending) {
+			return c.JSON(http.StatusConflict, &model.MatrixError{
+				Code:    "M_INVALID_PARAM",
+				Message: "The room has no pending appeal.",
+			})
+		}
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, appeal)
+	}
+}
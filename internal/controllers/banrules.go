@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/etkecc/go-apm"
+	"github.com/labstack/echo/v4"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+type banRuleRequest struct {
+	Kind     string `json:"kind"`    // server, alias, or text
+	Pattern  string `json:"pattern"` // server name for server, regex for alias and text
+	Reason   string `json:"reason"`
+	Category string `json:"category"` // spam, harassment, illegal, csam, or other (default)
+}
+
+// bindBanRule binds and validates the rule, nil if the request is invalid (and the error response is already sent)
+func bindBanRule(c echo.Context) (*model.BanRule, error) {
+	var req banRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return nil, c.JSON(http.StatusBadRequest, &model.MatrixError{
+			Code:    "M_NOT_JSON",
+			Message: "Request body is not a valid JSON.",
+		})
+	}
+	rule := &model.BanRule{
+		Kind:      req.Kind,
+		Pattern:   req.Pattern,
+		Reason:    req.Reason,
+		Category:  req.Category,
+		Moderator: moderator(c),
+	}
+	if err := rule.Compile(); err != nil {
+		return nil, c.JSON(http.StatusBadRequest, &model.MatrixError{
+			Code:    "M_INVALID_PARAM",
+			Message: "Invalid ban rule: " + err.Error(),
+		})
+	}
+	return rule, nil
+}
+
+// @Summary		List ban rules
+// @Description	Lists moderators' ban rules: rooms of a server, rooms with aliases matching a regex, or rooms with names or topics matching a regex, banned at crawl and query time. Unlike blocklist.servers in the config, these need no file edit. A "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Success		200	{array}	model.BanRule	"Ban rules"
+// @Failure		403	"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/rules [get]
+func listBanRules(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		rules, err := svc.BanRules(c.Request().Context())
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, rules)
+	}
+}
+
+// @Summary		Preview a ban rule
+// @Description	Shows how many indexed rooms the rule would remove (and the first of them) without applying it. Look before you leap: a text regex is one typo away from emptying the index. A "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Accept			json
+// @Produce		json
+// @Security		ModerationAuth
+// @Param			request	body		banRuleRequest			true	"Ban rule"
+// @Success		200		{object}	model.BanRulePreview	"Rooms the rule would remove"
+// @Failure		400		{object}	model.MatrixError		"Invalid rule"
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/rules/preview [post]
+func previewBanRule(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		rule, err := bindBanRule(c)
+		if rule == nil {
+			return err
+		}
+		preview, err := svc.PreviewBanRule(c.Request().Context(), rule)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
+}
+
+// @Summary		Add a ban rule
+// @Description	Stores the rule and applies it right away: matching rooms are removed from the index now, and are not crawled or shown in the results from now on. Returns what was removed, same as the preview. A "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Accept			json
+// @Produce		json
+// @Security		ModerationAuth
+// @Param			request	body		banRuleRequest			true	"Ban rule"
+// @Success		201		{object}	model.BanRulePreview	"The rule (with its ID) and the rooms it removed"
+// @Failure		400		{object}	model.MatrixError		"Invalid rule"
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/rules [post]
+func addBanRule(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		rule, err := bindBanRule(c)
+		if rule == nil {
+			return err
+		}
+		removed, err := svc.AddBanRule(c.Request().Context(), rule)
+		if err != nil {
+			apm.Log(c.Request().Context()).Error().Err(err).Str("rule", rule.String()).Msg("cannot add ban rule")
+			return err
+		}
+		return c.JSON(http.StatusCreated, removed)
+	}
+}
+
+// @Summary		Remove a ban rule
+// @Description	Removes the rule. Rooms it removed are not restored right away, they come back with the next parsing. A "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Param			id		path		string				true	"Rule ID"
+// @Param			reason	query		string				false	"Reason, recorded in the audit log"
+// @Success		200		{object}	model.BanRule		"The removed rule"
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Failure		404		{object}	model.MatrixError	"No such rule"
+// @Router			/mod/rules/{id} [delete]
+func removeBanRule(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		rule, err := svc.RemoveBanRule(c.Request().Context(), moderator(c), c.Param("id"), c.QueryParam("reason"))
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			return c.JSON(http.StatusNotFound, &model.MatrixError{
+				Code:    "M_NOT_FOUND",
+				Message: "Ban rule not found.",
+			})
+		}
+		return c.JSON(http.StatusOK, rule)
+	}
+}
+
+// @Summary		Policy lists
+// @Description	Stats of the last import of each policy list from the config: how many events each had and how many of them are room and server bans MRS applies. A list that failed to import keeps its previous rules, the error says why. A "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Success		200	{array}	model.PolicyStats	"Policy lists"
+// @Failure		403	"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/policies [get]
+func policyStats(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+		return c.JSON(http.StatusOK, svc.PolicyStats())
+	}
+}
+
+// @Summary		Flag rules
+// @Description	Stats of the flag rules from the config during the last indexing: how many rooms each rule matched and the first of them. Refreshes (/-/refresh) count into the totals only. Dry-run rules are counted the same way, that's the point of the dry run. Rules act on rooms when they are written to the index, by an indexing or a refresh (parsing alone does not run them): flag reports the room for review (see /mod/list-reported), quarantine hides it, reject removes it. A room a moderator has released or unreported is left alone. A "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Success		200	{array}	model.FlagStats	"Flag rules"
+// @Failure		403	"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/flags [get]
+func flagStats(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+		return c.JSON(http.StatusOK, svc.FlagStats())
+	}
+}
@@ -2,8 +2,10 @@ package controllers
 
 import (
 	"context"
+	"io"
 	"net/http"
 
+	"github.com/goccy/go-json"
 	"github.com/labstack/echo/v4"
 
 	"github.com/etkecc/mrs/internal/model"
@@ -13,10 +15,14 @@ import (
 type crawlerService interface {
 	OnlineServers(context.Context) []string
 	OnlineServersObjects(context.Context) map[string]*model.MatrixServer
+	SeedStats() []*model.SeedStats
+	LeaseBatch(context.Context) *model.CrawlBatch
+	SubmitResults(context.Context, string, *model.CrawlResults) bool
+	CheckRoomConfig(context.Context, *model.RoomConfigCheckRequest) (int, *model.RoomConfigCheck)
 }
 
 // @Summary		Room preview
-// @Description	Room preview by ID or alias: like the Matrix client-server room preview, but enriched with everything MRS knows about a room (language and so on). We try our own index first, then fall back to a live MSC3266 summary, and when that fallback fires we set the `X-MRS-MSC3266: true` response header so you can tell. Marked EXPERIMENT in the source, so treat the shape as not yet frozen.
+// @Description	Room preview by ID or alias: like the Matrix client-server room preview, but enriched with everything MRS knows about a room (language, space relations, whether its alias really resolves to it, and so on). We try our own index first, then fall back to a live MSC3266 summary, and when that fallback fires we set the `X-MRS-MSC3266: true` response header so you can tell. Marked EXPERIMENT in the source, so treat the shape as not yet frozen.
 // @Tags			catalog
 // @Produce		json
 // @Param			room_id_or_alias	path		string				true	"Room ID or alias"
@@ -74,6 +80,78 @@ func catalogRoom(dataSvc dataService, matrixSvc matrixService, plausible plausib
 	}
 }
 
+// @Summary		Room history
+// @Description	What we have seen of a room over time: when it first showed up in the index, the last name, topic, and alias changes, and one member count per day. Only rooms we index have one, so no MSC3266 fallback here. The history starts when this feature was deployed, so first_seen of old rooms is a lower bound, not a birthday.
+// @Tags			catalog
+// @Produce		json
+// @Param			room_id_or_alias	path		string				true	"Room ID or alias"
+// @Success		200					{object}	model.RoomHistory	"Room history"
+// @Failure		404					{object}	model.MatrixError	"Room is not indexed"
+// @Failure		500					{object}	model.MatrixError	"Internal error reading the history"
+// @Router			/room/{room_id_or_alias}/history [get]
+func catalogRoomHistory(dataSvc dataService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		roomIDorAlias := utils.Unescape(c.Param("room_id_or_alias"))
+		if !utils.IsValidID(roomIDorAlias) && utils.IsValidAlias("#"+roomIDorAlias) {
+			roomIDorAlias = "#" + roomIDorAlias
+		}
+
+		history, err := dataSvc.GetRoomHistory(c.Request().Context(), roomIDorAlias)
+		if err != nil {
+			return c.JSONBlob(http.StatusInternalServerError, utils.MustJSON(model.MatrixError{
+				Code:    "M_INTERNAL_SERVER_ERROR",
+				Message: err.Error(),
+			}))
+		}
+		if history == nil {
+			return c.JSONBlob(http.StatusNotFound, utils.MustJSON(model.MatrixError{
+				Code:    "M_NOT_FOUND",
+				Message: "room not found",
+			}))
+		}
+		return c.JSON(http.StatusOK, history)
+	}
+}
+
+// maxRoomConfigCheckBytes caps the room config check body, a topic with some room to spare
+const maxRoomConfigCheckBytes = 64 << 10
+
+// @Summary		Check a room config
+// @Description	Shows how MRS understands a room config block, e.g. `(MRS-language:EN|email:admin@example.com|noindex:false-MRS)`, before you wait a day for the next crawl to find out. Send either a topic as is, or a room ID or alias to fetch its live topic with an MSC3266 room summary. You get the parsed config, the topic as it will be indexed, a warning for each part that is ignored (unknown keys, unknown languages, invalid emails, etc.), and the language MRS would assign to the room, from the config or detected (`-` if detection is not sure). A noindex room gets no language, it will not be indexed at all.
+// @Tags			catalog
+// @Accept			json
+// @Produce		json
+// @Param			request	body		model.RoomConfigCheckRequest	true	"Topic or room to check"
+// @Success		200		{object}	model.RoomConfigCheck			"How MRS understands the room config"
+// @Failure		400		{object}	model.MatrixError				"Neither topic nor room is set"
+// @Failure		404		"The room's summary is not available"
+// @Failure		429		"Rate limited"
+// @Router			/room-config/check [post]
+func checkRoomConfig(crawler crawlerService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		defer c.Request().Body.Close()
+		var req *model.RoomConfigCheckRequest
+		if err := json.NewDecoder(io.LimitReader(c.Request().Body, maxRoomConfigCheckBytes)).Decode(&req); err != nil || req == nil {
+			return c.JSONBlob(http.StatusBadRequest, utils.MustJSON(model.MatrixError{
+				Code:    "M_NOT_JSON",
+				Message: "request body must be a JSON object with topic or room",
+			}))
+		}
+		if req.Topic == "" && req.Room == "" {
+			return c.JSONBlob(http.StatusBadRequest, utils.MustJSON(model.MatrixError{
+				Code:    "M_MISSING_PARAM",
+				Message: "either topic or room must be set",
+			}))
+		}
+
+		code, check := crawler.CheckRoomConfig(c.Request().Context(), req)
+		if check == nil {
+			return c.NoContent(code)
+		}
+		return c.JSON(code, check)
+	}
+}
+
 // @Summary		All rooms
 // @Description	Every indexed room as a room-ID to alias map. Big, authenticated, and exactly as heavy as it sounds.
 // @Tags			catalog
@@ -122,3 +200,20 @@ func serversObjects(crawler crawlerService) echo.HandlerFunc {
 		return c.JSON(http.StatusOK, servers)
 	}
 }
+
+// @Summary		Bans as a policy list
+// @Description	Banned rooms and server ban rules of this instance as m.policy.rule.room and m.policy.rule.server state events with the m.ban recommendation, the same shape as a policy room's state. Another MRS instance can import it as a policy list, a bot can copy it into a policy room. Alias and text ban rules are regexes, not globs, so they are not included.
+// @Tags			catalog
+// @Produce		json
+// @Security		CatalogAuth
+// @Success		200	{array}	model.PolicyEvent	"Policy rule events"
+// @Router			/catalog/policies [get]
+func policies(modSvc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		events, err := modSvc.ExportPolicies(c.Request().Context())
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, events)
+	}
+}
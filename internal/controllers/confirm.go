@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/etkecc/go-apm"
+	"github.com/labstack/echo/v4"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// confirmTemplate is the confirmation step of a moderation action link, the form posts back to the same URL
+var confirmTemplate = template.Must(template.New("confirm").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><meta name="robots" content="noindex"><title>MRS: {{.Action}}</title></head>
+<body>
+{{- if .Done}}
+<p>Done: {{.Action}} <a href="https://matrix.to/#/{{.RoomID}}">{{.RoomID}}</a>.</p>
+{{- else}}
+<form method="post">
+<p>Confirm <b>{{.Action}}</b> of <a href="https://matrix.to/#/{{.RoomID}}">{{.RoomID}}</a>?</p>
+<p><label>Reason <input name="reason" size="60"></label></p>
+<p><button type="submit">{{.Action}}</button></p>
+<p>The link works once, until {{.ExpiresAt.Format "2006-01-02 15:04 MST"}}.</p>
+</form>
+{{- end}}
+</body>
+</html>
+`))
+
+type confirmPage struct {
+	*model.ActionToken
+	Done bool
+}
+
+// actionTokenError responds to an unusable action token
+func actionTokenError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, model.ErrActionTokenInvalid):
+		return c.JSON(http.StatusForbidden, &model.MatrixError{
+			Code:    "M_FORBIDDEN",
+			Message: "Invalid link.",
+		})
+	case errors.Is(err, model.ErrActionTokenExpired), errors.Is(err, model.ErrActionTokenUsed):
+		return c.JSON(http.StatusGone, &model.MatrixError{
+			Code:    "M_FORBIDDEN",
+			Message: "The link has expired or has been used already.",
+		})
+	default:
+		return err
+	}
+}
+
+// renderConfirmPage renders the confirmation page
+func renderConfirmPage(c echo.Context, page *confirmPage) error {
+	var html strings.Builder
+	if err := confirmTemplate.Execute(&html, page); err != nil {
+		return err
+	}
+	return c.HTML(http.StatusOK, html.String())
+}
+
+// @Summary		Confirm a moderation action
+// @Description	Opens the confirmation step of a moderation action link from a report notification. The link carries a signed, short-lived token of the action and the room, and opening it changes nothing, so link previewers and mail scanners can prefetch it all they want. The token is the auth, no moderation credentials needed.
+// @Tags			moderation
+// @Produce		html
+// @Param			token	path		string				true	"Signed action token"
+// @Success		200		"Confirmation form"
+// @Failure		403		{object}	model.MatrixError	"Invalid token"
+// @Failure		410		{object}	model.MatrixError	"Expired or used token"
+// @Router			/mod/confirm/{token} [get]
+func confirmActionPage(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, err := svc.CheckActionToken(c.Request().Context(), c.Param("token"))
+		if err != nil {
+			return actionTokenError(c, err)
+		}
+		return renderConfirmPage(c, &confirmPage{ActionToken: token})
+	}
+}
+
+// @Summary		Perform a confirmed moderation action
+// @Description	Performs the action of the token: ban, unban, quarantine, release, or unreport (dismiss). A token works once, even across restarts. The audit log records the recipients of the link (the moderation webhook and email) and the confirming IP as the actor. A "bot" User-Agent gets a 403.
+// @Tags			moderation
+// @Accept			x-www-form-urlencoded
+// @Produce		html
+// @Param			token	path		string				true	"Signed action token"
+// @Param			reason	formData	string				false	"Reason, recorded in the audit log"
+// @Success		200		"Action performed"
+// @Failure		403		{object}	model.MatrixError	"Invalid token, or User-Agent contains 'bot'"
+// @Failure		410		{object}	model.MatrixError	"Expired or used token"
+// @Router			/mod/confirm/{token} [post]
+func confirmAction(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		token, err := svc.ConfirmAction(c.Request().Context(), moderator(c), c.Param("token"), c.FormValue("reason"))
+		if token == nil {
+			return actionTokenError(c, err)
+		}
+		if err != nil {
+			apm.Log(c.Request().Context()).Error().Err(err).Str("action", token.Action).Str("room", token.RoomID).Msg("cannot perform confirmed action")
+			return err
+		}
+		return renderConfirmPage(c, &confirmPage{ActionToken: token, Done: true})
+	}
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/goccy/go-json"
+	"github.com/labstack/echo/v4"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// @Summary		Lease a crawl batch
+// @Description	Internal API for `mrs worker` processes. Hands out a batch of servers of the running discovery or parsing. The worker has `coordinator.lease` seconds to send the results back, after that the servers are handed out again. A server is out with one worker at a time. Only works with `coordinator.enabled`, otherwise there is never anything to hand out.
+// @Tags			coordinator
+// @Produce		json
+// @Security		WorkersAuth
+// @Success		200	{object}	model.CrawlBatch	"Batch to process"
+// @Success		204	"Nothing to do right now, ask again later"
+// @Failure		401	"Invalid Workers credentials"
+// @Router			/coordinator/lease [post]
+func leaseBatch(crawler crawlerService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		batch := crawler.LeaseBatch(c.Request().Context())
+		if batch == nil {
+			return c.NoContent(http.StatusNoContent)
+		}
+		return c.JSON(http.StatusOK, batch)
+	}
+}
+
+// @Summary		Submit crawl batch results
+// @Description	Internal API for `mrs worker` processes. Stores results of a leased batch. Only the results of the batch's own servers are kept: their servers and the rooms their directories list, anything else is dropped. Late results of an expired lease are still accepted while the run lasts, except for servers another worker has returned in the meantime; once the run is over, they are refused.
+// @Tags			coordinator
+// @Accept			json
+// @Produce		json
+// @Security		WorkersAuth
+// @Param			id		path	string				true	"Batch ID"
+// @Param			request	body	model.CrawlResults	true	"Batch results"
+// @Success		204		"Results stored"
+// @Failure		400		{object}	model.MatrixError	"Malformed request body"
+// @Failure		401		"Invalid Workers credentials"
+// @Failure		404		{object}	model.MatrixError	"Unknown batch, or the run is over"
+// @Router			/coordinator/results/{id} [post]
+func submitResults(crawler crawlerService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		defer c.Request().Body.Close()
+		var results *model.CrawlResults
+		if err := json.NewDecoder(c.Request().Body).Decode(&results); err != nil || results == nil {
+			return c.JSON(http.StatusBadRequest, &model.MatrixError{Code: "M_NOT_JSON", Message: "request body must be batch results"})
+		}
+		if !crawler.SubmitResults(c.Request().Context(), c.Param("id"), results) {
+			return c.JSON(http.StatusNotFound, &model.MatrixError{Code: "M_NOT_FOUND", Message: "unknown batch"})
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -23,6 +23,10 @@ type matrixService interface {
 	GetMediaThumbnail(ctx context.Context, serverName, mediaID string, params url.Values) (io.Reader, string)
 	PublicRooms(context.Context, *http.Request, *model.RoomDirectoryRequest) (int, []byte)
 	QueryDirectory(ctx context.Context, req *http.Request, alias string) (int, []byte)
+	SpaceHierarchy(ctx context.Context, req *http.Request, roomID string, suggestedOnly bool, limit int, from string) (int, []byte)
+	PeerServers(ctx context.Context, req *http.Request) (int, []byte)
+	PeerRooms(ctx context.Context, req *http.Request, since int64, from string, limit int) (int, []byte)
 	QueryServerKeys(ctx context.Context, serverName string, validUntilTS int64) []byte
 	QueryServersKeys(ctx context.Context, req *model.QueryServerKeysRequest, validUntilTS int64) []byte
+	ResetBackoff(serverName string) bool
 }
@@ -20,6 +20,9 @@ func configureMatrixS2SEndpoints(e *echo.Echo, matrixSvc matrixService, plausibl
 	e.GET("/_matrix/key/v2/query/:serverName", queryServerKeys(matrixSvc, plausible))
 	e.POST("/_matrix/key/v2/query", queryServersKeys(matrixSvc, plausible))
 	e.GET("/_matrix/federation/v1/query/directory", queryDirectory(matrixSvc))
+	e.GET("/_matrix/federation/v1/hierarchy/:roomID", spaceHierarchy(matrixSvc))
+	e.GET("/_matrix/federation/unstable/cc.etke.mrs/peering/servers", peerServers(matrixSvc))
+	e.GET("/_matrix/federation/unstable/cc.etke.mrs/peering/rooms", peerRooms(matrixSvc))
 	e.GET("/_matrix/federation/v1/publicRooms", matrixRoomDirectory(matrixSvc), cacheSvc.MiddlewareSearch())
 	e.POST("/_matrix/federation/v1/publicRooms", matrixRoomDirectory(matrixSvc), cacheSvc.MiddlewareSearch())
 }
@@ -153,6 +156,62 @@ func queryDirectory(matrixSvc matrixService) echo.HandlerFunc {
 	}
 }
 
+// @Summary		Space hierarchy
+// @Description	Expands an indexed public space into its children, from the relations our crawler collected. Authenticated federation endpoint, so a missing or invalid X-Matrix signature is a 401. Children we never indexed (or banned) land in inaccessible_children instead of being made up. The spec'd endpoint has no pagination, so limit and from are ours: a client that ignores next_batch still gets the first 100 children, which is more than most spaces have.
+// @Tags			matrix-s2s
+// @Produce		json
+// @Param			roomID			path		string							true	"Space room ID"
+// @Param			suggested_only	query		bool							false	"Only consider children marked as suggested"
+// @Param			limit			query		int								false	"Max children per page, 100 at most"
+// @Param			from			query		string							false	"Pagination token from a previous next_batch"
+// @Success		200				{object}	model.SpaceHierarchyResponse	"The space, its children, and the children we cannot vouch for"
+// @Failure		401				{object}	model.MatrixError				"Federation auth failed (missing or invalid X-Matrix signature)"
+// @Failure		404				{object}	model.MatrixError				"Not an indexed public space"
+// @Security		FederationAuth
+// @Router			/_matrix/federation/v1/hierarchy/{roomID} [get]
+func spaceHierarchy(matrixSvc matrixService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		suggestedOnly, _ := strconv.ParseBool(c.QueryParam("suggested_only")) //nolint:errcheck // false is the default anyway
+		limit, _ := strconv.Atoi(c.QueryParam("limit"))                       //nolint:errcheck // 0 means default
+		return c.JSONBlob(matrixSvc.SpaceHierarchy(c.Request().Context(), c.Request(), c.Param("roomID"), suggestedOnly, limit, c.QueryParam("from")))
+	}
+}
+
+// @Summary		Peering: server catalog
+// @Description	MRS-to-MRS peering feed: the servers we consider online, so another instance can seed its discovery from our crawl. Signed like any federation request, and only instances listed in our peering.allow config get an answer, everybody else a 403.
+// @Tags			matrix-s2s
+// @Produce		json
+// @Success		200	{object}	model.PeerServersResponse	"Online servers"
+// @Failure		401	{object}	model.MatrixError			"Federation auth failed (missing or invalid X-Matrix signature)"
+// @Failure		403	{object}	model.MatrixError			"The origin is not allowed to peer with us"
+// @Security		FederationAuth
+// @Router			/_matrix/federation/unstable/cc.etke.mrs/peering/servers [get]
+func peerServers(matrixSvc matrixService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSONBlob(matrixSvc.PeerServers(c.Request().Context(), c.Request()))
+	}
+}
+
+// @Summary		Peering: room deltas
+// @Description	MRS-to-MRS peering feed: rooms we parsed after `since`, in room ID order, so another instance can bootstrap its index without a full crawl and then keep up with the changes. Banned rooms and blocked servers are left out. Same auth rules as the server catalog.
+// @Tags			matrix-s2s
+// @Produce		json
+// @Param			since	query		int							false	"Unix timestamp (ms), only rooms parsed after it. 0 for everything"
+// @Param			from	query		string						false	"Pagination token from a previous next_batch"
+// @Param			limit	query		int							false	"Max rooms per page, 1000 at most"
+// @Success		200		{object}	model.PeerRoomsResponse		"A page of rooms"
+// @Failure		401		{object}	model.MatrixError			"Federation auth failed (missing or invalid X-Matrix signature)"
+// @Failure		403		{object}	model.MatrixError			"The origin is not allowed to peer with us"
+// @Security		FederationAuth
+// @Router			/_matrix/federation/unstable/cc.etke.mrs/peering/rooms [get]
+func peerRooms(matrixSvc matrixService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		since, _ := strconv.ParseInt(c.QueryParam("since"), 10, 64) //nolint:errcheck // 0 means everything
+		limit, _ := strconv.Atoi(c.QueryParam("limit"))             //nolint:errcheck // 0 means default
+		return c.JSONBlob(matrixSvc.PeerRooms(c.Request().Context(), c.Request(), since, c.QueryParam("from"), limit))
+	}
+}
+
 // @Summary		Public rooms directory
 // @Description	Our slice of the federation public-rooms directory: the rooms we have crawled and indexed. Authenticated federation endpoint, so a missing or invalid X-Matrix signature is a 401. GET (query params) and POST (JSON filter body) share one handler, and a malformed POST body is logged then ignored, so you get the unfiltered listing rather than an error.
 // @Tags			matrix-s2s
@@ -6,6 +6,7 @@ import (
 	"slices"
 	"time"
 
+	echobasicauth "github.com/etkecc/go-echo-basic-auth"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"golang.org/x/time/rate"
@@ -20,6 +21,25 @@ var mForbidden = utils.MustJSON(&model.MatrixError{
 	Message: "forbidden",
 })
 
+// moderatorKey is the context key of the moderator account, see withRole
+const moderatorKey = "mrs.moderator"
+
+// withRole lets through moderators with the role (or a higher one), and only those not limited to specific servers if global is set.
+// Runs after the mod group's basic auth, which has already checked the credentials
+func withRole(auth *model.ConfigAuth, role string, global bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			login, _ := c.Get(echobasicauth.ContextLoginKey).(string)
+			moderator := auth.GetModerator(login)
+			if !moderator.HasRole(role) || (global && !moderator.IsGlobal()) {
+				return c.JSONBlob(http.StatusForbidden, mForbidden)
+			}
+			c.Set(moderatorKey, moderator)
+			return next(c)
+		}
+	}
+}
+
 func getRL(limit rate.Limit) echo.MiddlewareFunc {
 	cfg := middleware.DefaultRateLimiterConfig
 	cfg.Skipper = func(c echo.Context) bool {
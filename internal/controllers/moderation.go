@@ -2,10 +2,15 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/etkecc/go-apm"
+	echobasicauth "github.com/etkecc/go-echo-basic-auth"
+	"github.com/goccy/go-json"
 	"github.com/labstack/echo/v4"
 
 	"github.com/etkecc/mrs/internal/model"
@@ -13,29 +18,98 @@ import (
 )
 
 type moderationService interface {
-	Report(context.Context, string, string, string, bool) error
-	List(context.Context, ...string) ([]string, error)
-	ListReported(context.Context, ...string) (map[string]string, error)
-	Ban(context.Context, string) error
-	Unban(context.Context, string) error
-	Unreport(context.Context, string) error
+	Report(context.Context, string, string, string, string, bool) error
+	List(context.Context, ...string) ([]*model.RoomBan, error)
+	ListReported(context.Context, ...string) ([]*model.RoomReports, error)
+	Ban(context.Context, *model.RoomBan) error
+	Unban(context.Context, string, string, string) error
+	Unreport(context.Context, string, string, string) error
+	Quarantine(context.Context, *model.RoomQuarantine) error
+	Release(context.Context, string, string, string) error
+	ListQuarantined(context.Context, ...string) ([]*model.RoomQuarantine, error)
+	AuditLog(context.Context, *model.AuditFilter) ([]*model.AuditEntry, error)
+	BanRules(context.Context) ([]*model.BanRule, error)
+	PreviewBanRule(context.Context, *model.BanRule) (*model.BanRulePreview, error)
+	AddBanRule(context.Context, *model.BanRule) (*model.BanRulePreview, error)
+	RemoveBanRule(context.Context, string, string, string) (*model.BanRule, error)
+	PolicyStats() []*model.PolicyStats
+	ExportPolicies(context.Context) ([]*model.PolicyEvent, error)
+	FlagStats() []*model.FlagStats
+	CheckActionToken(context.Context, string) (*model.ActionToken, error)
+	ConfirmAction(context.Context, string, string, string) (*model.ActionToken, error)
+	Appeal(context.Context, string, string, string) error
+	CheckAppealToken(context.Context, string) (*model.Appeal, error)
+	VerifyAppeal(context.Context, string) (*model.Appeal, error)
+	ListAppeals(context.Context, ...string) ([]*model.Appeal, error)
+	DecideAppeal(context.Context, string, string, bool, string) (*model.Appeal, error)
+}
+
+// auditLimit is the default number of audit log entries per request, the export has no limit
+const auditLimit = 100
+
+// moderator returns who is performing the request, for the audit log
+func moderator(c echo.Context) string {
+	if login, ok := c.Get(echobasicauth.ContextLoginKey).(string); ok && login != "" {
+		return login
+	}
+	return "ip:" + c.RealIP()
+}
+
+// moderatorAccount returns the moderator account of the request, see withRole
+func moderatorAccount(c echo.Context) *model.Moderator {
+	account, _ := c.Get(moderatorKey).(*model.Moderator)
+	return account
+}
+
+// roomInScope returns true if the room's server is in the moderator's scope
+func roomInScope(c echo.Context, roomID string) bool {
+	return moderatorAccount(c).InScope(utils.ServerFrom(roomID))
+}
+
+// listScoped calls the list function for the server from the path, or for every server of the moderator's scope.
+// Returns false if the server is out of the scope
+func listScoped[T any](c echo.Context, list func(context.Context, ...string) ([]T, error)) ([]T, bool, error) {
+	ctx := c.Request().Context()
+	account := moderatorAccount(c)
+	if serverName := c.Param("server_name"); serverName != "" {
+		if !account.InScope(serverName) {
+			return nil, false, nil
+		}
+		items, err := list(ctx, serverName)
+		return items, true, err
+	}
+	if account.IsGlobal() {
+		items, err := list(ctx)
+		return items, true, err
+	}
+
+	var items []T
+	for _, serverName := range account.Servers {
+		serverItems, err := list(ctx, serverName)
+		if err != nil {
+			return nil, true, err
+		}
+		items = append(items, serverItems...)
+	}
+	return items, true, nil
 }
 
 type reportSubmission struct {
 	RoomID    string `param:"room_id"`
+	Category  string `json:"category"` // spam, harassment, illegal, csam, or other (default)
 	Reason    string `json:"reason"`
 	NoMSC1929 bool   `json:"no_msc1929"`
 }
 
 // @Summary		Report a room
-// @Description	Report a room for moderation. Open on purpose, no auth: anyone can flag a room, that is the whole point. The JSON body carries `reason` (5 chars minimum, or you get a 400) and an optional `no_msc1929` flag. This is the one honest, no-auth POST in the moderation set.
+// @Description	Report a room for moderation. Open on purpose, no auth: anyone can flag a room, that is the whole point. The JSON body carries `reason` (5 chars minimum, or you get a 400), an optional `category` (spam, harassment, illegal, csam, or other, the default) and an optional `no_msc1929` flag. Every reporter counts towards the room's priority, but only the first report, and a report of a more severe category than the room had so far, notify moderators. This is the one honest, no-auth POST in the moderation set.
 // @Tags			moderation
 // @Accept			json
 // @Produce		json
 // @Param			room_id	path	string				true	"Room ID being reported"
 // @Param			request	body	reportSubmission	true	"Report reason (5 chars minimum) and options"
 // @Success		202		"Report accepted"
-// @Failure		400		{object}	model.MatrixError	"Invalid room ID, or reason too short or missing"
+// @Failure		400		{object}	model.MatrixError	"Invalid room ID or category, or reason too short or missing"
 // @Failure		500		{object}	model.MatrixError	"Internal error while processing the report"
 // @Router			/mod/report/{room_id} [post]
 func report(svc moderationService) echo.HandlerFunc {
@@ -61,7 +135,17 @@ func report(svc moderationService) echo.HandlerFunc {
 			})
 		}
 
-		if err := svc.Report(c.Request().Context(), c.RealIP(), report.RoomID, report.Reason, report.NoMSC1929); err != nil {
+		if report.Category == "" {
+			report.Category = model.ReportOther
+		}
+		if !model.IsValidReportCategory(report.Category) {
+			return c.JSON(http.StatusBadRequest, &model.MatrixError{
+				Code:    "M_INVALID_PARAM",
+				Message: "Unknown report category.",
+			})
+		}
+
+		if err := svc.Report(c.Request().Context(), c.RealIP(), report.RoomID, report.Category, report.Reason, report.NoMSC1929); err != nil {
 			log.Error().Err(err).Msg("cannot report room")
 			return c.JSON(http.StatusInternalServerError, &model.MatrixError{
 				Code:    "M_INTERNAL_ERROR",
@@ -74,14 +158,15 @@ func report(svc moderationService) echo.HandlerFunc {
 }
 
 // @Summary		Clear a room's reports
-// @Description	Clears reports on a room. Two warts, both owned: it is a state-changing GET, and a User-Agent containing "bot" gets a 403 even with valid moderation credentials, a scar from crawlers tripping these endpoints, not a feature. There is also a no-room-id form (GET /mod/unreport) that hits the same handler with an empty ID.
+// @Description	Clears reports on a room. A User-Agent containing "bot" gets a 403 even with valid moderation credentials, a scar from crawlers tripping these endpoints back when they were GETs, not a feature. There is also a no-room-id form (POST /mod/unreport) that hits the same handler with an empty ID and clears every report.
 // @Tags			moderation
 // @Produce		json
 // @Security		ModerationAuth
 // @Param			room_id	path	string	true	"Room ID to clear reports for"
+// @Param			reason	query	string	false	"Reason, recorded in the audit log"
 // @Success		204		"Reports cleared"
-// @Failure		403		"User-Agent contains 'bot'"
-// @Router			/mod/unreport/{room_id} [get]
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/unreport/{room_id} [post]
 func unreport(svc moderationService) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		if strings.Contains(c.Request().UserAgent(), "bot") {
@@ -89,7 +174,10 @@ func unreport(svc moderationService) echo.HandlerFunc {
 		}
 
 		roomID := c.Param("room_id")
-		if err := svc.Unreport(c.Request().Context(), roomID); err != nil {
+		if roomID != "" && !roomInScope(c, roomID) {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
+		}
+		if err := svc.Unreport(c.Request().Context(), moderator(c), roomID, c.QueryParam("reason")); err != nil {
 			return err
 		}
 
@@ -98,13 +186,13 @@ func unreport(svc moderationService) echo.HandlerFunc {
 }
 
 // @Summary		List banned rooms
-// @Description	Lists banned room IDs. Append /{server_name} to filter to a single server. An empty list is a 204, not an empty 200. As with the rest of the mod group, a "bot" User-Agent gets a 403 even authenticated.
+// @Description	Lists ban records of the banned rooms: reason, category, moderator, and expiry of temporary bans. Bans made before bans had records only carry the room ID. Append /{server_name} to filter to a single server. An empty list is a 204, not an empty 200. As with the rest of the mod group, a "bot" User-Agent gets a 403 even authenticated.
 // @Tags			moderation
 // @Produce		json
 // @Security		ModerationAuth
-// @Success		200	{array}	string	"Banned room IDs"
+// @Success		200	{array}	model.RoomBan	"Ban records"
 // @Success		204	"No banned rooms"
-// @Failure		403	"User-Agent contains 'bot'"
+// @Failure		403	"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
 // @Router			/mod/list [get]
 func listBanned(svc moderationService) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -112,13 +200,9 @@ func listBanned(svc moderationService) echo.HandlerFunc {
 			return c.NoContent(http.StatusForbidden)
 		}
 
-		serverName := c.Param("server_name")
-		var list []string
-		var err error
-		if serverName != "" {
-			list, err = svc.List(c.Request().Context(), serverName)
-		} else {
-			list, err = svc.List(c.Request().Context())
+		list, ok, err := listScoped(c, svc.List)
+		if !ok {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
 		}
 		if err != nil {
 			return err
@@ -132,13 +216,13 @@ func listBanned(svc moderationService) echo.HandlerFunc {
 }
 
 // @Summary		List reported rooms
-// @Description	Lists reported rooms as a room-ID to reason map. Append /{server_name} to filter to a single server. Empty is a 204. A "bot" User-Agent gets a 403 even authenticated.
+// @Description	Lists reported rooms with their reports (one per reporter), highest priority first: the priority is the sum of the reports' category weights, so a single CSAM report outranks a pile of spam ones. Append /{server_name} to filter to a single server. Empty is a 204. A "bot" User-Agent gets a 403 even authenticated.
 // @Tags			moderation
 // @Produce		json
 // @Security		ModerationAuth
-// @Success		200	{object}	map[string]string	"Room ID to report reason"
+// @Success		200	{array}		model.RoomReports	"Reported rooms, most urgent first"
 // @Success		204	"No reported rooms"
-// @Failure		403	"User-Agent contains 'bot'"
+// @Failure		403	"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
 // @Router			/mod/list-reported [get]
 func listReported(svc moderationService) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -146,17 +230,14 @@ func listReported(svc moderationService) echo.HandlerFunc {
 			return c.NoContent(http.StatusForbidden)
 		}
 
-		serverName := c.Param("server_name")
-		var list map[string]string
-		var err error
-		if serverName != "" {
-			list, err = svc.ListReported(c.Request().Context(), serverName)
-		} else {
-			list, err = svc.ListReported(c.Request().Context())
+		list, ok, err := listScoped(c, svc.ListReported)
+		if !ok {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
 		}
 		if err != nil {
 			return err
 		}
+		model.SortRoomReports(list) // merged from several servers of the moderator's scope
 
 		if len(list) == 0 {
 			return c.NoContent(http.StatusNoContent)
@@ -166,22 +247,51 @@ func listReported(svc moderationService) echo.HandlerFunc {
 }
 
 // @Summary		Ban a room
-// @Description	Bans a room from the index. Yes, it is a GET that mutates state, we know, and no, we are not proud of it. And a User-Agent containing "bot" gets a 403 even with valid credentials, a scar from crawlers tripping this, not a feature. Both are real behavior, documented on purpose so they surprise you here and not in production.
+// @Description	Bans a room from the index, for good or, with `duration`, until the ban expires and is lifted automatically. The category defaults to the most severe category of the room's reports. It used to be a GET, and link previewers used to ban rooms, so now it's a POST and report notifications link to /mod/confirm instead. A User-Agent containing "bot" still gets a 403 even with valid credentials, a scar from those days, documented on purpose so it surprises you here and not in production.
 // @Tags			moderation
 // @Produce		json
 // @Security		ModerationAuth
-// @Param			room_id	path		string				true	"Room ID to ban"
-// @Success		200		{object}	map[string]string	"Confirmation message"
-// @Failure		403		"User-Agent contains 'bot'"
-// @Router			/mod/ban/{room_id} [get]
+// @Param			room_id		path		string				true	"Room ID to ban"
+// @Param			reason		query		string				false	"Reason, recorded in the ban and the audit log"
+// @Param			category	query		string				false	"Category: spam, harassment, illegal, csam, or other"
+// @Param			duration	query		string				false	"Temporary ban duration, e.g., 7d or 12h"
+// @Success		200			{object}	map[string]string	"Confirmation message"
+// @Failure		400			{object}	model.MatrixError	"Invalid category or duration"
+// @Failure		403			"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/ban/{room_id} [post]
 func ban(svc moderationService) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		if strings.Contains(c.Request().UserAgent(), "bot") {
 			return c.NoContent(http.StatusForbidden)
 		}
 
-		roomID := c.Param("room_id")
-		if err := svc.Ban(c.Request().Context(), roomID); err != nil {
+		if !roomInScope(c, c.Param("room_id")) {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
+		}
+		ban := &model.RoomBan{
+			RoomID:    c.Param("room_id"),
+			Reason:    c.QueryParam("reason"),
+			Category:  c.QueryParam("category"),
+			Moderator: moderator(c),
+		}
+		if ban.Category != "" && !model.IsValidReportCategory(ban.Category) {
+			return c.JSON(http.StatusBadRequest, &model.MatrixError{
+				Code:    "M_INVALID_PARAM",
+				Message: "Unknown ban category.",
+			})
+		}
+		if durationStr := c.QueryParam("duration"); durationStr != "" {
+			duration, err := parseBanDuration(durationStr)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, &model.MatrixError{
+					Code:    "M_INVALID_PARAM",
+					Message: "Invalid ban duration, use e.g. 7d or 12h.",
+				})
+			}
+			expiresAt := time.Now().UTC().Add(duration)
+			ban.ExpiresAt = &expiresAt
+		}
+		if err := svc.Ban(c.Request().Context(), ban); err != nil {
 			return err
 		}
 
@@ -189,15 +299,36 @@ func ban(svc moderationService) echo.HandlerFunc {
 	}
 }
 
+// parseBanDuration parses a Go duration, with days (7d) on top, because nobody bans a room for 168h
+func parseBanDuration(durationStr string) (time.Duration, error) {
+	var duration time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(durationStr, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		duration = time.Duration(n) * 24 * time.Hour
+	} else {
+		duration, err = time.ParseDuration(durationStr)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return duration, nil
+}
+
 // @Summary		Unban a room
-// @Description	Lifts a room ban. Same shape as ban: a state-changing GET, and a "bot" User-Agent gets a 403 even authenticated. Real behavior, owned.
+// @Description	Lifts a room ban. Same shape as ban: a POST, and a "bot" User-Agent gets a 403 even authenticated.
 // @Tags			moderation
 // @Produce		json
 // @Security		ModerationAuth
 // @Param			room_id	path		string				true	"Room ID to unban"
+// @Param			reason	query		string				false	"Reason, recorded in the audit log"
 // @Success		200		{object}	map[string]string	"Confirmation message"
-// @Failure		403		"User-Agent contains 'bot'"
-// @Router			/mod/unban/{room_id} [get]
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/unban/{room_id} [post]
 func unban(svc moderationService) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		if strings.Contains(c.Request().UserAgent(), "bot") {
@@ -205,10 +336,207 @@ func unban(svc moderationService) echo.HandlerFunc {
 		}
 
 		roomID := c.Param("room_id")
-		if err := svc.Unban(c.Request().Context(), roomID); err != nil {
+		if !roomInScope(c, roomID) {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
+		}
+		if err := svc.Unban(c.Request().Context(), moderator(c), roomID, c.QueryParam("reason")); err != nil {
 			return err
 		}
 
 		return c.JSON(http.StatusOK, map[string]string{"message": "the room has been unbanned"})
 	}
 }
+
+// @Summary		Quarantine a room
+// @Description	Hides a room from search and federation directory results, but keeps its data, so you can look at it calmly, then release it or escalate to a ban. The next parsing doesn't bring it back. Same shape as ban: a POST, and a "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Param			room_id	path		string				true	"Room ID to quarantine"
+// @Param			reason	query		string				false	"Reason, recorded in the quarantine and the audit log"
+// @Success		200		{object}	map[string]string	"Confirmation message"
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/quarantine/{room_id} [post]
+func quarantine(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		if !roomInScope(c, c.Param("room_id")) {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
+		}
+		err := svc.Quarantine(c.Request().Context(), &model.RoomQuarantine{
+			RoomID:    c.Param("room_id"),
+			Reason:    c.QueryParam("reason"),
+			Moderator: moderator(c),
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, map[string]string{"message": "the room has been quarantined"})
+	}
+}
+
+// @Summary		Release a room from quarantine
+// @Description	Lifts the quarantine and puts the room back into the index right away. To escalate instead, ban it, the ban replaces the quarantine. A POST, and a "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Param			room_id	path		string				true	"Room ID to release"
+// @Param			reason	query		string				false	"Reason, recorded in the audit log"
+// @Success		200		{object}	map[string]string	"Confirmation message"
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/release/{room_id} [post]
+func release(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		if !roomInScope(c, c.Param("room_id")) {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
+		}
+		if err := svc.Release(c.Request().Context(), moderator(c), c.Param("room_id"), c.QueryParam("reason")); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, map[string]string{"message": "the room has been released"})
+	}
+}
+
+// @Summary		List quarantined rooms
+// @Description	Lists quarantine records of the quarantined rooms. Append /{server_name} to filter to a single server. Empty is a 204. A "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Success		200	{array}	model.RoomQuarantine	"Quarantine records"
+// @Success		204	"No quarantined rooms"
+// @Failure		403	"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/list-quarantined [get]
+func listQuarantined(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		list, ok, err := listScoped(c, svc.ListQuarantined)
+		if !ok {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(list) == 0 {
+			return c.NoContent(http.StatusNoContent)
+		}
+		return c.JSON(http.StatusOK, list)
+	}
+}
+
+// @Summary		Moderation audit log
+// @Description	Who banned, unbanned, or cleared reports of what, when, and why, newest first. Every filter is optional and they combine. 100 entries by default, use /mod/audit/export for everything. A "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Param			actor	query		string				false	"Moderator login"
+// @Param			action	query		string				false	"Action: ban, unban, or unreport"
+// @Param			target	query		string				false	"Room ID"
+// @Param			server	query		string				false	"Server of the room"
+// @Param			since	query		string				false	"RFC3339 timestamp"
+// @Param			until	query		string				false	"RFC3339 timestamp"
+// @Param			limit	query		int					false	"Max entries, 100 by default"
+// @Success		200		{array}		model.AuditEntry	"Audit log entries"
+// @Failure		400		{object}	model.MatrixError	"Invalid filter"
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/audit [get]
+func auditLog(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		filter := &model.AuditFilter{Limit: auditLimit}
+		if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+			return c.JSON(http.StatusBadRequest, &model.MatrixError{
+				Code:    "M_INVALID_PARAM",
+				Message: "Invalid filter, timestamps must be RFC3339.",
+			})
+		}
+		if filter.Limit <= 0 {
+			filter.Limit = auditLimit
+		}
+		if !scopeAuditFilter(c, filter) {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
+		}
+		entries, err := svc.AuditLog(c.Request().Context(), filter)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, entries)
+	}
+}
+
+// scopeAuditFilter limits the filter to the moderator's scope, returns false if the filtered server is out of it
+func scopeAuditFilter(c echo.Context, filter *model.AuditFilter) bool {
+	account := moderatorAccount(c)
+	if filter.Server != "" && !account.InScope(filter.Server) {
+		return false
+	}
+	if !account.IsGlobal() {
+		filter.Servers = account.Servers
+	}
+	return true
+}
+
+// @Summary		Export the moderation audit log
+// @Description	The whole audit log (or the filtered part of it) as a JSON Lines download, one entry per line, newest first. Takes the same filters as /mod/audit, except limit. A "bot" User-Agent gets a 403 even authenticated.
+// @Tags			moderation
+// @Produce		json
+// @Security		ModerationAuth
+// @Param			actor	query		string				false	"Moderator login"
+// @Param			action	query		string				false	"Action: ban, unban, or unreport"
+// @Param			target	query		string				false	"Room ID"
+// @Param			server	query		string				false	"Server of the room"
+// @Param			since	query		string				false	"RFC3339 timestamp"
+// @Param			until	query		string				false	"RFC3339 timestamp"
+// @Success		200		{object}	model.AuditEntry	"One entry per line"
+// @Failure		400		{object}	model.MatrixError	"Invalid filter"
+// @Failure		403		"User-Agent contains 'bot', or the moderator's role or scope doesn't allow it"
+// @Router			/mod/audit/export [get]
+func auditLogExport(svc moderationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.Contains(c.Request().UserAgent(), "bot") {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		filter := &model.AuditFilter{}
+		if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+			return c.JSON(http.StatusBadRequest, &model.MatrixError{
+				Code:    "M_INVALID_PARAM",
+				Message: "Invalid filter, timestamps must be RFC3339.",
+			})
+		}
+		filter.Limit = 0
+		if !scopeAuditFilter(c, filter) {
+			return c.JSONBlob(http.StatusForbidden, mForbidden)
+		}
+		entries, err := svc.AuditLog(c.Request().Context(), filter)
+		if err != nil {
+			return err
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, "application/jsonl")
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="mrs-audit.jsonl"`)
+		c.Response().WriteHeader(http.StatusOK)
+		enc := json.NewEncoder(c.Response())
+		for _, entry := range entries {
+			if err := enc.Encode(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -63,9 +63,12 @@ func ConfigureRouter(
 	e.GET("/stats", stats(statsSvc))
 	e.GET("/avatar/:name/:id", avatar(matrixSvc), cacheSvc.MiddlewareImmutable(), getRL(100))
 	e.GET("/room/:room_id_or_alias", catalogRoom(dataSvc, matrixSvc, plausibleSvc), cacheSvc.Middleware(), getRL(3))
+	e.GET("/room/:room_id_or_alias/history", catalogRoomHistory(dataSvc), cacheSvc.Middleware(), getRL(3))
+	e.POST("/room-config/check", checkRoomConfig(crawlerSvc), getRL(1))
 	e.GET("/catalog/rooms", rooms(dataSvc), echobasicauth.NewMiddleware(&cfg.Get().Auth.Catalog))
 	e.GET("/catalog/servers", servers(crawlerSvc), echobasicauth.NewMiddleware(&cfg.Get().Auth.Catalog))
 	e.GET("/catalog/servers/objects", serversObjects(crawlerSvc), echobasicauth.NewMiddleware(&cfg.Get().Auth.Catalog))
+	e.GET("/catalog/policies", policies(modSvc), echobasicauth.NewMiddleware(&cfg.Get().Auth.Catalog))
 
 	rl := getRL(3)
 	searchCache := cacheSvc.MiddlewareSearch()
@@ -75,30 +78,67 @@ func ConfigureRouter(
 	e.GET("/search/:q/:l/:o", search(searchSvc, cfg, true), searchCache, rl)
 	e.GET("/search/:q/:l/:o/:s", search(searchSvc, cfg, true), searchCache, rl)
 	e.GET("/search/:q/:l/:o/:s/:rt", search(searchSvc, cfg, true), searchCache, rl)
+	e.GET("/trending", trending(searchSvc, cfg), searchCache, rl)
 
 	e.POST("/discover/bulk", addServers(dataSvc, cfg), echobasicauth.NewMiddleware(&cfg.Get().Auth.Discovery))
 	e.POST("/discover/:name", addServer(dataSvc), discoveryProtection(rl, cfg))
 	e.POST("/discover/msc1929/:name", checkMSC1929(), getRL(1))
 
-	e.POST("/mod/report/:room_id", report(modSvc), getRL(1)) // doesn't use mod group to allow without auth
+	e.POST("/mod/report/:room_id", report(modSvc), getRL(1))          // doesn't use mod group to allow without auth
+	e.GET("/mod/confirm/:token", confirmActionPage(modSvc), getRL(1)) // the signed token is the auth
+	e.POST("/mod/confirm/:token", confirmAction(modSvc), getRL(1))
+	e.POST("/mod/appeal/:room_id", fileAppeal(modSvc), getRL(1))           // the room's contact is the auth, see fileAppeal
+	e.GET("/mod/appeal/verify/:token", verifyAppealPage(modSvc), getRL(1)) // the signed token is the auth
+	e.POST("/mod/appeal/verify/:token", verifyAppeal(modSvc), getRL(1))
+	auth := cfg.Get().Auth
+	asViewer := withRole(auth, model.RoleViewer, false)
+	asModerator := withRole(auth, model.RoleModerator, false)
+	asGlobalViewer := withRole(auth, model.RoleViewer, true)
+	asGlobalModerator := withRole(auth, model.RoleModerator, true)
+	asAdmin := withRole(auth, model.RoleAdmin, true)
 	m := e.Group("mod")
-	m.Use(echobasicauth.NewMiddleware(&cfg.Get().Auth.Moderation))
-	m.GET("/list-reported", listReported(modSvc), rl)
-	m.GET("/list-reported/:server_name", listReported(modSvc), rl)
-	m.GET("/unreport/:room_id", unreport(modSvc), rl)
-	m.GET("/unreport", unreport(modSvc), rl)
-	m.GET("/list", listBanned(modSvc), rl)
-	m.GET("/list/:server_name", listBanned(modSvc), rl)
-	m.GET("/ban/:room_id", ban(modSvc), rl)
-	m.GET("/unban/:room_id", unban(modSvc), rl)
+	m.Use(echobasicauth.NewMiddleware(auth.ModeratorAuths()...))
+	m.GET("/list-reported", listReported(modSvc), rl, asViewer)
+	m.GET("/list-reported/:server_name", listReported(modSvc), rl, asViewer)
+	m.POST("/unreport/:room_id", unreport(modSvc), rl, asModerator)
+	m.POST("/unreport", unreport(modSvc), rl, asAdmin)
+	m.GET("/list", listBanned(modSvc), rl, asViewer)
+	m.GET("/list/:server_name", listBanned(modSvc), rl, asViewer)
+	m.POST("/ban/:room_id", ban(modSvc), rl, asModerator)
+	m.POST("/unban/:room_id", unban(modSvc), rl, asModerator)
+	m.POST("/quarantine/:room_id", quarantine(modSvc), rl, asModerator)
+	m.POST("/release/:room_id", release(modSvc), rl, asModerator)
+	m.GET("/list-quarantined", listQuarantined(modSvc), rl, asViewer)
+	m.GET("/list-quarantined/:server_name", listQuarantined(modSvc), rl, asViewer)
+	m.GET("/list-appeals", listAppeals(modSvc), rl, asViewer)
+	m.GET("/list-appeals/:server_name", listAppeals(modSvc), rl, asViewer)
+	m.POST("/accept-appeal/:room_id", acceptAppeal(modSvc), rl, asModerator)
+	m.POST("/reject-appeal/:room_id", rejectAppeal(modSvc), rl, asModerator)
+	m.GET("/audit", auditLog(modSvc), rl, asViewer)
+	m.GET("/audit/export", auditLogExport(modSvc), rl, asViewer)
+	m.GET("/rules", listBanRules(modSvc), rl, asGlobalViewer)
+	m.POST("/rules", addBanRule(modSvc), rl, asAdmin)
+	m.POST("/rules/preview", previewBanRule(modSvc), rl, asGlobalModerator)
+	m.DELETE("/rules/:id", removeBanRule(modSvc), rl, asAdmin)
+	m.GET("/policies", policyStats(modSvc), rl, asGlobalViewer)
+	m.GET("/flags", flagStats(modSvc), rl, asGlobalViewer)
+
+	w := e.Group("coordinator")
+	w.Use(echobasicauth.NewMiddleware(&cfg.Get().Auth.Workers))
+	w.POST("/lease", leaseBatch(crawlerSvc))
+	w.POST("/results/:id", submitResults(crawlerSvc))
 
 	a := e.Group("-")
 	a.Use(echobasicauth.NewMiddleware(&cfg.Get().Auth.Admin))
 	a.GET("/status", status(statsSvc))
+	a.GET("/seeds", seeds(crawlerSvc))
 	a.POST("/discover", discover(dataSvc, cfg))
 	a.POST("/parse", parse(dataSvc, cfg))
 	a.POST("/reindex", reindex(dataSvc))
 	a.POST("/full", full(dataSvc, cfg))
+	a.POST("/refresh/server/:name", refreshServer(dataSvc))
+	a.POST("/refresh/room/:room_id_or_alias", refreshRoom(dataSvc))
+	a.POST("/reset/backoff/:name", resetBackoff(matrixSvc))
 }
 
 func configureRouter(e *echo.Echo, cfgSvc configService, cacheSvc cacheService) {
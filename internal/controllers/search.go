@@ -13,7 +13,8 @@ import (
 )
 
 type searchService interface {
-	Search(ctx context.Context, req *http.Request, query, sortBy string, roomTypes []string, limit, offset int) ([]*model.Entry, int, error)
+	Search(ctx context.Context, req *http.Request, query, network, sortBy string, roomTypes []string, limit, offset int) ([]*model.Entry, int, error)
+	Trending(ctx context.Context, window, limit, offset int) []*model.TrendingEntry
 }
 
 // @Summary		Search rooms
@@ -23,7 +24,7 @@ type searchService interface {
 // @Param			q	query	string		false	"Search query"
 // @Param			l	query	int			false	"Limit"
 // @Param			o	query	int			false	"Offset"
-// @Param			s	query	string		false	"Sort field"
+// @Param			s	query	string		false	"Sort field, e.g. -members, or -first_seen for the newest rooms (works with an empty query too)"
 // @Param			rt	query	string		false	"Room type filter"
 // @Success		200	{array}	model.Entry	"Matching rooms"
 // @Success		204	"No matches"
@@ -47,7 +48,7 @@ func search(svc searchService, cfg configService, path bool) echo.HandlerFunc {
 		if roomType != "" {
 			roomTypes = []string{roomType}
 		}
-		entries, _, err := svc.Search(c.Request().Context(), c.Request(), query, sortBy, roomTypes, limit, offset)
+		entries, _, err := svc.Search(c.Request().Context(), c.Request(), query, "", sortBy, roomTypes, limit, offset)
 		if err != nil {
 			return err
 		}
@@ -57,3 +58,32 @@ func search(svc searchService, cfg configService, path bool) echo.HandlerFunc {
 		return c.JSON(http.StatusOK, entries)
 	}
 }
+
+// @Summary		Trending rooms
+// @Description	Rooms that gained the most members over a window, computed after each parsing from one member count sample per day. Only rooms that actually grew are listed, so a quiet week may be a 204.
+// @Tags			search
+// @Produce		json
+// @Param			w	query	int						false	"Window in days, one of the configured ones (7 and 30 by default), the first configured one if omitted"
+// @Param			l	query	int						false	"Limit"
+// @Param			o	query	int						false	"Offset"
+// @Success		200	{array}		model.TrendingEntry	"Trending rooms"
+// @Success		204	"No room grew over the window"
+// @Failure		400	{object}	model.MatrixError	"Window is not configured"
+// @Router			/trending [get]
+func trending(svc searchService, cfg configService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		window := kit.StringToInt(c.QueryParam("w"))
+		if window != 0 && !cfg.Get().Search.Trending.HasWindow(window) {
+			return c.JSONBlob(http.StatusBadRequest, utils.MustJSON(model.MatrixError{
+				Code:    "M_INVALID_PARAM",
+				Message: "unknown trending window",
+			}))
+		}
+
+		entries := svc.Trending(c.Request().Context(), window, kit.StringToInt(c.QueryParam("l")), kit.StringToInt(c.QueryParam("o")))
+		if len(entries) == 0 {
+			return c.NoContent(http.StatusNoContent)
+		}
+		return c.JSON(http.StatusOK, entries)
+	}
+}
@@ -24,6 +24,16 @@ func IncSearchQueries(api, server string) {
 	metrics.GetOrCreateCounter(fmt.Sprintf("mrs_search_queries{api=%q,server=%q}", api, server)).Inc()
 }
 
+// IncFederationFailure increments failed outgoing federation calls counter, by call
+func IncFederationFailure(call string) {
+	metrics.GetOrCreateCounter(fmt.Sprintf("mrs_federation_failures{call=%q}", call)).Inc()
+}
+
+// IncFederationBackoff increments outgoing federation calls skipped due to destination backoff counter, by call
+func IncFederationBackoff(call string) {
+	metrics.GetOrCreateCounter(fmt.Sprintf("mrs_federation_backoff{call=%q}", call)).Inc()
+}
+
 // Handler for metrics
 type Handler struct{}
 
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ActionTokenTTL is how long a moderation action link from a report notification works
+const ActionTokenTTL = 24 * time.Hour
+
+// action token errors
+var (
+	ErrActionTokenInvalid = errors.New("invalid action token")
+	ErrActionTokenExpired = errors.New("action token has expired")
+	ErrActionTokenUsed    = errors.New("action token has been used already")
+)
+
+// actionTokenActions are the moderation actions a link can confirm
+var actionTokenActions = map[string]bool{
+	AuditBan:        true,
+	AuditUnban:      true,
+	AuditQuarantine: true,
+	AuditRelease:    true,
+	AuditUnreport:   true,
+	AppealVerify:    true, // signed with a key of its own, see Moderation.appealTokenKey
+}
+
+// ActionToken is a signed moderation action on a room. It does nothing by itself, it opens a confirmation step
+type ActionToken struct {
+	Action    string    `json:"action"` // same as in the audit log: ban, unban, quarantine, release, or unreport
+	RoomID    string    `json:"room_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Sign returns the token as base64(action, room ID, expiry).base64(HMAC-SHA256 of them)
+func (t *ActionToken) Sign(key []byte) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(t.payload()))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(actionTokenMAC(key, payload))
+}
+
+func (t *ActionToken) payload() string {
+	return t.Action + "\n" + t.RoomID + "\n" + strconv.FormatInt(t.ExpiresAt.Unix(), 10)
+}
+
+func actionTokenMAC(key []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// ParseActionToken verifies the token's signature and expiry, and returns the token
+func ParseActionToken(key []byte, token string, now time.Time) (*ActionToken, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrActionTokenInvalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, actionTokenMAC(key, payload)) {
+		return nil, ErrActionTokenInvalid
+	}
+	payloadb, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrActionTokenInvalid
+	}
+	parts := strings.Split(string(payloadb), "\n")
+	if len(parts) != 3 || !actionTokenActions[parts[0]] || parts[1] == "" {
+		return nil, ErrActionTokenInvalid
+	}
+	expires, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, ErrActionTokenInvalid
+	}
+
+	parsed := &ActionToken{Action: parts[0], RoomID: parts[1], ExpiresAt: time.Unix(expires, 0).UTC()}
+	if !now.Before(parsed.ExpiresAt) {
+		return nil, ErrActionTokenExpired
+	}
+	return parsed, nil
+}
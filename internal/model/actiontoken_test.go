@@ -0,0 +1,33 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActionToken(t *testing.T) {
+	key := []byte("key")
+	now := time.Now()
+	token := (&ActionToken{Action: AuditBan, RoomID: "!r:host.example", ExpiresAt: now.Add(time.Hour)}).Sign(key)
+
+	parsed, err := ParseActionToken(key, token, now)
+	if err != nil || parsed.Action != AuditBan || parsed.RoomID != "!r:host.example" {
+		t.Fatalf("ParseActionToken() = %+v, %v", parsed, err)
+	}
+	if _, err := ParseActionToken(key, token, now.Add(2*time.Hour)); !errors.Is(err, ErrActionTokenExpired) {
+		t.Errorf("ParseActionToken() later = %v, want expired", err)
+	}
+	if _, err := ParseActionToken([]byte("other key"), token, now); !errors.Is(err, ErrActionTokenInvalid) {
+		t.Errorf("ParseActionToken() with another key = %v, want invalid", err)
+	}
+
+	// a valid signature of something else doesn't make a ban out of an unban
+	unban := (&ActionToken{Action: AuditUnban, RoomID: "!r:host.example", ExpiresAt: now.Add(time.Hour)}).Sign(key)
+	payload, _, _ := strings.Cut(token, ".")
+	_, signature, _ := strings.Cut(unban, ".")
+	if _, err := ParseActionToken(key, payload+"."+signature, now); !errors.Is(err, ErrActionTokenInvalid) {
+		t.Errorf("ParseActionToken() of a tampered token = %v, want invalid", err)
+	}
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// appeal statuses
+const (
+	AppealUnverified = "unverified" // waiting for the contact to open the verification link
+	AppealPending    = "pending"    // waiting for moderators
+	AppealAccepted   = "accepted"   // the room has been unbanned
+	AppealRejected   = "rejected"
+)
+
+// AppealVerify is the action of an appeal's verification token
+const AppealVerify = "verify_appeal"
+
+// AppealVerifyTTL is how long the verification link of an appeal works
+const AppealVerifyTTL = 24 * time.Hour
+
+// MaxAppealReason is the maximum length of an appeal's reason, it's a plea, not a novel
+const MaxAppealReason = 2000
+
+// ErrAppealNotPending is returned when a moderator decides on an appeal that waits for nothing
+var ErrAppealNotPending = errors.New("appeal is not pending")
+
+// Appeal of a banned room, filed by one of the room's contacts. A room has one appeal at a time, a new one replaces a closed one
+type Appeal struct {
+	RoomID     string     `json:"room_id"`
+	Email      string     `json:"email"` // the contact who filed the appeal, the decision goes there
+	Reason     string     `json:"reason"`
+	Status     string     `json:"status"` // unverified, pending, accepted, or rejected
+	CreatedAt  time.Time  `json:"created_at"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	DecidedAt  *time.Time `json:"decided_at,omitempty"`
+	Moderator  string     `json:"moderator,omitempty"` // actor of the decision, as in the audit log
+	Decision   string     `json:"decision,omitempty"`  // moderator's reason, sent to the contact
+}
+
+// VerifyExpiresAt returns when the verification link of the appeal expires, the link's token is tied to the appeal by it
+func (a *Appeal) VerifyExpiresAt() time.Time {
+	return a.CreatedAt.Add(AppealVerifyTTL).Truncate(time.Second)
+}
+
+// IsOpen returns true if the appeal is pending, or is unverified and its verification link still works
+func (a *Appeal) IsOpen(now time.Time) bool {
+	if a == nil {
+		return false
+	}
+	switch a.Status {
+	case AppealPending:
+		return true
+	case AppealUnverified:
+		return now.Before(a.VerifyExpiresAt())
+	default:
+		return false
+	}
+}
+
+// IsAppealContact returns true if the email is one of the contacts, case-insensitive
+func IsAppealContact(email string, contacts []string) bool {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return false
+	}
+	return slices.ContainsFunc(contacts, func(contact string) bool { return strings.EqualFold(strings.TrimSpace(contact), email) })
+}
+
+// SortAppeals sorts appeals by status (pending first, unverified last), then newest first
+func SortAppeals(appeals []*Appeal) {
+	order := map[string]int{AppealPending: 0, AppealAccepted: 1, AppealRejected: 1, AppealUnverified: 2}
+	slices.SortStableFunc(appeals, func(a, b *Appeal) int {
+		if order[a.Status] != order[b.Status] {
+			return order[a.Status] - order[b.Status]
+		}
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+}
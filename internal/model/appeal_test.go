@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+// an unverified appeal holds the room only while its link works, a decided one doesn't hold it at all.
+func TestAppeal_IsOpen(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		appeal *Appeal
+		want   bool
+	}{
+		{nil, false},
+		{&Appeal{Status: AppealUnverified, CreatedAt: now.Add(-time.Hour)}, true},
+		{&Appeal{Status: AppealUnverified, CreatedAt: now.Add(-AppealVerifyTTL - time.Minute)}, false},
+		{&Appeal{Status: AppealPending, CreatedAt: now.Add(-30 * 24 * time.Hour)}, true},
+		{&Appeal{Status: AppealAccepted, CreatedAt: now}, false},
+		{&Appeal{Status: AppealRejected, CreatedAt: now}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.appeal.IsOpen(now); got != tt.want {
+			t.Errorf("%+v.IsOpen() = %v, want %v", tt.appeal, got, tt.want)
+		}
+	}
+}
+
+func TestIsAppealContact(t *testing.T) {
+	contacts := []string{"Admin@Host.example", " abuse@host.example "}
+	tests := map[string]bool{
+		"admin@host.example":  true,
+		" abuse@host.example": true,
+		"admin@other.example": false,
+		"":                    false,
+	}
+	for email, want := range tests {
+		if got := IsAppealContact(email, contacts); got != want {
+			t.Errorf("IsAppealContact(%q) = %v, want %v", email, got, want)
+		}
+	}
+	if IsAppealContact("", []string{""}) {
+		t.Error("an empty email matched an empty contact")
+	}
+}
+
+func TestSortAppeals(t *testing.T) {
+	now := time.Now()
+	appeals := []*Appeal{
+		{RoomID: "!unverified", Status: AppealUnverified, CreatedAt: now},
+		{RoomID: "!old-rejected", Status: AppealRejected, CreatedAt: now.Add(-2 * time.Hour)},
+		{RoomID: "!pending", Status: AppealPending, CreatedAt: now.Add(-3 * time.Hour)},
+		{RoomID: "!accepted", Status: AppealAccepted, CreatedAt: now.Add(-time.Hour)},
+	}
+	SortAppeals(appeals)
+	want := []string{"!pending", "!accepted", "!old-rejected", "!unverified"}
+	for i, appeal := range appeals {
+		if appeal.RoomID != want[i] {
+			t.Fatalf("appeals[%d] = %s, want %s", i, appeal.RoomID, want[i])
+		}
+	}
+}
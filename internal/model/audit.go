@@ -0,0 +1,100 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// moderation actions recorded in the audit log
+const (
+	AuditBan            = "ban"
+	AuditUnban          = "unban"
+	AuditUnreport       = "unreport"
+	AuditQuarantine     = "quarantine"
+	AuditRelease        = "release"
+	AuditBanRule        = "ban_rule"         // target is the rule as kind:pattern
+	AuditUnbanRule      = "unban_rule"       // target is the rule as kind:pattern
+	AuditRejectAppeal   = "reject_appeal"    // accepted appeals are recorded as unbans
+	AuditSkipPolicyRule = "skip_policy_rule" // target is the rule as type:entity, it matched too many rooms to apply
+)
+
+// AuditActorSystem is the actor of actions MRS does on its own, e.g., lifting expired bans
+const AuditActorSystem = "system"
+
+// AuditEntry is a single moderation action, the audit log is append-only
+type AuditEntry struct {
+	ID       uint64      `json:"id"`
+	At       time.Time   `json:"at"`
+	Actor    string      `json:"actor"` // login of the moderator, ip:address if the moderation auth has no login, or link:recipients (ip:address) of a confirmed action link
+	Action   string      `json:"action"`
+	Target   string      `json:"target"` // room ID, or kind:pattern of a ban rule
+	Reason   string      `json:"reason,omitempty"`
+	Previous *AuditState `json:"previous,omitempty"` // state of the target right before the action
+}
+
+// AuditState is what the moderation knew about the target
+type AuditState struct {
+	Banned     bool            `json:"banned"`
+	Ban        *RoomBan        `json:"ban,omitempty"`
+	Quarantine *RoomQuarantine `json:"quarantine,omitempty"`
+	Reported   bool            `json:"reported"`
+	Reports    int             `json:"reports,omitempty"`  // number of reporters
+	Category   string          `json:"category,omitempty"` // the most severe category of the reports
+	Report     string          `json:"report,omitempty"`   // reason of the latest report
+}
+
+// SetReports records the room's reports (nil if there are none)
+func (s *AuditState) SetReports(reports *RoomReports) {
+	latest := reports.Latest()
+	if latest == nil {
+		return
+	}
+	s.Reported = true
+	s.Reports = reports.Count
+	s.Category = reports.TopCategory()
+	s.Report = latest.Reason
+}
+
+// AuditFilter of the audit log, empty fields match everything
+type AuditFilter struct {
+	Actor  string    `query:"actor"`
+	Action string    `query:"action"`
+	Target string    `query:"target"`
+	Server string    `query:"server"` // server of the target
+	Since  time.Time `query:"since"`  // RFC3339
+	Until  time.Time `query:"until"`  // RFC3339
+	Limit  int       `query:"limit"`  // 0 means no limit
+
+	Servers []string // servers of the targets, the scope of the moderator asking; set by MRS, not by the query
+}
+
+// Match returns true if the entry passes the filter
+func (f *AuditFilter) Match(entry *AuditEntry) bool {
+	if f == nil {
+		return true
+	}
+	if f.Actor != "" && entry.Actor != f.Actor {
+		return false
+	}
+	if f.Action != "" && entry.Action != f.Action {
+		return false
+	}
+	if f.Target != "" && entry.Target != f.Target {
+		return false
+	}
+	if f.Server != "" && utils.ServerFrom(entry.Target) != f.Server {
+		return false
+	}
+	if len(f.Servers) > 0 && !slices.Contains(f.Servers, utils.ServerFrom(entry.Target)) {
+		return false
+	}
+	if !f.Since.IsZero() && entry.At.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && entry.At.After(f.Until) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// RoomBan is a ban record of a room
+type RoomBan struct {
+	RoomID    string     `json:"room_id"`
+	Reason    string     `json:"reason,omitempty"`
+	Category  string     `json:"category,omitempty"`  // same categories as reports
+	Moderator string     `json:"moderator,omitempty"` // actor of the ban, as in the audit log
+	CreatedAt time.Time  `json:"created_at"`          // zero for bans made before bans had records
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Email     string     `json:"email,omitempty"` // the room's contact at the time of the ban, the room's data is gone after it, see Appeal
+}
+
+// IsExpired returns true if the ban is temporary and its time is up
+func (b *RoomBan) IsExpired(now time.Time) bool {
+	return b.ExpiresAt != nil && !now.Before(*b.ExpiresAt)
+}
+
+// RoomQuarantine is a quarantine record of a room: the room is kept, but hidden from search and directory results
+// until moderators release it or ban it
+type RoomQuarantine struct {
+	RoomID    string    `json:"room_id"`
+	Reason    string    `json:"reason,omitempty"`
+	Moderator string    `json:"moderator,omitempty"` // actor of the quarantine, as in the audit log
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// ban rule kinds
+const (
+	BanRuleServer = "server" // exact server name
+	BanRuleAlias  = "alias"  // regex, matched against room aliases
+	BanRuleText   = "text"   // regex, matched against room names and topics
+)
+
+// MaxBanRulePattern is the max length of a ban rule pattern
+const MaxBanRulePattern = 512
+
+// BanRule bans every room matching the pattern, now and in the future
+type BanRule struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Pattern   string    `json:"pattern"`
+	Reason    string    `json:"reason,omitempty"`
+	Category  string    `json:"category,omitempty"` // same categories as reports
+	Moderator string    `json:"moderator,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	re *regexp.Regexp
+}
+
+// BanRulePreview is what a ban rule would remove from the index
+type BanRulePreview struct {
+	Rule   *BanRule `json:"rule"`
+	Rooms  int      `json:"rooms"`  // number of matching rooms
+	Sample []string `json:"sample"` // IDs of the first matching rooms
+}
+
+// Compile validates the rule and prepares it for matching, must be called before Match*
+func (r *BanRule) Compile() error {
+	if r.Pattern == "" || len(r.Pattern) > MaxBanRulePattern {
+		return fmt.Errorf("pattern must be 1 to %d characters long", MaxBanRulePattern)
+	}
+	if r.Category != "" && !IsValidReportCategory(r.Category) {
+		return fmt.Errorf("unknown category %q", r.Category)
+	}
+	switch r.Kind {
+	case BanRuleServer:
+		return nil
+	case BanRuleAlias, BanRuleText:
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return err
+		}
+		r.re = re
+		return nil
+	default:
+		return fmt.Errorf("unknown kind %q", r.Kind)
+	}
+}
+
+// String returns the rule as kind:pattern, the rule's target in the audit log
+func (r *BanRule) String() string {
+	return r.Kind + ":" + r.Pattern
+}
+
+// MatchServer returns true if the rule bans the server
+func (r *BanRule) MatchServer(server string) bool {
+	return r.Kind == BanRuleServer && server != "" && r.Pattern == server
+}
+
+// MatchID returns true if the rule bans the room ID or alias (by its server, or by the alias itself)
+func (r *BanRule) MatchID(matrixID string) bool {
+	if r.MatchServer(utils.ServerFrom(matrixID)) {
+		return true
+	}
+	return r.Kind == BanRuleAlias && r.re != nil && len(matrixID) > 0 && matrixID[0] == '#' && r.re.MatchString(matrixID)
+}
+
+// MatchText returns true if the rule bans any of the texts (room name, topic)
+func (r *BanRule) MatchText(texts ...string) bool {
+	if r.Kind != BanRuleText || r.re == nil {
+		return false
+	}
+	for _, text := range texts {
+		if text != "" && r.re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchRoom returns true if the rule bans the room
+func (r *BanRule) MatchRoom(room *MatrixRoom) bool {
+	return r.MatchID(room.ID) || r.MatchID(room.Alias) || r.MatchServer(room.GetOwnServer()) || r.MatchText(room.Name, room.Topic)
+}
@@ -0,0 +1,40 @@
+package model
+
+import "testing"
+
+func TestBanRule_MatchRoom(t *testing.T) {
+	room := &MatrixRoom{ID: "!r:host.example", Alias: "#free-crypto:other.example", Server: "other.example", Name: "Free Crypto", Topic: "airdrop every day"}
+	tests := []struct {
+		rule  *BanRule
+		match bool
+	}{
+		{&BanRule{Kind: BanRuleServer, Pattern: "host.example"}, true},  // room ID server
+		{&BanRule{Kind: BanRuleServer, Pattern: "other.example"}, true}, // alias server
+		{&BanRule{Kind: BanRuleServer, Pattern: "example"}, false},      // exact names only
+		{&BanRule{Kind: BanRuleAlias, Pattern: `^#free-.*`}, true},
+		{&BanRule{Kind: BanRuleAlias, Pattern: `airdrop`}, false}, // aliases only
+		{&BanRule{Kind: BanRuleText, Pattern: `(?i)airdrop`}, true},
+		{&BanRule{Kind: BanRuleText, Pattern: `crypto`}, false}, // case-sensitive unless told otherwise
+	}
+	for _, test := range tests {
+		if err := test.rule.Compile(); err != nil {
+			t.Fatalf("%s: Compile() = %v", test.rule, err)
+		}
+		if match := test.rule.MatchRoom(room); match != test.match {
+			t.Errorf("%s: MatchRoom() = %v, want %v", test.rule, match, test.match)
+		}
+	}
+}
+
+func TestBanRule_Compile(t *testing.T) {
+	for _, rule := range []*BanRule{
+		{Kind: "room", Pattern: "x"},
+		{Kind: BanRuleServer},
+		{Kind: BanRuleText, Pattern: "(unclosed"},
+		{Kind: BanRuleServer, Pattern: "host.example", Category: "rude"},
+	} {
+		if err := rule.Compile(); err == nil {
+			t.Errorf("%+v: Compile() = nil, want an error", rule)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package model
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
 	echobasicauth "github.com/etkecc/go-echo-basic-auth"
 	"github.com/etkecc/go-msc1929"
 )
@@ -10,6 +15,7 @@ type Config struct {
 	Address      string              `yaml:"address"`
 	Port         string              `yaml:"port"`
 	SentryDSN    string              `yaml:"sentry_dsn"`
+	Secret       string              `yaml:"secret"` // random, behind reporter hashes and moderation links; generated into the data dir if not set, see -genkey
 	Healthchecks *ConfigHealthchecks `yaml:"healthchecks"`
 	Public       *ConfigPublic       `yaml:"public"`
 	Matrix       *ConfigMatrix       `yaml:"matrix"`
@@ -25,7 +31,14 @@ type Config struct {
 	Plausible    *ConfigPlausible    `yaml:"plausible"`
 	Languages    []string            `yaml:"languages"`
 	Servers      []string            `yaml:"servers"`
+	Seeds        []*ConfigSeed       `yaml:"seeds"`
+	Policies     []*ConfigSeed       `yaml:"policies"` // moderation policy lists, same sources as seeds
+	Peering      *ConfigPeering      `yaml:"peering"`
+	Networks     *ConfigNetworks     `yaml:"networks"`
 	Blocklist    *ConfigBlocklist    `yaml:"blocklist"`
+	Flags        []*FlagRule         `yaml:"flags"` // automatic moderation, evaluated on indexing and refresh
+	Retention    *ConfigRetention    `yaml:"retention"`
+	Coordinator  *ConfigCoordinator  `yaml:"coordinator"`
 }
 
 // ConfigHealthchecks - healthchecks.io configuration
@@ -45,6 +58,39 @@ type ConfigPublic struct {
 type ConfigSearch struct {
 	Defaults   ConfigSearchDefaults     `yaml:"defaults"`
 	Highlights []*ConfigSearchHighlight `yaml:"highlights"`
+	Trending   ConfigSearchTrending     `yaml:"trending"`
+}
+
+// ConfigSearchTrending - trending rooms configuration
+type ConfigSearchTrending struct {
+	Windows    []int `yaml:"windows"`    // in days, 7 and 30 if not set
+	Federation int   `yaml:"federation"` // window (in days) to order the federation empty-query directory by, 0 keeps the biggest rooms order
+}
+
+// GetWindows returns configured trending windows, in days
+func (c ConfigSearchTrending) GetWindows() []int {
+	if len(c.Windows) == 0 {
+		return []int{7, 30}
+	}
+	return c.Windows
+}
+
+// HasWindow returns true if the window (in days) is configured
+func (c ConfigSearchTrending) HasWindow(window int) bool {
+	for _, w := range c.GetWindows() {
+		if w == window {
+			return true
+		}
+	}
+	return false
+}
+
+// validate rejects a federation window that is not one of the windows, there would be no trending list to order by
+func (c ConfigSearchTrending) validate() error {
+	if c.Federation != 0 && !c.HasWindow(c.Federation) {
+		return fmt.Errorf("search.trending.federation: %d is not one of search.trending.windows %v", c.Federation, c.GetWindows())
+	}
+	return nil
 }
 
 // ConfigSearchDefaults default params
@@ -93,6 +139,21 @@ func (c *ConfigSearchHighlight) Entry() *Entry {
 	}
 }
 
+// Validate returns an error if the config is loaded fine, but would make MRS misbehave
+func (c *Config) Validate() error {
+	if c.Search != nil {
+		if err := c.Search.Trending.validate(); err != nil {
+			return err
+		}
+	}
+	if c.Auth != nil {
+		if err := c.Auth.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ConfigPlausible - plausible analytics configuration
 type ConfigPlausible struct {
 	Host   string `yaml:"host"`
@@ -111,7 +172,72 @@ type ConfigAuth struct {
 	Metrics    echobasicauth.Auth `yaml:"metrics"`
 	Catalog    echobasicauth.Auth `yaml:"catalog"`
 	Discovery  echobasicauth.Auth `yaml:"discovery"`
-	Moderation echobasicauth.Auth `yaml:"moderation"`
+	Moderation echobasicauth.Auth `yaml:"moderation"` // an admin moderator with access to everything, see Moderators for more
+	Moderators []*ConfigModerator `yaml:"moderators"` // named moderator accounts with roles and scopes, logins must be unique
+	Workers    echobasicauth.Auth `yaml:"workers"`    // crawl workers talking to the coordinator
+}
+
+// ConfigModerator - a moderator account
+type ConfigModerator struct {
+	echobasicauth.Auth `yaml:",inline"`
+	Role               string   `yaml:"role"`    // viewer, moderator, or admin
+	Servers            []string `yaml:"servers"` // homeservers the moderator is limited to, all if empty
+}
+
+// ModeratorAuths returns credentials of all moderator accounts, auth.moderation included if it's set (or if it's the only one)
+func (c *ConfigAuth) ModeratorAuths() []*echobasicauth.Auth {
+	auths := make([]*echobasicauth.Auth, 0, len(c.Moderators)+1)
+	if c.Moderation.Login != "" || len(c.Moderators) == 0 {
+		auths = append(auths, &c.Moderation)
+	}
+	for _, moderator := range c.Moderators {
+		if moderator.isValid() {
+			auths = append(auths, &moderator.Auth)
+		}
+	}
+	return auths
+}
+
+// GetModerator returns the moderator account of the login, nil if there is none. auth.moderation is a global admin
+func (c *ConfigAuth) GetModerator(login string) *Moderator {
+	if login == c.Moderation.Login && (login != "" || len(c.Moderators) == 0) {
+		return &Moderator{Login: login, Role: RoleAdmin}
+	}
+	for _, moderator := range c.Moderators {
+		if !moderator.isValid() || moderator.Login != login {
+			continue
+		}
+		servers := make([]string, 0, len(moderator.Servers))
+		for _, server := range moderator.Servers {
+			servers = append(servers, strings.ToLower(strings.TrimSpace(server)))
+		}
+		return &Moderator{Login: login, Role: moderator.Role, Servers: servers}
+	}
+	return nil
+}
+
+// validate rejects moderator logins used more than once: a role is resolved by the login,
+// so a duplicate would get the role of whichever account is checked first (auth.moderation, a global admin)
+func (c *ConfigAuth) validate() error {
+	logins := map[string]bool{}
+	if c.Moderation.Login != "" {
+		logins[c.Moderation.Login] = true
+	}
+	for _, moderator := range c.Moderators {
+		if moderator == nil || moderator.Login == "" {
+			continue
+		}
+		if logins[moderator.Login] {
+			return fmt.Errorf("auth.moderators: login %q is used more than once, auth.moderation included", moderator.Login)
+		}
+		logins[moderator.Login] = true
+	}
+	return nil
+}
+
+// isValid returns true if the account can be used: it has a login and a known role
+func (c *ConfigModerator) isValid() bool {
+	return c != nil && c.Login != "" && IsValidRole(c.Role)
 }
 
 // ConfigWebhooks - webhooks related config
@@ -142,8 +268,147 @@ type ConfigBatch struct {
 
 // ConfigWorkers - workers related configuration
 type ConfigWorkers struct {
-	Discovery int `yaml:"discovery"`
-	Parsing   int `yaml:"parsing"`
+	Discovery   int `yaml:"discovery"`
+	Parsing     int `yaml:"parsing"`
+	PerIP       int `yaml:"per_ip"`        // max in-flight publicRooms requests per resolved IP, shrinks on errors and 429s
+	PageSize    int `yaml:"page_size"`     // publicRooms page size to start from and never exceed
+	MinPageSize int `yaml:"min_page_size"` // floor for the adaptive page size
+}
+
+// ConfigSeed - an external list of servers pulled on each discovery run (or of policy events, see Config.Policies)
+type ConfigSeed struct {
+	Name     string `yaml:"name"`     // for logs and stats, path or url if not set
+	Path     string `yaml:"path"`     // local file
+	URL      string `yaml:"url"`      // http(s) url, e.g. another MRS instance's /catalog/servers
+	Login    string `yaml:"login"`    // (optional) basic auth
+	Password string `yaml:"password"` // (optional) basic auth
+}
+
+// String returns the seed's name
+func (c *ConfigSeed) String() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	if c.Path != "" {
+		return c.Path
+	}
+	return c.URL
+}
+
+// ConfigPeering - exchange of server catalogs and rooms with other MRS instances, signed with matrix server keys
+type ConfigPeering struct {
+	Peers []*ConfigPeer `yaml:"peers"` // instances to pull from
+	Allow []string      `yaml:"allow"` // server names of instances allowed to pull from this one, "*" for anyone who signs the request
+}
+
+// ConfigPeer - an MRS instance to pull from
+type ConfigPeer struct {
+	ServerName string `yaml:"server_name"` // the instance's matrix server name
+	Rooms      bool   `yaml:"rooms"`       // pull rooms the peer has parsed too, not only its server catalog
+}
+
+// IsAllowed returns true if the instance may pull this instance's feed
+func (c *ConfigPeering) IsAllowed(serverName string) bool {
+	if c == nil || serverName == "" {
+		return false
+	}
+	return slices.Contains(c.Allow, "*") || slices.Contains(c.Allow, serverName)
+}
+
+// GetPeers returns instances to pull from
+func (c *ConfigPeering) GetPeers() []*ConfigPeer {
+	if c == nil {
+		return nil
+	}
+	return c.Peers
+}
+
+// ConfigNetworks - third-party network directories (rooms bridged from IRC, XMPP, etc.) config
+type ConfigNetworks struct {
+	// All queries every indexable server with include_all_networks as well, bridged rooms found that way are tagged "thirdparty"
+	All bool `yaml:"all"`
+	// Instances are third_party_instance_id -> network name to crawl, per server name
+	Instances map[string]map[string]string `yaml:"instances"`
+}
+
+// IsEnabled returns true if any third-party network directory is crawled
+func (c *ConfigNetworks) IsEnabled() bool {
+	return c != nil && (c.All || len(c.Instances) > 0)
+}
+
+// Names returns the tags of all crawled third-party networks, sorted
+func (c *ConfigNetworks) Names() []string {
+	if !c.IsEnabled() {
+		return nil
+	}
+	names := []string{}
+	for _, instances := range c.Instances {
+		for _, name := range instances {
+			if name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	if c.All && !slices.Contains(names, NetworkThirdParty) {
+		names = append(names, NetworkThirdParty)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ConfigRetention - how long stale rooms and dead servers are kept
+type ConfigRetention struct {
+	Rooms      int                       `yaml:"rooms"`       // days since the last parse before a room is removed, 7 if not set
+	Servers    int                       `yaml:"servers"`     // days since a server was last online before it's removed, 30 if not set
+	Backoff    []*ConfigRetentionBackoff `yaml:"backoff"`     // redial schedule of offline servers, default if not set, [] dials them every run
+	MaxRemoval int                       `yaml:"max_removal"` // percent of rooms (or servers) one run may remove, 30 if not set, 100 to disable
+	DryRun     bool                      `yaml:"dry_run"`     // log what would be removed, remove nothing
+}
+
+// ConfigRetentionBackoff - a step of the offline servers redial schedule
+type ConfigRetentionBackoff struct {
+	After int `yaml:"after"` // days offline
+	Every int `yaml:"every"` // days between dials
+}
+
+// GetRooms returns the rooms retention window
+func (c *ConfigRetention) GetRooms() time.Duration {
+	if c == nil || c.Rooms <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(c.Rooms) * 24 * time.Hour
+}
+
+// GetServers returns the offline servers retention window
+func (c *ConfigRetention) GetServers() time.Duration {
+	if c == nil || c.Servers <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(c.Servers) * 24 * time.Hour
+}
+
+// GetBackoff returns the redial schedule steps, sorted by After.
+// Default: every run for the first week, every 4 days for the second, weekly after that
+func (c *ConfigRetention) GetBackoff() []*ConfigRetentionBackoff {
+	if c == nil || c.Backoff == nil {
+		return []*ConfigRetentionBackoff{{After: 7, Every: 4}, {After: 14, Every: 7}}
+	}
+	steps := slices.Clone(c.Backoff)
+	slices.SortFunc(steps, func(a, b *ConfigRetentionBackoff) int { return a.After - b.After })
+	return steps
+}
+
+// GetMaxRemoval returns the mass deletion threshold, in percent
+func (c *ConfigRetention) GetMaxRemoval() int {
+	if c == nil || c.MaxRemoval <= 0 {
+		return 30
+	}
+	return c.MaxRemoval
+}
+
+// IsDryRun returns true if nothing should be removed
+func (c *ConfigRetention) IsDryRun() bool {
+	return c != nil && c.DryRun
 }
 
 // ConfigBlocklist - blocklist related configuration
@@ -174,7 +439,9 @@ type ConfigEmailPostmarkType struct {
 
 // ConfigEmailTemplates - email templates config
 type ConfigEmailTemplates struct {
-	Report ConfigEmailTemplate `yaml:"report"`
+	Report         ConfigEmailTemplate `yaml:"report"`
+	AppealVerify   ConfigEmailTemplate `yaml:"appeal_verify"`   // optional, a built-in one is used if empty
+	AppealDecision ConfigEmailTemplate `yaml:"appeal_decision"` // optional, a built-in one is used if empty
 }
 
 // ConfigEmailTemplate - email template config
@@ -190,3 +457,35 @@ type ConfigMatrix struct {
 	Keys       []string          `yaml:"keys"`
 	OldKeys    []string          `yaml:"old_keys"`
 }
+
+// ConfigCoordinator - distributed crawling: the coordinator hands out server batches to `mrs worker` processes
+type ConfigCoordinator struct {
+	Enabled   bool `yaml:"enabled"`    // coordinator side: share discovery and parsing with remote workers
+	BatchSize int  `yaml:"batch_size"` // coordinator side: servers per batch
+	Lease     int  `yaml:"lease"`      // coordinator side: seconds a worker has to return a batch before it is handed out again
+
+	URL      string `yaml:"url"`      // worker side: the coordinator's base url
+	Login    string `yaml:"login"`    // worker side: auth.workers login of the coordinator
+	Password string `yaml:"password"` // worker side: auth.workers password of the coordinator
+}
+
+// IsEnabled returns true if the crawl is shared with remote workers
+func (c *ConfigCoordinator) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// GetBatchSize returns servers per batch
+func (c *ConfigCoordinator) GetBatchSize() int {
+	if c == nil || c.BatchSize <= 0 {
+		return 10
+	}
+	return c.BatchSize
+}
+
+// GetLease returns how long a worker may hold a batch
+func (c *ConfigCoordinator) GetLease() time.Duration {
+	if c == nil || c.Lease <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(c.Lease) * time.Second
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+
+	echobasicauth "github.com/etkecc/go-echo-basic-auth"
+)
+
+func TestConfig_Validate_DuplicateModeratorLogins(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    *ConfigAuth
+		wantErr bool
+	}{
+		{name: "no auth", auth: nil},
+		{
+			name: "unique",
+			auth: &ConfigAuth{
+				Moderation: echobasicauth.Auth{Login: "root"},
+				Moderators: []*ConfigModerator{{Auth: echobasicauth.Auth{Login: "alice"}, Role: RoleViewer}},
+			},
+		},
+		{
+			name: "same as auth.moderation",
+			auth: &ConfigAuth{
+				Moderation: echobasicauth.Auth{Login: "root"},
+				Moderators: []*ConfigModerator{{Auth: echobasicauth.Auth{Login: "root", Password: "other"}, Role: RoleViewer}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "same among moderators",
+			auth: &ConfigAuth{
+				Moderators: []*ConfigModerator{
+					{Auth: echobasicauth.Auth{Login: "alice"}, Role: RoleViewer},
+					{Auth: echobasicauth.Auth{Login: "alice"}, Role: RoleAdmin},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Config{Auth: tt.auth}).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_TrendingFederation(t *testing.T) {
+	tests := []struct {
+		name     string
+		trending ConfigSearchTrending
+		wantErr  bool
+	}{
+		{name: "off", trending: ConfigSearchTrending{}},
+		{name: "default window", trending: ConfigSearchTrending{Federation: 7}},
+		{name: "configured window", trending: ConfigSearchTrending{Windows: []int{1, 3}, Federation: 3}},
+		{name: "not a default window", trending: ConfigSearchTrending{Federation: 14}, wantErr: true},
+		{name: "not a configured window", trending: ConfigSearchTrending{Windows: []int{1, 3}, Federation: 7}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Config{Search: &ConfigSearch{Trending: tt.trending}}).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package model
+
+const (
+	// CrawlDiscovery batch: validate servers
+	CrawlDiscovery = "discovery"
+	// CrawlParsing batch: parse public rooms of the servers
+	CrawlParsing = "parsing"
+)
+
+// CrawlBatch is a chunk of servers handed out by the coordinator to a worker
+type CrawlBatch struct {
+	ID      string   `json:"id"`
+	Kind    string   `json:"kind" enums:"discovery,parsing"`
+	Servers []string `json:"servers"`
+	// Policies of the batch servers that publish one, parsing only
+	Policies map[string]*MatrixServerPolicy `json:"policies,omitempty"`
+}
+
+// CrawlResults is what a worker sends back for a batch
+type CrawlResults struct {
+	Servers    []*MatrixServer                `json:"servers,omitempty"`    // discovery: every server of the batch, online or not
+	Leased     []string                       `json:"leased,omitempty"`     // discovery: the batch's name of each of servers, in the same order (the server may call itself differently)
+	Rooms      []*MatrixRoom                  `json:"rooms,omitempty"`      // parsing: parsed rooms
+	Spaces     map[string][]*MatrixSpaceChild `json:"spaces,omitempty"`     // parsing: space ID => its children
+	Discovered []string                       `json:"discovered,omitempty"` // parsing: servers the rooms are reachable via
+}
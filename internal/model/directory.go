@@ -12,10 +12,13 @@ type RoomDirectoryRequest struct {
 	Limit  int                 `json:"limit" query:"limit"`
 	Since  string              `json:"since" query:"since"`
 	IP     string              `json:"-"` // custom field for plausible
-	// there should be more fields:
-	// `include_all_networks` (json and query)
-	// `third_party_instance_id` (json and query)
-	// but they aren't used in MRS, so not implemented
+	RoomDirectoryNetwork
+}
+
+// RoomDirectoryNetwork selects the directory of publicRooms: the server's own (zero value), a third-party network's, or all of them
+type RoomDirectoryNetwork struct {
+	IncludeAllNetworks   bool   `json:"include_all_networks,omitempty" query:"include_all_networks"`
+	ThirdPartyInstanceID string `json:"third_party_instance_id,omitempty" query:"third_party_instance_id"`
 }
 
 // RoomDirectoryFilter for the RoomDirectoryRequest
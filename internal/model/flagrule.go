@@ -0,0 +1,179 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// flag rule actions, ordered by severity
+const (
+	FlagActionFlag       = "flag"       // report the room for review, keep it indexed
+	FlagActionQuarantine = "quarantine" // hide the room, keep its data
+	FlagActionReject     = "reject"     // remove the room, it's evaluated again when parsed next time
+)
+
+// flag rule fields
+const (
+	FlagFieldName  = "name"
+	FlagFieldTopic = "topic"
+	FlagFieldAlias = "alias"
+)
+
+var flagSeverity = map[string]int{
+	FlagActionFlag:       1,
+	FlagActionQuarantine: 2,
+	FlagActionReject:     3,
+}
+
+// FlagReporter is the reporter of the reports filed by flag rules, followed by the rule name
+const FlagReporter = "rule:"
+
+// FlagRule acts on rooms matching all of its conditions when they are indexed. A rule without any condition is invalid,
+// it would act on every room
+type FlagRule struct {
+	Name       string   `yaml:"name"`        // for logs, stats, reports and the audit log, must be unique
+	Action     string   `yaml:"action"`      // flag, quarantine, or reject
+	Category   string   `yaml:"category"`    // of the reports, same categories as reports, other by default
+	DryRun     bool     `yaml:"dry_run"`     // count and log the matches, do nothing
+	Words      []string `yaml:"words"`       // whole words or phrases, case-insensitive
+	Regexes    []string `yaml:"regexes"`     // the room matches if any word or regex does
+	Fields     []string `yaml:"fields"`      // where to look for the words and regexes: name, topic, alias; all of them if empty
+	Languages  []string `yaml:"languages"`   // ISO 639-1 codes of the room languages the rule applies to, all if empty
+	MinMembers int      `yaml:"min_members"` // 0 is no limit
+	MaxMembers int      `yaml:"max_members"` // 0 is no limit
+	MaxAge     int      `yaml:"max_age"`     // days since MRS has first seen the room, 0 is no limit
+
+	words   *regexp.Regexp
+	regexes []*regexp.Regexp
+}
+
+// FlagStats of a flag rule during the last indexing
+type FlagStats struct {
+	Rule   string   `json:"rule"`
+	Action string   `json:"action"`
+	DryRun bool     `json:"dry_run"`
+	Hits   int      `json:"hits"`   // matching rooms
+	Total  int      `json:"total"`  // matching rooms of all indexings since MRS started
+	Sample []string `json:"sample"` // IDs of the first matching rooms
+	// Skipped rule matched more rooms than retention.max_removal allows, nothing was done about them
+	Skipped bool      `json:"skipped,omitempty"`
+	At      time.Time `json:"at"` // when the indexing has finished
+}
+
+// Compile validates the rule and prepares it for matching, must be called before Match
+func (r *FlagRule) Compile() error {
+	if r.Name == "" {
+		return fmt.Errorf("name is not set")
+	}
+	if _, ok := flagSeverity[r.Action]; !ok {
+		return fmt.Errorf("unknown action %q", r.Action)
+	}
+	if r.Category == "" {
+		r.Category = ReportOther
+	}
+	if !IsValidReportCategory(r.Category) {
+		return fmt.Errorf("unknown category %q", r.Category)
+	}
+	for _, field := range r.Fields {
+		if field != FlagFieldName && field != FlagFieldTopic && field != FlagFieldAlias {
+			return fmt.Errorf("unknown field %q", field)
+		}
+	}
+	for i, lang := range r.Languages {
+		r.Languages[i] = strings.ToUpper(strings.TrimSpace(lang))
+	}
+
+	r.words = nil
+	quoted := make([]string, 0, len(r.Words))
+	for _, word := range r.Words {
+		if word = strings.TrimSpace(word); word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+	if len(quoted) > 0 { // blank words only would make an empty alternation, matching every word boundary
+		// \b knows ASCII only, so word boundaries are spelled out to keep non-latin word lists working
+		re, err := regexp.Compile(`(?i)(?:^|[^\pL\pN_])(?:` + strings.Join(quoted, "|") + `)(?:$|[^\pL\pN_])`)
+		if err != nil {
+			return err
+		}
+		r.words = re
+	}
+	r.regexes = make([]*regexp.Regexp, 0, len(r.Regexes))
+	for _, pattern := range r.Regexes {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+		r.regexes = append(r.regexes, re)
+	}
+	if !r.hasConditions() {
+		return fmt.Errorf("no conditions, the rule would match every room")
+	}
+	return nil
+}
+
+// hasConditions returns true if the rule narrows the rooms down anyhow, call it on a compiled rule
+func (r *FlagRule) hasConditions() bool {
+	return r.words != nil || len(r.regexes) > 0 || len(r.Languages) > 0 ||
+		r.MinMembers > 0 || r.MaxMembers > 0 || r.MaxAge > 0
+}
+
+// Severity of the rule's action, a room matched by several rules gets the most severe one
+func (r *FlagRule) Severity() int {
+	return flagSeverity[r.Action]
+}
+
+// Match returns true if the room matches all conditions of the rule
+func (r *FlagRule) Match(room *MatrixRoom, now time.Time) bool {
+	if len(r.Languages) > 0 && !slices.Contains(r.Languages, room.Language) {
+		return false
+	}
+	if r.MinMembers > 0 && room.Members < r.MinMembers {
+		return false
+	}
+	if r.MaxMembers > 0 && room.Members > r.MaxMembers {
+		return false
+	}
+	if r.MaxAge > 0 && !room.FirstSeen.IsZero() && now.Sub(room.FirstSeen) > time.Duration(r.MaxAge)*24*time.Hour {
+		return false
+	}
+	if r.words == nil && len(r.regexes) == 0 {
+		return true
+	}
+	for _, text := range r.texts(room) {
+		if text == "" {
+			continue
+		}
+		if r.words != nil && r.words.MatchString(text) {
+			return true
+		}
+		for _, re := range r.regexes {
+			if re.MatchString(text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// texts returns the room's fields the rule looks into
+func (r *FlagRule) texts(room *MatrixRoom) []string {
+	if len(r.Fields) == 0 {
+		return []string{room.Name, room.Topic, room.Alias}
+	}
+	texts := make([]string, 0, len(r.Fields))
+	for _, field := range r.Fields {
+		switch field {
+		case FlagFieldName:
+			texts = append(texts, room.Name)
+		case FlagFieldTopic:
+			texts = append(texts, room.Topic)
+		case FlagFieldAlias:
+			texts = append(texts, room.Alias)
+		}
+	}
+	return texts
+}
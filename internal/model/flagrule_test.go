@@ -0,0 +1,55 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlagRule_Match(t *testing.T) {
+	now := time.Now()
+	room := &MatrixRoom{ID: "!r:host.example", Alias: "#deals:host.example", Name: "Бесплатно каждый день", Topic: "Free AIRDROP here", Language: "RU", Members: 3, FirstSeen: now.Add(-48 * time.Hour)}
+	tests := []struct {
+		name  string
+		rule  *FlagRule
+		match bool
+	}{
+		{"word", &FlagRule{Words: []string{"airdrop"}}, true},
+		{"not a whole word", &FlagRule{Words: []string{"air"}}, false},
+		{"non-latin word", &FlagRule{Words: []string{"бесплатно"}}, true},
+		{"non-latin part of a word", &FlagRule{Words: []string{"бесплат"}}, false},
+		{"regex", &FlagRule{Regexes: []string{`^#deal`}}, true},
+		{"other field", &FlagRule{Words: []string{"airdrop"}, Fields: []string{FlagFieldName}}, false},
+		{"language", &FlagRule{Words: []string{"airdrop"}, Languages: []string{"ru"}}, true},
+		{"other language", &FlagRule{Words: []string{"airdrop"}, Languages: []string{"DE"}}, false},
+		{"small room", &FlagRule{MaxMembers: 5}, true},
+		{"big rooms only", &FlagRule{MinMembers: 5}, false},
+		{"new room", &FlagRule{MaxAge: 7}, true},
+		{"newer room", &FlagRule{MaxAge: 1}, false},
+	}
+	for _, test := range tests {
+		test.rule.Name = test.name
+		test.rule.Action = FlagActionFlag
+		if err := test.rule.Compile(); err != nil {
+			t.Fatalf("%s: Compile() = %v", test.name, err)
+		}
+		if match := test.rule.Match(room, now); match != test.match {
+			t.Errorf("%s: Match() = %v, want %v", test.name, match, test.match)
+		}
+	}
+}
+
+func TestFlagRule_Compile(t *testing.T) {
+	for _, rule := range []*FlagRule{
+		{Action: FlagActionFlag},
+		{Name: "x", Action: "delete"},
+		{Name: "x", Action: FlagActionFlag, Category: "rude"},
+		{Name: "x", Action: FlagActionFlag, Fields: []string{"members"}},
+		{Name: "x", Action: FlagActionFlag, Regexes: []string{"(unclosed"}},
+		{Name: "x", Action: FlagActionReject},
+		{Name: "x", Action: FlagActionReject, Words: []string{" "}, Fields: []string{FlagFieldName}},
+	} {
+		if err := rule.Compile(); err == nil {
+			t.Errorf("%+v: Compile() = nil, want an error", rule)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+// EventTypeSpaceChild is the state event a space uses to list its children, state_key is the child room ID
+const EventTypeSpaceChild = "m.space.child"
+
+// SpaceHierarchyResponse of the /_matrix/federation/v1/hierarchy/{roomID} endpoint
+type SpaceHierarchyResponse struct {
+	Room                 *SpaceHierarchyRoom   `json:"room"`
+	Children             []*SpaceHierarchyRoom `json:"children"`
+	InaccessibleChildren []string              `json:"inaccessible_children"`
+	// NextBatch is MRS' own pagination over the children, the spec'd federation endpoint has none
+	NextBatch string `json:"next_batch,omitempty"`
+}
+
+// SpaceHierarchyRoom is a room directory's room with the space's m.space.child state on top
+type SpaceHierarchyRoom struct {
+	RoomDirectoryRoom
+	ChildrenState []*SpaceChildEvent `json:"children_state"`
+}
+
+// SpaceChildEvent is a stripped m.space.child state event
+type SpaceChildEvent struct {
+	Type           string                 `json:"type"`
+	StateKey       string                 `json:"state_key"`
+	Sender         string                 `json:"sender"`
+	OriginServerTS int64                  `json:"origin_server_ts"`
+	Content        SpaceChildEventContent `json:"content"`
+}
+
+// SpaceChildEventContent of m.space.child
+type SpaceChildEventContent struct {
+	Via       []string `json:"via"`
+	Order     string   `json:"order,omitempty"`
+	Suggested bool     `json:"suggested,omitempty"`
+}
+
+// MatrixSpaceChild is a parent -> child relation of a space, as crawled from its hierarchy.
+// Sender and timestamp are kept so the relation can be served back as a children_state event
+type MatrixSpaceChild struct {
+	ID             string   `json:"room_id"`
+	Via            []string `json:"via"`
+	Order          string   `json:"order,omitempty"`
+	Suggested      bool     `json:"suggested,omitempty"`
+	Sender         string   `json:"sender,omitempty"`
+	OriginServerTS int64    `json:"origin_server_ts,omitempty"`
+}
+
+// SpaceChildren returns the room's space children, skipping removed ones (an m.space.child without via is a tombstone)
+func (r *SpaceHierarchyRoom) SpaceChildren() []*MatrixSpaceChild {
+	if r == nil {
+		return nil
+	}
+	children := make([]*MatrixSpaceChild, 0, len(r.ChildrenState))
+	for _, evt := range r.ChildrenState {
+		if evt == nil || evt.Type != EventTypeSpaceChild || evt.StateKey == "" || len(evt.Content.Via) == 0 {
+			continue
+		}
+		children = append(children, &MatrixSpaceChild{
+			ID:             evt.StateKey,
+			Via:            evt.Content.Via,
+			Order:          evt.Content.Order,
+			Suggested:      evt.Content.Suggested,
+			Sender:         evt.Sender,
+			OriginServerTS: evt.OriginServerTS,
+		})
+	}
+	return children
+}
+
+// Event converts the relation back into a stripped m.space.child state event
+func (c *MatrixSpaceChild) Event() *SpaceChildEvent {
+	return &SpaceChildEvent{
+		Type:           EventTypeSpaceChild,
+		StateKey:       c.ID,
+		Sender:         c.Sender,
+		OriginServerTS: c.OriginServerTS,
+		Content: SpaceChildEventContent{
+			Via:       c.Via,
+			Order:     c.Order,
+			Suggested: c.Suggested,
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+const (
+	// RoomHistoryChanges is how many name/topic/alias changes are kept per room
+	RoomHistoryChanges = 20
+	// RoomHistoryMembers is how many member count samples (one per day at most) are kept per room
+	RoomHistoryMembers = 90
+)
+
+// RoomHistory is a compact change log of a room, updated on every parse
+type RoomHistory struct {
+	FirstSeen   time.Time            `json:"first_seen"`
+	LastChanged time.Time            `json:"last_changed"` // last name, topic or alias change, FirstSeen if there were none
+	Changes     []*RoomChange        `json:"changes"`      // oldest first
+	Members     []*RoomMembersSample `json:"members"`      // oldest first
+}
+
+// RoomChange of a single field
+type RoomChange struct {
+	Field string    `json:"field"` // name, topic, or alias
+	From  string    `json:"from"`
+	To    string    `json:"to"`
+	At    time.Time `json:"at"`
+}
+
+// RoomMembersSample is the room's member count on a given day
+type RoomMembersSample struct {
+	Members int       `json:"members"`
+	At      time.Time `json:"at"`
+}
+
+// Track records the difference between the previously stored room (nil if the room is new) and the freshly parsed one
+func (h *RoomHistory) Track(prev, room *MatrixRoom, now time.Time) {
+	if h.FirstSeen.IsZero() {
+		h.FirstSeen = now
+		h.LastChanged = now
+	}
+
+	if prev != nil {
+		for _, change := range []*RoomChange{
+			{Field: "name", From: prev.Name, To: room.Name},
+			{Field: "topic", From: prev.Topic, To: room.Topic},
+			{Field: "alias", From: prev.Alias, To: room.Alias},
+		} {
+			if change.From == change.To {
+				continue
+			}
+			change.At = now
+			h.Changes = append(h.Changes, change)
+			h.LastChanged = now
+		}
+		if extra := len(h.Changes) - RoomHistoryChanges; extra > 0 {
+			h.Changes = h.Changes[extra:]
+		}
+	}
+
+	sample := &RoomMembersSample{Members: room.Members, At: now}
+	if last := len(h.Members) - 1; last >= 0 && sameDay(h.Members[last].At, now) {
+		h.Members[last] = sample // the latest count of the day wins
+	} else {
+		h.Members = append(h.Members, sample)
+	}
+	if extra := len(h.Members) - RoomHistoryMembers; extra > 0 {
+		h.Members = h.Members[extra:]
+	}
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
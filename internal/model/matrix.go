@@ -16,6 +16,7 @@ import (
 type BlocklistService interface {
 	ByID(matrixID string) bool
 	ByServer(server string) bool
+	ByText(texts ...string) bool
 }
 
 // MatrixError model
@@ -23,6 +24,8 @@ type MatrixError struct {
 	HTTP    string `json:"-"`       // HTTP Status e.g., 401 Unauthorized
 	Code    string `json:"errcode"` // Matrix error code, e.g M_UNAUTHORIZED
 	Message string `json:"error"`   // Matrix error message
+	// RetryAfterMs is set on M_LIMIT_EXCEEDED, either by the remote body or from the Retry-After header
+	RetryAfterMs int64 `json:"retry_after_ms,omitempty"`
 }
 
 // Error string
@@ -32,13 +35,16 @@ func (e MatrixError) Error() string {
 
 // MatrixServer info
 type MatrixServer struct {
-	Name      string               `json:"name"`      // ServerName, as per spec, e.g., "example.com"
-	URL       string               `json:"url"`       // Server-Server API URL, e.g., "https://example.com:8448"
-	Software  string               `json:"software"`  // Software running on the server, e.g., Synapse, Dendrite
-	Version   string               `json:"version"`   // Version of the software, e.g., 1.0.0
-	Online    bool                 `json:"online"`    // Is the server online and federating?
-	Indexable bool                 `json:"indexable"` // Is the server published the public room directory over federation?
-	Contacts  MatrixServerContacts `json:"contacts"`  // Contacts as per MSC1929
+	Name      string               `json:"name"`             // ServerName, as per spec, e.g., "example.com"
+	URL       string               `json:"url"`              // Server-Server API URL, e.g., "https://example.com:8448"
+	Software  string               `json:"software"`         // Software running on the server, e.g., Synapse, Dendrite
+	Version   string               `json:"version"`          // Version of the software, e.g., 1.0.0
+	Online    bool                 `json:"online"`           // Is the server online and federating?
+	Indexable bool                 `json:"indexable"`        // Is the server published the public room directory over federation?
+	Contacts  MatrixServerContacts `json:"contacts"`         // Contacts as per MSC1929
+	Policy    *MatrixServerPolicy  `json:"policy,omitempty"` // Indexing policy as per /.well-known/matrix/mrs
+	// PolicyStale is set if the policy could not be fetched (timeout, 5xx), so the stored one is kept instead of a nil one
+	PolicyStale bool `json:"policy_stale,omitempty"`
 	// OnlineAt is the prune clock: last seen online, the sole basis for the 30d offline delete. Never bumped on an offline dial.
 	// CheckedAt is the backoff clock: last dial attempt, bumped every dial. Merge them and dead servers reset the prune clock and go immortal.
 	OnlineAt  time.Time `json:"online_at"`
@@ -56,6 +62,37 @@ func (c MatrixServerContacts) IsEmpty() bool {
 	return len(c.Emails) == 0 && len(c.MXIDs) == 0 && c.URL == ""
 }
 
+const (
+	// NetworkMatrix is the network of native matrix rooms, published in the server's own directory
+	NetworkMatrix = "matrix"
+	// NetworkThirdParty is the network of bridged rooms found with include_all_networks, when the exact one is unknown
+	NetworkThirdParty = "thirdparty"
+)
+
+const (
+	// AliasVerified - the alias resolves to the room
+	AliasVerified = "verified"
+	// AliasMismatch - the alias resolves to a different room
+	AliasMismatch = "mismatch"
+	// AliasDead - the alias doesn't resolve anymore
+	AliasDead = "dead"
+	// AliasUnverified - the alias' server couldn't be asked, so we don't know
+	AliasUnverified = "unverified"
+)
+
+// MatrixRoomAlias is the alias -> room_id resolution result of the room's canonical alias
+type MatrixRoomAlias struct {
+	Alias     string    `json:"alias"`
+	Status    string    `json:"status"`            // verified, mismatch, dead, or unverified
+	RoomID    string    `json:"room_id,omitempty"` // what the alias resolved to, differs from the room's ID on mismatch
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// IsVerified returns true if the alias is known to resolve to the room
+func (a *MatrixRoomAlias) IsVerified() bool {
+	return a != nil && a.Status == AliasVerified
+}
+
 // MatrixRoom from matrix client-server API
 type MatrixRoom struct {
 	ID            string `json:"room_id"`
@@ -75,11 +112,24 @@ type MatrixRoom struct {
 	Email     string    `json:"email"`
 	Language  string    `json:"language"`
 	AvatarURL string    `json:"avatar_url_http"`
+	Network   string    `json:"network,omitempty"` // third-party network the room is bridged from, empty for native matrix rooms
 	ParsedAt  time.Time `json:"parsed_at"`
+	FirstSeen time.Time `json:"first_seen"` // carried over from the stored room on every parse, see RoomHistory
+	// AliasVerification is the result of resolving the room's canonical alias when it was parsed
+	AliasVerification *MatrixRoomAlias `json:"alias_verification,omitempty"`
+
+	// Space relations, not stored with the room but looked up from the crawled hierarchies
+	Parents  []string            `json:"parents,omitempty"`  // IDs of spaces listing the room as a child
+	Children []*MatrixSpaceChild `json:"children,omitempty"` // children of the space, if the room is one
 }
 
 // Entry converts matrix room to search entry
 func (r *MatrixRoom) Entry() *Entry {
+	var firstSeen *time.Time
+	if !r.FirstSeen.IsZero() {
+		firstSeenCopy := r.FirstSeen // the room struct is reused by EachRoom, don't point into it
+		firstSeen = &firstSeenCopy
+	}
 	return &Entry{
 		ID:            r.ID,
 		Type:          "room",
@@ -96,7 +146,18 @@ func (r *MatrixRoom) Entry() *Entry {
 		JoinRule:      r.JoinRule,
 		GuestJoinable: r.GuestJoinable,
 		WorldReadable: r.WorldReadable,
+		Network:       r.GetNetwork(),
+		FirstSeen:     firstSeen,
+		Parents:       r.Parents,
+	}
+}
+
+// GetNetwork returns the network the room comes from, "matrix" for native rooms
+func (r *MatrixRoom) GetNetwork() string {
+	if r.Network == "" {
+		return NetworkMatrix
 	}
+	return r.Network
 }
 
 // DirectoryEntry converts matrix room into matrix room directory entry
@@ -132,7 +193,9 @@ func (r *MatrixRoom) Parse(detector lingua.LanguageDetector, media mediaURLServi
 	topic, rcfg := ParseRoomConfig(r.Topic)
 	r.Topic = topic
 	if !rcfg.IsEmpty() {
-		r.Language = rcfg.Language
+		if rcfg.Language != "" {
+			r.Language = rcfg.Language // room config beats the server's default language
+		}
 		r.Email = rcfg.Email
 	}
 
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"slices"
+	"strings"
+)
+
+// moderator roles, each role can do everything the previous one can
+const (
+	RoleViewer    = "viewer"    // read the reports, bans, quarantines, and the audit log
+	RoleModerator = "moderator" // ban, unban, quarantine, release, and dismiss reports of rooms
+	RoleAdmin     = "admin"     // ban rules and everything else that affects rooms of any server
+)
+
+var roleRanks = map[string]int{
+	RoleViewer:    1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+// IsValidRole returns true if the role is known
+func IsValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// Moderator is an account with access to the moderation endpoints
+type Moderator struct {
+	Login   string   `json:"login"`
+	Role    string   `json:"role"`
+	Servers []string `json:"servers,omitempty"` // homeservers the moderator is limited to, all if empty
+}
+
+// HasRole returns true if the moderator's role is the role or a higher one
+func (m *Moderator) HasRole(role string) bool {
+	return m != nil && roleRanks[m.Role] >= roleRanks[role]
+}
+
+// IsGlobal returns true if the moderator is not limited to specific homeservers
+func (m *Moderator) IsGlobal() bool {
+	return m != nil && len(m.Servers) == 0
+}
+
+// InScope returns true if the moderator may see and touch rooms of the server
+func (m *Moderator) InScope(server string) bool {
+	if m == nil {
+		return false
+	}
+	return m.IsGlobal() || (server != "" && slices.Contains(m.Servers, strings.ToLower(server)))
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+
+	echobasicauth "github.com/etkecc/go-echo-basic-auth"
+)
+
+func TestConfigAuth_GetModerator(t *testing.T) {
+	auth := &ConfigAuth{
+		Moderation: echobasicauth.Auth{Login: "root"},
+		Moderators: []*ConfigModerator{
+			{Auth: echobasicauth.Auth{Login: "alice"}, Role: RoleModerator, Servers: []string{"Example.com"}},
+			{Auth: echobasicauth.Auth{Login: "bob"}, Role: "superuser"},
+		},
+	}
+	if len(auth.ModeratorAuths()) != 2 {
+		t.Errorf("ModeratorAuths() = %d accounts, want 2 without the one with an unknown role", len(auth.ModeratorAuths()))
+	}
+
+	root := auth.GetModerator("root")
+	if !root.HasRole(RoleAdmin) || !root.InScope("other.example") {
+		t.Errorf("root = %+v, want a global admin", root)
+	}
+	alice := auth.GetModerator("alice")
+	if !alice.HasRole(RoleViewer) || alice.HasRole(RoleAdmin) || alice.IsGlobal() {
+		t.Errorf("alice = %+v, want a scoped moderator", alice)
+	}
+	if !alice.InScope("example.com") || alice.InScope("other.example") || alice.InScope("") {
+		t.Errorf("alice = %+v, want example.com only", alice)
+	}
+	if bob := auth.GetModerator("bob"); bob != nil {
+		t.Errorf("bob = %+v, want nil", bob)
+	}
+	if nobody := auth.GetModerator(""); nobody.HasRole(RoleViewer) {
+		t.Errorf("empty login = %+v, want nothing", nobody)
+	}
+}
@@ -0,0 +1,12 @@
+package model
+
+// PeerServersResponse is an MRS instance's catalog of online servers, as served to its peers
+type PeerServersResponse struct {
+	Servers []string `json:"servers"`
+}
+
+// PeerRoomsResponse is a page of rooms an MRS instance has parsed since the requested time, as served to its peers
+type PeerRoomsResponse struct {
+	Rooms     []*MatrixRoom `json:"rooms"`
+	NextBatch string        `json:"next_batch,omitempty"`
+}
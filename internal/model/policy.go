@@ -0,0 +1,68 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pemistahl/lingua-go"
+)
+
+// MatrixServerPolicy is the server-wide indexing policy, published by the server at /.well-known/matrix/mrs.
+// Per-room room config (see RoomConfig) still applies on top of it
+type MatrixServerPolicy struct {
+	Noindex        bool   `json:"noindex,omitempty"`         // don't index any room of the server
+	NoindexAvatars bool   `json:"noindex_avatars,omitempty"` // index the rooms, but not their avatars
+	Language       string `json:"language,omitempty"`        // default language of the rooms, ISO 639-1, used instead of detection
+	CrawlInterval  int    `json:"crawl_interval,omitempty"`  // hours, don't parse the server's rooms more often than that, e.g. 24 for daily; capped, see Interval
+}
+
+// Normalize drops values MRS doesn't understand
+func (p *MatrixServerPolicy) Normalize() {
+	p.Language = strings.ToUpper(strings.TrimSpace(p.Language))
+	if lingua.GetIsoCode639_1FromValue(p.Language) == lingua.UnknownIsoCode639_1 {
+		p.Language = ""
+	}
+	p.CrawlInterval = max(0, p.CrawlInterval)
+}
+
+// IsEmpty returns true if the policy has no directives
+func (p *MatrixServerPolicy) IsEmpty() bool {
+	return p == nil || (!p.Noindex && !p.NoindexAvatars && p.Language == "" && p.CrawlInterval == 0)
+}
+
+// IsNoindex returns true if no room of the server should be indexed
+func (p *MatrixServerPolicy) IsNoindex() bool {
+	return p != nil && p.Noindex
+}
+
+// Interval returns how often the server's rooms may be parsed. It's capped at half of the rooms retention,
+// so rooms of a server asking for a week between crawls are not removed as stale in the meantime
+func (p *MatrixServerPolicy) Interval(retention time.Duration) time.Duration {
+	if p == nil {
+		return 0
+	}
+	return min(time.Duration(p.CrawlInterval)*time.Hour, retention/2)
+}
+
+// IsDue returns true if the server's rooms may be parsed again, given the last time they were and the rooms retention
+func (p *MatrixServerPolicy) IsDue(lastParsed time.Time, retention time.Duration) bool {
+	interval := p.Interval(retention)
+	if interval <= 0 || lastParsed.IsZero() {
+		return true
+	}
+	return time.Since(lastParsed) >= interval
+}
+
+// Apply prepares a freshly crawled room before it's parsed, see MatrixRoom.Parse
+func (p *MatrixServerPolicy) Apply(room *MatrixRoom) {
+	if p == nil {
+		return
+	}
+	if p.NoindexAvatars {
+		room.Avatar = ""
+		room.AvatarURL = ""
+	}
+	if room.Language == "" {
+		room.Language = p.Language
+	}
+}
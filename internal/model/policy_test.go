@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatrixServerPolicy_Normalize(t *testing.T) {
+	policy := &MatrixServerPolicy{Language: " de ", CrawlInterval: -1}
+	policy.Normalize()
+	if policy.Language != "DE" || policy.CrawlInterval != 0 {
+		t.Errorf("Normalize() = %+v, want DE and 0", policy)
+	}
+
+	policy = &MatrixServerPolicy{Language: "klingon"}
+	policy.Normalize()
+	if !policy.IsEmpty() {
+		t.Errorf("Normalize() = %+v, want an unknown language dropped", policy)
+	}
+}
+
+// the server's default language yields to the room's own config, and stripped avatars stay stripped.
+func TestMatrixServerPolicy_Apply(t *testing.T) {
+	policy := &MatrixServerPolicy{NoindexAvatars: true, Language: "DE"}
+
+	room := &MatrixRoom{ID: "!a:example.com", Avatar: "mxc://example.com/avatar"}
+	policy.Apply(room)
+	room.Parse(nil, nil, "mrs.example.com")
+	if room.Language != "DE" || room.Avatar != "" || room.AvatarURL != "" {
+		t.Errorf("room = %+v, want DE without avatar", room)
+	}
+
+	room = &MatrixRoom{ID: "!b:example.com", Topic: "(MRS-language:FR-MRS)"}
+	policy.Apply(room)
+	room.Parse(nil, nil, "mrs.example.com")
+	if room.Language != "FR" {
+		t.Errorf("room language = %q, want FR from the room config", room.Language)
+	}
+}
+
+func TestMatrixServerPolicy_IsDue(t *testing.T) {
+	week := 7 * 24 * time.Hour
+	var none *MatrixServerPolicy
+	if !none.IsDue(time.Now(), week) {
+		t.Error("no policy, want due")
+	}
+	daily := &MatrixServerPolicy{CrawlInterval: 24}
+	if !daily.IsDue(time.Time{}, week) {
+		t.Error("never parsed, want due")
+	}
+	if daily.IsDue(time.Now().Add(-time.Hour), week) {
+		t.Error("parsed an hour ago, want not due")
+	}
+	if !daily.IsDue(time.Now().Add(-25*time.Hour), week) {
+		t.Error("parsed 25 hours ago, want due")
+	}
+	monthly := &MatrixServerPolicy{CrawlInterval: 30 * 24}
+	if !monthly.IsDue(time.Now().Add(-4*24*time.Hour), week) {
+		t.Error("monthly, parsed 4 days ago with a week of retention, want due before the rooms expire")
+	}
+}
@@ -0,0 +1,198 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// moderation policy list event types and recommendations, see https://spec.matrix.org/latest/client-server-api/#moderation-policy-lists
+const (
+	PolicyRuleRoom   = "m.policy.rule.room"
+	PolicyRuleServer = "m.policy.rule.server"
+	PolicyBan        = "m.ban"
+)
+
+// policyTypes maps the known rule event types (including the pre-spec ones, still found in the wild) to the spec ones.
+// User rules are not here on purpose, MRS has no users to ban
+var policyTypes = map[string]string{
+	PolicyRuleRoom:                   PolicyRuleRoom,
+	PolicyRuleServer:                 PolicyRuleServer,
+	"m.room.rule.room":               PolicyRuleRoom,
+	"m.room.rule.server":             PolicyRuleServer,
+	"org.matrix.mjolnir.rule.room":   PolicyRuleRoom,
+	"org.matrix.mjolnir.rule.server": PolicyRuleServer,
+}
+
+// PolicyEvent is a policy rule state event, as found in a policy room's state
+type PolicyEvent struct {
+	Type     string         `json:"type"`
+	StateKey string         `json:"state_key"`
+	Content  *PolicyContent `json:"content"`
+}
+
+// PolicyContent of a policy rule event, empty content means the rule was removed
+type PolicyContent struct {
+	Entity         string `json:"entity,omitempty"` // room ID, alias, or server name, globs allowed
+	Reason         string `json:"reason,omitempty"`
+	Recommendation string `json:"recommendation,omitempty"`
+}
+
+// NewPolicyEvent creates a ban rule event of the entity, the state key is the one mjolnir and draupnir use
+func NewPolicyEvent(eventType, entity, reason string) *PolicyEvent {
+	return &PolicyEvent{
+		Type:     eventType,
+		StateKey: "rule:" + entity,
+		Content: &PolicyContent{
+			Entity:         entity,
+			Reason:         reason,
+			Recommendation: PolicyBan,
+		},
+	}
+}
+
+// PolicyRule is an imported m.ban recommendation, ready for matching
+type PolicyRule struct {
+	Source string `json:"source"`
+	Type   string `json:"type"`
+	Entity string `json:"entity"`
+	Reason string `json:"reason,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// PolicyStats of a policy list source's last import
+type PolicyStats struct {
+	Source  string    `json:"source"`
+	Events  int       `json:"events"`            // events in the list
+	Rules   int       `json:"rules"`             // of them, room and server bans
+	Skipped []string  `json:"skipped,omitempty"` // of the rules, type:entity of those matching too many rooms to apply, see ConfigRetention.MaxRemoval
+	Error   string    `json:"error,omitempty"`   // why the import failed, the source keeps its previous rules then
+	At      time.Time `json:"at"`
+}
+
+// NewPolicyRule returns the event's rule, nil if it's not a room or server ban (or is malformed)
+func NewPolicyRule(source string, evt *PolicyEvent) *PolicyRule {
+	if evt == nil || evt.Content == nil || evt.Content.Recommendation != PolicyBan {
+		return nil
+	}
+	eventType, ok := policyTypes[evt.Type]
+	if !ok {
+		return nil
+	}
+	entity := strings.TrimSpace(evt.Content.Entity)
+	if entity == "" || len(entity) > MaxBanRulePattern {
+		return nil
+	}
+	return &PolicyRule{
+		Source: source,
+		Type:   eventType,
+		Entity: entity,
+		Reason: evt.Content.Reason,
+		re:     globRegexp(entity),
+	}
+}
+
+// globRegexp compiles a glob (* is any number of characters, ? is exactly one) into an anchored regexp
+func globRegexp(glob string) *regexp.Regexp {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			pattern.WriteString(".*")
+		case '?':
+			pattern.WriteString(".")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String())
+}
+
+// String returns the rule as type:entity
+func (r *PolicyRule) String() string {
+	return r.Type + ":" + r.Entity
+}
+
+// MatchServer returns true if the rule bans the server
+func (r *PolicyRule) MatchServer(server string) bool {
+	return r.Type == PolicyRuleServer && server != "" && r.re.MatchString(server)
+}
+
+// MatchID returns true if the rule bans the room ID or alias (by its server, or by the ID or alias itself)
+func (r *PolicyRule) MatchID(matrixID string) bool {
+	if matrixID == "" {
+		return false
+	}
+	if r.MatchServer(utils.ServerFrom(matrixID)) {
+		return true
+	}
+	return r.Type == PolicyRuleRoom && r.re.MatchString(matrixID)
+}
+
+// MatchRoom returns true if the rule bans the room
+func (r *PolicyRule) MatchRoom(room *MatrixRoom) bool {
+	return r.MatchID(room.ID) || r.MatchID(room.Alias) || r.MatchServer(room.GetOwnServer())
+}
+
+// ParsePolicyEvents parses a policy room's state: a JSON array of events (e.g., /rooms/{roomID}/state or /catalog/policies),
+// or one event per line. Events of other types are parsed too, NewPolicyRule sorts them out.
+// Only the latest event of each type and state key counts, and an empty one removes the rule, as in a room's state
+func ParsePolicyEvents(datab []byte) ([]*PolicyEvent, error) {
+	datab = bytes.TrimSpace(datab)
+	if bytes.HasPrefix(datab, []byte("[")) {
+		var events []*PolicyEvent
+		if err := json.Unmarshal(datab, &events); err != nil {
+			return nil, err
+		}
+		return latestPolicyEvents(events), nil
+	}
+
+	events := []*PolicyEvent{}
+	scanner := bufio.NewScanner(bytes.NewReader(datab))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		lineb := bytes.TrimSpace(scanner.Bytes())
+		if len(lineb) == 0 {
+			continue
+		}
+		var evt *PolicyEvent
+		if err := json.Unmarshal(lineb, &evt); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, evt)
+	}
+	return latestPolicyEvents(events), scanner.Err()
+}
+
+// latestPolicyEvents keeps the latest event of each type and state key, in the order they first appeared,
+// and drops the empty ones: an event with empty content is how a rule is revoked
+func latestPolicyEvents(events []*PolicyEvent) []*PolicyEvent {
+	positions := make(map[string]int, len(events))
+	latest := make([]*PolicyEvent, 0, len(events))
+	for _, evt := range events {
+		if evt == nil {
+			continue
+		}
+		key := evt.Type + "\x00" + evt.StateKey
+		if i, ok := positions[key]; ok {
+			latest[i] = evt
+			continue
+		}
+		positions[key] = len(latest)
+		latest = append(latest, evt)
+	}
+	return slices.DeleteFunc(latest, func(evt *PolicyEvent) bool {
+		return evt.Content == nil || *evt.Content == PolicyContent{}
+	})
+}
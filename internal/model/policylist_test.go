@@ -0,0 +1,70 @@
+package model
+
+import "testing"
+
+func TestPolicyRule_MatchRoom(t *testing.T) {
+	room := &MatrixRoom{ID: "!r:host.example", Alias: "#free-crypto:other.example", Server: "other.example"}
+	tests := []struct {
+		evt   *PolicyEvent
+		match bool
+	}{
+		{NewPolicyEvent(PolicyRuleServer, "host.example", ""), true},
+		{NewPolicyEvent(PolicyRuleServer, "*.example", ""), true},
+		{NewPolicyEvent(PolicyRuleServer, "example", ""), false}, // globs are anchored
+		{NewPolicyEvent(PolicyRuleRoom, "#free-*:other.example", ""), true},
+		{NewPolicyEvent(PolicyRuleRoom, "!?:host.example", ""), true},
+		{NewPolicyEvent(PolicyRuleRoom, "host.example", ""), false}, // room rules match IDs and aliases, not servers
+		{&PolicyEvent{Type: "org.matrix.mjolnir.rule.server", Content: &PolicyContent{Entity: "other.example", Recommendation: PolicyBan}}, true},
+	}
+	for _, test := range tests {
+		rule := NewPolicyRule("test", test.evt)
+		if rule == nil {
+			t.Fatalf("%+v: NewPolicyRule() = nil", test.evt.Content)
+		}
+		if match := rule.MatchRoom(room); match != test.match {
+			t.Errorf("%s %s: MatchRoom() = %v, want %v", rule.Type, rule.Entity, match, test.match)
+		}
+	}
+}
+
+func TestParsePolicyEvents(t *testing.T) {
+	state := `[
+		{"type": "m.room.create", "state_key": "", "content": {"room_version": "10"}},
+		{"type": "m.policy.rule.user", "state_key": "rule:@spam:host.example", "content": {"entity": "@spam:host.example", "recommendation": "m.ban"}},
+		{"type": "m.policy.rule.server", "state_key": "rule:removed", "content": {}},
+		{"type": "m.policy.rule.server", "state_key": "rule:host.example", "content": {"entity": "host.example", "recommendation": "m.ban", "reason": "spam"}}
+	]`
+	lines := `{"type": "m.policy.rule.room", "state_key": "rule:#a:host.example", "content": {"entity": "#a:host.example", "recommendation": "m.ban"}}
+
+{"type": "m.policy.rule.room", "state_key": "rule:#b:host.example", "content": {"entity": "#b:host.example", "recommendation": "org.example.mute"}}`
+
+	for input, want := range map[string]int{state: 1, lines: 1} {
+		events, err := ParsePolicyEvents([]byte(input))
+		if err != nil {
+			t.Fatalf("ParsePolicyEvents() = %v", err)
+		}
+		var rules int
+		for _, evt := range events {
+			if NewPolicyRule("test", evt) != nil {
+				rules++
+			}
+		}
+		if rules != want {
+			t.Errorf("rules = %d of %d events, want %d", rules, len(events), want)
+		}
+	}
+
+	// a rule revoked later in the timeline is gone, a rule changed later is the changed one
+	timeline := `{"type": "m.policy.rule.server", "state_key": "rule:a", "content": {"entity": "a.example", "recommendation": "m.ban"}}
+{"type": "m.policy.rule.server", "state_key": "rule:b", "content": {"entity": "b.example", "recommendation": "m.ban"}}
+{"type": "m.policy.rule.server", "state_key": "rule:a", "content": {}}
+{"type": "m.policy.rule.server", "state_key": "rule:b", "content": {"entity": "b.example", "recommendation": "m.ban", "reason": "spam"}}`
+	events, err := ParsePolicyEvents([]byte(timeline))
+	if err != nil || len(events) != 1 || events[0].StateKey != "rule:b" || events[0].Content.Reason != "spam" {
+		t.Errorf("ParsePolicyEvents(timeline) = %+v, %v", events, err)
+	}
+
+	if _, err := ParsePolicyEvents([]byte("{\"type\": \n")); err == nil {
+		t.Error("ParsePolicyEvents() = nil, want an error")
+	}
+}
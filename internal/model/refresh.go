@@ -0,0 +1,7 @@
+package model
+
+// RefreshServerResponse is the outcome of an on-demand server refresh
+type RefreshServerResponse struct {
+	Server *MatrixServer `json:"server"`
+	Rooms  []string      `json:"rooms"` // IDs of the refreshed rooms, now in the index
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// report categories, ordered by severity
+const (
+	ReportOther      = "other"
+	ReportSpam       = "spam"
+	ReportHarassment = "harassment"
+	ReportIllegal    = "illegal"
+	ReportCSAM       = "csam"
+)
+
+// MaxRoomReports is how many reports (one per reporter) are kept per room, enough to triage, not enough to flood
+const MaxRoomReports = 100
+
+// reportWeights are contributions of a single report to the room's priority
+var reportWeights = map[string]int{
+	ReportOther:      1,
+	ReportSpam:       2,
+	ReportHarassment: 5,
+	ReportIllegal:    20,
+	ReportCSAM:       100,
+}
+
+// IsValidReportCategory returns true if the category is known
+func IsValidReportCategory(category string) bool {
+	_, ok := reportWeights[category]
+	return ok
+}
+
+// RoomReport is a single report of a room
+type RoomReport struct {
+	At       time.Time `json:"at"`
+	Category string    `json:"category"`
+	Reason   string    `json:"reason"`
+	Reporter string    `json:"reporter"` // hash, not the IP
+}
+
+// RoomReports of a single room, one report per reporter (a repeated report replaces the previous one)
+type RoomReports struct {
+	RoomID   string        `json:"room_id"`
+	Count    int           `json:"count"`    // number of reporters
+	Priority int           `json:"priority"` // sum of category weights, triage the highest first
+	Reports  []*RoomReport `json:"reports"`  // newest first
+}
+
+// Add the report, returns true if it raises the room's top category (or is the first one), a reason to tell moderators again
+func (r *RoomReports) Add(report *RoomReport) bool {
+	top := r.TopCategory()
+	r.Reports = slices.DeleteFunc(r.Reports, func(existing *RoomReport) bool {
+		return existing.Reporter == report.Reporter
+	})
+	r.Reports = slices.Insert(r.Reports, 0, report)
+	if len(r.Reports) > MaxRoomReports {
+		r.Reports = r.Reports[:MaxRoomReports]
+	}
+	r.Update()
+	return top == "" || reportWeights[report.Category] > reportWeights[top]
+}
+
+// Update recalculates the count and the priority
+func (r *RoomReports) Update() {
+	r.Count = len(r.Reports)
+	r.Priority = 0
+	for _, report := range r.Reports {
+		r.Priority += reportWeights[report.Category]
+	}
+}
+
+// TopCategory returns the most severe category of the reports, empty if there are none
+func (r *RoomReports) TopCategory() string {
+	var top string
+	for _, report := range r.Reports {
+		if top == "" || reportWeights[report.Category] > reportWeights[top] {
+			top = report.Category
+		}
+	}
+	return top
+}
+
+// Latest returns the newest report, nil if there are none
+func (r *RoomReports) Latest() *RoomReport {
+	if r == nil || len(r.Reports) == 0 {
+		return nil
+	}
+	return r.Reports[0]
+}
+
+func (r *RoomReports) lastAt() time.Time {
+	if latest := r.Latest(); latest != nil {
+		return latest.At
+	}
+	return time.Time{}
+}
+
+// SortRoomReports sorts the rooms by priority, then by the newest report, most urgent first
+func SortRoomReports(list []*RoomReports) {
+	slices.SortStableFunc(list, func(a, b *RoomReports) int {
+		if a.Priority != b.Priority {
+			return b.Priority - a.Priority
+		}
+		return b.lastAt().Compare(a.lastAt())
+	})
+}
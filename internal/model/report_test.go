@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+// a reporter counts once however many times they report, and only a more severe category is worth telling moderators again.
+func TestRoomReports_Add(t *testing.T) {
+	reports := &RoomReports{}
+	now := time.Now()
+	steps := []struct {
+		report    *RoomReport
+		escalated bool
+		count     int
+		priority  int
+	}{
+		{&RoomReport{At: now, Category: ReportSpam, Reporter: "a"}, true, 1, 2},
+		{&RoomReport{At: now, Category: ReportSpam, Reporter: "b"}, false, 2, 4},
+		{&RoomReport{At: now, Category: ReportOther, Reporter: "a"}, false, 2, 3},
+		{&RoomReport{At: now, Category: ReportIllegal, Reporter: "a"}, true, 2, 22},
+		{&RoomReport{At: now, Category: ReportSpam, Reporter: "c"}, false, 3, 24},
+	}
+	for i, step := range steps {
+		if escalated := reports.Add(step.report); escalated != step.escalated {
+			t.Errorf("step %d: escalated = %v, want %v", i, escalated, step.escalated)
+		}
+		if reports.Count != step.count || reports.Priority != step.priority {
+			t.Errorf("step %d: count = %d, priority = %d, want %d and %d", i, reports.Count, reports.Priority, step.count, step.priority)
+		}
+	}
+	if reports.Latest().Reporter != "c" || reports.TopCategory() != ReportIllegal {
+		t.Errorf("latest = %+v, top = %s", reports.Latest(), reports.TopCategory())
+	}
+}
+
+func TestSortRoomReports(t *testing.T) {
+	now := time.Now()
+	spam := &RoomReports{RoomID: "spam", Reports: []*RoomReport{{At: now, Category: ReportSpam}, {At: now, Category: ReportSpam}}}
+	csam := &RoomReports{RoomID: "csam", Reports: []*RoomReport{{At: now.Add(-time.Hour), Category: ReportCSAM}}}
+	other := &RoomReports{RoomID: "other", Reports: []*RoomReport{{At: now.Add(-time.Hour), Category: ReportOther}}}
+	newer := &RoomReports{RoomID: "newer", Reports: []*RoomReport{{At: now, Category: ReportOther}}}
+	list := []*RoomReports{other, spam, newer, csam}
+	for _, reports := range list {
+		reports.Update()
+	}
+
+	SortRoomReports(list)
+	got := []string{}
+	for _, reports := range list {
+		got = append(got, reports.RoomID)
+	}
+	want := []string{"csam", "spam", "newer", "other"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("SortRoomReports() = %v, want %v", got, want)
+		}
+	}
+}
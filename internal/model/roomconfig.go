@@ -18,9 +18,31 @@ const (
 
 // RoomConfig contains configuration for a Matrix room from the room topic
 type RoomConfig struct {
-	Language string // language of the room, e.g. "EN", "DE", etc.
-	Email    string // email of the room, e.g. "yourname@example.com"
-	Noindex  bool   // if true, the room should not be indexed by MRS
+	Language string `json:"language,omitempty"` // language of the room, e.g. "EN", "DE", etc.
+	Email    string `json:"email,omitempty"`    // email of the room, e.g. "yourname@example.com"
+	Noindex  bool   `json:"noindex,omitempty"`  // if true, the room should not be indexed by MRS
+}
+
+// RoomConfigWarning is a part of the room config that MRS had to ignore
+type RoomConfigWarning struct {
+	Field   string `json:"field,omitempty"` // config key, empty if the problem is with the tag itself
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+// RoomConfigCheckRequest is a room config to check, either a topic as is, or a room to get the topic of
+type RoomConfigCheckRequest struct {
+	Topic string `json:"topic,omitempty"`
+	Room  string `json:"room,omitempty"` // room ID or alias, its topic is fetched with a room summary
+}
+
+// RoomConfigCheck is how MRS understands a room config
+type RoomConfigCheck struct {
+	RoomID   string               `json:"room_id,omitempty"` // if the topic was fetched
+	Topic    string               `json:"topic"`             // the topic as it will be indexed, without the config tag
+	Config   *RoomConfig          `json:"config"`
+	Language string               `json:"language,omitempty"` // what MRS assigns to the room: from the config, or detected. Empty with noindex
+	Warnings []*RoomConfigWarning `json:"warnings"`
 }
 
 // IsEmpty checks if the RoomConfig is empty.
@@ -31,37 +53,49 @@ func (cfg *RoomConfig) IsEmpty() bool {
 // ParseRoomConfig parses the room topic to extract the room configuration,
 // removing the room config string from the topic if it exists.
 func ParseRoomConfig(topic string) (string, *RoomConfig) {
+	topic, cfg, _ := CheckRoomConfig(topic)
+	return topic, cfg
+}
+
+// CheckRoomConfig is ParseRoomConfig that reports what it had to ignore
+func CheckRoomConfig(topic string) (string, *RoomConfig, []*RoomConfigWarning) {
 	cfg := &RoomConfig{}
+	warnings := []*RoomConfigWarning{}
 	if topic == "" {
-		return "", cfg
+		return "", cfg, warnings
 	}
 
 	start := strings.Index(topic, RoomConfigTagStart)
 	if start == -1 {
 		// No config found, return as is
-		return topic, cfg
+		return topic, cfg, warnings
 	}
 	end := strings.Index(topic[start+len(RoomConfigTagStart):], RoomConfigTagEnd)
 	if end == -1 {
 		// No end tag found, return as is
-		return topic, cfg
+		warnings = append(warnings, &RoomConfigWarning{Message: "the config starts with " + RoomConfigTagStart + " but never ends with " + RoomConfigTagEnd})
+		return topic, cfg, warnings
 	}
 	end = start + len(RoomConfigTagStart) + end
 
 	// Extract config string and rest topic
 	configStr := topic[start+len(RoomConfigTagStart) : end]
 	topic = strings.TrimSpace(topic[:start] + topic[end+len(RoomConfigTagEnd):])
-	cfg = parseRoomConfig(configStr)
+	cfg, warnings = parseRoomConfig(configStr)
 
-	return topic, cfg
+	return topic, cfg, warnings
 }
 
 // parseRoomConfig parses a room configuration string into a RoomConfig struct.
-func parseRoomConfig(configStr string) *RoomConfig {
+func parseRoomConfig(configStr string) (*RoomConfig, []*RoomConfigWarning) {
 	rcfg := &RoomConfig{}
+	warnings := []*RoomConfigWarning{}
 	for _, pair := range strings.Split(configStr, RoomConfigDelimiter) {
 		kv := strings.SplitN(pair, ":", 2)
 		if len(kv) != 2 {
+			if strings.TrimSpace(pair) != "" {
+				warnings = append(warnings, &RoomConfigWarning{Value: pair, Message: "expected key:value"})
+			}
 			continue
 		}
 		key, value := strings.TrimSpace(kv[0]), strings.ToLower(strings.TrimSpace(kv[1]))
@@ -70,14 +104,23 @@ func parseRoomConfig(configStr string) *RoomConfig {
 			value = strings.ToUpper(value)
 			if code := lingua.GetIsoCode639_1FromValue(value); code != lingua.UnknownIsoCode639_1 {
 				rcfg.Language = value
+			} else {
+				warnings = append(warnings, &RoomConfigWarning{Field: key, Value: value, Message: "unknown language, expected an ISO 639-1 code, e.g. EN"})
 			}
 		case "email":
 			if _, err := mail.ParseAddress(value); err == nil {
 				rcfg.Email = value
+			} else {
+				warnings = append(warnings, &RoomConfigWarning{Field: key, Value: value, Message: "invalid email address"})
 			}
 		case "noindex":
 			rcfg.Noindex = value == "yes" || value == "true" || value == "1"
+			if !rcfg.Noindex && value != "no" && value != "false" && value != "0" {
+				warnings = append(warnings, &RoomConfigWarning{Field: key, Value: value, Message: "expected true or false, treated as false"})
+			}
+		default:
+			warnings = append(warnings, &RoomConfigWarning{Field: key, Value: value, Message: "unknown key, supported keys are language, email, and noindex"})
 		}
 	}
-	return rcfg
+	return rcfg, warnings
 }
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -198,3 +199,25 @@ func TestRoomConfig_IsEmpty(t *testing.T) {
 		t.Error("Nil pointer must be empty")
 	}
 }
+
+// everything ParseRoomConfig silently drops gets a warning, everything it understands doesn't.
+func TestCheckRoomConfig_Warnings(t *testing.T) {
+	topic, cfg, warnings := CheckRoomConfig("Hi (MRS-language:XX|email:nope|noindex:maybe|color:red|oops-MRS)")
+	if topic != "Hi" || !cfg.IsEmpty() {
+		t.Errorf("CheckRoomConfig() = %q, %+v, want Hi and an empty config", topic, cfg)
+	}
+	fields := []string{}
+	for _, warning := range warnings {
+		fields = append(fields, warning.Field)
+	}
+	if want := []string{"language", "email", "noindex", "color", ""}; !slices.Equal(fields, want) {
+		t.Errorf("warnings for %v, want %v", fields, want)
+	}
+
+	if _, _, warnings := CheckRoomConfig("(MRS-language:EN|noindex:no-MRS)"); len(warnings) != 0 {
+		t.Errorf("valid config got warnings: %+v", warnings[0])
+	}
+	if _, _, warnings := CheckRoomConfig("(MRS-language:EN"); len(warnings) != 1 || warnings[0].Field != "" {
+		t.Errorf("unclosed tag, warnings = %+v", warnings)
+	}
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // Entry represents indexable and/or indexed matrix room
 type Entry struct {
 	ID            string `json:"id" yaml:"id"`
@@ -17,6 +19,11 @@ type Entry struct {
 	JoinRule      string `json:"join_rule" yaml:"join_rule"`
 	GuestJoinable bool   `json:"guest_can_join" yaml:"guest_can_join"`
 	WorldReadable bool   `json:"world_readable" yaml:"world_readable"`
+	Network       string `json:"network" yaml:"network"` // "matrix" for native rooms, third-party network name for bridged ones
+	// FirstSeen is when the room was indexed for the first time, sort by -first_seen for new rooms
+	FirstSeen *time.Time `json:"first_seen,omitempty" yaml:"first_seen,omitempty"`
+	// Parents are IDs of spaces listing the room, indexed for the space: filter only
+	Parents []string `json:"parents,omitempty" yaml:"parents,omitempty"`
 }
 
 // IsBlocked checks if room's server is blocked
@@ -30,6 +37,9 @@ func (r *Entry) IsBlocked(block BlocklistService) bool {
 	if block.ByServer(r.Server) {
 		return true
 	}
+	if block.ByText(r.Name, r.Topic) {
+		return true
+	}
 	return false
 }
 
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// SeedStats of a seed source's last pull
+type SeedStats struct {
+	Source  string    `json:"source"`
+	Fetched int       `json:"fetched"`         // valid server names in the list
+	New     int       `json:"new"`             // of them not known from the config, the db, or earlier sources
+	Error   string    `json:"error,omitempty"` // why the pull failed, the source contributed nothing then
+	At      time.Time `json:"at"`
+}
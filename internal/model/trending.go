@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// TrendingRoom is a room's member count change over a trending window
+type TrendingRoom struct {
+	ID     string      `json:"room_id"`
+	Delta  int         `json:"delta"`          // members gained (or lost) over the window
+	Growth float64     `json:"growth"`         // delta relative to the member count at the window start
+	Room   *MatrixRoom `json:"room,omitempty"` // filled in when the trending list is read
+}
+
+// TrendingEntry is a trending room as served by the API
+type TrendingEntry struct {
+	Entry
+	Window int     `json:"window"` // in days
+	Delta  int     `json:"delta"`
+	Growth float64 `json:"growth"`
+}
+
+// Entry converts TrendingRoom to TrendingEntry
+func (t *TrendingRoom) Entry(window int) *TrendingEntry {
+	return &TrendingEntry{
+		Entry:  *t.Room.Entry(),
+		Window: window,
+		Delta:  t.Delta,
+		Growth: t.Growth,
+	}
+}
+
+// MembersDelta returns the member count change over the window ending with the latest sample,
+// and the growth relative to the window start. A history younger than the window starts with its oldest sample,
+// so freshly discovered rooms don't "grow" from zero to their full size
+func (h *RoomHistory) MembersDelta(now time.Time, window time.Duration) (delta int, growth float64) {
+	if h == nil || len(h.Members) == 0 {
+		return 0, 0
+	}
+
+	since := now.Add(-window)
+	start := h.Members[0]
+	for _, sample := range h.Members {
+		if sample.At.After(since) {
+			break
+		}
+		start = sample
+	}
+	delta = h.Members[len(h.Members)-1].Members - start.Members
+	if start.Members > 0 {
+		growth = float64(delta) / float64(start.Members)
+	}
+	return delta, growth
+}
@@ -0,0 +1,63 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/etkecc/go-apm"
+	"go.etcd.io/bbolt"
+)
+
+// UseActionToken marks the token as used until it expires, returns false if it was used already
+func (d *Data) UseActionToken(ctx context.Context, tokenID string, expiresAt time.Time) (bool, error) {
+	apm.Log(ctx).Info().Str("token_id", tokenID).Msg("using an action token")
+	used := false
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(usedTokensBucket)
+		if bucket.Get([]byte(tokenID)) != nil {
+			used = true
+			return nil
+		}
+		return bucket.Put([]byte(tokenID), []byte(expiresAt.UTC().Format(time.RFC3339)))
+	})
+	return !used, err
+}
+
+// IsActionTokenUsed returns true if the token has been used already
+func (d *Data) IsActionTokenUsed(ctx context.Context, tokenID string) bool {
+	apm.Log(ctx).Debug().Str("token_id", tokenID).Msg("checking an action token")
+	var used bool
+	d.db.View(func(tx *bbolt.Tx) error { //nolint:errcheck // that's ok
+		used = tx.Bucket(usedTokensBucket).Get([]byte(tokenID)) != nil
+		return nil
+	})
+	return used
+}
+
+// RemoveExpiredActionTokens forgets used tokens that have expired, they are rejected as expired anyway.
+// Returns the number of forgotten tokens
+func (d *Data) RemoveExpiredActionTokens(ctx context.Context, now time.Time) (int, error) {
+	var removed int
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(usedTokensBucket)
+		expired := [][]byte{}
+		if err := bucket.ForEach(func(k, v []byte) error {
+			expiresAt, err := time.Parse(time.RFC3339, string(v))
+			if err != nil || !now.Before(expiresAt) {
+				expired = append(expired, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range expired {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		removed = len(expired)
+		return nil
+	})
+	apm.Log(ctx).Info().Int("removed", removed).Msg("expired action tokens removed")
+	return removed, err
+}
@@ -0,0 +1,41 @@
+package data
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// a used token stays used across reopening the db, until it expires and is forgotten.
+func TestUseActionToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	d, err := New(path)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+	now := time.Now().UTC()
+	if ok, err := d.UseActionToken(ctx, "fresh", now.Add(time.Hour)); !ok || err != nil {
+		t.Fatalf("UseActionToken(fresh) = %v, %v", ok, err)
+	}
+	if ok, err := d.UseActionToken(ctx, "stale", now.Add(-time.Hour)); !ok || err != nil {
+		t.Fatalf("UseActionToken(stale) = %v, %v", ok, err)
+	}
+	d.Close()
+
+	d, err = New(path)
+	if err != nil {
+		t.Fatalf("New again: %v", err)
+	}
+	defer d.Close()
+	if ok, err := d.UseActionToken(ctx, "fresh", now.Add(time.Hour)); ok || err != nil {
+		t.Errorf("UseActionToken(fresh) again = %v, %v, want used", ok, err)
+	}
+	if removed, err := d.RemoveExpiredActionTokens(ctx, now); removed != 1 || err != nil {
+		t.Errorf("RemoveExpiredActionTokens() = %d, %v, want 1", removed, err)
+	}
+	if !d.IsActionTokenUsed(ctx, "fresh") || d.IsActionTokenUsed(ctx, "stale") {
+		t.Errorf("IsActionTokenUsed() = %v, %v, want the fresh one only", d.IsActionTokenUsed(ctx, "fresh"), d.IsActionTokenUsed(ctx, "stale"))
+	}
+}
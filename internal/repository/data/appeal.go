@@ -0,0 +1,62 @@
+package data
+
+import (
+	"context"
+
+	"github.com/etkecc/go-apm"
+	"github.com/goccy/go-json"
+	"go.etcd.io/bbolt"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// SaveAppeal stores the appeal, replacing the room's previous one
+func (d *Data) SaveAppeal(ctx context.Context, appeal *model.Appeal) error {
+	apm.Log(ctx).Info().Str("room_id", appeal.RoomID).Str("status", appeal.Status).Msg("saving an appeal")
+	appealb, err := json.Marshal(appeal)
+	if err != nil {
+		return err
+	}
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(roomsAppealsBucket).Put([]byte(appeal.RoomID), appealb)
+	})
+}
+
+// GetAppeal returns the latest appeal of the room, nil if there is none
+func (d *Data) GetAppeal(ctx context.Context, roomID string) (*model.Appeal, error) {
+	apm.Log(ctx).Debug().Str("room_id", roomID).Msg("getting an appeal")
+	var appeal *model.Appeal
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(roomsAppealsBucket).Get([]byte(roomID))
+		if v == nil {
+			return nil
+		}
+		return json.Unmarshal(v, &appeal)
+	})
+	return appeal, err
+}
+
+// GetAppeals returns the latest appeals of the rooms (optionally from specific server)
+func (d *Data) GetAppeals(ctx context.Context, serverName ...string) ([]*model.Appeal, error) {
+	var server string
+	if len(serverName) > 0 {
+		server = serverName[0]
+	}
+	apm.Log(ctx).Info().Str("server", server).Msg("getting appeals")
+	list := []*model.Appeal{}
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(roomsAppealsBucket).ForEach(func(k, v []byte) error {
+			if server != "" && utils.ServerFrom(string(k)) != server {
+				return nil
+			}
+			var appeal *model.Appeal
+			if err := json.Unmarshal(v, &appeal); err != nil {
+				return err
+			}
+			list = append(list, appeal)
+			return nil
+		})
+	})
+	return list, err
+}
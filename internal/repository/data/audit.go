@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/etkecc/go-apm"
+	"github.com/goccy/go-json"
+	"go.etcd.io/bbolt"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// AddAuditEntry appends the entry to the moderation audit log, the entry's ID is set from the bucket's sequence
+func (d *Data) AddAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
+	apm.Log(ctx).Info().Str("actor", entry.Actor).Str("action", entry.Action).Str("target", entry.Target).Msg("adding an audit entry")
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(modAuditBucket)
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		entry.ID = id
+		entryb, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, id)
+		return bucket.Put(key, entryb)
+	})
+}
+
+// GetAuditLog returns entries of the moderation audit log matching the filter, newest first
+func (d *Data) GetAuditLog(ctx context.Context, filter *model.AuditFilter) ([]*model.AuditEntry, error) {
+	apm.Log(ctx).Debug().Any("filter", filter).Msg("getting the audit log")
+	entries := []*model.AuditEntry{}
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(modAuditBucket).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			var entry *model.AuditEntry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				return err
+			}
+			if !filter.Match(entry) {
+				continue
+			}
+			entries = append(entries, entry)
+			if filter != nil && filter.Limit > 0 && len(entries) >= filter.Limit {
+				return nil
+			}
+		}
+		return nil
+	})
+	return entries, err
+}
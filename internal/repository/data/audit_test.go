@@ -0,0 +1,40 @@
+package data
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// the log keeps the order it was written in, newest first on the way out, and the filter is applied before the limit.
+func TestAuditLog_Order(t *testing.T) {
+	d, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	for _, entry := range []*model.AuditEntry{
+		{Actor: "alice", Action: model.AuditBan, Target: "!a:one.example"},
+		{Actor: "bob", Action: model.AuditBan, Target: "!b:two.example"},
+		{Actor: "alice", Action: model.AuditUnban, Target: "!a:one.example"},
+		{Actor: "alice", Action: model.AuditBan, Target: "!c:one.example"},
+	} {
+		if err := d.AddAuditEntry(ctx, entry); err != nil {
+			t.Fatalf("AddAuditEntry: %v", err)
+		}
+	}
+
+	all, err := d.GetAuditLog(ctx, nil)
+	if err != nil || len(all) != 4 || all[0].ID != 4 || all[3].ID != 1 {
+		t.Fatalf("GetAuditLog(nil) = %+v, %v", all, err)
+	}
+
+	entries, err := d.GetAuditLog(ctx, &model.AuditFilter{Server: "one.example", Action: model.AuditBan, Limit: 1})
+	if err != nil || len(entries) != 1 || entries[0].Target != "!c:one.example" {
+		t.Fatalf("GetAuditLog(filter) = %+v, %v", entries, err)
+	}
+}
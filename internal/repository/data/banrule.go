@@ -0,0 +1,65 @@
+package data
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/etkecc/go-apm"
+	"github.com/goccy/go-json"
+	"go.etcd.io/bbolt"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// AddBanRule stores the ban rule, the rule's ID is set from the bucket's sequence
+func (d *Data) AddBanRule(ctx context.Context, rule *model.BanRule) error {
+	apm.Log(ctx).Info().Str("rule", rule.String()).Msg("adding a ban rule")
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(banRulesBucket)
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		rule.ID = strconv.FormatUint(id, 10)
+		ruleb, err := json.Marshal(rule)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(rule.ID), ruleb)
+	})
+}
+
+// RemoveBanRule removes the ban rule and returns it, nil if there is no such rule
+func (d *Data) RemoveBanRule(ctx context.Context, id string) (*model.BanRule, error) {
+	apm.Log(ctx).Info().Str("id", id).Msg("removing a ban rule")
+	var rule *model.BanRule
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(banRulesBucket)
+		v := bucket.Get([]byte(id))
+		if v == nil {
+			return nil
+		}
+		if err := json.Unmarshal(v, &rule); err != nil {
+			return err
+		}
+		return bucket.Delete([]byte(id))
+	})
+	return rule, err
+}
+
+// GetBanRules returns all ban rules, not compiled
+func (d *Data) GetBanRules(ctx context.Context) ([]*model.BanRule, error) {
+	apm.Log(ctx).Debug().Msg("getting ban rules")
+	rules := []*model.BanRule{}
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(banRulesBucket).ForEach(func(_, v []byte) error {
+			var rule *model.BanRule
+			if err := json.Unmarshal(v, &rule); err != nil {
+				return err
+			}
+			rules = append(rules, rule)
+			return nil
+		})
+	})
+	return rules, err
+}
@@ -17,12 +17,39 @@ var (
 	// biggest rooms bucket
 	// contains the same content as rooms bucket, but sorted by the number of users
 	biggestRoomsBucket = []byte(`rooms_biggest`)
+	// trending rooms bucket
+	// contains room IDs with member count deltas, sorted by the delta, per trending window (the rooms are read from rooms bucket)
+	trendingRoomsBucket = []byte(`rooms_trending`)
 	// rooms banlist bucket
 	// contains information about banned rooms
 	roomsBanlistBucket = []byte(`rooms_banlist`)
+	// rooms quarantine bucket
+	// contains room_id -> quarantine record, quarantined rooms are kept but hidden
+	roomsQuarantineBucket = []byte(`rooms_quarantine`)
+	// rooms appeals bucket
+	// contains room_id -> the latest appeal of a banned room
+	roomsAppealsBucket = []byte(`rooms_appeals`)
 	// rooms reports bucket
 	// contains information about reported rooms
 	roomsReportsBucket = []byte(`rooms_reports`)
+	// rooms spaces bucket
+	// contains space_id -> children relations, as crawled from space hierarchies
+	roomsSpacesBucket = []byte(`rooms_spaces`)
+	// rooms parents bucket
+	// contains child room_id -> parent space IDs, the reverse of rooms spaces bucket, kept in sync with it
+	roomsParentsBucket = []byte(`rooms_parents`)
+	// rooms history bucket
+	// contains room_id -> compact change log of the room, updated on every parse
+	roomsHistoryBucket = []byte(`rooms_history`)
+	// ban rules bucket
+	// contains rule_id -> moderators' ban rule (by server, alias pattern, or name/topic regex)
+	banRulesBucket = []byte(`ban_rules`)
+	// used action tokens bucket
+	// contains token_id -> expiry of the action and appeal tokens used already, forgotten once expired
+	usedTokensBucket = []byte(`action_tokens_used`)
+	// moderation audit bucket
+	// contains sequence -> moderation action, append-only
+	modAuditBucket = []byte(`moderation_audit`)
 	// index bucket
 	// contains latest index stats
 	indexBucket = []byte(`index`)
@@ -30,7 +57,7 @@ var (
 	// contains index stats by date
 	indexTLBucket = []byte(`index_timeline`)
 
-	buckets = [][]byte{serversInfoBucket, roomsBucket, biggestRoomsBucket, roomsBanlistBucket, roomsReportsBucket, roomsMappingsBucket, indexBucket, indexTLBucket}
+	buckets = [][]byte{serversInfoBucket, roomsBucket, biggestRoomsBucket, trendingRoomsBucket, roomsBanlistBucket, roomsQuarantineBucket, roomsAppealsBucket, roomsReportsBucket, roomsMappingsBucket, roomsSpacesBucket, roomsParentsBucket, roomsHistoryBucket, banRulesBucket, usedTokensBucket, modAuditBucket, indexBucket, indexTLBucket}
 )
 
 func initBuckets(db *bbolt.DB) error {
@@ -46,6 +73,7 @@ func initBuckets(db *bbolt.DB) error {
 		}
 
 		// Create buckets if they don't exist
+		backfillParents := tx.Bucket(roomsParentsBucket) == nil
 		for _, bucket := range buckets {
 			_, err := tx.CreateBucketIfNotExists(bucket)
 			if err != nil {
@@ -53,6 +81,10 @@ func initBuckets(db *bbolt.DB) error {
 			}
 		}
 
+		// the reverse index is newer than the relations themselves
+		if backfillParents {
+			return backfillSpaceParents(tx)
+		}
 		return nil
 	})
 }
@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/etkecc/go-apm"
 	"github.com/goccy/go-json"
@@ -31,7 +32,11 @@ func New(path string) (*Data, error) {
 		rb: batch.New(10000, func(ctx context.Context, rooms []*model.MatrixRoom) {
 			db.Update(func(tx *bbolt.Tx) error { //nolint:errcheck // checked inside
 				log := apm.Log(ctx)
+				now := time.Now().UTC()
 				for _, room := range rooms {
+					if err := trackRoomHistory(tx, room, now); err != nil {
+						log.Warn().Err(err).Str("id", room.ID).Str("server", room.Server).Msg("cannot track room history")
+					}
 					roomb, err := json.Marshal(room)
 					if err != nil {
 						log.Error().Err(err).Str("id", room.ID).Str("server", room.Server).Msg("cannot marshal room")
@@ -0,0 +1,53 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/etkecc/go-apm"
+	"github.com/goccy/go-json"
+	"go.etcd.io/bbolt"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// trackRoomHistory compares the room with its stored version, updates the room's history,
+// and carries over the room's first_seen. Must be called before the room is put into the rooms bucket
+func trackRoomHistory(tx *bbolt.Tx, room *model.MatrixRoom, now time.Time) error {
+	var prev *model.MatrixRoom
+	if v := tx.Bucket(roomsBucket).Get([]byte(room.ID)); v != nil {
+		if err := json.Unmarshal(v, &prev); err != nil {
+			return err
+		}
+	}
+
+	hbucket := tx.Bucket(roomsHistoryBucket)
+	history := &model.RoomHistory{}
+	if v := hbucket.Get([]byte(room.ID)); v != nil {
+		if err := json.Unmarshal(v, history); err != nil {
+			return err
+		}
+	}
+	history.Track(prev, room, now)
+	room.FirstSeen = history.FirstSeen
+
+	historyb, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return hbucket.Put([]byte(room.ID), historyb)
+}
+
+// GetRoomHistory returns the room's history, nil if the room was never stored
+func (d *Data) GetRoomHistory(ctx context.Context, roomID string) (*model.RoomHistory, error) {
+	apm.Log(ctx).Debug().Str("room_id", roomID).Msg("getting a room history")
+	var history *model.RoomHistory
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(roomsHistoryBucket).Get([]byte(roomID))
+		if v == nil {
+			return nil
+		}
+		return json.Unmarshal(v, &history)
+	})
+	return history, err
+}
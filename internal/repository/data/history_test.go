@@ -0,0 +1,54 @@
+package data
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// first_seen must survive every re-parse (the crawler hands over a fresh struct each time), and only real changes make the log.
+func TestRoomHistory_FirstSeenSurvivesReparse(t *testing.T) {
+	d, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!room:example.com", Name: "Old", Members: 10})
+	d.FlushRoomBatch(ctx)
+	first, err := d.GetRoom(ctx, "!room:example.com")
+	if err != nil || first == nil || first.FirstSeen.IsZero() {
+		t.Fatalf("first parse: room = %+v, err = %v", first, err)
+	}
+
+	time.Sleep(time.Millisecond)
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!room:example.com", Name: "New", Members: 12})
+	d.FlushRoomBatch(ctx)
+	second, err := d.GetRoom(ctx, "!room:example.com")
+	if err != nil || second == nil {
+		t.Fatalf("second parse: room = %+v, err = %v", second, err)
+	}
+	if !second.FirstSeen.Equal(first.FirstSeen) {
+		t.Errorf("first_seen moved: %v -> %v", first.FirstSeen, second.FirstSeen)
+	}
+
+	history, err := d.GetRoomHistory(ctx, "!room:example.com")
+	if err != nil || history == nil {
+		t.Fatalf("GetRoomHistory = %+v, %v", history, err)
+	}
+	if len(history.Changes) != 1 || history.Changes[0].Field != "name" || history.Changes[0].From != "Old" || history.Changes[0].To != "New" {
+		t.Errorf("changes = %+v, want a single name change", history.Changes)
+	}
+	if len(history.Members) != 1 || history.Members[0].Members != 12 {
+		t.Errorf("members = %+v, want one sample of the day with the latest count", history.Members)
+	}
+
+	d.RemoveRooms(ctx, []string{"!room:example.com"})
+	if history, _ := d.GetRoomHistory(ctx, "!room:example.com"); history != nil {
+		t.Error("history must go with the room")
+	}
+}
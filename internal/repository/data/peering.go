@@ -0,0 +1,51 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/etkecc/go-apm"
+	"github.com/goccy/go-json"
+	"go.etcd.io/bbolt"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// GetRoomsParsedSince returns a page of rooms (except banned and quarantined) parsed after since, in room ID order,
+// starting after the from room ID, and the room ID to continue from (empty if that was the last page)
+func (d *Data) GetRoomsParsedSince(ctx context.Context, since time.Time, from string, limit int) (rooms []*model.MatrixRoom, next string, err error) {
+	apm.Log(ctx).Debug().Time("since", since).Str("from", from).Int("limit", limit).Msg("getting rooms parsed since")
+	rooms = []*model.MatrixRoom{}
+	err = d.db.View(func(tx *bbolt.Tx) error {
+		banlist := tx.Bucket(roomsBanlistBucket)
+		quarantine := tx.Bucket(roomsQuarantineBucket)
+		c := tx.Bucket(roomsBucket).Cursor()
+		k, v := c.First()
+		if from != "" {
+			k, v = c.Seek([]byte(from))
+			if k != nil && string(k) == from {
+				k, v = c.Next()
+			}
+		}
+		var last string
+		for ; k != nil; k, v = c.Next() {
+			if len(rooms) >= limit {
+				next = last
+				return nil
+			}
+			if banlist.Get(k) != nil || quarantine.Get(k) != nil {
+				continue
+			}
+			var room *model.MatrixRoom
+			if err := json.Unmarshal(v, &room); err != nil {
+				return err
+			}
+			if room.ParsedAt.After(since) {
+				rooms = append(rooms, room)
+				last = string(k)
+			}
+		}
+		return nil
+	})
+	return rooms, next, err
+}
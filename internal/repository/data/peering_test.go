@@ -0,0 +1,57 @@
+package data
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// the peering feed pages by room ID and must neither skip nor repeat a room at a page border.
+func TestGetRoomsParsedSince(t *testing.T) {
+	d, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	since := time.Now().UTC().Add(-time.Hour)
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!a:example.com", ParsedAt: since.Add(time.Minute)})
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!b:example.com", ParsedAt: since.Add(-time.Minute)}) // old
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!c:example.com", ParsedAt: since.Add(time.Minute)})
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!d:example.com", ParsedAt: since.Add(time.Minute)}) // banned
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!e:example.com", ParsedAt: since.Add(time.Minute)})
+	d.FlushRoomBatch(ctx)
+	if err := d.BanRoom(ctx, &model.RoomBan{RoomID: "!d:example.com"}); err != nil {
+		t.Fatalf("BanRoom: %v", err)
+	}
+
+	got := []string{}
+	var from string
+	for range 5 {
+		rooms, next, err := d.GetRoomsParsedSince(ctx, since, from, 2)
+		if err != nil {
+			t.Fatalf("GetRoomsParsedSince: %v", err)
+		}
+		for _, room := range rooms {
+			got = append(got, room.ID)
+		}
+		if next == "" {
+			break
+		}
+		from = next
+	}
+	want := []string{"!a:example.com", "!c:example.com", "!e:example.com"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"context"
+
+	"github.com/etkecc/go-apm"
+	"github.com/goccy/go-json"
+	"go.etcd.io/bbolt"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// QuarantineRoom stores the quarantine record, the room itself is kept
+func (d *Data) QuarantineRoom(ctx context.Context, quarantine *model.RoomQuarantine) error {
+	apm.Log(ctx).Info().Str("room_id", quarantine.RoomID).Msg("quarantining a room")
+	quarantineb, err := json.Marshal(quarantine)
+	if err != nil {
+		return err
+	}
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(roomsQuarantineBucket).Put([]byte(quarantine.RoomID), quarantineb)
+	})
+}
+
+// ReleaseRoom removes the quarantine record
+func (d *Data) ReleaseRoom(ctx context.Context, roomID string) error {
+	apm.Log(ctx).Info().Str("room_id", roomID).Msg("releasing a room from quarantine")
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(roomsQuarantineBucket).Delete([]byte(roomID))
+	})
+}
+
+// GetRoomQuarantine returns quarantine record of the room, nil if it is not quarantined
+func (d *Data) GetRoomQuarantine(ctx context.Context, roomID string) (*model.RoomQuarantine, error) {
+	apm.Log(ctx).Debug().Str("room_id", roomID).Msg("getting a quarantine record")
+	var quarantine *model.RoomQuarantine
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(roomsQuarantineBucket).Get([]byte(roomID))
+		if v == nil {
+			return nil
+		}
+		return json.Unmarshal(v, &quarantine)
+	})
+	return quarantine, err
+}
+
+// IsQuarantined checks if a room is quarantined
+func (d *Data) IsQuarantined(ctx context.Context, roomID string) bool {
+	apm.Log(ctx).Debug().Str("room_id", roomID).Msg("checking if a room is quarantined")
+	var quarantined bool
+	d.db.View(func(tx *bbolt.Tx) error { //nolint:errcheck // that's ok
+		quarantined = tx.Bucket(roomsQuarantineBucket).Get([]byte(roomID)) != nil
+		return nil
+	})
+	return quarantined
+}
+
+// GetQuarantinedRooms returns quarantine records (optionally from specific server)
+func (d *Data) GetQuarantinedRooms(ctx context.Context, serverName ...string) ([]*model.RoomQuarantine, error) {
+	var server string
+	if len(serverName) > 0 {
+		server = serverName[0]
+	}
+	apm.Log(ctx).Info().Str("server", server).Msg("getting quarantine records")
+	list := []*model.RoomQuarantine{}
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(roomsQuarantineBucket).ForEach(func(k, v []byte) error {
+			if server != "" && utils.ServerFrom(string(k)) != server {
+				return nil
+			}
+			var quarantine *model.RoomQuarantine
+			if err := json.Unmarshal(v, &quarantine); err != nil {
+				return err
+			}
+			list = append(list, quarantine)
+			return nil
+		})
+	})
+	return list, err
+}
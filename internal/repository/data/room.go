@@ -60,6 +60,7 @@ func (d *Data) GetBiggestRooms(ctx context.Context, limit, offset int) []*model.
 	rooms := []*model.MatrixRoom{}
 
 	d.db.View(func(tx *bbolt.Tx) error { //nolint:errcheck // that's ok
+		quarantine := tx.Bucket(roomsQuarantineBucket)
 		c := tx.Bucket(biggestRoomsBucket).Cursor()
 		for k, v := c.Seek(start); k != nil && bytes.Compare(k, end) <= 0; k, v = c.Next() {
 			var room *model.MatrixRoom
@@ -68,6 +69,10 @@ func (d *Data) GetBiggestRooms(ctx context.Context, limit, offset int) []*model.
 				log.Error().Err(err).Msg("cannot unmarshal a biggest room")
 				return err
 			}
+			// the list is rebuilt after parsing, a room quarantined since then is still in it
+			if quarantine.Get([]byte(room.ID)) != nil {
+				continue
+			}
 			rooms = append(rooms, room)
 		}
 		return nil
@@ -99,9 +104,12 @@ func (d *Data) RemoveRooms(ctx context.Context, keys []string) {
 	d.db.Update(func(tx *bbolt.Tx) error { //nolint:errcheck // that's ok
 		bucket := tx.Bucket(roomsBucket)
 		mbucket := tx.Bucket(roomsMappingsBucket)
+		hbucket := tx.Bucket(roomsHistoryBucket)
 		for _, k := range keys {
-			bucket.Delete([]byte(k))  //nolint:errcheck // that's ok
-			mbucket.Delete([]byte(k)) //nolint:errcheck // that's ok
+			bucket.Delete([]byte(k))     //nolint:errcheck // that's ok
+			mbucket.Delete([]byte(k))    //nolint:errcheck // that's ok
+			setSpaceChildren(tx, k, nil) //nolint:errcheck // that's ok
+			hbucket.Delete([]byte(k))    //nolint:errcheck // that's ok
 		}
 		return nil
 	})
@@ -120,10 +128,13 @@ func (d *Data) EachRoom(ctx context.Context, handler func(roomID string, data *m
 			if err != nil {
 				return err
 			}
-			// ignore banned rooms
+			// ignore banned and quarantined rooms
 			if tx.Bucket(roomsBanlistBucket).Get(k) != nil {
 				return nil
 			}
+			if tx.Bucket(roomsQuarantineBucket).Get(k) != nil {
+				return nil
+			}
 
 			if handler(string(k), room) {
 				return fmt.Errorf("stop")
@@ -156,6 +167,41 @@ func (d *Data) GetBannedRooms(ctx context.Context, serverName ...string) ([]stri
 	return list, err
 }
 
+// GetRoomBans returns ban records of the banned rooms (optionally from specific server)
+func (d *Data) GetRoomBans(ctx context.Context, serverName ...string) ([]*model.RoomBan, error) {
+	var server string
+	if len(serverName) > 0 {
+		server = serverName[0]
+	}
+	apm.Log(ctx).Info().Str("server", server).Msg("getting ban records")
+	list := []*model.RoomBan{}
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(roomsBanlistBucket).ForEach(func(k, v []byte) error {
+			roomID := string(k)
+			if server != "" && utils.ServerFrom(roomID) != server {
+				return nil
+			}
+
+			list = append(list, unmarshalRoomBan(roomID, v))
+			return nil
+		})
+	})
+	return list, err
+}
+
+// GetRoomBan returns ban record of the room, nil if it is not banned
+func (d *Data) GetRoomBan(ctx context.Context, roomID string) (*model.RoomBan, error) {
+	apm.Log(ctx).Debug().Str("room_id", roomID).Msg("getting a ban record")
+	var ban *model.RoomBan
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		if v := tx.Bucket(roomsBanlistBucket).Get([]byte(roomID)); v != nil {
+			ban = unmarshalRoomBan(roomID, v)
+		}
+		return nil
+	})
+	return ban, err
+}
+
 // IsBanned checks if a room is banned
 func (d *Data) IsBanned(ctx context.Context, roomID string) bool {
 	apm.Log(ctx).Debug().Str("room_id", roomID).Msg("checking if a room is banned")
@@ -169,20 +215,38 @@ func (d *Data) IsBanned(ctx context.Context, roomID string) bool {
 	return banned
 }
 
-// BanRoom
-func (d *Data) BanRoom(ctx context.Context, roomID string) error {
-	apm.Log(ctx).Info().Str("room_id", roomID).Msg("banning a room")
+// BanRoom removes the room and stores its ban record (replacing the existing one, if any)
+func (d *Data) BanRoom(ctx context.Context, ban *model.RoomBan) error {
+	apm.Log(ctx).Info().Str("room_id", ban.RoomID).Msg("banning a room")
+	banb, err := json.Marshal(ban)
+	if err != nil {
+		return err
+	}
 	return d.db.Batch(func(tx *bbolt.Tx) error {
-		if err := tx.Bucket(roomsBucket).Delete([]byte(roomID)); err != nil {
-			return fmt.Errorf("cannot delete room %s: %w", roomID, err)
+		if err := tx.Bucket(roomsBucket).Delete([]byte(ban.RoomID)); err != nil {
+			return fmt.Errorf("cannot delete room %s: %w", ban.RoomID, err)
 		}
-		if err := tx.Bucket(roomsBanlistBucket).Put([]byte(roomID), []byte(`true`)); err != nil {
-			return fmt.Errorf("cannot put room %s to banlist: %w", roomID, err)
+		if err := tx.Bucket(roomsBanlistBucket).Put([]byte(ban.RoomID), banb); err != nil {
+			return fmt.Errorf("cannot put room %s to banlist: %w", ban.RoomID, err)
 		}
-		return tx.Bucket(roomsReportsBucket).Delete([]byte(roomID))
+		if err := tx.Bucket(roomsQuarantineBucket).Delete([]byte(ban.RoomID)); err != nil {
+			return err
+		}
+		return tx.Bucket(roomsReportsBucket).Delete([]byte(ban.RoomID))
 	})
 }
 
+// unmarshalRoomBan parses a stored ban record. Before bans had records, a bare `true` was stored,
+// such bans are permanent and say nothing else
+func unmarshalRoomBan(roomID string, v []byte) *model.RoomBan {
+	var ban *model.RoomBan
+	if err := json.Unmarshal(v, &ban); err != nil || ban == nil {
+		ban = &model.RoomBan{}
+	}
+	ban.RoomID = roomID
+	return ban
+}
+
 // UnbanRoom
 func (d *Data) UnbanRoom(ctx context.Context, roomID string) error {
 	apm.Log(ctx).Info().Str("room_id", roomID).Msg("unbanning a room")
@@ -194,15 +258,15 @@ func (d *Data) UnbanRoom(ctx context.Context, roomID string) error {
 	})
 }
 
-// GetReportedRooms returns full list of the banned rooms with reasons
-func (d *Data) GetReportedRooms(ctx context.Context, serverName ...string) (map[string]string, error) {
+// GetReportedRooms returns reports of the reported rooms (optionally from specific server)
+func (d *Data) GetReportedRooms(ctx context.Context, serverName ...string) (map[string]*model.RoomReports, error) {
 	apm.Log(ctx).Info().Msg("getting a list of reported rooms")
 
 	var server string
 	if len(serverName) > 0 {
 		server = serverName[0]
 	}
-	data := map[string]string{}
+	data := map[string]*model.RoomReports{}
 	err := d.db.View(func(tx *bbolt.Tx) error {
 		return tx.Bucket(roomsReportsBucket).ForEach(func(k, v []byte) error {
 			roomID := string(k)
@@ -210,13 +274,26 @@ func (d *Data) GetReportedRooms(ctx context.Context, serverName ...string) (map[
 				return nil
 			}
 
-			data[string(k)] = string(v)
+			data[roomID] = unmarshalRoomReports(roomID, v)
 			return nil
 		})
 	})
 	return data, err
 }
 
+// GetRoomReports returns reports of the room, nil if it is not reported
+func (d *Data) GetRoomReports(ctx context.Context, roomID string) (*model.RoomReports, error) {
+	apm.Log(ctx).Debug().Str("room_id", roomID).Msg("getting reports of a room")
+	var reports *model.RoomReports
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		if v := tx.Bucket(roomsReportsBucket).Get([]byte(roomID)); v != nil {
+			reports = unmarshalRoomReports(roomID, v)
+		}
+		return nil
+	})
+	return reports, err
+}
+
 // IsReported returns true if room was already reported
 func (d *Data) IsReported(ctx context.Context, roomID string) bool {
 	apm.Log(ctx).Debug().Str("room_id", roomID).Msg("checking if a room is reported")
@@ -230,12 +307,36 @@ func (d *Data) IsReported(ctx context.Context, roomID string) bool {
 	return reported
 }
 
-// ReportRoom
-func (d *Data) ReportRoom(ctx context.Context, fromIP, roomID, reason string) error {
-	apm.Log(ctx).Info().Str("room", roomID).Str("from", fromIP).Msg("reporting a room")
-	return d.db.Batch(func(tx *bbolt.Tx) error {
-		return tx.Bucket(roomsReportsBucket).Put([]byte(roomID), []byte(reason))
+// ReportRoom adds the report to the room's reports, returns them as they are now
+// and whether the report raised the room's top category (true for the first report, too)
+func (d *Data) ReportRoom(ctx context.Context, roomID string, report *model.RoomReport) (reports *model.RoomReports, escalated bool, err error) {
+	apm.Log(ctx).Info().Str("room", roomID).Str("category", report.Category).Msg("reporting a room")
+	err = d.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(roomsReportsBucket)
+		reports = &model.RoomReports{RoomID: roomID}
+		if v := bucket.Get([]byte(roomID)); v != nil {
+			reports = unmarshalRoomReports(roomID, v)
+		}
+		escalated = reports.Add(report)
+		reportsb, err := json.Marshal(reports)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(roomID), reportsb)
 	})
+	return reports, escalated, err
+}
+
+// unmarshalRoomReports parses stored reports. Before reports were lists, a single plain-text reason was stored,
+// such reports are kept as one report of the "other" category
+func unmarshalRoomReports(roomID string, v []byte) *model.RoomReports {
+	var reports *model.RoomReports
+	if err := json.Unmarshal(v, &reports); err != nil || reports == nil {
+		reports = &model.RoomReports{Reports: []*model.RoomReport{{Category: model.ReportOther, Reason: string(v)}}}
+	}
+	reports.RoomID = roomID
+	reports.Update()
+	return reports
 }
 
 // UnreportRoom
@@ -0,0 +1,104 @@
+package data
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// reports stored as a plain reason before they became lists are still there, as one report.
+func TestReportRoom_LegacyReason(t *testing.T) {
+	d, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	err = d.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(roomsReportsBucket).Put([]byte("!r:example.com"), []byte("old reason"))
+	})
+	if err != nil {
+		t.Fatalf("put legacy report: %v", err)
+	}
+
+	reports, escalated, err := d.ReportRoom(ctx, "!r:example.com", &model.RoomReport{Category: model.ReportSpam, Reason: "new reason", Reporter: "x"})
+	if err != nil || !escalated || reports.Count != 2 || reports.Reports[1].Reason != "old reason" {
+		t.Fatalf("ReportRoom() = %+v, %v, %v", reports, escalated, err)
+	}
+	stored, err := d.GetRoomReports(ctx, "!r:example.com")
+	if err != nil || stored == nil || stored.RoomID != "!r:example.com" || stored.Priority != 3 {
+		t.Fatalf("GetRoomReports() = %+v, %v", stored, err)
+	}
+}
+
+// bans stored as a bare true before bans had records are permanent bans without details.
+func TestGetRoomBans_Legacy(t *testing.T) {
+	d, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	err = d.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(roomsBanlistBucket).Put([]byte("!old:example.com"), []byte(`true`))
+	})
+	if err != nil {
+		t.Fatalf("put legacy ban: %v", err)
+	}
+	if err := d.BanRoom(ctx, &model.RoomBan{RoomID: "!new:example.com", Reason: "spam", Category: model.ReportSpam}); err != nil {
+		t.Fatalf("BanRoom: %v", err)
+	}
+
+	bans, err := d.GetRoomBans(ctx)
+	if err != nil || len(bans) != 2 {
+		t.Fatalf("GetRoomBans() = %+v, %v", bans, err)
+	}
+	if bans[0].RoomID != "!new:example.com" || bans[0].Reason != "spam" || bans[1].RoomID != "!old:example.com" || bans[1].ExpiresAt != nil {
+		t.Errorf("GetRoomBans() = %+v, %+v", bans[0], bans[1])
+	}
+	if !d.IsBanned(ctx, "!old:example.com") {
+		t.Error("legacy ban is not a ban anymore")
+	}
+}
+
+// a quarantined room is kept, but skipped by everything that lists rooms, until a ban takes over.
+func TestQuarantineRoom(t *testing.T) {
+	d, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!a:example.com"})
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!q:example.com"})
+	d.FlushRoomBatch(ctx)
+	if err := d.QuarantineRoom(ctx, &model.RoomQuarantine{RoomID: "!q:example.com", Reason: "review"}); err != nil {
+		t.Fatalf("QuarantineRoom: %v", err)
+	}
+
+	listed := []string{}
+	d.EachRoom(ctx, func(roomID string, _ *model.MatrixRoom) bool {
+		listed = append(listed, roomID)
+		return false
+	})
+	if len(listed) != 1 || listed[0] != "!a:example.com" {
+		t.Errorf("EachRoom() listed %v", listed)
+	}
+	if room, err := d.GetRoom(ctx, "!q:example.com"); err != nil || room == nil {
+		t.Errorf("quarantined room is gone: %+v, %v", room, err)
+	}
+
+	if err := d.BanRoom(ctx, &model.RoomBan{RoomID: "!q:example.com"}); err != nil {
+		t.Fatalf("BanRoom: %v", err)
+	}
+	if d.IsQuarantined(ctx, "!q:example.com") {
+		t.Error("banned room is still quarantined")
+	}
+}
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"context"
+	"slices"
+
+	"github.com/etkecc/go-apm"
+	"github.com/goccy/go-json"
+	"go.etcd.io/bbolt"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// SetSpaceChildren replaces children of the space, an empty list removes the space from the relations
+func (d *Data) SetSpaceChildren(ctx context.Context, spaceID string, children []*model.MatrixSpaceChild) error {
+	apm.Log(ctx).Debug().Str("space_id", spaceID).Int("children", len(children)).Msg("setting space children")
+	return d.db.Batch(func(tx *bbolt.Tx) error {
+		return setSpaceChildren(tx, spaceID, children)
+	})
+}
+
+// setSpaceChildren replaces children of the space within the transaction, and the space in the children's parents with them
+func setSpaceChildren(tx *bbolt.Tx, spaceID string, children []*model.MatrixSpaceChild) error {
+	sbucket := tx.Bucket(roomsSpacesBucket)
+	var old []*model.MatrixSpaceChild
+	if v := sbucket.Get([]byte(spaceID)); v != nil {
+		if err := json.Unmarshal(v, &old); err != nil {
+			return err
+		}
+	}
+	for _, child := range old {
+		if err := updateSpaceParents(tx, child.ID, func(parents []string) []string {
+			return slices.DeleteFunc(parents, func(parent string) bool { return parent == spaceID })
+		}); err != nil {
+			return err
+		}
+	}
+	if len(children) == 0 {
+		return sbucket.Delete([]byte(spaceID))
+	}
+
+	for _, child := range children {
+		if err := updateSpaceParents(tx, child.ID, func(parents []string) []string {
+			if slices.Contains(parents, spaceID) {
+				return parents
+			}
+			parents = append(parents, spaceID)
+			slices.Sort(parents)
+			return parents
+		}); err != nil {
+			return err
+		}
+	}
+	datab, err := json.Marshal(children)
+	if err != nil {
+		return err
+	}
+	return sbucket.Put([]byte(spaceID), datab)
+}
+
+// updateSpaceParents rewrites parents of the child room, a room left without parents is removed from the index
+func updateSpaceParents(tx *bbolt.Tx, childID string, update func([]string) []string) error {
+	pbucket := tx.Bucket(roomsParentsBucket)
+	var parents []string
+	if v := pbucket.Get([]byte(childID)); v != nil {
+		if err := json.Unmarshal(v, &parents); err != nil {
+			return err
+		}
+	}
+	parents = update(parents)
+	if len(parents) == 0 {
+		return pbucket.Delete([]byte(childID))
+	}
+	datab, err := json.Marshal(parents)
+	if err != nil {
+		return err
+	}
+	return pbucket.Put([]byte(childID), datab)
+}
+
+// backfillSpaceParents builds the child -> parents index from the relations stored before the index existed
+func backfillSpaceParents(tx *bbolt.Tx) error {
+	parents := map[string][]string{}
+	err := tx.Bucket(roomsSpacesBucket).ForEach(func(k, v []byte) error {
+		var children []*model.MatrixSpaceChild
+		if err := json.Unmarshal(v, &children); err != nil {
+			return err
+		}
+		for _, child := range children {
+			parents[child.ID] = append(parents[child.ID], string(k))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for childID, spaceIDs := range parents {
+		if err := updateSpaceParents(tx, childID, func([]string) []string {
+			slices.Sort(spaceIDs)
+			return slices.Compact(spaceIDs)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSpaceChildren returns children of the space, nil if the room is not a (known) space
+func (d *Data) GetSpaceChildren(ctx context.Context, spaceID string) ([]*model.MatrixSpaceChild, error) {
+	apm.Log(ctx).Debug().Str("space_id", spaceID).Msg("getting space children")
+	var children []*model.MatrixSpaceChild
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(roomsSpacesBucket).Get([]byte(spaceID))
+		if v == nil {
+			return nil
+		}
+		return json.Unmarshal(v, &children)
+	})
+	return children, err
+}
+
+// GetSpaceParents returns child room ID -> parent space IDs map of all known spaces
+func (d *Data) GetSpaceParents(ctx context.Context) (map[string][]string, error) {
+	apm.Log(ctx).Debug().Msg("getting space parents")
+	parents := map[string][]string{}
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(roomsParentsBucket).ForEach(func(k, v []byte) error {
+			var spaceIDs []string
+			if err := json.Unmarshal(v, &spaceIDs); err != nil {
+				return err
+			}
+			parents[string(k)] = spaceIDs
+			return nil
+		})
+	})
+	return parents, err
+}
+
+// GetRoomParents returns parent space IDs of the room, nil if it's in no (known) space
+func (d *Data) GetRoomParents(ctx context.Context, roomID string) ([]string, error) {
+	apm.Log(ctx).Debug().Str("room_id", roomID).Msg("getting room parents")
+	var parents []string
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(roomsParentsBucket).Get([]byte(roomID))
+		if v == nil {
+			return nil
+		}
+		return json.Unmarshal(v, &parents)
+	})
+	return parents, err
+}
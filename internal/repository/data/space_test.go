@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// relations are stored per space but read by child too; removing the space (or emptying it) must drop both views.
+func TestSpaceRelations(t *testing.T) {
+	d, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	child := &model.MatrixSpaceChild{ID: "!child:example.com", Via: []string{"example.com"}}
+	if err := d.SetSpaceChildren(ctx, "!a:example.com", []*model.MatrixSpaceChild{child}); err != nil {
+		t.Fatalf("SetSpaceChildren: %v", err)
+	}
+	if err := d.SetSpaceChildren(ctx, "!b:example.com", []*model.MatrixSpaceChild{child}); err != nil {
+		t.Fatalf("SetSpaceChildren: %v", err)
+	}
+
+	children, err := d.GetSpaceChildren(ctx, "!a:example.com")
+	if err != nil || len(children) != 1 || children[0].ID != child.ID {
+		t.Fatalf("GetSpaceChildren = %+v, %v", children, err)
+	}
+	parents, err := d.GetSpaceParents(ctx)
+	if err != nil {
+		t.Fatalf("GetSpaceParents: %v", err)
+	}
+	slices.Sort(parents[child.ID])
+	if !slices.Equal(parents[child.ID], []string{"!a:example.com", "!b:example.com"}) {
+		t.Fatalf("parents = %v, want both spaces", parents[child.ID])
+	}
+	if parents, err := d.GetRoomParents(ctx, child.ID); err != nil || !slices.Equal(parents, []string{"!a:example.com", "!b:example.com"}) {
+		t.Fatalf("GetRoomParents = %v, %v, want both spaces", parents, err)
+	}
+
+	// a space that no longer lists the child is no longer its parent
+	other := &model.MatrixSpaceChild{ID: "!other:example.com"}
+	if err := d.SetSpaceChildren(ctx, "!b:example.com", []*model.MatrixSpaceChild{other}); err != nil {
+		t.Fatalf("SetSpaceChildren: %v", err)
+	}
+	if parents, err := d.GetRoomParents(ctx, child.ID); err != nil || !slices.Equal(parents, []string{"!a:example.com"}) {
+		t.Fatalf("GetRoomParents = %v, %v, want !a only", parents, err)
+	}
+
+	d.RemoveRooms(ctx, []string{"!a:example.com"})
+	if err := d.SetSpaceChildren(ctx, "!b:example.com", nil); err != nil {
+		t.Fatalf("SetSpaceChildren: %v", err)
+	}
+	parents, err = d.GetSpaceParents(ctx)
+	if err != nil {
+		t.Fatalf("GetSpaceParents: %v", err)
+	}
+	if len(parents) != 0 {
+		t.Fatalf("parents = %v, want none after the spaces are gone", parents)
+	}
+}
+
+// a db from before the reverse index gets it built from the relations on open.
+func TestSpaceParentsBackfill(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	d, err := New(path)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+	if err := d.SetSpaceChildren(ctx, "!a:example.com", []*model.MatrixSpaceChild{{ID: "!child:example.com"}}); err != nil {
+		t.Fatalf("SetSpaceChildren: %v", err)
+	}
+	if err := d.db.Update(func(tx *bbolt.Tx) error { return tx.DeleteBucket(roomsParentsBucket) }); err != nil {
+		t.Fatalf("DeleteBucket: %v", err)
+	}
+	d.Close()
+
+	d, err = New(path)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer d.Close()
+	if parents, err := d.GetRoomParents(ctx, "!child:example.com"); err != nil || !slices.Equal(parents, []string{"!a:example.com"}) {
+		t.Fatalf("GetRoomParents = %v, %v, want the space backfilled", parents, err)
+	}
+}
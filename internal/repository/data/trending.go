@@ -0,0 +1,110 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/etkecc/go-apm"
+	"github.com/goccy/go-json"
+	"go.etcd.io/bbolt"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// trendingKey is window (in days) + position as big-endian uint32s, so each window is a contiguous sorted range of any length
+func trendingKey(window, position int) []byte {
+	key := binary.BigEndian.AppendUint32(make([]byte, 0, 8), uint32(window)) //nolint:gosec // that's ok
+	return binary.BigEndian.AppendUint32(key, uint32(position))              //nolint:gosec // that's ok
+}
+
+// EachRoomHistory iterates over all room histories
+func (d *Data) EachRoomHistory(ctx context.Context, handler func(roomID string, history *model.RoomHistory) bool) {
+	apm.Log(ctx).Warn().Msg("iterating over all room histories")
+
+	d.db.View(func(tx *bbolt.Tx) error { //nolint:errcheck // that's ok
+		return tx.Bucket(roomsHistoryBucket).ForEach(func(k, v []byte) error {
+			history := &model.RoomHistory{}
+			if err := json.Unmarshal(v, history); err != nil {
+				return err
+			}
+			if handler(string(k), history) {
+				return fmt.Errorf("stop")
+			}
+			return nil
+		})
+	})
+}
+
+// SetTrendingRooms replaces trending lists, window (in days) => rooms sorted by delta
+func (d *Data) SetTrendingRooms(ctx context.Context, trending map[int][]*model.TrendingRoom) error {
+	apm.Log(ctx).Info().Int("windows", len(trending)).Msg("updating trending rooms")
+
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket(trendingRoomsBucket); err != nil {
+			return err
+		}
+		tBucket, cerr := tx.CreateBucket(trendingRoomsBucket)
+		if cerr != nil {
+			return cerr
+		}
+		rBucket := tx.Bucket(roomsBucket)
+
+		for window, rooms := range trending {
+			position := 0
+			for _, item := range rooms {
+				if rBucket.Get([]byte(item.ID)) == nil {
+					continue
+				}
+				// the room itself is loaded from the rooms bucket on read, no need to keep a copy per window
+				storedb, err := json.Marshal(&model.TrendingRoom{ID: item.ID, Delta: item.Delta, Growth: item.Growth})
+				if err != nil {
+					return err
+				}
+				position++
+				if err := tBucket.Put(trendingKey(window, position), storedb); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetTrendingRooms returns a page of the window's trending list
+func (d *Data) GetTrendingRooms(ctx context.Context, window, limit, offset int) []*model.TrendingRoom {
+	log := apm.Log(ctx)
+
+	start := trendingKey(window, offset+1)
+	end := trendingKey(window, offset+limit)
+	rooms := []*model.TrendingRoom{}
+
+	d.db.View(func(tx *bbolt.Tx) error { //nolint:errcheck // that's ok
+		quarantine := tx.Bucket(roomsQuarantineBucket)
+		rBucket := tx.Bucket(roomsBucket)
+		c := tx.Bucket(trendingRoomsBucket).Cursor()
+		for k, v := c.Seek(start); k != nil && bytes.Compare(k, end) <= 0; k, v = c.Next() {
+			var room *model.TrendingRoom
+			if err := json.Unmarshal(v, &room); err != nil {
+				log.Error().Err(err).Msg("cannot unmarshal a trending room")
+				return err
+			}
+			if quarantine.Get([]byte(room.ID)) != nil {
+				continue
+			}
+			roomb := rBucket.Get([]byte(room.ID))
+			if roomb == nil {
+				continue // removed since the list was stored
+			}
+			if err := json.Unmarshal(roomb, &room.Room); err != nil {
+				log.Error().Err(err).Msg("cannot unmarshal a trending room")
+				return err
+			}
+			rooms = append(rooms, room)
+		}
+		return nil
+	})
+	return rooms
+}
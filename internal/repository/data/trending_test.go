@@ -0,0 +1,60 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// windows share one bucket, a page of one must never leak into the other, and gone rooms are skipped without holes.
+func TestTrendingRooms(t *testing.T) {
+	d, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!a:example.com", Members: 10})
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!b:example.com", Members: 20})
+	d.FlushRoomBatch(ctx)
+
+	err = d.SetTrendingRooms(ctx, map[int][]*model.TrendingRoom{
+		7:  {{ID: "!a:example.com", Delta: 5}, {ID: "!gone:example.com", Delta: 3}, {ID: "!b:example.com", Delta: 1}},
+		30: {{ID: "!b:example.com", Delta: 15}},
+	})
+	if err != nil {
+		t.Fatalf("SetTrendingRooms: %v", err)
+	}
+
+	week := d.GetTrendingRooms(ctx, 7, 10, 0)
+	if len(week) != 2 || week[0].ID != "!a:example.com" || week[1].ID != "!b:example.com" || week[1].Room == nil || week[1].Room.Members != 20 {
+		t.Errorf("7d = %+v, want !a then !b with the room attached", week)
+	}
+	if page := d.GetTrendingRooms(ctx, 7, 1, 1); len(page) != 1 || page[0].ID != "!b:example.com" {
+		t.Errorf("7d page 2 = %+v, want !b", page)
+	}
+	if month := d.GetTrendingRooms(ctx, 30, 10, 0); len(month) != 1 || month[0].Delta != 15 {
+		t.Errorf("30d = %+v, want a single !b", month)
+	}
+
+	// only IDs are stored, the room is whatever it is now
+	d.AddRoomBatch(ctx, &model.MatrixRoom{ID: "!b:example.com", Members: 25})
+	d.FlushRoomBatch(ctx)
+	if month := d.GetTrendingRooms(ctx, 30, 10, 0); len(month) != 1 || month[0].Room == nil || month[0].Room.Members != 25 {
+		t.Errorf("30d = %+v, want !b with the current member count", month)
+	}
+}
+
+// keys sort by window, then by position, however long the list is.
+func TestTrendingKey(t *testing.T) {
+	keys := [][]byte{trendingKey(7, 1), trendingKey(7, 999_999), trendingKey(7, 1_000_000), trendingKey(30, 1)}
+	for i := 1; i < len(keys); i++ {
+		if bytes.Compare(keys[i-1], keys[i]) >= 0 {
+			t.Errorf("key %d = %x, want it after %x", i, keys[i], keys[i-1])
+		}
+	}
+}
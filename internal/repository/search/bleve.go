@@ -123,6 +123,13 @@ func getIndexMapping(ctx context.Context) mapping.IndexMapping {
 
 	numericFM := bleve.NewNumericFieldMapping()
 
+	// parentsFM holds IDs of spaces listing the room, exact match for the space: filter, never read back
+	parentsFM := bleve.NewKeywordFieldMapping()
+	parentsFM.Store = false
+	parentsFM.IncludeInAll = false
+	parentsFM.IncludeTermVectors = false
+	parentsFM.DocValues = false
+
 	matrixIDFM := bleve.NewTextFieldMapping()
 	matrixIDFM.Analyzer = "matrix_id"
 
@@ -140,11 +147,14 @@ func getIndexMapping(ctx context.Context) mapping.IndexMapping {
 	r.AddFieldMappingsAt("server", bleve.NewKeywordFieldMapping())
 	r.AddFieldMappingsAt("servers", noindexFM)
 	r.AddFieldMappingsAt("members", numericFM)
+	r.AddFieldMappingsAt("first_seen", bleve.NewDateTimeFieldMapping())
 	r.AddFieldMappingsAt("language", bleve.NewKeywordFieldMapping())
 	r.AddFieldMappingsAt("room_type", bleve.NewKeywordFieldMapping()) // e.g., "m.space" for spaces, empty for rooms
 	r.AddFieldMappingsAt("join_rule", bleve.NewKeywordFieldMapping()) // e.g., "public"
+	r.AddFieldMappingsAt("network", bleve.NewKeywordFieldMapping())   // e.g., "matrix" for native rooms, "irc-libera" for bridged ones
 	r.AddFieldMappingsAt("guest_can_join", noindexFM)
 	r.AddFieldMappingsAt("world_readable", noindexFM)
+	r.AddFieldMappingsAt("parents", parentsFM)
 	m.AddDocumentMapping("room", r)
 
 	return m
@@ -2,6 +2,7 @@ package search
 
 import (
 	"context"
+	"time"
 
 	"github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/search"
@@ -48,6 +49,8 @@ func parseSearchResults(result []*search.DocumentMatch) []*model.Entry {
 			JoinRule:      parseHitField[string](hit, "join_rule"),
 			GuestJoinable: parseHitField[bool](hit, "guest_can_join"),
 			WorldReadable: parseHitField[bool](hit, "world_readable"),
+			Network:       parseHitField[string](hit, "network"),
+			FirstSeen:     parseHitTime(hit, "first_seen"),
 		})
 	}
 
@@ -63,3 +66,12 @@ func parseHitField[T any](hit *search.DocumentMatch, field string) T {
 
 	return v
 }
+
+// parseHitTime returns a stored datetime field, nil if the entry has none
+func parseHitTime(hit *search.DocumentMatch, field string) *time.Time {
+	t, err := time.Parse(time.RFC3339Nano, parseHitField[string](hit, field))
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -51,6 +51,7 @@ var testEntries = []*model.Entry{
 		Language: "EN",
 		RoomType: "",
 		JoinRule: "public",
+		Parents:  []string{"!XpiGHbsblpxcKvDUnZ:etke.cc"},
 	},
 	{
 		ID:       "!RikgWhAbCrfXLlFaNY:etke.cc",
@@ -123,6 +124,7 @@ var testEntries = []*model.Entry{
 		Language: "EN",
 		RoomType: "",
 		JoinRule: "public",
+		Parents:  []string{"!XpiGHbsblpxcKvDUnZ:etke.cc", "!GKRrhSQkiZgqGyhwXa:etke.cc"},
 	},
 	{
 		ID:       "!fkhmtSHXjxDMsdakCR:etke.cc",
@@ -371,6 +373,35 @@ func TestSearch_ByServer(t *testing.T) {
 	}
 }
 
+// parents is a multi-valued keyword field: a room listed by two spaces is found by either, the space itself by none.
+func TestSearch_ByParents(t *testing.T) {
+	idx := newTestIndex(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		parent string
+		want   []string
+	}{
+		{"!XpiGHbsblpxcKvDUnZ:etke.cc", []string{"!LpivaKUdewaGfawMoR:etke.cc", "!vMHrTKpEfwWkosbMER:etke.cc"}},
+		{"!GKRrhSQkiZgqGyhwXa:etke.cc", []string{"!vMHrTKpEfwWkosbMER:etke.cc"}},
+		{"!xpighbsblpxckvdunz:etke.cc", nil}, // room IDs are case-sensitive
+	}
+	for _, tt := range tests {
+		q := bleve.NewTermQuery(tt.parent)
+		q.SetField("parents")
+		results, total, err := idx.Search(ctx, q, 100, 0, []string{"-members"})
+		if err != nil {
+			t.Fatal("Search error:", err)
+		}
+		if total != len(tt.want) {
+			t.Errorf("parents:%s: expected %d results, got %d (%v)", tt.parent, len(tt.want), total, searchIDs(results))
+		}
+		for _, id := range tt.want {
+			assertContainsID(t, results, id)
+		}
+	}
+}
+
 func TestSearch_ByLanguage(t *testing.T) {
 	idx := newTestIndex(t)
 	ctx := context.Background()
@@ -739,6 +770,40 @@ func TestSearch_RegularRoomsFilter(t *testing.T) {
 	}
 }
 
+func TestSearch_NativeNetworkIncludesUntagged(t *testing.T) {
+	idx := newTestIndex(t)
+	ctx := context.Background()
+
+	// testEntries carry no network, like documents indexed before the field existed
+	for _, entry := range []*model.Entry{
+		{ID: "!native:example.com", Type: "room", Name: "Native", Server: "example.com", Network: "matrix"},
+		{ID: "!bridged:example.com", Type: "room", Name: "Bridged", Server: "example.com", Network: "irc-libera"},
+	} {
+		if err := idx.Index(entry.ID, entry); err != nil {
+			t.Fatal("Index() error:", err)
+		}
+	}
+
+	// Simulate native rooms → not tagged with any crawled network
+	bridged := bleve.NewTermQuery("irc-libera")
+	bridged.SetField("network")
+	native := bleve.NewBooleanQuery()
+	native.AddMust(bleve.NewMatchAllQuery())
+	native.AddMustNot(bridged)
+
+	results, total, err := idx.Search(ctx, native, 100, 0, nil)
+	if err != nil {
+		t.Fatal("Search error:", err)
+	}
+	if want := len(testEntries) + 1; total != want {
+		t.Errorf("expected %d native rooms, got %d", want, total)
+	}
+	assertContainsID(t, results, "!native:example.com")
+	if containsID(results, "!bridged:example.com") {
+		t.Error("unexpected bridged room in native rooms")
+	}
+}
+
 func TestSearch_CombinedSearchAndRoomType(t *testing.T) {
 	idx := newTestIndex(t)
 	ctx := context.Background()
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/etkecc/go-apm"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// actionTokenKey returns the key action tokens are signed with, derived from the instance's secret.
+// No secret, no tokens: a token signed with an empty key is a token anyone can sign
+func (m *Moderation) actionTokenKey() []byte {
+	key := m.secret()
+	if len(key) == 0 {
+		return nil
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("mrs moderation action token"))
+	return mac.Sum(nil)
+}
+
+// actionLink returns a link to the confirmation step of the action on the room, valid for model.ActionTokenTTL
+func (m *Moderation) actionLink(apiURL *url.URL, action, roomID string) string {
+	token := &model.ActionToken{
+		Action:    action,
+		RoomID:    roomID,
+		ExpiresAt: time.Now().UTC().Add(model.ActionTokenTTL),
+	}
+	return apiURL.JoinPath("/mod/confirm", token.Sign(m.actionTokenKey())).String()
+}
+
+// CheckActionToken returns the token's action if the token is valid, not expired, and not used yet. It doesn't use the token
+func (m *Moderation) CheckActionToken(ctx context.Context, token string) (*model.ActionToken, error) {
+	key := m.actionTokenKey()
+	if key == nil {
+		return nil, model.ErrActionTokenInvalid
+	}
+	parsed, err := model.ParseActionToken(key, token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if m.data.IsActionTokenUsed(ctx, actionTokenID(token)) {
+		return nil, model.ErrActionTokenUsed
+	}
+	return parsed, nil
+}
+
+// ConfirmAction performs the token's action, the token cannot be used again.
+// The token is the auth, so the actor in the audit log is whoever the link was sent to, confirmed from the client (e.g., ip:address)
+func (m *Moderation) ConfirmAction(ctx context.Context, client, token, reason string) (*model.ActionToken, error) {
+	parsed, err := m.CheckActionToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	used, err := m.useActionToken(ctx, token, parsed.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, model.ErrActionTokenUsed
+	}
+
+	actor := m.actionLinkActor(client)
+	switch parsed.Action {
+	case model.AuditBan:
+		err = m.Ban(ctx, &model.RoomBan{RoomID: parsed.RoomID, Reason: reason, Moderator: actor})
+	case model.AuditUnban:
+		err = m.Unban(ctx, actor, parsed.RoomID, reason)
+	case model.AuditQuarantine:
+		err = m.Quarantine(ctx, &model.RoomQuarantine{RoomID: parsed.RoomID, Reason: reason, Moderator: actor})
+	case model.AuditRelease:
+		err = m.Release(ctx, actor, parsed.RoomID, reason)
+	case model.AuditUnreport:
+		err = m.Unreport(ctx, actor, parsed.RoomID, reason)
+	}
+	return parsed, err
+}
+
+// actionLinkActor returns the audit actor of an action confirmed with a link: the recipients of the report notification
+// the link came with (the moderation webhook and email), and the client that confirmed it
+func (m *Moderation) actionLinkActor(client string) string {
+	recipients := []string{}
+	if cfg := m.cfg.Get(); cfg != nil {
+		if cfg.Webhooks != nil && cfg.Webhooks.Moderation != "" {
+			recipients = append(recipients, "webhook")
+		}
+		if cfg.Email != nil && cfg.Email.Moderation != "" {
+			recipients = append(recipients, cfg.Email.Moderation)
+		}
+	}
+	if len(recipients) == 0 {
+		recipients = append(recipients, "nobody") // the link was sent when there were recipients, the config has changed since
+	}
+	return "link:" + strings.Join(recipients, ",") + " (" + client + ")"
+}
+
+// actionTokenID returns the ID a used token is stored by, the token itself is not stored
+func actionTokenID(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// useActionToken marks the token as used, returns false if it was used already.
+// Used tokens are stored until they expire, so a restart doesn't make them work again
+func (m *Moderation) useActionToken(ctx context.Context, token string, expiresAt time.Time) (bool, error) {
+	return m.data.UseActionToken(ctx, actionTokenID(token), expiresAt)
+}
+
+// RemoveExpiredActionTokens forgets used tokens that have expired, they are rejected as expired from then on
+func (m *Moderation) RemoveExpiredActionTokens(ctx context.Context) {
+	if _, err := m.data.RemoveExpiredActionTokens(ctx, time.Now().UTC()); err != nil {
+		apm.Log(ctx).Error().Err(err).Msg("cannot remove expired action tokens")
+	}
+}
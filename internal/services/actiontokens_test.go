@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// expectUsedTokens makes the data mock remember used tokens, as the bolt bucket does
+func expectUsedTokens(data *MockDataRepository) {
+	used := map[string]time.Time{}
+	data.EXPECT().IsActionTokenUsed(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, id string) bool {
+		_, ok := used[id]
+		return ok
+	}).Maybe()
+	data.EXPECT().UseActionToken(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, id string, expiresAt time.Time) (bool, error) {
+		if _, ok := used[id]; ok {
+			return false, nil
+		}
+		used[id] = expiresAt
+		return true, nil
+	}).Maybe()
+}
+
+// a link does nothing until confirmed, and works once, restarts included.
+func TestConfirmAction(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{
+		Secret: "a random secret",
+		Email:  &model.ConfigEmail{Moderation: "mods@mrs.example.com"},
+	})
+	data := NewMockDataRepository(t)
+	expectUsedTokens(data)
+	data.EXPECT().IsReported(mock.Anything, "!r:host.example").Return(true).Once()
+	data.EXPECT().GetRoomBan(mock.Anything, "!r:host.example").Return(nil, nil).Once()
+	data.EXPECT().GetRoomQuarantine(mock.Anything, "!r:host.example").Return(nil, nil).Once()
+	data.EXPECT().GetRoomReports(mock.Anything, "!r:host.example").Return(nil, nil).Once()
+	data.EXPECT().UnreportRoom(mock.Anything, "!r:host.example").Return(nil).Once()
+	data.EXPECT().AddAuditEntry(mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Actor == "link:mods@mrs.example.com (ip:192.0.2.1)" && entry.Action == model.AuditUnreport && entry.Reason == "fine"
+	})).Return(nil).Once()
+
+	m := NewModeration(cfg, data, nil, nil, nil, nil, nil)
+	apiURL, _ := url.Parse("https://mrs.example.com")
+	link, _ := url.Parse(m.actionLink(apiURL, model.AuditUnreport, "!r:host.example"))
+	token := path.Base(link.Path)
+
+	if parsed, err := m.CheckActionToken(context.Background(), token); err != nil || parsed.RoomID != "!r:host.example" {
+		t.Fatalf("CheckActionToken() = %+v, %v", parsed, err)
+	}
+	if _, err := m.ConfirmAction(context.Background(), "ip:192.0.2.1", token, "fine"); err != nil {
+		t.Fatalf("ConfirmAction() = %v", err)
+	}
+	if _, err := m.ConfirmAction(context.Background(), "ip:192.0.2.1", token, "fine"); !errors.Is(err, model.ErrActionTokenUsed) {
+		t.Errorf("ConfirmAction() again = %v, want used", err)
+	}
+	if _, err := m.CheckActionToken(context.Background(), token); !errors.Is(err, model.ErrActionTokenUsed) {
+		t.Errorf("CheckActionToken() of a used token = %v, want used", err)
+	}
+	restarted := NewModeration(cfg, data, nil, nil, nil, nil, nil)
+	if _, err := restarted.ConfirmAction(context.Background(), "ip:192.0.2.1", token, "fine"); !errors.Is(err, model.ErrActionTokenUsed) {
+		t.Errorf("ConfirmAction() after a restart = %v, want used", err)
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/etkecc/go-apm"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// verifyAlias resolves the room's canonical alias over federation and records the result on the room.
+// An alias pointing at another room or nowhere is dropped from the room, so it is neither indexed nor mapped.
+// An inconclusive check (a flaky server, a backed off destination) keeps the previous verification, see keepStoredAlias.
+// Returns servers that can help to join the room, as reported by the alias' server, only if the alias is verified
+func (m *Crawler) verifyAlias(ctx context.Context, room *model.MatrixRoom) []string {
+	if room.Alias == "" {
+		return nil
+	}
+
+	verification := &model.MatrixRoomAlias{Alias: room.Alias, CheckedAt: time.Now().UTC()}
+	room.AliasVerification = verification
+	resp, err := m.fed.QueryDirectoryExternal(ctx, room.Alias)
+	var merr *model.MatrixError
+	switch {
+	case err != nil && errors.As(err, &merr) && merr.Code == "M_NOT_FOUND":
+		verification.Status = model.AliasDead
+	case err != nil || resp == nil:
+		verification.Status = model.AliasUnverified
+	case resp.RoomID != room.ID:
+		verification.Status = model.AliasMismatch
+		verification.RoomID = resp.RoomID
+	default:
+		verification.Status = model.AliasVerified
+		verification.RoomID = resp.RoomID
+	}
+
+	log := apm.Log(ctx).With().Str("room", room.ID).Str("alias", room.Alias).Str("status", verification.Status).Logger()
+	switch verification.Status {
+	case model.AliasVerified:
+		return resp.Servers
+	case model.AliasDead, model.AliasMismatch:
+		log.Info().Str("resolved_to", verification.RoomID).Msg("alias doesn't resolve to the room, dropping it")
+		room.Alias = ""
+	default:
+		log.Warn().Err(err).Msg("cannot verify alias")
+		m.keepStoredAlias(ctx, room)
+	}
+	return nil
+}
+
+// keepStoredAlias carries over the stored verification of the room's alias when the fresh one is inconclusive,
+// so a single bad night doesn't drop a working alias from the room mapping. Only a dead or mismatched alias does that
+func (m *Crawler) keepStoredAlias(ctx context.Context, room *model.MatrixRoom) {
+	if m.data == nil || room.AliasVerification == nil || room.AliasVerification.Status != model.AliasUnverified {
+		return // worker mode has no storage, the coordinator does it on applying the results
+	}
+	stored, err := m.data.GetRoom(ctx, room.ID)
+	if err != nil || stored == nil || stored.Alias != room.Alias || stored.AliasVerification == nil {
+		return
+	}
+	room.AliasVerification = stored.AliasVerification
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// only an alias resolving to the room itself may lend its servers; a dead or hijacked one is dropped, a flaky server isn't held against it.
+func TestVerifyAlias(t *testing.T) {
+	const roomID = "!room:example.com"
+	tests := []struct {
+		name        string
+		resp        *model.QueryDirectoryResponse
+		err         error
+		wantStatus  string
+		wantAlias   string
+		wantServers []string
+	}{
+		{"verified", &model.QueryDirectoryResponse{RoomID: roomID, Servers: []string{"example.com", "other.com"}}, nil, model.AliasVerified, "#room:example.com", []string{"example.com", "other.com"}},
+		{"mismatch", &model.QueryDirectoryResponse{RoomID: "!other:example.com", Servers: []string{"evil.com"}}, nil, model.AliasMismatch, "", nil},
+		{"dead", nil, &model.MatrixError{HTTP: "404 Not Found", Code: "M_NOT_FOUND"}, model.AliasDead, "", nil},
+		{"unverified", nil, errors.New("connection refused"), model.AliasUnverified, "#room:example.com", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fed := NewMockFederationService(t)
+			fed.EXPECT().QueryDirectoryExternal(context.Background(), "#room:example.com").Return(tt.resp, tt.err)
+			m := &Crawler{fed: fed}
+
+			room := &model.MatrixRoom{ID: roomID, Alias: "#room:example.com"}
+			servers := m.verifyAlias(context.Background(), room)
+			if room.AliasVerification == nil || room.AliasVerification.Status != tt.wantStatus {
+				t.Fatalf("verification = %+v, want status %q", room.AliasVerification, tt.wantStatus)
+			}
+			if room.Alias != tt.wantAlias {
+				t.Errorf("alias = %q, want %q", room.Alias, tt.wantAlias)
+			}
+			if !slices.Equal(servers, tt.wantServers) {
+				t.Errorf("servers = %v, want %v", servers, tt.wantServers)
+			}
+		})
+	}
+}
+
+// an inconclusive check keeps what was known about the same alias, a conclusive one or a changed alias doesn't
+func TestVerifyAlias_KeepsStored(t *testing.T) {
+	const roomID = "!room:example.com"
+	ctx := context.Background()
+	checked := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	verified := &model.MatrixRoomAlias{Alias: "#room:example.com", Status: model.AliasVerified, RoomID: roomID, CheckedAt: checked}
+	tests := []struct {
+		name       string
+		err        error
+		stored     *model.MatrixRoom
+		wantStatus string
+	}{
+		{"flaky keeps verified", errors.New("destination is backed off"), &model.MatrixRoom{ID: roomID, Alias: "#room:example.com", AliasVerification: verified}, model.AliasVerified},
+		{"flaky with another alias stored", errors.New("timeout"), &model.MatrixRoom{ID: roomID, Alias: "#old:example.com", AliasVerification: verified}, model.AliasUnverified},
+		{"flaky with nothing stored", errors.New("timeout"), nil, model.AliasUnverified},
+		{"dead drops verified", &model.MatrixError{HTTP: "404 Not Found", Code: "M_NOT_FOUND"}, nil, model.AliasDead},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fed := NewMockFederationService(t)
+			fed.EXPECT().QueryDirectoryExternal(ctx, "#room:example.com").Return(nil, tt.err)
+			data := NewMockDataRepository(t)
+			if tt.wantStatus != model.AliasDead {
+				data.EXPECT().GetRoom(ctx, roomID).Return(tt.stored, nil).Once()
+			}
+			m := &Crawler{fed: fed, data: data}
+
+			room := &model.MatrixRoom{ID: roomID, Alias: "#room:example.com"}
+			m.verifyAlias(ctx, room)
+			if room.AliasVerification.Status != tt.wantStatus {
+				t.Fatalf("status = %q, want %q", room.AliasVerification.Status, tt.wantStatus)
+			}
+			if tt.wantStatus == model.AliasVerified && !room.AliasVerification.CheckedAt.Equal(checked) {
+				t.Errorf("checked_at = %v, want the stored %v", room.AliasVerification.CheckedAt, checked)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/etkecc/go-apm"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// appealTokenKey returns the key appeal verification tokens are signed with. It's derived apart from actionTokenKey,
+// so a verification link confirms no moderation action, and a moderation link verifies no appeal
+func (m *Moderation) appealTokenKey() []byte {
+	key := m.secret()
+	if len(key) == 0 {
+		return nil
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("mrs appeal verification token"))
+	return mac.Sum(nil)
+}
+
+// appealContacts returns the emails allowed to appeal the ban: the room's own contact as of the ban, and its server's MSC1929 ones
+func (m *Moderation) appealContacts(ctx context.Context, ban *model.RoomBan) []string {
+	contacts := []string{}
+	if ban.Email != "" {
+		contacts = append(contacts, ban.Email)
+	}
+	server, err := m.data.GetServerInfo(ctx, utils.ServerFrom(ban.RoomID))
+	if err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("room", ban.RoomID).Msg("cannot get server info")
+	}
+	if server != nil {
+		contacts = append(contacts, server.Contacts.Emails...)
+	}
+	return contacts
+}
+
+// Appeal files an appeal of the room's ban on behalf of the contact, and emails the contact a verification link.
+// Moderators hear about the appeal once it's verified. The caller learns nothing about the room's ban or contacts:
+// a room that is not banned, an email that is not a contact, and an appeal already open are all a quiet nil
+func (m *Moderation) Appeal(ctx context.Context, roomID, email, reason string) error {
+	log := apm.Log(ctx).With().Str("room", roomID).Logger()
+	key := m.appealTokenKey()
+	if key == nil {
+		return errors.New("appeals need a secret")
+	}
+	ban, err := m.data.GetRoomBan(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if ban == nil {
+		log.Info().Msg("room is not banned, nothing to appeal")
+		return nil
+	}
+	now := time.Now().UTC()
+	previous, err := m.data.GetAppeal(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if previous.IsOpen(now) {
+		log.Info().Str("status", previous.Status).Msg("room has an open appeal already")
+		return nil
+	}
+	if !model.IsAppealContact(email, m.appealContacts(ctx, ban)) {
+		log.Info().Msg("appeal email is not a contact of the room")
+		return nil
+	}
+	apiURL, err := url.Parse(m.cfg.Get().Public.API)
+	if err != nil {
+		return err
+	}
+
+	appeal := &model.Appeal{
+		RoomID:    roomID,
+		Email:     strings.TrimSpace(email),
+		Reason:    reason,
+		Status:    model.AppealUnverified,
+		CreatedAt: now,
+	}
+	if err := m.data.SaveAppeal(ctx, appeal); err != nil {
+		return err
+	}
+	token := &model.ActionToken{Action: model.AppealVerify, RoomID: roomID, ExpiresAt: appeal.VerifyExpiresAt()}
+	return m.mail.SendAppealVerification(ctx, appeal, apiURL.JoinPath("/mod/appeal/verify", token.Sign(key)).String())
+}
+
+// CheckAppealToken returns the appeal the verification token is for, if the token is valid and the appeal is not verified yet.
+// It doesn't verify the appeal
+func (m *Moderation) CheckAppealToken(ctx context.Context, token string) (*model.Appeal, error) {
+	key := m.appealTokenKey()
+	if key == nil {
+		return nil, model.ErrActionTokenInvalid
+	}
+	parsed, err := model.ParseActionToken(key, token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Action != model.AppealVerify {
+		return nil, model.ErrActionTokenInvalid
+	}
+	appeal, err := m.data.GetAppeal(ctx, parsed.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	// the token of a previous appeal of the same room has another expiry
+	if appeal == nil || !appeal.VerifyExpiresAt().Equal(parsed.ExpiresAt) {
+		return nil, model.ErrActionTokenInvalid
+	}
+	if appeal.Status != model.AppealUnverified {
+		return nil, model.ErrActionTokenUsed
+	}
+	return appeal, nil
+}
+
+// VerifyAppeal marks the token's appeal as verified and notifies moderators about it
+func (m *Moderation) VerifyAppeal(ctx context.Context, token string) (*model.Appeal, error) {
+	appeal, err := m.CheckAppealToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	used, err := m.useActionToken(ctx, token, appeal.VerifyExpiresAt())
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, model.ErrActionTokenUsed
+	}
+
+	now := time.Now().UTC()
+	appeal.Status = model.AppealPending
+	appeal.VerifiedAt = &now
+	if err := m.data.SaveAppeal(ctx, appeal); err != nil {
+		return nil, err
+	}
+
+	log := apm.Log(ctx).With().Str("room", appeal.RoomID).Logger()
+	text := m.getAppealText(ctx, appeal)
+	if err := m.sendWebhook(ctx, text); err != nil {
+		log.Error().Err(err).Msg("cannot send moderation webhook")
+	}
+	if email := m.cfg.Get().Email.Moderation; email != "" {
+		if err := m.mail.SendModAppeal(text, email); err != nil {
+			log.Error().Err(err).Msg("cannot send moderation email")
+		}
+	}
+	return appeal, nil
+}
+
+func (m *Moderation) getAppealText(ctx context.Context, appeal *model.Appeal) string {
+	var text strings.Builder
+	text.WriteString("**New appeal**\n\n")
+
+	text.WriteString("* ID: [")
+	text.WriteString(appeal.RoomID)
+	text.WriteString("](https://matrix.to/#/")
+	text.WriteString(appeal.RoomID)
+	text.WriteString(")\n")
+
+	text.WriteString("* From: ")
+	text.WriteString(utils.MarkdownEmail(appeal.Email))
+	text.WriteString("\n")
+
+	text.WriteString("* Reason: ")
+	text.WriteString(appeal.Reason)
+	text.WriteString("\n")
+
+	ban, err := m.data.GetRoomBan(ctx, appeal.RoomID)
+	if err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("room", appeal.RoomID).Msg("cannot get ban record of the room")
+	}
+	if ban != nil {
+		text.WriteString("* Banned: ")
+		text.WriteString(ban.Category)
+		text.WriteString(", ")
+		text.WriteString(ban.Reason)
+		text.WriteString(" (by ")
+		text.WriteString(ban.Moderator)
+		text.WriteString(")\n")
+	} else {
+		text.WriteString("* Banned: not anymore\n")
+	}
+
+	apiURL, err := url.Parse(m.cfg.Get().Public.API)
+	if err != nil {
+		apm.Log(ctx).Error().Err(err).Msg("cannot parse public api url")
+		return text.String()
+	}
+	text.WriteString("\n---\n\n")
+	text.WriteString("Accept (unban) with POST ")
+	text.WriteString(apiURL.JoinPath("/mod/accept-appeal", appeal.RoomID).String())
+	text.WriteString(" or reject with POST ")
+	text.WriteString(apiURL.JoinPath("/mod/reject-appeal", appeal.RoomID).String())
+	text.WriteString(" | [list appeals](")
+	text.WriteString(apiURL.JoinPath("/mod/list-appeals").String())
+	text.WriteString(")")
+
+	return text.String()
+}
+
+// ListAppeals returns the latest appeals of the rooms (optionally from specific server), pending first
+func (m *Moderation) ListAppeals(ctx context.Context, serverName ...string) ([]*model.Appeal, error) {
+	appeals, err := m.data.GetAppeals(ctx, serverName...)
+	if err != nil {
+		return nil, err
+	}
+	model.SortAppeals(appeals)
+	return appeals, nil
+}
+
+// DecideAppeal accepts (and unbans the room) or rejects the room's pending appeal, and emails the decision with the reason
+// to the contact who filed the appeal
+func (m *Moderation) DecideAppeal(ctx context.Context, actor, roomID string, accept bool, reason string) (*model.Appeal, error) {
+	appeal, err := m.data.GetAppeal(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if appeal == nil || appeal.Status != model.AppealPending {
+		return nil, model.ErrAppealNotPending
+	}
+
+	if accept {
+		auditReason := "appeal accepted"
+		if reason != "" {
+			auditReason += ": " + reason
+		}
+		if err := m.Unban(ctx, actor, roomID, auditReason); err != nil {
+			return nil, err
+		}
+		appeal.Status = model.AppealAccepted
+	} else {
+		if err := m.audit(ctx, actor, model.AuditRejectAppeal, roomID, reason, m.auditState(ctx, roomID)); err != nil {
+			return nil, err
+		}
+		appeal.Status = model.AppealRejected
+	}
+	now := time.Now().UTC()
+	appeal.DecidedAt = &now
+	appeal.Moderator = actor
+	appeal.Decision = reason
+	if err := m.data.SaveAppeal(ctx, appeal); err != nil {
+		return nil, err
+	}
+
+	if err := m.mail.SendAppealDecision(ctx, appeal); err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("room", roomID).Msg("cannot send appeal decision")
+	}
+	return appeal, nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// a contact files, verifies, and hears back; anyone else gets the same 202 and nothing happens.
+func TestAppeal(t *testing.T) {
+	roomID := "!r:host.example"
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{
+		Public:   &model.ConfigPublic{API: "https://mrs.example.com"},
+		Secret:   "a random secret",
+		Webhooks: &model.ConfigWebhooks{},
+		Email:    &model.ConfigEmail{Moderation: "mods@mrs.example.com"},
+	})
+	data := NewMockDataRepository(t)
+	expectUsedTokens(data)
+	data.EXPECT().GetRoomBan(mock.Anything, roomID).Return(&model.RoomBan{RoomID: roomID, Email: "room@host.example"}, nil)
+	data.EXPECT().GetServerInfo(mock.Anything, "host.example").Return(&model.MatrixServer{
+		Name:     "host.example",
+		Contacts: model.MatrixServerContacts{Emails: []string{"admin@host.example"}},
+	}, nil)
+	var stored *model.Appeal
+	data.EXPECT().GetAppeal(mock.Anything, roomID).RunAndReturn(func(_ context.Context, _ string) (*model.Appeal, error) {
+		if stored == nil {
+			return nil, nil
+		}
+		appeal := *stored
+		return &appeal, nil
+	})
+	data.EXPECT().SaveAppeal(mock.Anything, mock.Anything).Run(func(_ context.Context, appeal *model.Appeal) { stored = appeal }).Return(nil)
+	data.EXPECT().GetRoomQuarantine(mock.Anything, roomID).Return(nil, nil).Once()
+	data.EXPECT().GetRoomReports(mock.Anything, roomID).Return(nil, nil).Once()
+	data.EXPECT().AddAuditEntry(mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Actor == "alice" && entry.Action == model.AuditRejectAppeal && entry.Reason == "still spam"
+	})).Return(nil).Once()
+	mail := NewMockEmailService(t)
+	var link string
+	mail.EXPECT().SendAppealVerification(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ *model.Appeal, l string) { link = l }).Return(nil).Once()
+	mail.EXPECT().SendModAppeal(mock.Anything, "mods@mrs.example.com").Return(nil).Once()
+	mail.EXPECT().SendAppealDecision(mock.Anything, mock.MatchedBy(func(appeal *model.Appeal) bool {
+		return appeal.Status == model.AppealRejected && appeal.Decision == "still spam" && appeal.Email == "Admin@host.example"
+	})).Return(nil).Once()
+
+	m := NewModeration(cfg, data, nil, nil, nil, mail, nil)
+	ctx := context.Background()
+	if err := m.Appeal(ctx, roomID, "stranger@other.example", "please"); err != nil || stored != nil {
+		t.Fatalf("Appeal() by a stranger = %v, stored %+v", err, stored)
+	}
+	if err := m.Appeal(ctx, roomID, "Admin@host.example", "we cleaned it up"); err != nil || stored == nil || stored.Status != model.AppealUnverified {
+		t.Fatalf("Appeal() = %v, stored %+v", err, stored)
+	}
+	if err := m.Appeal(ctx, roomID, "room@host.example", "again"); err != nil || stored.Reason != "we cleaned it up" {
+		t.Fatalf("Appeal() over an open one = %v, stored %+v", err, stored)
+	}
+	if _, err := m.DecideAppeal(ctx, "alice", roomID, false, "too early"); !errors.Is(err, model.ErrAppealNotPending) {
+		t.Errorf("DecideAppeal() of an unverified appeal = %v, want not pending", err)
+	}
+
+	parsed, _ := url.Parse(link)
+	token := path.Base(parsed.Path)
+	if _, err := m.CheckActionToken(ctx, token); !errors.Is(err, model.ErrActionTokenInvalid) {
+		t.Errorf("CheckActionToken() of a verification token = %v, want invalid", err)
+	}
+	if appeal, err := m.VerifyAppeal(ctx, token); err != nil || appeal.Status != model.AppealPending || stored.VerifiedAt == nil {
+		t.Fatalf("VerifyAppeal() = %+v, %v", appeal, err)
+	}
+	if _, err := m.VerifyAppeal(ctx, token); !errors.Is(err, model.ErrActionTokenUsed) {
+		t.Errorf("VerifyAppeal() again = %v, want used", err)
+	}
+
+	if _, err := m.DecideAppeal(ctx, "alice", roomID, false, "still spam"); err != nil || stored.Status != model.AppealRejected || stored.Moderator != "alice" {
+		t.Fatalf("DecideAppeal() = %v, stored %+v", err, stored)
+	}
+}
+
+// the links of the moderators' notification are the routes of the mod API, not a guess of them.
+func TestGetAppealText(t *testing.T) {
+	roomID := "!r:host.example"
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{Public: &model.ConfigPublic{API: "https://mrs.example.com"}})
+	data := NewMockDataRepository(t)
+	data.EXPECT().GetRoomBan(mock.Anything, roomID).Return(nil, nil)
+
+	m := NewModeration(cfg, data, nil, nil, nil, nil, nil)
+	text := m.getAppealText(context.Background(), &model.Appeal{RoomID: roomID, Email: "admin@host.example", Reason: "please"})
+	for _, want := range []string{
+		"POST https://mrs.example.com/mod/accept-appeal/!r:host.example",
+		"POST https://mrs.example.com/mod/reject-appeal/!r:host.example",
+		"(https://mrs.example.com/mod/list-appeals)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("appeal text has no %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/etkecc/go-apm"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// banRulePreviewSample is how many matching room IDs a ban rule preview shows
+const banRulePreviewSample = 20
+
+type banRulesBlocklist interface {
+	SetRules(rules []*model.BanRule)
+	SetPolicies(rules []*model.PolicyRule)
+}
+
+// LoadBanRules hands the stored ban rules to the blocklist, rules that don't compile (anymore) are skipped
+func (m *Moderation) LoadBanRules(ctx context.Context) error {
+	log := apm.Log(ctx)
+	rules, err := m.data.GetBanRules(ctx)
+	if err != nil {
+		return err
+	}
+	compiled := make([]*model.BanRule, 0, len(rules))
+	for _, rule := range rules {
+		if err := rule.Compile(); err != nil {
+			log.Error().Err(err).Str("id", rule.ID).Str("rule", rule.String()).Msg("cannot compile ban rule, skipping")
+			continue
+		}
+		compiled = append(compiled, rule)
+	}
+	m.block.SetRules(compiled)
+	log.Info().Int("rules", len(compiled)).Msg("ban rules loaded")
+	return nil
+}
+
+// BanRules returns all ban rules
+func (m *Moderation) BanRules(ctx context.Context) ([]*model.BanRule, error) {
+	return m.data.GetBanRules(ctx)
+}
+
+// PreviewBanRule returns the rooms the rule would remove, without applying it
+func (m *Moderation) PreviewBanRule(ctx context.Context, rule *model.BanRule) (*model.BanRulePreview, error) {
+	if err := rule.Compile(); err != nil {
+		return nil, err
+	}
+	preview, _ := m.matchBanRule(ctx, rule)
+	return preview, nil
+}
+
+// AddBanRule stores the rule, applies it right away, and removes the matching rooms. Returns what was removed
+func (m *Moderation) AddBanRule(ctx context.Context, rule *model.BanRule) (*model.BanRulePreview, error) {
+	if err := rule.Compile(); err != nil {
+		return nil, err
+	}
+	if rule.Category == "" {
+		rule.Category = model.ReportOther
+	}
+	rule.CreatedAt = time.Now().UTC()
+	if err := m.data.AddBanRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	if err := m.LoadBanRules(ctx); err != nil {
+		return nil, err
+	}
+	if err := m.audit(ctx, rule.Moderator, model.AuditBanRule, rule.String(), rule.Reason, nil); err != nil {
+		return nil, err
+	}
+
+	preview, ids := m.matchBanRule(ctx, rule)
+	m.removeRooms(ctx, ids)
+	return preview, nil
+}
+
+// RemoveBanRule removes the rule, the rooms it matched come back with the next parsing. Returns the removed rule, nil if there was none
+func (m *Moderation) RemoveBanRule(ctx context.Context, actor, id, reason string) (*model.BanRule, error) {
+	rule, err := m.data.RemoveBanRule(ctx, id)
+	if err != nil || rule == nil {
+		return nil, err
+	}
+	if err := m.LoadBanRules(ctx); err != nil {
+		return nil, err
+	}
+	return rule, m.audit(ctx, actor, model.AuditUnbanRule, rule.String(), reason, nil)
+}
+
+// matchBanRule returns the preview of the rule and IDs of all rooms it matches
+func (m *Moderation) matchBanRule(ctx context.Context, rule *model.BanRule) (*model.BanRulePreview, []string) {
+	preview := &model.BanRulePreview{Rule: rule, Sample: []string{}}
+	ids := []string{}
+	m.data.EachRoom(ctx, func(roomID string, room *model.MatrixRoom) bool {
+		if !rule.MatchRoom(room) {
+			return false
+		}
+		ids = append(ids, roomID)
+		if len(preview.Sample) < banRulePreviewSample {
+			preview.Sample = append(preview.Sample, roomID)
+		}
+		return false
+	})
+	preview.Rooms = len(ids)
+	return preview, ids
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// a new rule goes to the blocklist before the matching rooms are removed, so the next crawl doesn't bring them back.
+func TestAddBanRule(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{Blocklist: &model.ConfigBlocklist{}}).Maybe()
+	data := NewMockDataRepository(t)
+	index := NewMockIndexRepository(t)
+	block := NewBlocklist(cfg)
+
+	data.EXPECT().AddBanRule(mock.Anything, mock.Anything).Return(nil)
+	data.EXPECT().GetBanRules(mock.Anything).Return([]*model.BanRule{{ID: "1", Kind: model.BanRuleText, Pattern: "(?i)airdrop"}}, nil)
+	data.EXPECT().AddAuditEntry(mock.Anything, mock.Anything).Return(nil)
+	data.EXPECT().EachRoom(mock.Anything, mock.Anything).Run(func(_ context.Context, handler func(string, *model.MatrixRoom) bool) {
+		for _, room := range []*model.MatrixRoom{
+			{ID: "!a:one.example", Name: "AIRDROP"},
+			{ID: "!b:one.example", Name: "Linux"},
+		} {
+			if handler(room.ID, room) {
+				return
+			}
+		}
+	}).Return()
+	data.EXPECT().GetRoomMapping(mock.Anything, "!a:one.example").Return("#airdrop:one.example").Once()
+	data.EXPECT().RemoveRoomMapping(mock.Anything, "!a:one.example", "#airdrop:one.example").Return().Once()
+	data.EXPECT().RemoveRooms(mock.Anything, []string{"!a:one.example"}).Return().Once()
+	index.EXPECT().Delete("!a:one.example").Return(nil).Once()
+
+	m := NewModeration(cfg, data, nil, index, block, nil, nil)
+	removed, err := m.AddBanRule(context.Background(), &model.BanRule{Kind: model.BanRuleText, Pattern: "(?i)airdrop", Moderator: "alice"})
+	if err != nil || removed.Rooms != 1 || removed.Sample[0] != "!a:one.example" || removed.Rule.Category != model.ReportOther {
+		t.Fatalf("AddBanRule() = %+v, %v", removed, err)
+	}
+	if !block.ByText("Free airdrop") || block.ByText("Linux") {
+		t.Error("the rule is not in the blocklist")
+	}
+}
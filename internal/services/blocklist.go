@@ -6,14 +6,18 @@ import (
 	"sync"
 
 	"github.com/etkecc/go-apm"
+
+	"github.com/etkecc/mrs/internal/model"
 )
 
 // Blocklist service
 type Blocklist struct {
-	mu      *sync.Mutex
-	cfg     ConfigService
-	regexes []*regexp.Regexp
-	dynamic map[string]struct{}
+	mu       *sync.Mutex
+	cfg      ConfigService
+	regexes  []*regexp.Regexp
+	dynamic  map[string]struct{}
+	rules    []*model.BanRule    // moderators' ban rules, compiled
+	policies []*model.PolicyRule // bans imported from policy lists
 }
 
 // NewBlocklist creates new blocklist service
@@ -45,7 +49,46 @@ func (b *Blocklist) initRegexes() {
 
 // Len of the blocklist
 func (b *Blocklist) Len() int {
-	return len(b.cfg.Get().Blocklist.Servers) + len(b.dynamic)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var servers int
+	for _, rule := range b.rules {
+		if rule.Kind == model.BanRuleServer {
+			servers++
+		}
+	}
+	for _, rule := range b.policies {
+		if rule.Type == model.PolicyRuleServer {
+			servers++
+		}
+	}
+	return len(b.cfg.Get().Blocklist.Servers) + len(b.dynamic) + servers
+}
+
+// SetRules replaces moderators' ban rules, the rules must be compiled
+func (b *Blocklist) SetRules(rules []*model.BanRule) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rules = rules
+}
+
+func (b *Blocklist) getRules() []*model.BanRule {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rules
+}
+
+// SetPolicies replaces the bans imported from policy lists
+func (b *Blocklist) SetPolicies(rules []*model.PolicyRule) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.policies = rules
+}
+
+func (b *Blocklist) getPolicies() []*model.PolicyRule {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.policies
 }
 
 // Reset dynamic part of the blocklist
@@ -73,8 +116,31 @@ func (b *Blocklist) ByID(matrixID string) bool {
 		return false
 	}
 	server := matrixID[idx+1:]
+	if b.ByServer(server) {
+		return true
+	}
 
-	return b.ByServer(server)
+	for _, rule := range b.getRules() {
+		if rule.MatchID(matrixID) {
+			return true
+		}
+	}
+	for _, rule := range b.getPolicies() {
+		if rule.MatchID(matrixID) {
+			return true
+		}
+	}
+	return false
+}
+
+// ByText checks if any of the texts (room name, topic) is matched by the ban rules
+func (b *Blocklist) ByText(texts ...string) bool {
+	for _, rule := range b.getRules() {
+		if rule.MatchText(texts...) {
+			return true
+		}
+	}
+	return false
 }
 
 // ByServer checks if server is present in the blocklist
@@ -89,5 +155,19 @@ func (b *Blocklist) ByServer(server string) bool {
 	if _, ok := b.dynamic[server]; ok {
 		return true
 	}
+
+	for _, rule := range b.getRules() {
+		if rule.MatchServer(server) {
+			apm.Log().Info().Str("server", server).Str("rule", rule.ID).Msg("server is blocked by ban rule")
+			return true
+		}
+	}
+
+	for _, rule := range b.getPolicies() {
+		if rule.MatchServer(server) {
+			apm.Log().Info().Str("server", server).Str("policy_list", rule.Source).Str("entity", rule.Entity).Msg("server is blocked by policy list")
+			return true
+		}
+	}
 	return false
 }
@@ -2,7 +2,12 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/etkecc/go-apm"
@@ -32,14 +37,16 @@ func NewConfig(path string) (*Config, error) {
 		mu:   &sync.Mutex{},
 		path: path,
 	}
-	c.Read(ctx)
+	if err := c.Read(ctx); err != nil {
+		return nil, err
+	}
 
 	var err error
 	c.fsw, err = fswatcher.New([]string{path}, 0)
 	if err != nil {
 		return nil, err
 	}
-	go c.fsw.Start(func(_ fsnotify.Event) { c.Read(ctx) })
+	go c.fsw.Start(func(_ fsnotify.Event) { c.Read(ctx) }) //nolint:errcheck // logged, the previous config stays
 
 	return c, nil
 }
@@ -49,8 +56,8 @@ func (c *Config) Get() *model.Config {
 	return c.cfg
 }
 
-// Read config
-func (c *Config) Read(ctx context.Context) {
+// Read config, an invalid one is rejected and the previous config (if any) stays
+func (c *Config) Read(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	log := apm.Log(ctx)
@@ -59,16 +66,60 @@ func (c *Config) Read(ctx context.Context) {
 	configb, err := os.ReadFile(c.path)
 	if err != nil {
 		log.Error().Err(err).Msg("cannot read config")
-		return
+		return err
 	}
 	var config *model.Config
 	err = yaml.Unmarshal(configb, &config)
 	if err != nil {
 		log.Error().Err(err).Msg("cannot unmarshal config")
-		return
+		return err
+	}
+	if config == nil {
+		err = errors.New("config is empty")
+		log.Error().Err(err).Msg("cannot use config")
+		return err
+	}
+	if err := config.Validate(); err != nil {
+		log.Error().Err(err).Msg("invalid config")
+		return err
+	}
+	if config.Secret == "" {
+		if secret, err := os.ReadFile(secretPath(config)); err == nil {
+			config.Secret = strings.TrimSpace(string(secret))
+		}
 	}
 
 	c.cfg = config
+	return nil
+}
+
+// EnsureSecret generates a secret and keeps it in the data dir if the config has none,
+// so configs older than the secret keep working (and keep their report links valid across restarts)
+func (c *Config) EnsureSecret(ctx context.Context) error {
+	if c.Get().Secret != "" {
+		return nil
+	}
+	path := secretPath(c.Get())
+	if path == "" {
+		return errors.New("neither secret nor path.data is set")
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(base64.RawURLEncoding.EncodeToString(secret)), 0o600); err != nil {
+		return err
+	}
+	apm.Log(ctx).Warn().Str("path", path).Msg("secret is not set, generated one and stored it in the data dir")
+	return c.Read(ctx)
+}
+
+// secretPath returns where a generated secret is kept: next to the data db, empty if there is no data db
+func secretPath(cfg *model.Config) string {
+	if cfg.Path == nil || cfg.Path.Data == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(cfg.Path.Data), "secret")
 }
 
 // Write config
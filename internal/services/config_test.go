@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// a config without a secret gets one generated once, and the same one back on every read after that.
+func TestEnsureSecret(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yml")
+	if err := os.WriteFile(path, []byte("path:\n  data: "+filepath.Join(dir, "data.db")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c := &Config{mu: &sync.Mutex{}, path: path}
+	ctx := context.Background()
+	if err := c.Read(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.EnsureSecret(ctx); err != nil {
+		t.Fatalf("EnsureSecret: %v", err)
+	}
+	secret := c.Get().Secret
+	if secret == "" {
+		t.Fatal("secret is empty after EnsureSecret")
+	}
+	if err := c.Read(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.EnsureSecret(ctx); err != nil {
+		t.Fatalf("EnsureSecret: %v", err)
+	}
+	if got := c.Get().Secret; got != secret {
+		t.Errorf("secret = %q after a re-read, want the stored %q", got, secret)
+	}
+}
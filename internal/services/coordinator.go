@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etkecc/go-apm"
+	"github.com/etkecc/go-kit/workpool"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// queuePoll is how often idle local workers check the queue for servers of expired leases
+const queuePoll = time.Second
+
+// crawlQueue is the server list of one discovery or parsing run, shared by local workers and remote ones.
+// Local workers take servers one by one, remote workers lease them in batches.
+// A lease is per server, so a server is out with one worker at a time, whatever batch it came with.
+// A lease that isn't returned in time goes back to the queue, so a dead worker costs a delay, not servers
+type crawlQueue struct {
+	mu       sync.Mutex
+	kind     string
+	size     int
+	ttl      time.Duration
+	seq      int
+	pending  []string
+	left     map[string]struct{}    // not processed by anyone yet
+	leases   map[string]*crawlLease // server => its lease
+	batches  map[string][]string    // batch ID => its servers, until the batch is returned
+	applying int                    // accepted batches whose results are being stored
+	// policies of the servers, handed out with the batches
+	policies map[string]*model.MatrixServerPolicy
+	apply    func(context.Context, *model.CrawlResults)
+}
+
+type crawlLease struct {
+	batch    string
+	until    time.Time
+	requeued bool
+}
+
+func newCrawlQueue(kind string, names []string, policies map[string]*model.MatrixServerPolicy, size int, ttl time.Duration, apply func(context.Context, *model.CrawlResults)) *crawlQueue {
+	left := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		left[name] = struct{}{}
+	}
+	return &crawlQueue{
+		kind:     kind,
+		size:     size,
+		ttl:      ttl,
+		pending:  append([]string(nil), names...),
+		left:     left,
+		leases:   map[string]*crawlLease{},
+		batches:  map[string][]string{},
+		policies: policies,
+		apply:    apply,
+	}
+}
+
+// requeue puts servers of expired leases back to the queue, the batch itself stays valid for a late submit
+func (q *crawlQueue) requeue(now time.Time) {
+	expired := []string{}
+	for name, lease := range q.leases {
+		if lease.requeued || now.Before(lease.until) {
+			continue
+		}
+		lease.requeued = true
+		if _, ok := q.left[name]; ok {
+			expired = append(expired, name)
+		}
+	}
+	sort.Strings(expired) // map order is random, keep batches predictable
+	q.pending = append(q.pending, expired...)
+}
+
+// pop returns up to n servers nobody has processed yet and nobody holds a live lease of
+func (q *crawlQueue) pop(n int, now time.Time) []string {
+	names := []string{}
+	for len(q.pending) > 0 && len(names) < n {
+		name := q.pending[0]
+		q.pending = q.pending[1:]
+		if _, ok := q.left[name]; !ok {
+			continue
+		}
+		if lease, ok := q.leases[name]; ok && now.Before(lease.until) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// next returns a server for a local worker. When there is none, wait tells if some are still out on lease
+func (q *crawlQueue) next() (name string, wait bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now()
+	q.requeue(now)
+	if names := q.pop(1, now); len(names) > 0 {
+		delete(q.leases, names[0]) // an expired lease of a dead worker
+		return names[0], false
+	}
+	return "", len(q.left) > 0 || q.applying > 0
+}
+
+// lease hands out a batch to a remote worker, nil if there is nothing to hand out
+func (q *crawlQueue) lease() *model.CrawlBatch {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now()
+	q.requeue(now)
+	names := q.pop(q.size, now)
+	if len(names) == 0 {
+		return nil
+	}
+	q.seq++
+	id := fmt.Sprintf("%s-%d", q.kind, q.seq)
+	q.batches[id] = names
+	batch := &model.CrawlBatch{ID: id, Kind: q.kind, Servers: names}
+	for _, name := range names {
+		q.leases[name] = &crawlLease{batch: id, until: now.Add(q.ttl)}
+		if policy, ok := q.policies[name]; ok {
+			if batch.Policies == nil {
+				batch.Policies = map[string]*model.MatrixServerPolicy{}
+			}
+			batch.Policies[name] = policy
+		}
+	}
+	return batch
+}
+
+// accept takes the batch's results in: only what belongs to the batch's servers nobody has processed yet is kept.
+// The servers are processed from now on, call complete once the results are stored.
+// False if the batch was never handed out or has been returned already
+func (q *crawlQueue) accept(id string, results *model.CrawlResults) (*model.CrawlResults, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	names, ok := q.batches[id]
+	if !ok {
+		return nil, false
+	}
+	delete(q.batches, id)
+	servers := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, ok := q.left[name]; !ok {
+			continue // a late batch, another worker has returned the server already
+		}
+		servers[name] = struct{}{}
+		delete(q.left, name)
+		delete(q.leases, name)
+	}
+	q.applying++
+	return filterCrawlResults(results, servers), true
+}
+
+// complete marks the results of an accepted batch as stored
+func (q *crawlQueue) complete() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.applying--
+}
+
+// done marks a server processed by a local worker
+func (q *crawlQueue) done(name string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.left, name)
+}
+
+// filterCrawlResults drops whatever a worker sent beyond the servers: discovered servers are matched by the batch's names,
+// rooms must be listed by one of them, and spaces must be among those rooms.
+// Discovered servers are candidates only, they go through discovery anyway
+func filterCrawlResults(results *model.CrawlResults, servers map[string]struct{}) *model.CrawlResults {
+	filtered := &model.CrawlResults{}
+	if results == nil {
+		return filtered
+	}
+	for i, server := range results.Servers {
+		if server == nil {
+			continue
+		}
+		name := server.Name
+		if i < len(results.Leased) && results.Leased[i] != "" {
+			name = results.Leased[i] // the server may call itself differently, e.g. in another case
+		}
+		if _, ok := servers[name]; ok {
+			filtered.Servers = append(filtered.Servers, server)
+		}
+	}
+	rooms := make(map[string]struct{}, len(results.Rooms))
+	for _, room := range results.Rooms {
+		if room == nil {
+			continue
+		}
+		if _, ok := servers[room.Server]; ok {
+			filtered.Rooms = append(filtered.Rooms, room)
+			rooms[room.ID] = struct{}{}
+		}
+	}
+	for spaceID, children := range results.Spaces {
+		if _, ok := rooms[spaceID]; !ok {
+			continue
+		}
+		if filtered.Spaces == nil {
+			filtered.Spaces = map[string][]*model.MatrixSpaceChild{}
+		}
+		filtered.Spaces[spaceID] = children
+	}
+	if len(servers) > 0 {
+		filtered.Discovered = results.Discovered
+	}
+	return filtered
+}
+
+func (m *Crawler) getQueue(kind string) *crawlQueue {
+	m.queuesMu.Lock()
+	defer m.queuesMu.Unlock()
+	return m.queues[kind]
+}
+
+func (m *Crawler) setQueue(kind string, q *crawlQueue) {
+	m.queuesMu.Lock()
+	defer m.queuesMu.Unlock()
+	if m.queues == nil {
+		m.queues = map[string]*crawlQueue{}
+	}
+	if q == nil {
+		delete(m.queues, kind)
+		return
+	}
+	m.queues[kind] = q
+}
+
+// runQueue processes the servers with local workers and, if the coordinator mode is enabled, remote ones.
+// local processes one server, apply stores results of a remote batch, policies (if any) go to remote workers with the batches.
+// Returns once every server is processed
+func (m *Crawler) runQueue(ctx context.Context, kind string, names []string, policies map[string]*model.MatrixServerPolicy, workers int, local func(name string), apply func(context.Context, *model.CrawlResults)) {
+	cfg := m.cfg.Get().Coordinator
+	if !cfg.IsEnabled() {
+		wp := workpool.New(workers)
+		for _, name := range names {
+			wp.Do(func() { local(name) })
+		}
+		wp.Run()
+		return
+	}
+
+	q := newCrawlQueue(kind, names, policies, cfg.GetBatchSize(), cfg.GetLease(), apply)
+	m.setQueue(kind, q)
+	defer m.setQueue(kind, nil)
+
+	var wg sync.WaitGroup
+	for range max(1, workers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				name, wait := q.next()
+				if name != "" {
+					local(name)
+					q.done(name)
+					continue
+				}
+				if !wait || !sleepCtx(ctx, queuePoll) {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// LeaseBatch hands out a batch of servers of the running discovery or parsing to a remote worker, nil if there is none
+func (m *Crawler) LeaseBatch(ctx context.Context) *model.CrawlBatch {
+	for _, kind := range []string{model.CrawlDiscovery, model.CrawlParsing} {
+		q := m.getQueue(kind)
+		if q == nil {
+			continue
+		}
+		if batch := q.lease(); batch != nil {
+			apm.Log(ctx).Info().Str("batch", batch.ID).Int("servers", len(batch.Servers)).Msg("batch leased")
+			return batch
+		}
+	}
+	return nil
+}
+
+// SubmitResults stores results of a batch processed by a remote worker, anything beyond the batch's servers is dropped.
+// False if the batch was never handed out, has been returned already, or its run is over
+func (m *Crawler) SubmitResults(ctx context.Context, batchID string, results *model.CrawlResults) bool {
+	kind, _, _ := strings.Cut(batchID, "-")
+	q := m.getQueue(kind)
+	if q == nil {
+		return false
+	}
+	accepted, ok := q.accept(batchID, results)
+	if !ok {
+		return false
+	}
+	// the run waits for complete, so it doesn't finish before the results are stored
+	defer q.complete()
+	q.apply(ctx, accepted)
+	apm.Log(ctx).Info().Str("batch", batchID).Int("servers", len(accepted.Servers)).Int("rooms", len(accepted.Rooms)).Msg("batch results stored")
+	return true
+}
+
+// applyDiscovery stores a server validated by a remote worker, false if it is blocked here
+func (m *Crawler) applyDiscovery(ctx context.Context, server *model.MatrixServer) bool {
+	if server == nil || server.Name == "" || m.block.ByServer(server.Name) {
+		return false
+	}
+	if !server.Online {
+		return true // offline servers are marked by the caller, same as local ones
+	}
+	m.keepStoredPolicy(ctx, server)
+	if err := m.data.AddServer(ctx, server); err != nil {
+		apm.Log(ctx).Error().Err(err).Str("server", server.Name).Msg("cannot store server")
+	}
+	return true
+}
+
+// applyParsing stores rooms and space children parsed by a remote worker, checked against this instance's blocklist
+func (m *Crawler) applyParsing(ctx context.Context, results *model.CrawlResults) {
+	for _, room := range results.Rooms {
+		if room == nil || !m.v.IsRoomAllowed(room) {
+			continue
+		}
+		m.keepStoredAlias(ctx, room)
+		m.data.AddRoomBatch(ctx, room)
+		if room.AliasVerification.IsVerified() {
+			m.data.AddRoomMapping(ctx, room.ID, room.Alias) //nolint:errcheck // ignore error
+		}
+	}
+	for spaceID, children := range results.Spaces {
+		if err := m.data.SetSpaceChildren(ctx, spaceID, children); err != nil {
+			apm.Log(ctx).Warn().Err(err).Str("space", spaceID).Msg("cannot store space children")
+		}
+	}
+}
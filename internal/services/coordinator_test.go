@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// a worker that dies with a lease must not lose its servers, and one that comes back late must not count twice.
+func TestCrawlQueue_LeaseExpiry(t *testing.T) {
+	q := newCrawlQueue(model.CrawlParsing, []string{"a.com", "b.com", "c.com"}, nil, 2, time.Millisecond, nil)
+
+	batch := q.lease()
+	if batch == nil || batch.Kind != model.CrawlParsing || !slices.Equal(batch.Servers, []string{"a.com", "b.com"}) {
+		t.Fatalf("lease() = %+v, want a.com and b.com", batch)
+	}
+	if name, _ := q.next(); name != "c.com" {
+		t.Fatalf("next() = %q, want c.com", name)
+	}
+	q.done("c.com")
+	if name, wait := q.next(); name != "" || !wait {
+		t.Fatalf("next() = %q, %v, want to wait for the lease", name, wait)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	again := q.lease()
+	if again == nil || !slices.Equal(again.Servers, []string{"a.com", "b.com"}) {
+		t.Fatalf("lease() after expiry = %+v, want a.com and b.com again", again)
+	}
+
+	// the first worker was slow, not dead; the second one counts nothing twice
+	late := &model.CrawlResults{Servers: []*model.MatrixServer{{Name: "a.com"}, {Name: "b.com"}}}
+	if accepted, ok := q.accept(batch.ID, late); !ok || len(accepted.Servers) != 2 {
+		t.Fatalf("accept() of a late batch = %+v, %v, want its servers", accepted, ok)
+	}
+	q.complete()
+	if accepted, ok := q.accept(again.ID, late); !ok || len(accepted.Servers) != 0 {
+		t.Fatalf("accept() of the second batch = %+v, %v, want nothing new", accepted, ok)
+	}
+	q.complete()
+	if _, ok := q.accept(batch.ID, late); ok {
+		t.Error("accept() of a returned batch, want refused")
+	}
+	if name, wait := q.next(); name != "" || wait {
+		t.Fatalf("next() = %q, %v, want nothing left", name, wait)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if b := q.lease(); b != nil {
+		t.Fatalf("lease() = %+v, want nil once everything is done", b)
+	}
+}
+
+// a worker gets the results of its batch's servers stored, and nothing else.
+func TestFilterCrawlResults(t *testing.T) {
+	results := &model.CrawlResults{
+		Servers: []*model.MatrixServer{{Name: "a.com"}, {Name: "evil.com"}, nil},
+		Rooms: []*model.MatrixRoom{
+			{ID: "!space:other.com", Server: "a.com", RoomType: "m.space"},
+			{ID: "!fake:a.com", Server: "evil.com"},
+		},
+		Spaces: map[string][]*model.MatrixSpaceChild{
+			"!space:other.com": {{ID: "!child:a.com"}},
+			"!fake:a.com":      {{ID: "!child:evil.com"}},
+		},
+		Discovered: []string{"b.com"},
+	}
+	filtered := filterCrawlResults(results, map[string]struct{}{"a.com": {}})
+	if len(filtered.Servers) != 1 || filtered.Servers[0].Name != "a.com" {
+		t.Errorf("servers = %+v, want a.com only", filtered.Servers)
+	}
+	if len(filtered.Rooms) != 1 || filtered.Rooms[0].ID != "!space:other.com" {
+		t.Errorf("rooms = %+v, want the room listed by a.com only", filtered.Rooms)
+	}
+	if _, ok := filtered.Spaces["!space:other.com"]; !ok || len(filtered.Spaces) != 1 {
+		t.Errorf("spaces = %+v, want the space listed by a.com only", filtered.Spaces)
+	}
+	if empty := filterCrawlResults(results, map[string]struct{}{}); len(empty.Discovered) != 0 {
+		t.Errorf("discovered = %v, want nothing from a batch with nothing new", empty.Discovered)
+	}
+
+	// a server calling itself differently is still the one the batch has leased
+	renamed := &model.CrawlResults{Servers: []*model.MatrixServer{{Name: "a.com"}, {Name: "b.com"}}, Leased: []string{"A.com", "evil.com"}}
+	filtered = filterCrawlResults(renamed, map[string]struct{}{"A.com": {}, "b.com": {}})
+	if len(filtered.Servers) != 1 || filtered.Servers[0].Name != "a.com" {
+		t.Errorf("servers = %+v, want a.com leased as A.com only", filtered.Servers)
+	}
+}
+
+// the run waits for remote results, and the results go through apply.
+func TestRunQueue_Remote(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{Coordinator: &model.ConfigCoordinator{Enabled: true, BatchSize: 2}}).Maybe()
+	m := &Crawler{cfg: cfg}
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	processed := []string{}
+	leased := make(chan *model.CrawlBatch, 1)
+	local := func(name string) {
+		// the first local server gives a remote worker the time to lease the rest
+		if name == "a.com" {
+			batch := m.LeaseBatch(ctx)
+			leased <- batch
+		}
+		mu.Lock()
+		processed = append(processed, name)
+		mu.Unlock()
+	}
+	apply := func(_ context.Context, results *model.CrawlResults) {
+		mu.Lock()
+		for _, server := range results.Servers {
+			processed = append(processed, "remote:"+server.Name)
+		}
+		mu.Unlock()
+	}
+
+	go func() {
+		batch := <-leased
+		if m.SubmitResults(ctx, "parsing-404", &model.CrawlResults{}) {
+			t.Error("unknown batch accepted")
+		}
+		results := &model.CrawlResults{}
+		for _, name := range batch.Servers {
+			results.Servers = append(results.Servers, &model.MatrixServer{Name: name})
+		}
+		if !m.SubmitResults(ctx, batch.ID, results) {
+			t.Error("batch results refused")
+		}
+	}()
+	m.runQueue(ctx, model.CrawlParsing, []string{"a.com", "b.com", "c.com"}, nil, 1, local, apply)
+
+	slices.Sort(processed)
+	if want := []string{"a.com", "remote:b.com", "remote:c.com"}; !slices.Equal(processed, want) {
+		t.Errorf("processed = %v, want %v", processed, want)
+	}
+	if m.LeaseBatch(ctx) != nil {
+		t.Error("batch leased after the run")
+	}
+}
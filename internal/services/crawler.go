@@ -2,15 +2,17 @@ package services
 
 import (
 	"context"
+	"maps"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/etkecc/go-apm"
 	"github.com/etkecc/go-kit"
-	"github.com/etkecc/go-kit/workpool"
 	"github.com/etkecc/go-msc1929"
 	"github.com/pemistahl/lingua-go"
 
@@ -27,14 +29,32 @@ type Crawler struct {
 	fed         FederationService
 	block       BlocklistService
 	media       MediaService
-	data        DataRepository
+	data        DataRepository // nil in worker mode, see NewWorker
+	store       crawlStore     // where a single server's discovery and parsing go, the data repository or a worker's results
 	detector    lingua.LanguageDetector
+	polite      *politeness
+	seedStats   atomic.Pointer[[]*model.SeedStats]
+	peersMu     sync.Mutex
+	peersSince  map[string]time.Time // peer => latest parsed_at of its rooms pulled so far
+	queuesMu    sync.Mutex
+	queues      map[string]*crawlQueue // batch kind => the running discovery or parsing, for remote workers
+	parsedMu    sync.Mutex
+	parsedAt    map[string]time.Time // server => last time its rooms were parsed, for policy crawl intervals
+}
+
+// crawlStore is what discovery and parsing of a single server write to, see discoverServer and parseServer
+type crawlStore interface {
+	AddServer(context.Context, *model.MatrixServer) error
+	AddRoomBatch(context.Context, *model.MatrixRoom)
+	AddRoomMapping(context.Context, string, string) error
+	SetSpaceChildren(context.Context, string, []*model.MatrixSpaceChild) error
 }
 
 type BlocklistService interface {
 	Add(server string)
 	ByID(matrixID string) bool
 	ByServer(server string) bool
+	ByText(texts ...string) bool
 	Reset()
 }
 
@@ -56,15 +76,42 @@ type DataRepository interface {
 	RecreateRoomMapping(context.Context, map[string]string) error
 	EachRoom(context.Context, func(string, *model.MatrixRoom) bool)
 	SetBiggestRooms(context.Context, []string) error
+	EachRoomHistory(context.Context, func(string, *model.RoomHistory) bool)
+	SetTrendingRooms(context.Context, map[int][]*model.TrendingRoom) error
 	GetBannedRooms(context.Context, ...string) ([]string, error)
+	IsBanned(context.Context, string) bool
 	RemoveRooms(context.Context, []string)
-	BanRoom(context.Context, string) error
+	GetRoomBans(context.Context, ...string) ([]*model.RoomBan, error)
+	GetRoomBan(context.Context, string) (*model.RoomBan, error)
+	BanRoom(context.Context, *model.RoomBan) error
 	UnbanRoom(context.Context, string) error
-	GetReportedRooms(context.Context, ...string) (map[string]string, error)
-	ReportRoom(context.Context, string, string, string) error
+	GetReportedRooms(context.Context, ...string) (map[string]*model.RoomReports, error)
+	GetRoomReports(context.Context, string) (*model.RoomReports, error)
+	ReportRoom(context.Context, string, *model.RoomReport) (*model.RoomReports, bool, error)
 	UnreportRoom(context.Context, string) error
 	UnreportAll(context.Context) error
 	IsReported(context.Context, string) bool
+	SetSpaceChildren(context.Context, string, []*model.MatrixSpaceChild) error
+	GetSpaceChildren(context.Context, string) ([]*model.MatrixSpaceChild, error)
+	GetSpaceParents(context.Context) (map[string][]string, error)
+	GetRoomParents(context.Context, string) ([]string, error)
+	GetRoomHistory(context.Context, string) (*model.RoomHistory, error)
+	AddAuditEntry(context.Context, *model.AuditEntry) error
+	GetAuditLog(context.Context, *model.AuditFilter) ([]*model.AuditEntry, error)
+	QuarantineRoom(context.Context, *model.RoomQuarantine) error
+	ReleaseRoom(context.Context, string) error
+	GetRoomQuarantine(context.Context, string) (*model.RoomQuarantine, error)
+	IsQuarantined(context.Context, string) bool
+	GetQuarantinedRooms(context.Context, ...string) ([]*model.RoomQuarantine, error)
+	AddBanRule(context.Context, *model.BanRule) error
+	RemoveBanRule(context.Context, string) (*model.BanRule, error)
+	GetBanRules(context.Context) ([]*model.BanRule, error)
+	SaveAppeal(context.Context, *model.Appeal) error
+	GetAppeal(context.Context, string) (*model.Appeal, error)
+	GetAppeals(context.Context, ...string) ([]*model.Appeal, error)
+	UseActionToken(context.Context, string, time.Time) (bool, error)
+	IsActionTokenUsed(context.Context, string) bool
+	RemoveExpiredActionTokens(context.Context, time.Time) (int, error)
 }
 
 type ValidatorService interface {
@@ -75,11 +122,18 @@ type ValidatorService interface {
 }
 
 type FederationService interface {
-	QueryPublicRooms(ctx context.Context, serverName, limit, since string) (*model.RoomDirectoryResponse, error)
+	QueryPublicRooms(ctx context.Context, serverName, limit, since string, network model.RoomDirectoryNetwork) (*model.RoomDirectoryResponse, error)
 	QueryDirectoryExternal(ctx context.Context, roomAlias string) (*model.QueryDirectoryResponse, error)
 	QueryServerName(ctx context.Context, serverName string) (string, error)
 	QueryVersion(ctx context.Context, serverName string) (string, string, error)
 	QueryCSURL(ctx context.Context, serverName string) string
+	QueryServerIP(ctx context.Context, serverName string) string
+	QuerySpaceHierarchy(ctx context.Context, serverName, roomID string, suggestedOnly bool) (*model.SpaceHierarchyResponse, error)
+	QueryPeerServers(ctx context.Context, serverName string) ([]string, error)
+	QueryPeerRooms(ctx context.Context, serverName string, since time.Time, from string) (*model.PeerRoomsResponse, error)
+	GetClientRoomSummary(ctx context.Context, aliasOrID, via string, onlyMSC3266 bool) (int, *model.RoomDirectoryRoom)
+	GetAdminRoomSummary(ctx context.Context, aliasOrID, via string) (int, *model.RoomDirectoryRoom)
+	QueryServerPolicy(ctx context.Context, serverName string) (*model.MatrixServerPolicy, error)
 }
 
 // NewCrawler service
@@ -91,7 +145,9 @@ func NewCrawler(cfg ConfigService, fedSvc FederationService, v ValidatorService,
 		block:    block,
 		media:    media,
 		data:     data,
+		store:    data,
 		detector: detector,
+		polite:   newPoliteness(),
 	}
 }
 
@@ -158,12 +214,23 @@ func (m *Crawler) ParseRooms(ctx context.Context, workers int) {
 	defer m.parsing.Store(false)
 
 	servers := kit.NewList[string, string]()
-	indexable := m.IndexableServers(ctx)
-	for _, server := range indexable {
-		if m.block.ByServer(server) {
+	policies := map[string]*model.MatrixServerPolicy{}
+	now := time.Now().UTC()
+	indexable := m.data.FilterServers(ctx, func(server *model.MatrixServer) bool {
+		return server.Online && server.Indexable
+	})
+	for name, server := range indexable {
+		if m.block.ByServer(name) {
+			continue
+		}
+		if !m.isDue(name, server.Policy, now) {
+			log.Info().Str("server", name).Dur("crawl_interval", server.Policy.Interval(m.cfg.Get().Retention.GetRooms())).Msg("server asked to be parsed less often, skipping")
 			continue
 		}
-		servers.Add(server)
+		servers.Add(name)
+		if server.Policy != nil {
+			policies[name] = server.Policy
+		}
 	}
 	slice := servers.Slice()
 	total := len(slice)
@@ -171,18 +238,17 @@ func (m *Crawler) ParseRooms(ctx context.Context, workers int) {
 	if total < workers {
 		workers = total
 	}
-	wp := workpool.New(workers)
+	m.pullPeerRooms(ctx)
+
 	discoveredServers := kit.NewList[string, string]()
 	log.Info().Int("servers", total).Int("workers", workers).Msg("parsing rooms")
-	for _, srvName := range slice {
-		name := srvName
-		wp.Do(func() {
-			serversFromRooms := m.getPublicRooms(ctx, name)
-			discoveredServers.AddSlice(serversFromRooms.Slice())
-		})
-	}
+	m.runQueue(ctx, model.CrawlParsing, slice, policies, workers, func(name string) {
+		discoveredServers.AddSlice(m.parseServer(ctx, name, policies[name]).Slice())
+	}, func(ctx context.Context, results *model.CrawlResults) {
+		m.applyParsing(ctx, results)
+		discoveredServers.AddSlice(results.Discovered)
+	})
 
-	wp.Run()
 	m.data.FlushRoomBatch(ctx)
 	discoveredServers.RemoveSlice(servers.Slice())
 	log.
@@ -207,6 +273,10 @@ func (m *Crawler) EachRoom(ctx context.Context, handler func(roomID string, data
 	}
 	defer m.eachrooming.Store(false)
 
+	parents, err := m.data.GetSpaceParents(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("cannot get space parents")
+	}
 	toRemove := []string{}
 	m.data.EachRoom(ctx, func(id string, room *model.MatrixRoom) bool {
 		if !m.v.IsRoomAllowed(room) {
@@ -214,6 +284,7 @@ func (m *Crawler) EachRoom(ctx context.Context, handler func(roomID string, data
 			return false
 		}
 
+		room.Parents = parents[id]
 		return handler(id, room)
 	})
 	m.data.RemoveRooms(ctx, toRemove)
@@ -239,6 +310,25 @@ func (m *Crawler) IndexableServers(ctx context.Context) []string {
 }
 
 func (m *Crawler) GetRoom(ctx context.Context, roomIDorAlias string) (*model.MatrixRoom, error) {
+	room, err := m.getAllowedRoom(ctx, roomIDorAlias)
+	if err != nil || room == nil {
+		return nil, err
+	}
+	m.withSpaceRelations(ctx, room)
+	return room, nil
+}
+
+// GetRoomHistory returns the change history of an indexed room, nil if there is no such room
+func (m *Crawler) GetRoomHistory(ctx context.Context, roomIDorAlias string) (*model.RoomHistory, error) {
+	room, err := m.getAllowedRoom(ctx, roomIDorAlias)
+	if err != nil || room == nil {
+		return nil, err
+	}
+	return m.data.GetRoomHistory(ctx, room.ID)
+}
+
+// getAllowedRoom returns a stored room by ID or (mapped) alias, nil if it is unknown, quarantined, or not allowed anymore
+func (m *Crawler) getAllowedRoom(ctx context.Context, roomIDorAlias string) (*model.MatrixRoom, error) {
 	roomID := roomIDorAlias
 	if utils.IsValidAlias(roomIDorAlias) {
 		if mapped := m.data.GetRoomMapping(ctx, roomIDorAlias); mapped != "" {
@@ -253,12 +343,32 @@ func (m *Crawler) GetRoom(ctx context.Context, roomIDorAlias string) (*model.Mat
 	if room == nil {
 		return nil, nil
 	}
-	if !m.v.IsRoomAllowed(room) {
+	if !m.v.IsRoomAllowed(room) || m.data.IsQuarantined(ctx, room.ID) {
 		return nil, nil
 	}
 	return room, nil
 }
 
+// withSpaceRelations fills the room's space parents and children from the crawled hierarchies
+func (m *Crawler) withSpaceRelations(ctx context.Context, room *model.MatrixRoom) {
+	log := apm.Log(ctx)
+	children, err := m.data.GetSpaceChildren(ctx, room.ID)
+	if err != nil {
+		log.Warn().Err(err).Str("room", room.ID).Msg("cannot get space children")
+	}
+	room.Children = children
+	room.Parents = m.roomParents(ctx, room.ID)
+}
+
+// roomParents returns parent space IDs of the room, a single key read unlike GetSpaceParents
+func (m *Crawler) roomParents(ctx context.Context, roomID string) []string {
+	parents, err := m.data.GetRoomParents(ctx, roomID)
+	if err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("room", roomID).Msg("cannot get space parents")
+	}
+	return parents
+}
+
 func (m *Crawler) loadServers(ctx context.Context) *kit.List[string, string] {
 	log := apm.Log(ctx)
 	log.Info().Msg("loading servers")
@@ -266,33 +376,19 @@ func (m *Crawler) loadServers(ctx context.Context) *kit.List[string, string] {
 	servers.AddSlice(m.cfg.Get().Servers)
 	log.Info().Int("servers", servers.Len()).Msg("loaded servers from config")
 	now := time.Now().UTC()
+	backoff := m.cfg.Get().Retention.GetBackoff()
 	servers.AddSlice(kit.MapKeys(m.data.FilterServers(ctx, func(server *model.MatrixServer) bool {
 		if server.Online || server.CheckedAt.IsZero() {
 			return true // online servers always; never-dialed stubs (harvested / pre-backoff rows) are due
 		}
-		return now.Sub(server.CheckedAt) >= offlineBackoff(now.Sub(server.OnlineAt))
+		return now.Sub(server.CheckedAt) >= offlineBackoff(now.Sub(server.OnlineAt), backoff)
 	})))
 	log.Info().Int("servers", servers.Len()).Msg("loaded servers from config and db")
+	m.addSeeds(ctx, servers)
 
 	return servers
 }
 
-// offlineBackoff is the minimum gap between dials of an offline server, widening the longer it's been dead.
-// age <7d: 0, dial every run to catch a quick recovery; <14d: every 4d; older: weekly. >30d never reaches here, removeOldOfflineServers deleted it.
-func offlineBackoff(age time.Duration) time.Duration {
-	if age < 0 {
-		age = 0 // a backwards clock step is not an invitation to resurrect the whole graveyard for a redial. floor it.
-	}
-	switch {
-	case age < 7*24*time.Hour:
-		return 0
-	case age < 14*24*time.Hour:
-		return 4 * 24 * time.Hour
-	default:
-		return 7 * 24 * time.Hour
-	}
-}
-
 // discoverServer parses server information
 func (m *Crawler) discoverServer(ctx context.Context, rawName string) *model.MatrixServer {
 	if m.block.ByServer(rawName) {
@@ -319,12 +415,18 @@ func (m *Crawler) discoverServer(ctx context.Context, rawName string) *model.Mat
 		OnlineAt:  time.Now().UTC(),
 		CheckedAt: time.Now().UTC(),
 	}
+	server.Policy, server.PolicyStale = m.getServerPolicy(ctx, name)
+	if m.data != nil { // a worker has no data, the coordinator keeps the stored policy, see applyDiscovery
+		m.keepStoredPolicy(ctx, server)
+	}
 
-	if m.v.IsIndexable(ctx, name) {
+	if server.Policy.IsNoindex() {
+		apm.Log(ctx).Info().Str("server", name).Msg("server opted out of indexing by its policy")
+	} else if m.v.IsIndexable(ctx, name) {
 		server.Indexable = true
 	}
 
-	if err := m.data.AddServer(ctx, server); err != nil {
+	if err := m.store.AddServer(ctx, server); err != nil {
 		apm.Log(ctx).
 			Error().Err(err).Msg("cannot store server")
 	}
@@ -333,33 +435,36 @@ func (m *Crawler) discoverServer(ctx context.Context, rawName string) *model.Mat
 
 // discoverServers parses servers information and returns lists of OFFLINE servers
 func (m *Crawler) discoverServers(ctx context.Context, servers *kit.List[string, string], workers int) (offline *kit.List[string, string]) {
-	wp := workpool.New(workers)
 	log := apm.Log(ctx)
 	online := kit.NewList[string, string]()
 	offline = kit.NewList[string, string]()
 	indexable := kit.NewList[string, string]() // just for stats
 	log.Info().Int("servers", servers.Len()).Int("workers", workers).Msg("validating servers")
 
-	for _, server := range servers.Slice() {
-		srvName := server
-		wp.Do(func() {
-			server := m.discoverServer(ctx, srvName)
-			serverName := srvName
-			if server.Name != "" {
-				serverName = server.Name
-			}
+	classify := func(rawName string, server *model.MatrixServer) {
+		serverName := rawName
+		if server.Name != "" {
+			serverName = server.Name
+		}
 
-			if server.Online {
-				online.Add(serverName)
-			} else {
-				offline.Add(serverName)
-			}
-			if server.Indexable {
-				indexable.Add(serverName)
-			}
-		})
+		if server.Online {
+			online.Add(serverName)
+		} else {
+			offline.Add(serverName)
+		}
+		if server.Indexable {
+			indexable.Add(serverName)
+		}
 	}
-	wp.Run()
+	m.runQueue(ctx, model.CrawlDiscovery, servers.Slice(), nil, workers, func(name string) {
+		classify(name, m.discoverServer(ctx, name))
+	}, func(ctx context.Context, results *model.CrawlResults) {
+		for _, server := range results.Servers {
+			if m.applyDiscovery(ctx, server) {
+				classify(server.Name, server)
+			}
+		}
+	})
 
 	log.Info().
 		Int("online", online.Len()).
@@ -372,8 +477,11 @@ func (m *Crawler) discoverServers(ctx context.Context, servers *kit.List[string,
 
 func (m *Crawler) removeOldOfflineServers(ctx context.Context) {
 	log := apm.Log(ctx)
-	threshold := time.Now().UTC().AddDate(0, -1, 0)
+	retention := m.cfg.Get().Retention.GetServers()
+	threshold := time.Now().UTC().Add(-retention)
+	var total int
 	servers := m.data.FilterServers(ctx, func(server *model.MatrixServer) bool {
+		total++
 		return !server.Online && server.OnlineAt.Before(threshold)
 	})
 	if len(servers) == 0 {
@@ -382,7 +490,10 @@ func (m *Crawler) removeOldOfflineServers(ctx context.Context) {
 	}
 
 	toRemove := kit.MapKeys(servers)
-	log.Info().Int("servers", len(toRemove)).Msg("removing old offline servers")
+	if !m.retentionAllows(ctx, "servers", toRemove, total) {
+		return
+	}
+	log.Info().Int("servers", len(toRemove)).Strs("sample", sampleIDs(toRemove)).Str("retention", retention.String()).Msg("removing old offline servers")
 	m.data.RemoveServers(ctx, toRemove)
 }
 
@@ -397,17 +508,31 @@ func (m *Crawler) afterRoomParsing(ctx context.Context) {
 	log.Info().Msg("after room parsing......")
 	started := time.Now().UTC()
 	counts := []roomCount{}
+	stale := []roomCount{}
 	toRemove := map[string]string{}
 	mapping := map[string]string{}
+	staleMapping := map[string]string{}
+	retention := m.cfg.Get().Retention.GetRooms()
 	m.data.EachRoom(ctx, func(id string, data *model.MatrixRoom) bool {
-		if started.Sub(data.ParsedAt) >= 24*7*time.Hour { // parsed more than a week ago
+		roomMapping := mapping
+		if started.Sub(data.ParsedAt) >= retention {
+			stale = append(stale, roomCount{data.ID, data.Members})
 			toRemove[id] = data.Avatar
-			return false
+			roomMapping = staleMapping
+		} else {
+			counts = append(counts, roomCount{data.ID, data.Members})
+		}
+		if data.AliasVerification.IsVerified() {
+			roomMapping[data.ID] = data.Alias
 		}
-		counts = append(counts, roomCount{data.ID, data.Members})
-		mapping[data.ID] = data.Alias
 		return false
 	})
+	if len(toRemove) > 0 && !m.retentionAllows(ctx, "rooms", kit.MapKeys(toRemove), len(counts)+len(toRemove)) {
+		// still real rooms, keep them listed until a run decides otherwise
+		counts = append(counts, stale...)
+		maps.Copy(mapping, staleMapping)
+		toRemove = map[string]string{}
+	}
 
 	sort.Slice(counts, func(i, j int) bool {
 		return counts[i].members > counts[j].members
@@ -421,10 +546,11 @@ func (m *Crawler) afterRoomParsing(ctx context.Context) {
 		log.Error().Err(err).Msg("cannot set biggest rooms")
 	}
 	log.Info().Str("took", time.Since(started).String()).Msg("biggest rooms have been calculated and stored")
+	m.calculateTrending(ctx, ids)
 
 	if len(toRemove) > 0 {
-		log.Info().Int("rooms", len(toRemove)).Msg("removing rooms last updated more than a week ago...")
 		toRemoveSlice := kit.MapKeys(toRemove)
+		log.Info().Int("rooms", len(toRemove)).Strs("sample", sampleIDs(toRemoveSlice)).Str("retention", retention.String()).Msg("removing stale rooms...")
 		for _, mxcURL := range toRemove {
 			if mxcURL == "" {
 				continue
@@ -478,67 +604,197 @@ func (m *Crawler) getServerContacts(ctx context.Context, name string) model.Matr
 	return contacts
 }
 
+// getServerPolicy as per /.well-known/matrix/mrs, stale is true if it could not be fetched (anything but 200 or 404)
+func (m *Crawler) getServerPolicy(ctx context.Context, name string) (policy *model.MatrixServerPolicy, stale bool) {
+	policy, err := m.fed.QueryServerPolicy(ctx, name)
+	if err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("server", name).Msg("cannot get server policy, keeping the stored one")
+		return nil, true
+	}
+	return policy, false
+}
+
+// keepStoredPolicy puts the stored policy back into the server if the fresh one could not be fetched.
+// An opt-out fails closed: a server that published noindex doesn't become indexable because its well-known timed out once
+func (m *Crawler) keepStoredPolicy(ctx context.Context, server *model.MatrixServer) {
+	if !server.PolicyStale {
+		return
+	}
+	server.PolicyStale = false
+	stored, err := m.data.GetServerInfo(ctx, server.Name)
+	if err != nil || stored == nil {
+		return
+	}
+	server.Policy = stored.Policy
+	if server.Policy.IsNoindex() {
+		server.Indexable = false
+	}
+}
+
+// isDue returns true if the server's policy allows parsing its rooms now, and remembers the parsing if so.
+// The clock lives in memory, so a restart makes every server due again
+func (m *Crawler) isDue(name string, policy *model.MatrixServerPolicy, now time.Time) bool {
+	m.parsedMu.Lock()
+	defer m.parsedMu.Unlock()
+	if m.parsedAt == nil {
+		m.parsedAt = map[string]time.Time{}
+	}
+	if !policy.IsDue(m.parsedAt[name], m.cfg.Get().Retention.GetRooms()) {
+		return false
+	}
+	m.parsedAt[name] = now
+	return true
+}
+
 // getPublicRooms reads public rooms of the given server from the matrix client-server api
-// and sends them into channel
+// and sends them into channel. Page size and per-IP concurrency adapt to how the server copes, see politeness.
+// Returns servers found in the rooms and IDs of the public spaces, to crawl their hierarchy.
+// Rooms already seen in another directory of the same server are skipped, so the first (most precise) network tag sticks
 //
-//nolint:gocognit // TODO: refactor
-func (m *Crawler) getPublicRooms(ctx context.Context, name string) *kit.List[string, string] {
+//nolint:gocognit,gocyclo // TODO: refactor
+func (m *Crawler) getPublicRooms(ctx context.Context, name, ip string, network directoryNetwork, seen map[string]struct{}, policy *model.MatrixServerPolicy) (servers *kit.List[string, string], spaces []string) {
 	var since string
-	var added int
-	limit := "10000"
-	servers := kit.NewList[string, string]()
+	var added, retries int
+	servers = kit.NewList[string, string]()
 	log := apm.Log(ctx)
+	perIP, maxPageSize, minPageSize := politenessLimits(m.cfg.Get())
+	if ip == "" {
+		ip = name // unresolved, so at least the server itself is capped
+	}
+	if wait := m.polite.backoff(name); wait > 0 {
+		log.Info().Str("server", name).Str("retry_after", wait.String()).Msg("server asked us to back off, skipping")
+		return servers, spaces
+	}
 
 	for {
+		limit := m.polite.pageSize(name, maxPageSize, minPageSize)
+		if !m.polite.acquire(ctx, ip, perIP) {
+			return servers, spaces
+		}
 		start := time.Now()
-		resp, err := m.fed.QueryPublicRooms(ctx, name, limit, since)
+		resp, err := m.fed.QueryPublicRooms(ctx, name, strconv.Itoa(limit), since, network.query)
+		outcome, retryAfter := classifyPage(time.Since(start), err)
+		m.polite.release(ip, outcome, perIP)
+		m.polite.observe(name, outcome, retryAfter, maxPageSize, minPageSize)
+		if outcome == pageLimited {
+			retries++
+			if retries > maxLimitedRetries || retryAfter > maxRetryAfterWait || !sleepCtx(ctx, retryAfter) {
+				log.Warn().Err(err).Str("server", name).Str("retry_after", retryAfter.String()).Int("retries", retries-1).Msg("rate limited, skipping")
+				return servers, spaces
+			}
+			log.Info().Str("server", name).Str("retry_after", retryAfter.String()).Msg("rate limited, retrying with a smaller page")
+			continue // same since, smaller page
+		}
 		if err != nil {
-			log.Warn().Err(err).Str("server", name).Msg("cannot query public rooms")
-			return servers
+			log.Warn().Err(err).Str("server", name).Str("network", network.String()).Int("limit", limit).Msg("cannot query public rooms")
+			return servers, spaces
 		}
 		if len(resp.Chunk) == 0 {
-			log.Info().Str("server", name).Msg("no public rooms available")
-			return servers
+			log.Info().Str("server", name).Str("network", network.String()).Msg("no public rooms available")
+			return servers, spaces
 		}
 
 		added += len(resp.Chunk)
 		for _, rdRoom := range resp.Chunk {
 			room := rdRoom.Convert(name)
+			room.Network = network.tag
+			if _, ok := seen[room.ID]; ok {
+				added--
+				continue
+			}
+			seen[room.ID] = struct{}{}
 			if !m.v.IsRoomAllowed(room) {
 				added--
 				continue
 			}
 
+			policy.Apply(room)
 			if !room.Parse(m.detector, m.media, m.cfg.Get().Matrix.ServerName) {
 				added--
 				continue
 			}
 
-			qDir, err := m.fed.QueryDirectoryExternal(ctx, room.Alias)
-			if err != nil {
-				log.Warn().Err(err).Str("server", name).Str("room", room.ID).Str("alias", room.Alias).Msg("cannot query client directory")
-			}
-			if qDir != nil && len(qDir.Servers) > 0 {
-				room.Servers = kit.Uniq(append(room.AllServers(), qDir.Servers...))
+			if aliasServers := m.verifyAlias(ctx, room); len(aliasServers) > 0 {
+				room.Servers = kit.Uniq(append(room.AllServers(), aliasServers...))
 			}
 
 			servers.AddSlice(room.AllServers())
+			if room.RoomType == "m.space" {
+				spaces = append(spaces, room.ID)
+			}
 
-			m.data.AddRoomBatch(ctx, room)
-			m.data.AddRoomMapping(ctx, room.ID, room.Alias) //nolint:errcheck // ignore error
+			m.store.AddRoomBatch(ctx, room)
+			if room.AliasVerification.IsVerified() {
+				m.store.AddRoomMapping(ctx, room.ID, room.Alias) //nolint:errcheck // ignore error
+			}
 		}
 		log.
 			Info().
 			Str("server", name).
+			Str("network", network.String()).
 			Int("added", added).
 			Int("of", resp.Total).
 			Str("took", time.Since(start).String()).
 			Msg("added rooms")
 
 		if resp.NextBatch == "" {
-			return servers
+			return servers, spaces
 		}
 
 		since = resp.NextBatch
 	}
 }
+
+// parseServer parses public rooms and space hierarchies of the server, following its policy (if any).
+// Returns servers found along the way
+func (m *Crawler) parseServer(ctx context.Context, name string, policy *model.MatrixServerPolicy) *kit.List[string, string] {
+	discovered := kit.NewList[string, string]()
+	ip := m.fed.QueryServerIP(ctx, name)
+	seen := map[string]struct{}{}
+	for _, network := range directoryNetworks(m.cfg.Get().Networks, name) {
+		serversFromRooms, spaces := m.getPublicRooms(ctx, name, ip, network, seen, policy)
+		discovered.AddSlice(serversFromRooms.Slice())
+		discovered.AddSlice(m.getSpaceHierarchies(ctx, name, ip, spaces).Slice())
+	}
+	return discovered
+}
+
+// getSpaceHierarchies crawls hierarchies of the server's public spaces and stores their children,
+// sharing the per-IP slots with publicRooms. Returns servers the children are reachable via
+func (m *Crawler) getSpaceHierarchies(ctx context.Context, name, ip string, spaces []string) *kit.List[string, string] {
+	servers := kit.NewList[string, string]()
+	if len(spaces) == 0 {
+		return servers
+	}
+	log := apm.Log(ctx)
+	perIP, _, _ := politenessLimits(m.cfg.Get())
+	if ip == "" {
+		ip = name
+	}
+
+	for _, spaceID := range spaces {
+		if !m.polite.acquire(ctx, ip, perIP) {
+			return servers
+		}
+		start := time.Now()
+		resp, err := m.fed.QuerySpaceHierarchy(ctx, name, spaceID, false)
+		outcome, _ := classifyPage(time.Since(start), err)
+		m.polite.release(ip, outcome, perIP)
+		if err != nil {
+			log.Warn().Err(err).Str("server", name).Str("space", spaceID).Msg("cannot query space hierarchy")
+			if outcome == pageLimited {
+				return servers // the rest of the spaces would get the same answer
+			}
+			continue
+		}
+
+		children := resp.Room.SpaceChildren()
+		for _, child := range children {
+			servers.AddSlice(child.Via)
+		}
+		if err := m.store.SetSpaceChildren(ctx, spaceID, children); err != nil {
+			log.Warn().Err(err).Str("server", name).Str("space", spaceID).Msg("cannot store space children")
+		}
+	}
+	return servers
+}
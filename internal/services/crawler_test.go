@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"slices"
 	"sync/atomic"
@@ -70,9 +71,10 @@ func TestParseRooms_DefersHarvestNotDiscovers(t *testing.T) {
 			{ID: "!r:known.example", Alias: "#r:known.example", Name: "Test", Topic: "(MRS-language:EN-MRS)", JoinRule: "public"},
 		},
 	}
-	fed.EXPECT().QueryPublicRooms(mock.Anything, "known.example", mock.Anything, mock.Anything).Return(resp, nil)
+	fed.EXPECT().QueryServerIP(mock.Anything, "known.example").Return("192.0.2.1")
+	fed.EXPECT().QueryPublicRooms(mock.Anything, "known.example", mock.Anything, mock.Anything, model.RoomDirectoryNetwork{}).Return(resp, nil)
 	v.EXPECT().IsRoomAllowed(mock.Anything).Return(true)
-	fed.EXPECT().QueryDirectoryExternal(mock.Anything, mock.Anything).Return(&model.QueryDirectoryResponse{Servers: []string{"new.example"}}, nil)
+	fed.EXPECT().QueryDirectoryExternal(mock.Anything, mock.Anything).Return(&model.QueryDirectoryResponse{RoomID: "!r:known.example", Servers: []string{"new.example"}}, nil)
 	data.EXPECT().AddRoomBatch(mock.Anything, mock.Anything).Return()
 	data.EXPECT().AddRoomMapping(mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	data.EXPECT().FlushRoomBatch(mock.Anything).Return()
@@ -86,6 +88,8 @@ func TestParseRooms_DefersHarvestNotDiscovers(t *testing.T) {
 	// afterRoomParsing tail: no rooms flow through the mocked EachRoom, so mapping/removal stay empty.
 	data.EXPECT().EachRoom(mock.Anything, mock.Anything).Return()
 	data.EXPECT().SetBiggestRooms(mock.Anything, mock.Anything).Return(nil)
+	data.EXPECT().EachRoomHistory(mock.Anything, mock.Anything).Return()
+	data.EXPECT().SetTrendingRooms(mock.Anything, mock.Anything).Return(nil)
 
 	m := NewCrawler(cfg, fed, v, block, media, data, nil)
 	m.ParseRooms(ctx, 1)
@@ -95,6 +99,61 @@ func TestParseRooms_DefersHarvestNotDiscovers(t *testing.T) {
 	}
 }
 
+// a server answering 429 to every page is retried a few times, not forever, and not past the context's deadline.
+func TestGetPublicRooms_RateLimitedGivesUp(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	fed := NewMockFederationService(t)
+	cfg.EXPECT().Get().Return(&model.Config{}).Maybe()
+	limited := &model.MatrixError{Code: "M_LIMIT_EXCEEDED", RetryAfterMs: 1}
+	fed.EXPECT().QueryPublicRooms(mock.Anything, "known.example", mock.Anything, "", mock.Anything).Return(nil, limited).Times(maxLimitedRetries + 1)
+
+	m := NewCrawler(cfg, fed, nil, nil, nil, nil, nil)
+	m.getPublicRooms(context.Background(), "known.example", "192.0.2.1", directoryNetwork{}, map[string]struct{}{}, nil)
+
+	slow := &model.MatrixError{Code: "M_LIMIT_EXCEEDED", RetryAfterMs: 60000}
+	fed.EXPECT().QueryPublicRooms(mock.Anything, "other.example", mock.Anything, "", mock.Anything).Return(nil, slow).Once()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	m.getPublicRooms(ctx, "other.example", "192.0.2.2", directoryNetwork{}, map[string]struct{}{}, nil)
+	if took := time.Since(start); took > 500*time.Millisecond {
+		t.Errorf("getPublicRooms() took %s, want no sleeping past the deadline", took)
+	}
+}
+
+// a space's children are stored and their via servers harvested; a child without via is a removed one and must not stick.
+func TestGetSpaceHierarchies_StoresChildren(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	fed := NewMockFederationService(t)
+	data := NewMockDataRepository(t)
+	cfg.EXPECT().Get().Return(&model.Config{}).Maybe()
+
+	resp := &model.SpaceHierarchyResponse{
+		Room: &model.SpaceHierarchyRoom{
+			RoomDirectoryRoom: model.RoomDirectoryRoom{ID: "!space:known.example", RoomType: "m.space"},
+			ChildrenState: []*model.SpaceChildEvent{
+				{Type: model.EventTypeSpaceChild, StateKey: "!kept:other.example", Content: model.SpaceChildEventContent{Via: []string{"other.example"}}},
+				{Type: model.EventTypeSpaceChild, StateKey: "!removed:known.example"},
+			},
+		},
+	}
+	fed.EXPECT().QuerySpaceHierarchy(mock.Anything, "known.example", "!space:known.example", false).Return(resp, nil)
+	var stored []*model.MatrixSpaceChild
+	data.EXPECT().SetSpaceChildren(mock.Anything, "!space:known.example", mock.Anything).Run(func(_ context.Context, _ string, children []*model.MatrixSpaceChild) {
+		stored = children
+	}).Return(nil)
+
+	m := NewCrawler(cfg, fed, nil, nil, nil, data, nil)
+	servers := m.getSpaceHierarchies(context.Background(), "known.example", "192.0.2.1", []string{"!space:known.example"})
+
+	if len(stored) != 1 || stored[0].ID != "!kept:other.example" {
+		t.Fatalf("stored children = %+v, want only !kept:other.example", stored)
+	}
+	if !slices.Contains(servers.Slice(), "other.example") {
+		t.Fatalf("via server was not harvested; got %v", servers.Slice())
+	}
+}
+
 // the backoff schedule is the whole feature; an off-by-one at a 7d/14d boundary silently reshapes the dial curve.
 func TestOfflineBackoff(t *testing.T) {
 	day := 24 * time.Hour
@@ -110,7 +169,7 @@ func TestOfflineBackoff(t *testing.T) {
 		{40 * day, 7 * day}, // weekly holds (>30d never reaches here in prod, prune deleted it)
 	}
 	for _, tt := range tests {
-		if got := offlineBackoff(tt.age); got != tt.want {
+		if got := offlineBackoff(tt.age, new(model.ConfigRetention).GetBackoff()); got != tt.want {
 			t.Errorf("offlineBackoff(%v) = %v, want %v", tt.age, got, tt.want)
 		}
 	}
@@ -178,6 +237,7 @@ func TestDiscoverServer_StampsCheckedAt(t *testing.T) {
 	block.EXPECT().ByServer("test.example").Return(false)
 	v.EXPECT().IsOnline(mock.Anything, "test.example").Return("test.example", "Synapse", "1.0.0", true)
 	fed.EXPECT().QueryCSURL(mock.Anything, "test.example").Return("https://test.example")
+	fed.EXPECT().QueryServerPolicy(mock.Anything, "test.example").Return(nil, nil)
 	v.EXPECT().IsIndexable(mock.Anything, "test.example").Return(true)
 
 	var stored *model.MatrixServer
@@ -196,6 +256,56 @@ func TestDiscoverServer_StampsCheckedAt(t *testing.T) {
 	}
 }
 
+// a server that opted out by its policy is stored with the policy, but never asked for its directory.
+func TestDiscoverServer_PolicyNoindex(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	fed := NewMockFederationService(t)
+	v := NewMockValidatorService(t)
+	block := NewMockBlocklistService(t)
+	data := NewMockDataRepository(t)
+
+	block.EXPECT().ByServer("test.example").Return(false)
+	v.EXPECT().IsOnline(mock.Anything, "test.example").Return("test.example", "Synapse", "1.0.0", true)
+	fed.EXPECT().QueryCSURL(mock.Anything, "test.example").Return("https://test.example")
+	fed.EXPECT().QueryServerPolicy(mock.Anything, "test.example").Return(&model.MatrixServerPolicy{Noindex: true}, nil)
+	data.EXPECT().AddServer(mock.Anything, mock.Anything).Return(nil).Once()
+
+	m := NewCrawler(cfg, fed, v, block, nil, data, nil)
+	server := m.discoverServer(context.Background(), "test.example")
+
+	if server.Indexable || !server.Policy.IsNoindex() {
+		t.Fatalf("server = %+v, want not indexable with the policy", server)
+	}
+}
+
+// an opt-out fails closed: a policy that can't be fetched keeps the stored one, locally and from a remote worker.
+func TestDiscoverServer_PolicyUnavailable(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	fed := NewMockFederationService(t)
+	v := NewMockValidatorService(t)
+	block := NewMockBlocklistService(t)
+	data := NewMockDataRepository(t)
+
+	block.EXPECT().ByServer("test.example").Return(false)
+	v.EXPECT().IsOnline(mock.Anything, "test.example").Return("test.example", "Synapse", "1.0.0", true)
+	fed.EXPECT().QueryCSURL(mock.Anything, "test.example").Return("https://test.example")
+	fed.EXPECT().QueryServerPolicy(mock.Anything, "test.example").Return(nil, errors.New("HTTP 503"))
+	stored := &model.MatrixServer{Name: "test.example", Policy: &model.MatrixServerPolicy{Noindex: true}}
+	data.EXPECT().GetServerInfo(mock.Anything, "test.example").Return(stored, nil)
+	data.EXPECT().AddServer(mock.Anything, mock.Anything).Return(nil)
+
+	m := NewCrawler(cfg, fed, v, block, nil, data, nil)
+	server := m.discoverServer(context.Background(), "test.example")
+	if server.Indexable || !server.Policy.IsNoindex() || server.PolicyStale {
+		t.Fatalf("server = %+v, want not indexable with the stored policy", server)
+	}
+
+	remote := &model.MatrixServer{Name: "test.example", Online: true, Indexable: true, PolicyStale: true}
+	if !m.applyDiscovery(context.Background(), remote) || remote.Indexable || !remote.Policy.IsNoindex() {
+		t.Fatalf("remote server = %+v, want not indexable with the stored policy", remote)
+	}
+}
+
 // the clobber regression guard: a resolves-but-down server (IsOnline returns a name but ok=false) must NOT be
 // persisted by discoverServer. AddServer is a blind Put; persisting here would stamp OnlineAt=now and reset the
 // prune clock, making the corpse immortal. MarkServersOffline is the sole offline writer. No AddServer expectation
@@ -239,6 +349,7 @@ func TestAddServer_StatusReflectsPersistence(t *testing.T) {
 		block.EXPECT().ByServer("up.example").Return(false)
 		v.EXPECT().IsOnline(mock.Anything, "up.example").Return("up.example", "Synapse", "1.0.0", true)
 		fed.EXPECT().QueryCSURL(mock.Anything, "up.example").Return("https://up.example")
+		fed.EXPECT().QueryServerPolicy(mock.Anything, "up.example").Return(nil, nil)
 		v.EXPECT().IsIndexable(mock.Anything, "up.example").Return(true)
 		data.EXPECT().AddServer(mock.Anything, mock.Anything).Return(nil).Once()
 
@@ -288,3 +399,21 @@ func TestAddServer_StatusReflectsPersistence(t *testing.T) {
 		}
 	})
 }
+
+// a quarantined room keeps its data, but the room page and its history are a 404 until it's released.
+func TestGetRoom_Quarantined(t *testing.T) {
+	room := &model.MatrixRoom{ID: "!r:one.example", Name: "Room"}
+	v := NewMockValidatorService(t)
+	v.EXPECT().IsRoomAllowed(room).Return(true)
+	data := NewMockDataRepository(t)
+	data.EXPECT().GetRoom(mock.Anything, room.ID).Return(room, nil)
+	data.EXPECT().IsQuarantined(mock.Anything, room.ID).Return(true)
+
+	m := NewCrawler(nil, nil, v, nil, nil, data, nil)
+	if got, err := m.GetRoom(context.Background(), room.ID); got != nil || err != nil {
+		t.Errorf("GetRoom() = %+v, %v, want nil", got, err)
+	}
+	if history, err := m.GetRoomHistory(context.Background(), room.ID); history != nil || err != nil {
+		t.Errorf("GetRoomHistory() = %+v, %v, want nil", history, err)
+	}
+}
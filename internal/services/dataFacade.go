@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/etkecc/go-apm"
@@ -17,12 +18,17 @@ type dataCrawlerService interface {
 	ParseRooms(context.Context, int)
 	EachRoom(context.Context, func(string, *model.MatrixRoom) bool)
 	GetRoom(ctx context.Context, roomID string) (*model.MatrixRoom, error)
+	GetRoomHistory(ctx context.Context, roomIDorAlias string) (*model.RoomHistory, error)
+	RefreshServer(ctx context.Context, name string) (int, *model.MatrixServer, []*model.MatrixRoom)
+	RefreshRoom(ctx context.Context, roomIDorAlias string) (int, *model.MatrixRoom)
 }
 
 type dataIndexService interface {
 	EmptyIndex(ctx context.Context) error
 	RoomsBatch(ctx context.Context, roomID string, data *model.Entry) error
 	IndexBatch(ctx context.Context) error
+	IndexRoom(roomID string, data *model.Entry) error
+	DeleteRoom(roomID string) error
 }
 
 type dataStatsService interface {
@@ -33,11 +39,17 @@ type dataStatsService interface {
 	CollectServers(context.Context, bool)
 }
 
+type dataFlagService interface {
+	NewFlagRun(ctx context.Context) *FlagRun
+	NewRefreshFlagRun(ctx context.Context) *FlagRun
+}
+
 // DataFacade wraps all data-related services to provide reusable API across all components of the system
 type DataFacade struct {
 	crawler dataCrawlerService
 	index   dataIndexService
 	stats   dataStatsService
+	flags   dataFlagService
 }
 
 // NewDataFacade creates new data facade service
@@ -45,8 +57,9 @@ func NewDataFacade(
 	crawler dataCrawlerService,
 	index dataIndexService,
 	stats dataStatsService,
+	flags dataFlagService,
 ) *DataFacade {
-	return &DataFacade{crawler, index, stats}
+	return &DataFacade{crawler, index, stats, flags}
 }
 
 // AddServer by name, intended for HTTP API
@@ -102,12 +115,23 @@ func (df *DataFacade) Ingest(ctx context.Context) {
 	log.Info().Msg("indexing matrix rooms...")
 	start := time.Now().UTC()
 	df.stats.SetStartedAt(ctx, "indexing", start)
+	flags := df.flags.NewFlagRun(ctx)
+	held := map[string]*model.Entry{} // rooms the flag rules keep out, unless a rule turns out to match too many
 	df.crawler.EachRoom(ctx, func(roomID string, room *model.MatrixRoom) bool {
+		if !flags.Check(room) {
+			held[roomID] = room.Entry()
+			return false
+		}
 		if err := df.index.RoomsBatch(ctx, roomID, room.Entry()); err != nil {
 			log.Warn().Err(err).Str("id", room.ID).Msg("cannot add room to batch")
 		}
 		return false
 	})
+	for _, roomID := range flags.Apply(ctx) {
+		if err := df.index.RoomsBatch(ctx, roomID, held[roomID]); err != nil {
+			log.Warn().Err(err).Str("id", roomID).Msg("cannot add room to batch")
+		}
+	}
 	if err := df.index.IndexBatch(ctx); err != nil {
 		log.Warn().Err(err).Msg("indexing of the last batch failed")
 	}
@@ -130,3 +154,58 @@ func (df *DataFacade) Full(ctx context.Context, discoveryWorkers, parsingWorkers
 func (df *DataFacade) GetRoom(ctx context.Context, roomID string) (*model.MatrixRoom, error) {
 	return df.crawler.GetRoom(ctx, roomID)
 }
+
+// GetRoomHistory returns the change history of an indexed room
+func (df *DataFacade) GetRoomHistory(ctx context.Context, roomIDorAlias string) (*model.RoomHistory, error) {
+	return df.crawler.GetRoomHistory(ctx, roomIDorAlias)
+}
+
+// RefreshServer re-discovers the server and re-parses its rooms right away, the index is updated as well.
+// Flag rules are evaluated the same way as during Ingest, a room held back by them is removed from the index
+func (df *DataFacade) RefreshServer(ctx context.Context, name string) (int, *model.RefreshServerResponse) {
+	log := apm.Log(ctx)
+	code, server, rooms := df.crawler.RefreshServer(ctx, name)
+	resp := &model.RefreshServerResponse{Server: server, Rooms: make([]string, 0, len(rooms))}
+	flags := df.flags.NewRefreshFlagRun(ctx)
+	for _, room := range rooms {
+		if !flags.Check(room) {
+			if err := df.index.DeleteRoom(room.ID); err != nil {
+				log.Warn().Err(err).Str("id", room.ID).Msg("cannot remove room from index")
+			}
+			continue
+		}
+		if err := df.index.IndexRoom(room.ID, room.Entry()); err != nil {
+			log.Warn().Err(err).Str("id", room.ID).Msg("cannot index room")
+			continue
+		}
+		resp.Rooms = append(resp.Rooms, room.ID)
+	}
+	flags.Apply(ctx)
+	return code, resp
+}
+
+// RefreshRoom re-reads the room right away, the index is updated as well.
+// Flag rules are evaluated the same way as during Ingest, a room held back by them is a 202, as a quarantined one
+func (df *DataFacade) RefreshRoom(ctx context.Context, roomIDorAlias string) (int, *model.MatrixRoom) {
+	log := apm.Log(ctx)
+	code, room := df.crawler.RefreshRoom(ctx, roomIDorAlias)
+	switch code {
+	case http.StatusOK:
+		flags := df.flags.NewRefreshFlagRun(ctx)
+		defer flags.Apply(ctx)
+		if !flags.Check(room) {
+			if err := df.index.DeleteRoom(room.ID); err != nil {
+				log.Warn().Err(err).Str("id", room.ID).Msg("cannot remove room from index")
+			}
+			return http.StatusAccepted, room
+		}
+		if err := df.index.IndexRoom(room.ID, room.Entry()); err != nil {
+			log.Warn().Err(err).Str("id", room.ID).Msg("cannot index room")
+		}
+	case http.StatusGone:
+		if err := df.index.DeleteRoom(room.ID); err != nil {
+			log.Warn().Err(err).Str("id", room.ID).Msg("cannot remove room from index")
+		}
+	}
+	return code, room
+}
@@ -24,6 +24,40 @@ type emailVars struct {
 	Server        *model.MatrixServer
 	RoomAliasOrID string
 	Reason        string
+	Appeal        *model.Appeal
+	Link          string
+}
+
+// defaultAppealVerify is the appeal verification email, unless the config has one
+var defaultAppealVerify = model.ConfigEmailTemplate{
+	Subject: "{{ .Public.Name }}: confirm the appeal of {{ .Appeal.RoomID }}",
+	Body: `Hello,
+
+Someone has appealed the ban of the room [{{ .Appeal.RoomID }}](https://matrix.to/#/{{ .Appeal.RoomID }}) on [{{ .Public.Name }}]({{ .Public.UI }}) using this email address, a contact of the room, with the following reason:
+
+` + "```" + `
+{{ .Appeal.Reason }}
+` + "```" + `
+
+If it was you, confirm the appeal, so moderators can review it: [{{ .Link }}]({{ .Link }})
+
+The link works until {{ .Appeal.VerifyExpiresAt.Format "2006-01-02 15:04 MST" }}. If it wasn't you, ignore this email, the appeal goes nowhere without the confirmation.`,
+}
+
+// defaultAppealDecision is the appeal decision email, unless the config has one
+var defaultAppealDecision = model.ConfigEmailTemplate{
+	Subject: "{{ .Public.Name }}: the appeal of {{ .Appeal.RoomID }} has been {{ .Appeal.Status }}",
+	Body: `Hello,
+
+Moderators of [{{ .Public.Name }}]({{ .Public.UI }}) have reviewed the appeal of the room [{{ .Appeal.RoomID }}](https://matrix.to/#/{{ .Appeal.RoomID }}), it has been **{{ .Appeal.Status }}**.
+{{ if .Appeal.Decision }}
+The moderators provided the following reason:
+
+` + "```" + `
+{{ .Appeal.Decision }}
+` + "```" + `
+{{ end }}
+{{ if eq .Appeal.Status "accepted" }}The room is unbanned and will be back in the search results after the next indexing.{{ end }}`,
 }
 
 // NewEmail creates new email service
@@ -94,6 +128,55 @@ func (e *Email) SendModReport(message, email string) error {
 	return err
 }
 
+// SendAppealVerification sends the verification link of the appeal to the contact who filed it
+func (e *Email) SendAppealVerification(ctx context.Context, appeal *model.Appeal, link string) error {
+	return e.sendAppeal(ctx, appeal, link, e.cfg.Get().Email.Templates.AppealVerify, defaultAppealVerify, "appeal-verify")
+}
+
+// SendAppealDecision sends moderators' decision on the appeal to the contact who filed it
+func (e *Email) SendAppealDecision(ctx context.Context, appeal *model.Appeal) error {
+	return e.sendAppeal(ctx, appeal, "", e.cfg.Get().Email.Templates.AppealDecision, defaultAppealDecision, "appeal-decision")
+}
+
+// sendAppeal sends an appeal email to the appeal's contact, using the config template or the default one
+func (e *Email) sendAppeal(ctx context.Context, appeal *model.Appeal, link string, tpl, defaultTpl model.ConfigEmailTemplate, tag string) error {
+	log := apm.Log(ctx)
+	client := e.getClient()
+	if client == nil {
+		log.Info().Str("reason", "no sender").Msg("email sending canceled")
+		return nil
+	}
+	if tpl.Subject == "" || tpl.Body == "" {
+		tpl = defaultTpl
+	}
+
+	vars := emailVars{Public: e.cfg.Get().Public, Appeal: appeal, Link: link}
+	subject, err := template.Execute(tpl.Subject, vars)
+	if err != nil {
+		return err
+	}
+	body, err := template.Execute(tpl.Body, vars)
+	if err != nil {
+		return err
+	}
+	text, html := utils.MarkdownRender(body)
+	req := e.buildPMReqs(subject, text, html, []string{appeal.Email}, &e.cfg.Get().Email.Postmark.Report)[0]
+	req.Tag = tag
+	log.Info().Str("to", req.To).Str("room", appeal.RoomID).Msg("sending appeal email")
+	_, _, err = client.Send(req)
+	return err
+}
+
+// SendModAppeal sends appeal email to MRS instance's moderators
+func (e *Email) SendModAppeal(message, email string) error {
+	subject := "New appeal from MRS instance"
+	text, html := utils.MarkdownRender(message)
+	req := e.buildPMReqs(subject, text, html, []string{email}, &e.cfg.Get().Email.Postmark.Report)[0]
+	req.Tag = "appeal-mod"
+	_, _, err := e.getClient().Send(req)
+	return err
+}
+
 // validateConfig checks if all config vars are set
 func (e *Email) validateConfig() bool {
 	cfg := e.cfg.Get().Email
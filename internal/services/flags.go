@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/etkecc/go-apm"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// flagSample is how many matching room IDs the flag rule stats show
+const flagSample = 20
+
+// flagReviewActions settle a room: once a moderator has released or unreported it, flag rules leave it alone
+var flagReviewActions = []string{model.AuditRelease, model.AuditUnreport}
+
+// compiledFlags are the flag rules of a specific config, compiled once per config (re)load
+type compiledFlags struct {
+	cfg   *model.Config
+	rules []*model.FlagRule
+}
+
+// FlagRun is a single pass of the flag rules over the rooms being indexed, see DataFacade.Ingest and DataFacade.RefreshRoom.
+// Every path writing rooms to the index goes through one, except Moderation.Release: a released room is reviewed, rules leave it alone.
+// A nil run has no rules and lets every room through
+type FlagRun struct {
+	m        *Moderation
+	now      time.Time
+	rules    []*model.FlagRule
+	reviewed map[string]bool
+	stats    []*model.FlagStats         // in the order of rules
+	hits     map[string]*model.FlagRule // room ID => the most severe matching rule, dry-run rules excluded
+	checked  int                        // rooms checked, for the retention.max_removal safety valve
+	partial  bool                       // a refresh of a few rooms, it counts into the totals, but the stats of the last indexing stay
+}
+
+// FlagStats returns stats of the flag rules during the last indexing
+func (m *Moderation) FlagStats() []*model.FlagStats {
+	if stats := m.flagStats.Load(); stats != nil {
+		return *stats
+	}
+	return []*model.FlagStats{}
+}
+
+// NewFlagRun starts a pass of the flag rules, nil if there are no rules
+func (m *Moderation) NewFlagRun(ctx context.Context) *FlagRun {
+	rules := m.flagRules(ctx)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	run := &FlagRun{
+		m:        m,
+		now:      time.Now().UTC(),
+		rules:    rules,
+		reviewed: map[string]bool{},
+		stats:    make([]*model.FlagStats, 0, len(rules)),
+		hits:     map[string]*model.FlagRule{},
+	}
+	for _, rule := range rules {
+		run.stats = append(run.stats, &model.FlagStats{Rule: rule.Name, Action: rule.Action, DryRun: rule.DryRun, Sample: []string{}})
+	}
+	for _, action := range flagReviewActions {
+		entries, err := m.data.GetAuditLog(ctx, &model.AuditFilter{Action: action})
+		if err != nil {
+			apm.Log(ctx).Warn().Err(err).Str("action", action).Msg("cannot get reviewed rooms from the audit log")
+			continue
+		}
+		for _, entry := range entries {
+			run.reviewed[entry.Target] = true
+		}
+	}
+	return run
+}
+
+// NewRefreshFlagRun starts a pass of the flag rules over a few refreshed rooms, nil if there are no rules
+func (m *Moderation) NewRefreshFlagRun(ctx context.Context) *FlagRun {
+	run := m.NewFlagRun(ctx)
+	if run != nil {
+		run.partial = true
+	}
+	return run
+}
+
+// flagRules returns the compiled flag rules of the current config, invalid rules are skipped
+func (m *Moderation) flagRules(ctx context.Context) []*model.FlagRule {
+	cfg := m.cfg.Get()
+	if cached := m.flags.Load(); cached != nil && cached.cfg == cfg {
+		return cached.rules
+	}
+
+	log := apm.Log(ctx)
+	rules := make([]*model.FlagRule, 0, len(cfg.Flags))
+	for _, rule := range cfg.Flags {
+		if err := rule.Compile(); err != nil {
+			log.Error().Err(err).Str("rule", rule.Name).Msg("cannot compile flag rule, skipping")
+			continue
+		}
+		if slices.ContainsFunc(rules, func(existing *model.FlagRule) bool { return existing.Name == rule.Name }) {
+			log.Error().Str("rule", rule.Name).Msg("flag rule name is not unique, skipping")
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	m.flags.Store(&compiledFlags{cfg: cfg, rules: rules})
+	return rules
+}
+
+// Check evaluates the rules against the room, returns false if the room must not be indexed
+func (r *FlagRun) Check(room *model.MatrixRoom) bool {
+	if r == nil {
+		return true
+	}
+
+	r.checked++
+	var hit *model.FlagRule
+	for i, rule := range r.rules {
+		if !rule.Match(room, r.now) {
+			continue
+		}
+		stat := r.stats[i]
+		stat.Hits++
+		if len(stat.Sample) < flagSample {
+			stat.Sample = append(stat.Sample, room.ID)
+		}
+		if rule.DryRun {
+			continue
+		}
+		if hit == nil || rule.Severity() > hit.Severity() {
+			hit = rule
+		}
+	}
+	if hit == nil || r.reviewed[room.ID] {
+		return true
+	}
+	r.hits[room.ID] = hit
+	return hit.Action == model.FlagActionFlag
+}
+
+// Apply acts on the matching rooms and publishes the stats, call it once all rooms are checked.
+// Returns IDs of the rooms held back by rules that matched too many of them, see limitFlagRules. They must be indexed after all
+func (r *FlagRun) Apply(ctx context.Context) (released []string) {
+	if r == nil {
+		return nil
+	}
+
+	log := apm.Log(ctx)
+	released = r.limitFlagRules(ctx)
+	ids := make([]string, 0, len(r.hits))
+	for id := range r.hits {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	rejected := []string{}
+	for _, id := range ids {
+		rule := r.hits[id]
+		reason := "matched flag rule " + rule.Name
+		switch rule.Action {
+		case model.FlagActionFlag:
+			_, _, err := r.m.data.ReportRoom(ctx, id, &model.RoomReport{
+				At:       r.now,
+				Category: rule.Category,
+				Reason:   reason,
+				Reporter: model.FlagReporter + rule.Name,
+			})
+			if err != nil {
+				log.Error().Err(err).Str("room", id).Str("rule", rule.Name).Msg("cannot flag room")
+			}
+		case model.FlagActionQuarantine:
+			err := r.m.Quarantine(ctx, &model.RoomQuarantine{RoomID: id, Reason: reason, Moderator: model.FlagReporter + rule.Name})
+			if err != nil {
+				log.Error().Err(err).Str("room", id).Str("rule", rule.Name).Msg("cannot quarantine room")
+			}
+		case model.FlagActionReject:
+			rejected = append(rejected, id)
+		}
+	}
+	r.m.removeRooms(ctx, rejected)
+
+	previous := map[string]*model.FlagStats{}
+	if stats := r.m.flagStats.Load(); stats != nil {
+		for _, stat := range *stats {
+			previous[stat.Rule] = stat
+		}
+	}
+	at := time.Now().UTC()
+	for i, stat := range r.stats {
+		log.Info().Str("rule", stat.Rule).Str("action", stat.Action).Bool("dry_run", stat.DryRun).Bool("skipped", stat.Skipped).Bool("partial", r.partial).Int("hits", stat.Hits).Strs("sample", stat.Sample).Msg("flag rule applied")
+		last, ok := previous[stat.Rule]
+		if !ok {
+			stat.Total = stat.Hits
+			stat.At = at
+			continue
+		}
+		stat.Total = last.Total + stat.Hits
+		if r.partial { // the last indexing's hits and sample stay
+			merged := *last
+			merged.Total = stat.Total
+			r.stats[i] = &merged
+			continue
+		}
+		stat.At = at
+	}
+	r.m.flagStats.Store(&r.stats)
+	return released
+}
+
+// limitFlagRules drops hits of the rules matching more rooms than retention.max_removal allows, the same way policy lists
+// are limited: a rule matching most of the index is a typo, not a spam wave. Returns IDs of the rooms those rules held back.
+// A refresh checks a few rooms only, they are not a share of anything
+func (r *FlagRun) limitFlagRules(ctx context.Context) []string {
+	if r.partial {
+		return nil
+	}
+
+	maxRemoval := r.m.cfg.Get().Retention.GetMaxRemoval()
+	skipped := map[string]bool{}
+	for i, rule := range r.rules {
+		stat := r.stats[i]
+		if rule.DryRun || !exceedsMaxRemoval(stat.Hits, r.checked, maxRemoval) {
+			continue
+		}
+		apm.Log(ctx).Error().
+			Str("rule", rule.Name).
+			Int("count", stat.Hits).
+			Int("of", r.checked).
+			Int("max_removal", maxRemoval).
+			Msg("skipping flag rule, it matches too many rooms")
+		stat.Skipped = true
+		skipped[rule.Name] = true
+	}
+	if len(skipped) == 0 {
+		return nil
+	}
+
+	released := []string{}
+	for id, rule := range r.hits {
+		if !skipped[rule.Name] {
+			continue
+		}
+		delete(r.hits, id)
+		if rule.Action != model.FlagActionFlag { // flagged rooms are indexed already
+			released = append(released, id)
+		}
+	}
+	slices.Sort(released)
+	return released
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// each room gets the most severe action of the rules it matches, dry-run rules only count, and reviewed rooms are left alone.
+func TestFlagRun(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{Retention: &model.ConfigRetention{MaxRemoval: 100}, Flags: []*model.FlagRule{
+		{Name: "crypto", Action: model.FlagActionFlag, Category: model.ReportSpam, Words: []string{"airdrop"}},
+		{Name: "scam", Action: model.FlagActionReject, Words: []string{"seed phrase"}},
+		{Name: "tiny", Action: model.FlagActionQuarantine, DryRun: true, MaxMembers: 2},
+		{Name: "broken", Action: model.FlagActionFlag, Regexes: []string{"(unclosed"}},
+	}})
+	data := NewMockDataRepository(t)
+	index := NewMockIndexRepository(t)
+
+	data.EXPECT().GetAuditLog(mock.Anything, &model.AuditFilter{Action: model.AuditRelease}).Return(nil, nil)
+	data.EXPECT().GetAuditLog(mock.Anything, &model.AuditFilter{Action: model.AuditUnreport}).Return([]*model.AuditEntry{{Target: "!reviewed:host.example"}}, nil)
+	data.EXPECT().ReportRoom(mock.Anything, "!a:host.example", mock.MatchedBy(func(report *model.RoomReport) bool {
+		return report.Category == model.ReportSpam && report.Reporter == "rule:crypto"
+	})).Return(&model.RoomReports{}, true, nil).Once()
+	data.EXPECT().GetRoomMapping(mock.Anything, "!b:host.example").Return("#scam:host.example").Once()
+	data.EXPECT().RemoveRoomMapping(mock.Anything, "!b:host.example", "#scam:host.example").Return().Once()
+	data.EXPECT().RemoveRooms(mock.Anything, []string{"!b:host.example"}).Return().Once()
+	index.EXPECT().Delete("!b:host.example").Return(nil).Once()
+
+	m := NewModeration(cfg, data, nil, index, nil, nil, nil)
+	run := m.NewFlagRun(context.Background())
+	for _, test := range []struct {
+		room  *model.MatrixRoom
+		index bool
+	}{
+		{&model.MatrixRoom{ID: "!a:host.example", Name: "Airdrop", Members: 1}, true},
+		{&model.MatrixRoom{ID: "!b:host.example", Topic: "share your seed phrase, get an airdrop", Members: 100}, false},
+		{&model.MatrixRoom{ID: "!reviewed:host.example", Name: "airdrop", Members: 100}, true},
+		{&model.MatrixRoom{ID: "!c:host.example", Name: "Linux", Members: 100}, true},
+	} {
+		if index := run.Check(test.room); index != test.index {
+			t.Errorf("%s: Check() = %v, want %v", test.room.ID, index, test.index)
+		}
+	}
+	run.Apply(context.Background())
+
+	stats := m.FlagStats()
+	if len(stats) != 3 {
+		t.Fatalf("FlagStats() = %+v, want 3 rules", stats)
+	}
+	for i, want := range []int{3, 1, 1} {
+		if stats[i].Hits != want || stats[i].Total != want {
+			t.Errorf("%s: hits = %d, total = %d, want %d", stats[i].Rule, stats[i].Hits, stats[i].Total, want)
+		}
+	}
+
+	// a refresh counts into the totals, the hits are still of the last indexing
+	refresh := m.NewRefreshFlagRun(context.Background())
+	refresh.Check(&model.MatrixRoom{ID: "!d:host.example", Name: "Linux", Members: 1})
+	refresh.Apply(context.Background())
+	stats = m.FlagStats()
+	if stats[2].Hits != 1 || stats[2].Total != 2 || len(stats[2].Sample) != 1 {
+		t.Errorf("%s after a refresh: %+v, want 1 hit and 2 in total", stats[2].Rule, stats[2])
+	}
+}
+
+// a rule matching too much of the index is a typo: it's skipped, and the rooms it held back are indexed after all.
+func TestFlagRun_MaxRemoval(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{Flags: []*model.FlagRule{
+		{Name: "oops", Action: model.FlagActionReject, MaxMembers: 1000},
+		{Name: "scam", Action: model.FlagActionQuarantine, Words: []string{"seed phrase"}},
+	}})
+	data := NewMockDataRepository(t)
+	data.EXPECT().GetAuditLog(mock.Anything, mock.Anything).Return(nil, nil)
+	// no RemoveRooms and no Quarantine expected: the room of the quarantine rule is held back by the skipped one
+
+	m := NewModeration(cfg, data, nil, nil, nil, nil, nil)
+	run := m.NewFlagRun(context.Background())
+	run.Check(&model.MatrixRoom{ID: "!a:host.example", Members: 10})
+	run.Check(&model.MatrixRoom{ID: "!b:host.example", Members: 20})
+	run.Check(&model.MatrixRoom{ID: "!c:host.example", Members: 5000})
+	released := run.Apply(context.Background())
+
+	if len(released) != 2 || released[0] != "!a:host.example" || released[1] != "!b:host.example" {
+		t.Errorf("Apply() = %v, want the rooms of the skipped rule", released)
+	}
+	if stats := m.FlagStats(); !stats[0].Skipped || stats[0].Hits != 2 || stats[1].Skipped {
+		t.Errorf("FlagStats() = %+v, want the broad rule skipped", stats)
+	}
+}
@@ -53,6 +53,16 @@ func (i *Index) RoomsBatch(ctx context.Context, roomID string, data *model.Entry
 	return i.batch.Index(roomID, data)
 }
 
+// IndexRoom indexes (or re-indexes) a single room right away
+func (i *Index) IndexRoom(roomID string, data *model.Entry) error {
+	return i.index.Index(roomID, data)
+}
+
+// DeleteRoom removes a single room from the index right away
+func (i *Index) DeleteRoom(roomID string) error {
+	return i.index.Delete(roomID)
+}
+
 // IndexBatch performs indexing of the current batch
 func (i *Index) IndexBatch(ctx context.Context) error {
 	log := apm.Log(ctx)
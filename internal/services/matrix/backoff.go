@@ -0,0 +1,81 @@
+package matrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/etkecc/mrs/internal/metrics"
+)
+
+const (
+	// backoffMin is the window after the first failure, doubled on each consecutive one up to backoffMax.
+	// The HTTP client already spent three patient attempts before a call counts as failed, so one is enough to back off.
+	backoffMin = time.Minute
+	backoffMax = 12 * time.Hour
+	// backoffTTL forgets a destination nobody has tried for a while, so a server that was dead last week starts clean
+	backoffTTL = 2 * backoffMax
+)
+
+// errBackoff is returned instead of knocking on a destination that failed recently
+var errBackoff = errors.New("destination is backing off")
+
+// destinationBackoff is the failure streak of a single destination, shared by every outgoing federation call
+type destinationBackoff struct {
+	failures int
+	until    time.Time
+}
+
+// backoffCheck short-circuits calls to a destination within its backoff window
+func (s *Server) backoffCheck(serverName, call string) error {
+	s.backoffMu.Lock()
+	defer s.backoffMu.Unlock()
+
+	state, ok := s.backoffCache.Get(serverName)
+	if !ok || time.Now().After(state.until) {
+		return nil
+	}
+	metrics.IncFederationBackoff(call)
+	return fmt.Errorf("%w: %s for %s after %d failures", errBackoff, serverName, time.Until(state.until).Round(time.Second), state.failures)
+}
+
+// backoffRecord feeds the call result into the destination's backoff: transport errors and 5xx widen the window,
+// anything else (a 404 or a matrix error included) proves the destination is alive and clears it
+func (s *Server) backoffRecord(ctx context.Context, serverName, call string, resp *http.Response, err error) {
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return // we gave up, not them. a deadline is still on them
+	}
+
+	s.backoffMu.Lock()
+	defer s.backoffMu.Unlock()
+
+	if err == nil && resp != nil && resp.StatusCode < http.StatusInternalServerError {
+		s.backoffCache.Remove(serverName)
+		return
+	}
+
+	state, _ := s.backoffCache.Get(serverName)
+	state.failures++
+	state.until = time.Now().Add(backoffWindow(state.failures))
+	s.backoffCache.Add(serverName, state)
+	metrics.IncFederationFailure(call)
+}
+
+// backoffWindow is backoffMin * 2^(failures-1), capped at backoffMax
+func backoffWindow(failures int) time.Duration {
+	window := backoffMin
+	for i := 1; i < failures && window < backoffMax; i++ {
+		window *= 2
+	}
+	return min(window, backoffMax)
+}
+
+// ResetBackoff forgets the destination's failures, so the next call goes through. Returns false if there was nothing to forget
+func (s *Server) ResetBackoff(serverName string) bool {
+	s.backoffMu.Lock()
+	defer s.backoffMu.Unlock()
+
+	return s.backoffCache.Remove(serverName)
+}
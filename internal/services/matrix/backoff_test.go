@@ -0,0 +1,82 @@
+package matrix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+func newBackoffTestServer() *Server {
+	return &Server{backoffCache: expirable.NewLRU[string, destinationBackoff](100, nil, time.Hour)}
+}
+
+// the schedule is the feature: an off-by-one doubles every window, a missing cap writes a server off for weeks.
+func TestBackoffWindow(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{5, 16 * time.Minute},
+		{10, 512 * time.Minute},
+		{11, backoffMax},
+		{1000, backoffMax},
+	}
+	for _, tt := range tests {
+		if got := backoffWindow(tt.failures); got != tt.want {
+			t.Errorf("backoffWindow(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+// one failing call puts the destination on hold for every call, a living answer (even a 404) and an admin reset both clear it,
+// and a caller cancelling its own request is never held against the destination.
+func TestBackoff_sharedAcrossCalls(t *testing.T) {
+	ctx := context.Background()
+	s := newBackoffTestServer()
+	const dest = "dead.example"
+
+	s.backoffRecord(ctx, dest, "version", nil, errors.New("connection refused"))
+	for _, call := range []string{"version", "publicRooms", "directory", "keys", "thumbnail"} {
+		if err := s.backoffCheck(dest, call); !errors.Is(err, errBackoff) {
+			t.Errorf("%s: expected a short-circuit, got %v", call, err)
+		}
+	}
+
+	s.backoffCache.Add(dest, destinationBackoff{failures: 1, until: time.Now().Add(-time.Second)}) // window elapsed
+	if err := s.backoffCheck(dest, "version"); err != nil {
+		t.Errorf("an elapsed window must let the call through, got %v", err)
+	}
+	s.backoffRecord(ctx, dest, "version", &http.Response{StatusCode: http.StatusBadGateway}, nil)
+	if state, _ := s.backoffCache.Get(dest); state.failures != 2 {
+		t.Errorf("a 5xx must extend the streak, got %d failures", state.failures)
+	}
+
+	s.backoffRecord(ctx, dest, "directory", &http.Response{StatusCode: http.StatusNotFound}, nil)
+	if err := s.backoffCheck(dest, "version"); err != nil {
+		t.Errorf("a 404 proves the destination alive and must clear the backoff, got %v", err)
+	}
+
+	s.backoffRecord(ctx, dest, "version", nil, errors.New("timeout"))
+	if !s.ResetBackoff(dest) {
+		t.Error("reset must report the destination was backing off")
+	}
+	if err := s.backoffCheck(dest, "version"); err != nil {
+		t.Errorf("reset must clear the backoff, got %v", err)
+	}
+	if s.ResetBackoff(dest) {
+		t.Error("reset of an untracked destination must report false")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	s.backoffRecord(cancelled, dest, "version", nil, context.Canceled)
+	if err := s.backoffCheck(dest, "version"); err != nil {
+		t.Errorf("our own cancellation must not back the destination off, got %v", err)
+	}
+}
@@ -75,6 +75,16 @@ func (s *Server) GetClientDirectory(ctx context.Context, alias string) (statusCo
 
 // GetClientRoomSummary is /_matrix/client/unstable/is.nheko.summary/summary/{roomIdOrAlias}
 func (s *Server) GetClientRoomSummary(ctx context.Context, aliasOrID, via string, onlyMSC3266 bool) (statusCode int, directoryRoom *model.RoomDirectoryRoom) {
+	return s.getRoomSummary(ctx, aliasOrID, via, onlyMSC3266, false)
+}
+
+// GetAdminRoomSummary returns the MSC3266 room summary like GetClientRoomSummary does, quarantined rooms included.
+// Admin paths only: quarantine hides a room from the public, not from the ones who have to look at it
+func (s *Server) GetAdminRoomSummary(ctx context.Context, aliasOrID, via string) (statusCode int, directoryRoom *model.RoomDirectoryRoom) {
+	return s.getRoomSummary(ctx, aliasOrID, via, true, true)
+}
+
+func (s *Server) getRoomSummary(ctx context.Context, aliasOrID, via string, onlyMSC3266, withQuarantined bool) (statusCode int, directoryRoom *model.RoomDirectoryRoom) {
 	log := apm.Log(ctx)
 	aliasOrID = utils.Unescape(aliasOrID)
 	log.Info().Str("aliasOrID", aliasOrID).Str("origin", "client").Msg("getting room summary")
@@ -102,6 +112,10 @@ func (s *Server) GetClientRoomSummary(ctx context.Context, aliasOrID, via string
 		log.Warn().Msg("attempting to get summary of a banned room")
 		return http.StatusNotFound, nil
 	}
+	if !withQuarantined && s.data.IsQuarantined(ctx, entry.ID) {
+		log.Warn().Msg("attempting to get summary of a quarantined room")
+		return http.StatusNotFound, nil
+	}
 
 	servers := kit.Uniq([]string{
 		utils.ServerFrom(entry.ID),
@@ -117,7 +131,7 @@ func (s *Server) GetClientRoomSummary(ctx context.Context, aliasOrID, via string
 
 // GetClientRoomVisibility is /_matrix/client/v3/directory/list/room/{roomID}
 // MRS indexes only public rooms, so a room we hold is public by definition; a room we do not
-// hold (or have banned) is a 404 per spec, not a blanket "public" for the whole ID space.
+// hold (or have banned or quarantined) is a 404 per spec, not a blanket "public" for the whole ID space.
 func (s *Server) GetClientRoomVisibility(ctx context.Context, roomID string) (statusCode int, resp []byte) {
 	roomID = utils.Unescape(roomID)
 	if roomID == "" {
@@ -14,10 +14,11 @@ import (
 )
 
 // fakeVisibilityData is a minimal dataRepository: enough to drive GetClientRoomVisibility's
-// found / not-found / banned branches without dragging in a real store.
+// found / not-found / banned / quarantined branches without dragging in a real store.
 type fakeVisibilityData struct {
-	room   *model.MatrixRoom
-	banned bool
+	room        *model.MatrixRoom
+	banned      bool
+	quarantined bool
 }
 
 func (f *fakeVisibilityData) GetRoom(context.Context, string) (*model.MatrixRoom, error) {
@@ -25,6 +26,16 @@ func (f *fakeVisibilityData) GetRoom(context.Context, string) (*model.MatrixRoom
 }
 func (f *fakeVisibilityData) GetRoomMapping(context.Context, string) string { return "" }
 func (f *fakeVisibilityData) IsBanned(context.Context, string) bool         { return f.banned }
+func (f *fakeVisibilityData) IsQuarantined(context.Context, string) bool    { return f.quarantined }
+func (f *fakeVisibilityData) GetSpaceChildren(context.Context, string) ([]*model.MatrixSpaceChild, error) {
+	return nil, nil
+}
+func (f *fakeVisibilityData) FilterServers(context.Context, func(*model.MatrixServer) bool) map[string]*model.MatrixServer {
+	return nil
+}
+func (f *fakeVisibilityData) GetRoomsParsedSince(context.Context, time.Time, string, int) ([]*model.MatrixRoom, string, error) {
+	return nil, "", nil
+}
 
 // TestGetClientRoomVisibility pins the spec fix: MRS holds only public rooms, so a room we have is
 // "public" (200), and anything we never crawled or have banned is a 404, not a blanket "public".
@@ -39,6 +50,7 @@ func TestGetClientRoomVisibility(t *testing.T) {
 		{"empty id", "", &fakeVisibilityData{}, http.StatusBadRequest},
 		{"unknown room", roomID, &fakeVisibilityData{room: nil}, http.StatusNotFound},
 		{"banned room", roomID, &fakeVisibilityData{room: &model.MatrixRoom{ID: roomID}, banned: true}, http.StatusNotFound},
+		{"quarantined room", roomID, &fakeVisibilityData{room: &model.MatrixRoom{ID: roomID}, quarantined: true}, http.StatusNotFound},
 		{"indexed public room", roomID, &fakeVisibilityData{room: &model.MatrixRoom{ID: roomID}}, http.StatusOK},
 	}
 	for _, tc := range cases {
@@ -52,6 +64,23 @@ func TestGetClientRoomVisibility(t *testing.T) {
 	}
 }
 
+// a quarantined room keeps its data, but neither the summary nor the alias directory hands it out.
+func TestQuarantinedRoom_notServed(t *testing.T) {
+	room := &model.MatrixRoom{ID: "!room:example.org", Alias: "#room:example.org"}
+	s := &Server{data: &fakeVisibilityData{room: room, quarantined: true}}
+	if entry := s.roomSummaryDirect(context.Background(), room.ID); entry != nil {
+		t.Errorf("roomSummaryDirect() = %+v, want nil", entry)
+	}
+	if status, _ := s.GetClientDirectory(context.Background(), room.Alias); status != http.StatusNotFound {
+		t.Errorf("GetClientDirectory() status = %d, want %d", status, http.StatusNotFound)
+	}
+
+	s = &Server{data: &fakeVisibilityData{room: room}}
+	if entry := s.roomSummaryDirect(context.Background(), room.ID); entry == nil || entry.ID != room.ID {
+		t.Errorf("roomSummaryDirect() of a released room = %+v", entry)
+	}
+}
+
 // TestRoomSummaryFallback_rejectsIPLiteralVia checks the early reject catches the canonical IP-literal forms
 // of via before any resolve or dial, and never caches them. Disguised forms (decimal/octal/zone) pass this
 // check by design and are refused later by the shared dial guard, which is the real authority.
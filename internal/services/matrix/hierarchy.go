@@ -0,0 +1,136 @@
+package matrix
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/etkecc/go-apm"
+	"github.com/etkecc/go-kit"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/model/mcontext"
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// SpaceHierarchy returns /_matrix/federation/v1/hierarchy/{roomID} response, built from the crawled space relations.
+// Children are paginated with limit and from (MRS extension, the spec'd endpoint returns everything at once)
+func (s *Server) SpaceHierarchy(ctx context.Context, req *http.Request, roomID string, suggestedOnly bool, limit int, from string) (statusCode int, resp []byte) {
+	log := apm.Log(ctx)
+	origin, err := s.ValidateAuth(ctx, req)
+	if err != nil {
+		log.Warn().Err(err).Msg("matrix auth failed")
+		return http.StatusUnauthorized, s.getErrorResp(ctx, "M_UNAUTHORIZED", "authorization failed")
+	}
+	ctx = mcontext.WithOrigin(ctx, origin)
+	roomID = utils.Unescape(roomID)
+	log.Info().Str("room_id", roomID).Str("origin", origin).Bool("suggested_only", suggestedOnly).Msg("querying space hierarchy")
+
+	space := s.getHierarchyRoom(ctx, roomID)
+	if space == nil || space.RoomType != "m.space" {
+		return http.StatusNotFound, s.getErrorResp(ctx, "M_NOT_FOUND", "space not found")
+	}
+	children, err := s.getSpaceChildren(ctx, roomID, suggestedOnly)
+	if err != nil {
+		log.Error().Err(err).Msg("cannot get space children from data store")
+		return http.StatusInternalServerError, nil
+	}
+
+	if limit <= 0 || limit > MatrixSearchLimit {
+		limit = MatrixSearchLimit
+	}
+	offset := min(max(0, kit.StringToInt(from)), len(children))
+	page := children[offset:min(offset+limit, len(children))]
+
+	hierarchy := &model.SpaceHierarchyResponse{
+		Room:                 s.toHierarchyRoom(space, children),
+		Children:             []*model.SpaceHierarchyRoom{},
+		InaccessibleChildren: []string{},
+	}
+	for _, child := range page {
+		room := s.getHierarchyRoom(ctx, child.ID)
+		if room == nil {
+			hierarchy.InaccessibleChildren = append(hierarchy.InaccessibleChildren, child.ID)
+			continue
+		}
+		var grandchildren []*model.MatrixSpaceChild
+		if room.RoomType == "m.space" {
+			grandchildren, err = s.getSpaceChildren(ctx, room.ID, suggestedOnly)
+			if err != nil {
+				log.Warn().Err(err).Str("room_id", room.ID).Msg("cannot get space children from data store")
+			}
+		}
+		hierarchy.Children = append(hierarchy.Children, s.toHierarchyRoom(room, grandchildren))
+	}
+	if next := offset + len(page); next < len(children) {
+		hierarchy.NextBatch = strconv.Itoa(next)
+	}
+
+	value, err := utils.JSON(hierarchy)
+	if err != nil {
+		log.Error().Err(err).Msg("cannot marshal space hierarchy json")
+		return http.StatusInternalServerError, nil
+	}
+	return http.StatusOK, value
+}
+
+// getHierarchyRoom returns an indexed room, nil if it's unknown, banned, quarantined, or its server is blocked
+func (s *Server) getHierarchyRoom(ctx context.Context, roomID string) *model.MatrixRoom {
+	if roomID == "" || s.data.IsBanned(ctx, roomID) || s.data.IsQuarantined(ctx, roomID) {
+		return nil
+	}
+	room, err := s.data.GetRoom(ctx, roomID)
+	if err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("room_id", roomID).Msg("cannot get room from data store")
+		return nil
+	}
+	if room == nil || s.blocklist.ByServer(room.GetOwnServer()) {
+		return nil
+	}
+	return room
+}
+
+// getSpaceChildren returns the space's children in the spec'd order: by order (if set), then by timestamp, then by ID.
+// Banned and quarantined children are left out entirely, so their IDs don't leak through children_state or inaccessible_children
+func (s *Server) getSpaceChildren(ctx context.Context, spaceID string, suggestedOnly bool) ([]*model.MatrixSpaceChild, error) {
+	all, err := s.data.GetSpaceChildren(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	children := make([]*model.MatrixSpaceChild, 0, len(all))
+	for _, child := range all {
+		if suggestedOnly && !child.Suggested {
+			continue
+		}
+		if s.data.IsBanned(ctx, child.ID) || s.data.IsQuarantined(ctx, child.ID) {
+			continue
+		}
+		children = append(children, child)
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		a, b := children[i], children[j]
+		if (a.Order == "") != (b.Order == "") {
+			return a.Order != "" // ordered children go first
+		}
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		if a.OriginServerTS != b.OriginServerTS {
+			return a.OriginServerTS < b.OriginServerTS
+		}
+		return a.ID < b.ID
+	})
+	return children, nil
+}
+
+func (s *Server) toHierarchyRoom(room *model.MatrixRoom, children []*model.MatrixSpaceChild) *model.SpaceHierarchyRoom {
+	state := make([]*model.SpaceChildEvent, 0, len(children))
+	for _, child := range children {
+		state = append(state, child.Event())
+	}
+	return &model.SpaceHierarchyRoom{
+		RoomDirectoryRoom: *room.DirectoryEntry(),
+		ChildrenState:     state,
+	}
+}
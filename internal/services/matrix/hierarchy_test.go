@@ -0,0 +1,89 @@
+package matrix
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// fakeSpaceData serves children of a single space, the rest of dataRepository is never touched here
+type fakeSpaceData struct {
+	fakeVisibilityData
+	children []*model.MatrixSpaceChild
+	hidden   map[string]bool // banned or quarantined room IDs
+}
+
+func (f *fakeSpaceData) IsBanned(_ context.Context, roomID string) bool      { return f.hidden[roomID] }
+func (f *fakeSpaceData) IsQuarantined(_ context.Context, roomID string) bool { return f.hidden[roomID] }
+
+func (f *fakeSpaceData) GetSpaceChildren(context.Context, string) ([]*model.MatrixSpaceChild, error) {
+	return f.children, nil
+}
+
+// the spec'd order is what clients render: explicit order first, then oldest, then by ID; suggested_only narrows before sorting.
+func TestGetSpaceChildren_orderAndSuggested(t *testing.T) {
+	data := &fakeSpaceData{children: []*model.MatrixSpaceChild{
+		{ID: "!c:example.org", OriginServerTS: 1},
+		{ID: "!b:example.org", OriginServerTS: 1, Suggested: true},
+		{ID: "!z:example.org", Order: "b"},
+		{ID: "!a:example.org", OriginServerTS: 2, Suggested: true},
+		{ID: "!y:example.org", Order: "a", Suggested: true},
+	}}
+	s := &Server{data: data}
+
+	tests := []struct {
+		name          string
+		suggestedOnly bool
+		want          []string
+	}{
+		{"all", false, []string{"!y:example.org", "!z:example.org", "!b:example.org", "!c:example.org", "!a:example.org"}},
+		{"suggested only", true, []string{"!y:example.org", "!b:example.org", "!a:example.org"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			children, err := s.getSpaceChildren(context.Background(), "!space:example.org", tt.suggestedOnly)
+			if err != nil {
+				t.Fatalf("getSpaceChildren: %v", err)
+			}
+			got := make([]string, 0, len(children))
+			for _, child := range children {
+				got = append(got, child.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// a quarantined space or child is left out of the hierarchy, as if it was never crawled.
+func TestGetHierarchyRoom_quarantined(t *testing.T) {
+	s := &Server{data: &fakeVisibilityData{room: &model.MatrixRoom{ID: "!space:example.org"}, quarantined: true}}
+	if room := s.getHierarchyRoom(context.Background(), "!space:example.org"); room != nil {
+		t.Errorf("getHierarchyRoom() = %+v, want nil", room)
+	}
+}
+
+// banned and quarantined children don't show up anywhere, not even as bare IDs in children_state.
+func TestGetSpaceChildren_hidden(t *testing.T) {
+	data := &fakeSpaceData{
+		children: []*model.MatrixSpaceChild{{ID: "!a:example.org"}, {ID: "!banned:example.org"}, {ID: "!b:example.org"}},
+		hidden:   map[string]bool{"!banned:example.org": true},
+	}
+	s := &Server{data: data}
+
+	children, err := s.getSpaceChildren(context.Background(), "!space:example.org", false)
+	if err != nil {
+		t.Fatalf("getSpaceChildren: %v", err)
+	}
+	state := s.toHierarchyRoom(&model.MatrixRoom{ID: "!space:example.org"}, children).ChildrenState
+	got := make([]string, 0, len(state))
+	for _, event := range state {
+		got = append(got, event.StateKey)
+	}
+	if want := []string{"!a:example.org", "!b:example.org"}; !slices.Equal(got, want) {
+		t.Errorf("children_state = %v, want %v", got, want)
+	}
+}
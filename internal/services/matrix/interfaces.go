@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/etkecc/mrs/internal/model"
 )
@@ -14,7 +15,7 @@ type configService interface {
 }
 
 type searchService interface {
-	Search(ctx context.Context, req *http.Request, query, sortBy string, roomTypes []string, limit, offset int) ([]*model.Entry, int, error)
+	Search(ctx context.Context, req *http.Request, query, network, sortBy string, roomTypes []string, limit, offset int) ([]*model.Entry, int, error)
 }
 
 type mediaService interface {
@@ -30,4 +31,8 @@ type dataRepository interface {
 	GetRoom(ctx context.Context, roomID string) (*model.MatrixRoom, error)
 	GetRoomMapping(ctx context.Context, roomIDorAlias string) string
 	IsBanned(ctx context.Context, roomID string) bool
+	IsQuarantined(ctx context.Context, roomID string) bool
+	GetSpaceChildren(ctx context.Context, spaceID string) ([]*model.MatrixSpaceChild, error)
+	FilterServers(ctx context.Context, filter func(server *model.MatrixServer) bool) map[string]*model.MatrixServer
+	GetRoomsParsedSince(ctx context.Context, since time.Time, from string, limit int) ([]*model.MatrixRoom, string, error)
 }
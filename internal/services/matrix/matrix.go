@@ -2,6 +2,7 @@ package matrix
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/golang-lru/v2/expirable"
@@ -39,6 +40,8 @@ type Server struct {
 	keysCache          *expirable.LRU[string, model.ServerKeys]
 	namesCache         *expirable.LRU[string, string]
 	namesNegativeCache *expirable.LRU[string, struct{}] // recently-failed name lookups, short-TTL'd
+	backoffMu          sync.Mutex
+	backoffCache       *expirable.LRU[string, destinationBackoff] // per-destination failure streaks, see backoff.go
 }
 
 // NewServer creates new matrix server
@@ -48,6 +51,7 @@ func NewServer(cfg configService, data dataRepository, media mediaService, searc
 	surlsCache := expirable.NewLRU[string, string](100000, nil, cacheTTL)
 	curlsCache := expirable.NewLRU[string, string](100000, nil, cacheTTL)
 	namesNegativeCache := expirable.NewLRU[string, struct{}](100000, nil, namesNegativeCacheTTL)
+	backoffCache := expirable.NewLRU[string, destinationBackoff](100000, nil, backoffTTL)
 
 	s := &Server{
 		cfg:                cfg,
@@ -60,6 +64,7 @@ func NewServer(cfg configService, data dataRepository, media mediaService, searc
 		keysCache:          keysCache,
 		namesCache:         namesCache,
 		namesNegativeCache: namesNegativeCache,
+		backoffCache:       backoffCache,
 	}
 	// bound concurrent fire-and-forget discovery spawns to the configured worker count (floor 1)
 	s.discoverSem = make(chan struct{}, max(1, cfg.Get().Workers.Discovery))
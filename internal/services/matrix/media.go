@@ -27,6 +27,10 @@ func (s *Server) GetMediaThumbnail(ctx context.Context, serverName, mediaID stri
 		return content, contentType
 	}
 
+	if err := s.backoffCheck(serverName, "thumbnail"); err != nil {
+		log.Info().Err(err).Str("server", serverName).Str("mediaID", mediaID).Msg("skipping media thumbnail")
+		return nil, ""
+	}
 	ctx, serverURL, serverHost := s.getURL(ctx, serverName, false)
 	if serverURL == "" {
 		log.Warn().Str("server", serverName).Msg("cannot get server URL")
@@ -59,6 +63,7 @@ func (s *Server) GetMediaThumbnail(ctx context.Context, serverName, mediaID stri
 	req.Header.Set("User-Agent", version.UserAgent)
 
 	resp, err := utils.Do(req)
+	s.backoffRecord(ctx, serverName, "thumbnail", resp, err)
 	if err != nil {
 		log.Warn().Err(err).Str("server", serverName).Str("mediaID", mediaID).Msg("cannot get media thumbnail")
 		return nil, ""
@@ -50,6 +50,32 @@ func (s *Server) parseErrorResp(status string, body []byte) *model.MatrixError {
 	return merr
 }
 
+// parseRateLimitResp makes a 429 an M_LIMIT_EXCEEDED no matter what the body said (or didn't),
+// and fills retry_after_ms from the Retry-After header when the body left it out
+func (s *Server) parseRateLimitResp(resp *http.Response, merr *model.MatrixError) *model.MatrixError {
+	if merr == nil {
+		merr = &model.MatrixError{HTTP: resp.Status, Code: "M_LIMIT_EXCEEDED", Message: "too many requests"}
+	}
+	if merr.RetryAfterMs <= 0 {
+		merr.RetryAfterMs = parseRetryAfter(resp.Header.Get("Retry-After")).Milliseconds()
+	}
+	return merr
+}
+
+// parseRetryAfter reads Retry-After in both delta-seconds and HTTP-date forms, 0 if absent or already past
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return max(0, time.Duration(secs)*time.Second)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(0, time.Until(at))
+	}
+	return 0
+}
+
 // parseClientWellKnown returns URL of the Matrix CS API server
 func (s *Server) parseClientWellKnown(ctx context.Context, serverName string) (string, error) {
 	resp, err := utils.Get(ctx, "https://"+serverName+"/.well-known/matrix/client")
@@ -274,6 +300,9 @@ func (s *Server) getURLFromWK(ctx context.Context, serverName string, discover b
 // lookupKeys requests /_matrix/key/v2/server by serverName
 func (s *Server) lookupKeys(ctx context.Context, serverName string, discover bool) (*model.ServerKeys, error) {
 	log := apm.Log(ctx).With().Str("server", serverName).Logger()
+	if err := s.backoffCheck(serverName, "keys"); err != nil {
+		return nil, err
+	}
 
 	ctx, serverURL, serverHost := s.getURL(ctx, serverName, discover)
 	keysURL, err := url.Parse(serverURL + "/_matrix/key/v2/server")
@@ -282,6 +311,7 @@ func (s *Server) lookupKeys(ctx context.Context, serverName string, discover boo
 		return nil, err
 	}
 	resp, err := utils.Get(ctx, keysURL.String(), serverHost)
+	s.backoffRecord(ctx, serverName, "keys", resp, err)
 	if err != nil {
 		log.Warn().Err(err).Msg("failed to get keys")
 		return nil, err
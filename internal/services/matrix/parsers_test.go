@@ -2,11 +2,14 @@ package matrix
 
 import (
 	"context"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	"github.com/etkecc/mrs/internal/model"
 )
 
 func newCacheTestServer() *Server {
@@ -82,3 +85,46 @@ func TestGetURL_staleTwoPartEntry_treatedAsMiss(t *testing.T) {
 		t.Errorf("stale entry must be re-cached in 3-part format, got %d parts: %q", len(parts), cached)
 	}
 }
+
+// a bare 429 with only a header must still come out as M_LIMIT_EXCEEDED with the wait on it,
+// otherwise the crawler reads it as a plain failure and shrugs instead of backing off.
+func TestParseRateLimitResp_headerOnly(t *testing.T) {
+	s := newCacheTestServer()
+	resp := &http.Response{Status: "429 Too Many Requests", StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "120")
+
+	merr := s.parseRateLimitResp(resp, nil)
+	if merr.Code != "M_LIMIT_EXCEEDED" {
+		t.Errorf("code: got %q", merr.Code)
+	}
+	if merr.RetryAfterMs != 120000 {
+		t.Errorf("retry_after_ms: got %d, want 120000", merr.RetryAfterMs)
+	}
+
+	// the body's own retry_after_ms wins over the header
+	merr = s.parseRateLimitResp(resp, &model.MatrixError{Code: "M_LIMIT_EXCEEDED", RetryAfterMs: 5000})
+	if merr.RetryAfterMs != 5000 {
+		t.Errorf("body retry_after_ms overwritten: got %d", merr.RetryAfterMs)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{"-5", 0},
+		{"garbage", 0},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0}, // already past
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+	if got := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)); got < 58*time.Minute || got > time.Hour {
+		t.Errorf("HTTP-date form: got %v, want ~1h", got)
+	}
+}
@@ -0,0 +1,187 @@
+package matrix
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/etkecc/go-apm"
+	"github.com/goccy/go-json"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/utils"
+	"github.com/etkecc/mrs/internal/version"
+)
+
+const (
+	// PeeringServersPath is the MRS peering endpoint serving the instance's catalog of online servers
+	PeeringServersPath = "/_matrix/federation/unstable/cc.etke.mrs/peering/servers"
+	// PeeringRoomsPath is the MRS peering endpoint serving rooms the instance has parsed since the given time
+	PeeringRoomsPath = "/_matrix/federation/unstable/cc.etke.mrs/peering/rooms"
+	// PeeringRoomsLimit is the max rooms per page of the peering rooms feed
+	PeeringRoomsLimit = 1000
+)
+
+// PeerServers returns the peering servers feed: online servers, except blocked ones
+func (s *Server) PeerServers(ctx context.Context, req *http.Request) (statusCode int, resp []byte) {
+	if statusCode, resp := s.authorizePeer(ctx, req); statusCode != http.StatusOK {
+		return statusCode, resp
+	}
+
+	servers := []string{}
+	for name := range s.data.FilterServers(ctx, func(server *model.MatrixServer) bool { return server.Online }) {
+		if s.blocklist.ByServer(name) {
+			continue
+		}
+		servers = append(servers, name)
+	}
+	value, err := utils.JSON(&model.PeerServersResponse{Servers: servers})
+	if err != nil {
+		apm.Log(ctx).Error().Err(err).Msg("cannot marshal peering servers json")
+		return http.StatusInternalServerError, nil
+	}
+	return http.StatusOK, value
+}
+
+// PeerRooms returns the peering rooms feed: a page of rooms parsed after since (unix ms), except banned and blocked ones
+func (s *Server) PeerRooms(ctx context.Context, req *http.Request, since int64, from string, limit int) (statusCode int, resp []byte) {
+	if statusCode, resp := s.authorizePeer(ctx, req); statusCode != http.StatusOK {
+		return statusCode, resp
+	}
+	if limit <= 0 || limit > PeeringRoomsLimit {
+		limit = PeeringRoomsLimit
+	}
+
+	log := apm.Log(ctx)
+	rooms, next, err := s.data.GetRoomsParsedSince(ctx, time.UnixMilli(since).UTC(), from, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("cannot get rooms from data store")
+		return http.StatusInternalServerError, nil
+	}
+	feed := &model.PeerRoomsResponse{Rooms: make([]*model.MatrixRoom, 0, len(rooms)), NextBatch: next}
+	for _, room := range rooms {
+		if s.blocklist.ByServer(room.Server) {
+			continue
+		}
+		feed.Rooms = append(feed.Rooms, room)
+	}
+	value, err := utils.JSON(feed)
+	if err != nil {
+		log.Error().Err(err).Msg("cannot marshal peering rooms json")
+		return http.StatusInternalServerError, nil
+	}
+	return http.StatusOK, value
+}
+
+// authorizePeer checks the request is signed by an instance allowed to pull from this one
+func (s *Server) authorizePeer(ctx context.Context, req *http.Request) (statusCode int, resp []byte) {
+	log := apm.Log(ctx)
+	origin, err := s.ValidateAuth(ctx, req)
+	if err != nil {
+		log.Warn().Err(err).Msg("matrix auth failed")
+		return http.StatusUnauthorized, s.getErrorResp(ctx, "M_UNAUTHORIZED", "authorization failed")
+	}
+	if !s.cfg.Get().Peering.IsAllowed(origin) {
+		log.Warn().Str("origin", origin).Msg("peering request from a server that is not allowed")
+		return http.StatusForbidden, s.getErrorResp(ctx, "M_FORBIDDEN", "peering is not allowed")
+	}
+	log.Info().Str("origin", origin).Str("path", req.URL.Path).Msg("peering request")
+	return http.StatusOK, nil
+}
+
+// QueryPeerServers returns the peer's catalog of online servers
+func (s *Server) QueryPeerServers(ctx context.Context, serverName string) ([]string, error) {
+	var resp *model.PeerServersResponse
+	if err := s.queryPeer(ctx, serverName, PeeringServersPath, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("cannot get peer servers: empty response")
+	}
+	return resp.Servers, nil
+}
+
+// QueryPeerRooms returns a page of rooms the peer has parsed after since, from is the previous page's next_batch
+func (s *Server) QueryPeerRooms(ctx context.Context, serverName string, since time.Time, from string) (*model.PeerRoomsResponse, error) {
+	query := url.Values{}
+	query.Set("since", strconv.FormatInt(since.UnixMilli(), 10))
+	if from != "" {
+		query.Set("from", from)
+	}
+	var resp *model.PeerRoomsResponse
+	if err := s.queryPeer(ctx, serverName, PeeringRoomsPath, query, &resp); err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("cannot get peer rooms: empty response")
+	}
+	return resp, nil
+}
+
+// queryPeer sends a signed peering request and unmarshals the response into result
+func (s *Server) queryPeer(ctx context.Context, serverName, endpoint string, query url.Values, result any) error {
+	if err := s.backoffCheck(serverName, "peering"); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, utils.DefaultTimeout)
+	defer cancel()
+	req, err := s.buildPeeringReq(ctx, serverName, endpoint, query)
+	if err != nil {
+		return err
+	}
+
+	resp, err := utils.SlowHTTPClient.Do(req)
+	s.backoffRecord(ctx, serverName, "peering", resp, err)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		if merr := s.parseErrorResp(resp.Status, body); merr != nil {
+			return merr
+		}
+		return fmt.Errorf("cannot query peer: %s", resp.Status)
+	}
+	return json.Unmarshal(body, result)
+}
+
+func (s *Server) buildPeeringReq(ctx context.Context, serverName, endpoint string, query url.Values) (*http.Request, error) {
+	ctx, apiURLStr, apiURLHost := s.getURL(ctx, serverName, false)
+	apiURL, err := url.Parse(apiURLStr)
+	if err != nil {
+		return nil, err
+	}
+	apiURL = apiURL.JoinPath(endpoint)
+	apiURL.RawQuery = query.Encode()
+
+	path := "/" + apiURL.EscapedPath()
+	if apiURL.RawQuery != "" {
+		path += "?" + apiURL.RawQuery
+	}
+	authHeaders, err := s.Authorize(serverName, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if apiURLHost != "" {
+		req.Host = apiURLHost
+	}
+	for _, h := range authHeaders {
+		req.Header.Add("Authorization", h)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", version.UserAgent)
+	return req, nil
+}
@@ -0,0 +1,34 @@
+package matrix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// a valid signature is not enough, the origin must be on the allow list; no peering config means no peers.
+func TestAuthorizePeer(t *testing.T) {
+	tests := []struct {
+		name    string
+		peering *model.ConfigPeering
+		want    int
+	}{
+		{"no peering config", nil, http.StatusForbidden},
+		{"not allowed", &model.ConfigPeering{Allow: []string{"other.example.com"}}, http.StatusForbidden},
+		{"anyone who signs", &model.ConfigPeering{Allow: []string{"*"}}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// on the dev host signatures are not checked, so the origin is "ignored"
+			cfg := &model.Config{Matrix: &model.ConfigMatrix{ServerName: devhost}, Peering: tt.peering}
+			s := &Server{cfg: fakeConfig{cfg}}
+			req := httptest.NewRequest(http.MethodGet, PeeringServersPath, http.NoBody)
+			if got, _ := s.authorizePeer(context.Background(), req); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
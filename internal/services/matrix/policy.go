@@ -0,0 +1,48 @@
+package matrix
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/goccy/go-json"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// maxPolicyBytes caps /.well-known/matrix/mrs, a policy is a handful of flags
+const maxPolicyBytes = 64 << 10
+
+// QueryServerPolicy returns the server's indexing policy from /.well-known/matrix/mrs, nil if it doesn't publish one
+func (s *Server) QueryServerPolicy(ctx context.Context, serverName string) (*model.MatrixServerPolicy, error) {
+	resp, err := utils.Get(ctx, "https://"+serverName+"/.well-known/matrix/mrs")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil //nolint:nilnil // no policy is the default, not an error
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("/.well-known/matrix/mrs returned HTTP %d", resp.StatusCode)
+	}
+
+	datab, err := io.ReadAll(io.LimitReader(resp.Body, maxPolicyBytes))
+	if err != nil {
+		return nil, err
+	}
+	var policy *model.MatrixServerPolicy
+	if err := json.Unmarshal(datab, &policy); err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, nil //nolint:nilnil // same as no policy
+	}
+	policy.Normalize()
+	if policy.IsEmpty() {
+		return nil, nil //nolint:nilnil // same as no policy
+	}
+	return policy, nil
+}
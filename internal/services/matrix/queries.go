@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -93,6 +95,10 @@ func (s *Server) QueryDirectoryExternal(ctx context.Context, alias string) (*mod
 		return nil, fmt.Errorf("cannot extract server name from alias")
 	}
 
+	if err := s.backoffCheck(serverName, "directory"); err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, utils.DefaultTimeout)
 	defer cancel()
 	req, err := s.buildQueryDirectoryReq(ctx, serverName, alias)
@@ -101,6 +107,7 @@ func (s *Server) QueryDirectoryExternal(ctx context.Context, alias string) (*mod
 	}
 
 	resp, err := utils.Do(req)
+	s.backoffRecord(ctx, serverName, "directory", resp, err)
 	if err != nil {
 		return nil, err
 	}
@@ -134,8 +141,12 @@ func (s *Server) QueryDirectoryExternal(ctx context.Context, alias string) (*mod
 
 // QueryVersion from /_matrix/federation/v1/version
 func (s *Server) QueryVersion(ctx context.Context, serverName string) (server, serverVersion string, err error) {
+	if err := s.backoffCheck(serverName, "version"); err != nil {
+		return "", "", err
+	}
 	ctx, serverURL, serverHost := s.getURL(ctx, serverName, false)
 	resp, err := utils.Get(ctx, serverURL+"/_matrix/federation/v1/version", serverHost)
+	s.backoffRecord(ctx, serverName, "version", resp, err)
 	if err != nil {
 		return "", "", err
 	}
@@ -162,17 +173,21 @@ func (s *Server) QueryVersion(ctx context.Context, serverName string) (server, s
 	return vResp.Server.Name, vResp.Server.Version, nil
 }
 
-// QueryPublicRooms over federation. Uses SlowHTTPClient for heavy queries.
-func (s *Server) QueryPublicRooms(ctx context.Context, serverName, limit, since string) (*model.RoomDirectoryResponse, error) {
+// QueryPublicRooms over federation, of the server's own directory or a third-party network's. Uses SlowHTTPClient for heavy queries.
+func (s *Server) QueryPublicRooms(ctx context.Context, serverName, limit, since string, network model.RoomDirectoryNetwork) (*model.RoomDirectoryResponse, error) {
+	if err := s.backoffCheck(serverName, "publicRooms"); err != nil {
+		return nil, err
+	}
 	ctx, cancel := context.WithTimeout(ctx, utils.DefaultTimeout)
 	defer cancel()
-	req, err := s.buildPublicRoomsReq(ctx, serverName, limit, since)
+	req, err := s.buildPublicRoomsReq(ctx, serverName, limit, since, network)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("User-Agent", version.UserAgent)
 	resp, err := utils.SlowHTTPClient.Do(req)
+	s.backoffRecord(ctx, serverName, "publicRooms", resp, err)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +196,9 @@ func (s *Server) QueryPublicRooms(ctx context.Context, serverName, limit, since
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // intended
 		merr := s.parseErrorResp(resp.Status, body)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return nil, s.parseRateLimitResp(resp, merr)
+		}
 		if merr == nil {
 			bodyhint := ""
 			if len(body) > 0 {
@@ -203,6 +221,78 @@ func (s *Server) QueryPublicRooms(ctx context.Context, serverName, limit, since
 	return roomsResp, nil
 }
 
+// QuerySpaceHierarchy returns the space's own room and its children as the server sees them
+func (s *Server) QuerySpaceHierarchy(ctx context.Context, serverName, roomID string, suggestedOnly bool) (*model.SpaceHierarchyResponse, error) {
+	if err := s.backoffCheck(serverName, "hierarchy"); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, utils.DefaultTimeout)
+	defer cancel()
+	req, err := s.buildHierarchyReq(ctx, serverName, roomID, suggestedOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := utils.SlowHTTPClient.Do(req)
+	s.backoffRecord(ctx, serverName, "hierarchy", resp, err)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		merr := s.parseErrorResp(resp.Status, body)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return nil, s.parseRateLimitResp(resp, merr)
+		}
+		if merr == nil {
+			return nil, fmt.Errorf("cannot get space hierarchy: %s", resp.Status)
+		}
+		return nil, merr
+	}
+
+	var hierarchy *model.SpaceHierarchyResponse
+	if err := json.Unmarshal(body, &hierarchy); err != nil {
+		return nil, err
+	}
+	if hierarchy == nil || hierarchy.Room == nil {
+		return nil, fmt.Errorf("cannot get space hierarchy: no room in the response")
+	}
+	return hierarchy, nil
+}
+
+// QueryServerIP returns the IP federation requests to serverName land on, empty if it doesn't resolve.
+// A hosting provider parks hundreds of small homeservers on one box, so that's what politeness is counted by, not the name.
+func (s *Server) QueryServerIP(ctx context.Context, serverName string) string {
+	_, ssURL, _ := s.getURL(ctx, serverName, false)
+	if cached, ok := s.surlsCache.Get(serverName); ok {
+		if parts := strings.Split(cached, "||"); len(parts) == 3 && parts[2] != "" {
+			return parts[2] // SRV-pinned dial IP, already resolved
+		}
+	}
+	apiURL, err := url.Parse(ssURL)
+	if err != nil {
+		return ""
+	}
+	host := apiURL.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil || len(ips) == 0 {
+		apm.Log(ctx).Debug().Err(err).Str("server", serverName).Msg("cannot resolve server IP")
+		return ""
+	}
+	return ips[0]
+}
+
 // QueryServerKeys is /_matrix/key/v2/query/{serverName}
 func (s *Server) QueryServerKeys(ctx context.Context, serverName string, validUntilTS int64) []byte {
 	log := apm.Log(ctx).With().Str("server", serverName).Logger()
@@ -279,7 +369,7 @@ func (s *Server) resolveCSURL(ctx context.Context, serverName string) string {
 	return csurl
 }
 
-func (s *Server) buildPublicRoomsReq(ctx context.Context, serverName, limit, since string) (*http.Request, error) {
+func (s *Server) buildPublicRoomsReq(ctx context.Context, serverName, limit, since string, network model.RoomDirectoryNetwork) (*http.Request, error) {
 	ctx, apiURLStr, apiURLHost := s.getURL(ctx, serverName, false)
 	apiURL, err := url.Parse(apiURLStr)
 	if err != nil {
@@ -293,6 +383,12 @@ func (s *Server) buildPublicRoomsReq(ctx context.Context, serverName, limit, sin
 	if since != "" {
 		query.Set("since", since)
 	}
+	if network.IncludeAllNetworks {
+		query.Set("include_all_networks", "true")
+	}
+	if network.ThirdPartyInstanceID != "" {
+		query.Set("third_party_instance_id", network.ThirdPartyInstanceID)
+	}
 	apiURL.RawQuery = query.Encode()
 
 	path := "/" + apiURL.Path
@@ -318,6 +414,42 @@ func (s *Server) buildPublicRoomsReq(ctx context.Context, serverName, limit, sin
 	return req, nil
 }
 
+func (s *Server) buildHierarchyReq(ctx context.Context, serverName, roomID string, suggestedOnly bool) (*http.Request, error) {
+	ctx, apiURLStr, apiURLHost := s.getURL(ctx, serverName, false)
+	apiURL, err := url.Parse(apiURLStr)
+	if err != nil {
+		return nil, err
+	}
+	apiURL = apiURL.JoinPath("/_matrix/federation/v1/hierarchy", roomID)
+	if suggestedOnly {
+		query := apiURL.Query()
+		query.Set("suggested_only", "true")
+		apiURL.RawQuery = query.Encode()
+	}
+
+	path := "/" + apiURL.EscapedPath()
+	if apiURL.RawQuery != "" {
+		path += "?" + apiURL.RawQuery
+	}
+	authHeaders, err := s.Authorize(serverName, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if apiURLHost != "" {
+		req.Host = apiURLHost
+	}
+	for _, h := range authHeaders {
+		req.Header.Add("Authorization", h)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", version.UserAgent)
+	return req, nil
+}
+
 func (s *Server) buildQueryDirectoryReq(ctx context.Context, serverName, alias string) (*http.Request, error) {
 	ctx, apiURLStr, apiURLHost := s.getURL(ctx, serverName, false)
 	apiURL, err := url.Parse(apiURLStr)
@@ -361,5 +493,9 @@ func (s *Server) getRoom(ctx context.Context, roomIDorAlias string) (*model.Matr
 		}
 	}
 
+	// a quarantined room keeps its data, but is served nowhere
+	if s.data.IsQuarantined(ctx, roomID) {
+		return nil, nil
+	}
 	return s.data.GetRoom(ctx, roomID)
 }
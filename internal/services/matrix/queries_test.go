@@ -16,6 +16,7 @@ func TestQueryServerName_negativeCacheStopsRefetch(t *testing.T) {
 		namesCache:         expirable.NewLRU[string, string](100, nil, time.Hour),
 		namesNegativeCache: expirable.NewLRU[string, struct{}](100, nil, time.Hour),
 		surlsCache:         expirable.NewLRU[string, string](100, nil, time.Hour),
+		backoffCache:       expirable.NewLRU[string, destinationBackoff](100, nil, time.Hour),
 		discoverSem:        make(chan struct{}, 1),
 	}
 	const dead = "nonexistent.invalid"
@@ -50,3 +51,17 @@ func TestQueryServerName_positiveCacheBeatsNegative(t *testing.T) {
 		t.Fatalf("the positive cache must win over a stale negative entry, got (%q, %v)", got, err)
 	}
 }
+
+// an SRV-pinned dial IP is the real destination; resolving the delegated host instead would count the wrong box.
+func TestQueryServerIP_prefersPinnedDialIP(t *testing.T) {
+	s := newCacheTestServer()
+	s.surlsCache.Add("example.org", "https://delegated.example:8448||delegated.example||1.2.3.4")
+	if got := s.QueryServerIP(context.Background(), "example.org"); got != "1.2.3.4" {
+		t.Errorf("got %q, want the pinned dial IP", got)
+	}
+
+	// IP literal server name: nothing to resolve, no DNS egress
+	if got := s.QueryServerIP(context.Background(), "5.6.7.8:8448"); got != "5.6.7.8" {
+		t.Errorf("got %q, want the literal IP", got)
+	}
+}
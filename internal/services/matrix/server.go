@@ -58,7 +58,8 @@ func (s *Server) PublicRooms(ctx context.Context, req *http.Request, rdReq *mode
 		limit = MatrixSearchLimit
 	}
 	offset := kit.StringToInt(rdReq.Since)
-	entries, total, err := s.search.Search(ctx, req, rdReq.Filter.GenericSearchTerm, "", rdReq.Filter.RoomTypes, limit, offset)
+	network := s.networkFilter(rdReq.RoomDirectoryNetwork)
+	entries, total, err := s.search.Search(ctx, req, rdReq.Filter.GenericSearchTerm, network, "", rdReq.Filter.RoomTypes, limit, offset)
 	if err != nil {
 		log.Error().Err(err).Msg("search from matrix failed")
 		return http.StatusInternalServerError, nil
@@ -95,3 +96,19 @@ func (s *Server) PublicRooms(ctx context.Context, req *http.Request, rdReq *mode
 	}
 	return http.StatusOK, value
 }
+
+// networkFilter returns the network to narrow publicRooms down to. MRS' third-party instance IDs are network names,
+// and without include_all_networks only native matrix rooms are listed, as the spec says. Until any third-party
+// network is crawled there is nothing to narrow down, so the filter is skipped
+func (s *Server) networkFilter(network model.RoomDirectoryNetwork) string {
+	switch {
+	case network.ThirdPartyInstanceID != "":
+		return network.ThirdPartyInstanceID
+	case network.IncludeAllNetworks:
+		return ""
+	case s.cfg.Get().Networks.IsEnabled():
+		return model.NetworkMatrix
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,35 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+type fakeConfig struct{ cfg *model.Config }
+
+func (f fakeConfig) Get() *model.Config { return f.cfg }
+
+// without include_all_networks the spec lists native rooms only, but until a network is crawled there's nothing to hide.
+func TestNetworkFilter(t *testing.T) {
+	enabled := &model.Config{Networks: &model.ConfigNetworks{All: true}}
+	tests := []struct {
+		name    string
+		cfg     *model.Config
+		network model.RoomDirectoryNetwork
+		want    string
+	}{
+		{"networks not crawled", &model.Config{}, model.RoomDirectoryNetwork{}, ""},
+		{"native only", enabled, model.RoomDirectoryNetwork{}, model.NetworkMatrix},
+		{"all networks", enabled, model.RoomDirectoryNetwork{IncludeAllNetworks: true}, ""},
+		{"one network", enabled, model.RoomDirectoryNetwork{ThirdPartyInstanceID: "irc-libera"}, "irc-libera"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{cfg: fakeConfig{tt.cfg}}
+			if got := s.networkFilter(tt.network); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -20,6 +20,113 @@ import (
 	mock "github.com/stretchr/testify/mock"
 )
 
+// newMockbanRulesBlocklist creates a new instance of mockbanRulesBlocklist. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockbanRulesBlocklist(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockbanRulesBlocklist {
+	mock := &mockbanRulesBlocklist{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockbanRulesBlocklist is an autogenerated mock type for the banRulesBlocklist type
+type mockbanRulesBlocklist struct {
+	mock.Mock
+}
+
+type mockbanRulesBlocklist_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockbanRulesBlocklist) EXPECT() *mockbanRulesBlocklist_Expecter {
+	return &mockbanRulesBlocklist_Expecter{mock: &_m.Mock}
+}
+
+// SetPolicies provides a mock function for the type mockbanRulesBlocklist
+func (_mock *mockbanRulesBlocklist) SetPolicies(rules []*model.PolicyRule) {
+	_mock.Called(rules)
+	return
+}
+
+// mockbanRulesBlocklist_SetPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPolicies'
+type mockbanRulesBlocklist_SetPolicies_Call struct {
+	*mock.Call
+}
+
+// SetPolicies is a helper method to define mock.On call
+//   - rules []*model.PolicyRule
+func (_e *mockbanRulesBlocklist_Expecter) SetPolicies(rules interface{}) *mockbanRulesBlocklist_SetPolicies_Call {
+	return &mockbanRulesBlocklist_SetPolicies_Call{Call: _e.mock.On("SetPolicies", rules)}
+}
+
+func (_c *mockbanRulesBlocklist_SetPolicies_Call) Run(run func(rules []*model.PolicyRule)) *mockbanRulesBlocklist_SetPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []*model.PolicyRule
+		if args[0] != nil {
+			arg0 = args[0].([]*model.PolicyRule)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockbanRulesBlocklist_SetPolicies_Call) Return() *mockbanRulesBlocklist_SetPolicies_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockbanRulesBlocklist_SetPolicies_Call) RunAndReturn(run func(rules []*model.PolicyRule)) *mockbanRulesBlocklist_SetPolicies_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetRules provides a mock function for the type mockbanRulesBlocklist
+func (_mock *mockbanRulesBlocklist) SetRules(rules []*model.BanRule) {
+	_mock.Called(rules)
+	return
+}
+
+// mockbanRulesBlocklist_SetRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRules'
+type mockbanRulesBlocklist_SetRules_Call struct {
+	*mock.Call
+}
+
+// SetRules is a helper method to define mock.On call
+//   - rules []*model.BanRule
+func (_e *mockbanRulesBlocklist_Expecter) SetRules(rules interface{}) *mockbanRulesBlocklist_SetRules_Call {
+	return &mockbanRulesBlocklist_SetRules_Call{Call: _e.mock.On("SetRules", rules)}
+}
+
+func (_c *mockbanRulesBlocklist_SetRules_Call) Run(run func(rules []*model.BanRule)) *mockbanRulesBlocklist_SetRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []*model.BanRule
+		if args[0] != nil {
+			arg0 = args[0].([]*model.BanRule)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockbanRulesBlocklist_SetRules_Call) Return() *mockbanRulesBlocklist_SetRules_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockbanRulesBlocklist_SetRules_Call) RunAndReturn(run func(rules []*model.BanRule)) *mockbanRulesBlocklist_SetRules_Call {
+	_c.Run(run)
+	return _c
+}
+
 // newMockcacheStats creates a new instance of mockcacheStats. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockcacheStats(t interface {
@@ -166,13 +273,13 @@ func (_c *MockConfigService_Get_Call) RunAndReturn(run func() *model.Config) *Mo
 	return _c
 }
 
-// NewMockBlocklistService creates a new instance of MockBlocklistService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// newMockcrawlStore creates a new instance of mockcrawlStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockBlocklistService(t interface {
+func newMockcrawlStore(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockBlocklistService {
-	mock := &MockBlocklistService{}
+}) *mockcrawlStore {
+	mock := &mockcrawlStore{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -180,201 +287,255 @@ func NewMockBlocklistService(t interface {
 	return mock
 }
 
-// MockBlocklistService is an autogenerated mock type for the BlocklistService type
-type MockBlocklistService struct {
+// mockcrawlStore is an autogenerated mock type for the crawlStore type
+type mockcrawlStore struct {
 	mock.Mock
 }
 
-type MockBlocklistService_Expecter struct {
+type mockcrawlStore_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockBlocklistService) EXPECT() *MockBlocklistService_Expecter {
-	return &MockBlocklistService_Expecter{mock: &_m.Mock}
+func (_m *mockcrawlStore) EXPECT() *mockcrawlStore_Expecter {
+	return &mockcrawlStore_Expecter{mock: &_m.Mock}
 }
 
-// Add provides a mock function for the type MockBlocklistService
-func (_mock *MockBlocklistService) Add(server string) {
-	_mock.Called(server)
+// AddRoomBatch provides a mock function for the type mockcrawlStore
+func (_mock *mockcrawlStore) AddRoomBatch(context1 context.Context, matrixRoom *model.MatrixRoom) {
+	_mock.Called(context1, matrixRoom)
 	return
 }
 
-// MockBlocklistService_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
-type MockBlocklistService_Add_Call struct {
+// mockcrawlStore_AddRoomBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomBatch'
+type mockcrawlStore_AddRoomBatch_Call struct {
 	*mock.Call
 }
 
-// Add is a helper method to define mock.On call
-//   - server string
-func (_e *MockBlocklistService_Expecter) Add(server interface{}) *MockBlocklistService_Add_Call {
-	return &MockBlocklistService_Add_Call{Call: _e.mock.On("Add", server)}
+// AddRoomBatch is a helper method to define mock.On call
+//   - context1 context.Context
+//   - matrixRoom *model.MatrixRoom
+func (_e *mockcrawlStore_Expecter) AddRoomBatch(context1 interface{}, matrixRoom interface{}) *mockcrawlStore_AddRoomBatch_Call {
+	return &mockcrawlStore_AddRoomBatch_Call{Call: _e.mock.On("AddRoomBatch", context1, matrixRoom)}
 }
 
-func (_c *MockBlocklistService_Add_Call) Run(run func(server string)) *MockBlocklistService_Add_Call {
+func (_c *mockcrawlStore_AddRoomBatch_Call) Run(run func(context1 context.Context, matrixRoom *model.MatrixRoom)) *mockcrawlStore_AddRoomBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.MatrixRoom
+		if args[1] != nil {
+			arg1 = args[1].(*model.MatrixRoom)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockBlocklistService_Add_Call) Return() *MockBlocklistService_Add_Call {
+func (_c *mockcrawlStore_AddRoomBatch_Call) Return() *mockcrawlStore_AddRoomBatch_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockBlocklistService_Add_Call) RunAndReturn(run func(server string)) *MockBlocklistService_Add_Call {
+func (_c *mockcrawlStore_AddRoomBatch_Call) RunAndReturn(run func(context1 context.Context, matrixRoom *model.MatrixRoom)) *mockcrawlStore_AddRoomBatch_Call {
 	_c.Run(run)
 	return _c
 }
 
-// ByID provides a mock function for the type MockBlocklistService
-func (_mock *MockBlocklistService) ByID(matrixID string) bool {
-	ret := _mock.Called(matrixID)
+// AddRoomMapping provides a mock function for the type mockcrawlStore
+func (_mock *mockcrawlStore) AddRoomMapping(context1 context.Context, s string, s1 string) error {
+	ret := _mock.Called(context1, s, s1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ByID")
+		panic("no return value specified for AddRoomMapping")
 	}
 
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = returnFunc(matrixID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(context1, s, s1)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockBlocklistService_ByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ByID'
-type MockBlocklistService_ByID_Call struct {
+// mockcrawlStore_AddRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomMapping'
+type mockcrawlStore_AddRoomMapping_Call struct {
 	*mock.Call
 }
 
-// ByID is a helper method to define mock.On call
-//   - matrixID string
-func (_e *MockBlocklistService_Expecter) ByID(matrixID interface{}) *MockBlocklistService_ByID_Call {
-	return &MockBlocklistService_ByID_Call{Call: _e.mock.On("ByID", matrixID)}
+// AddRoomMapping is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - s1 string
+func (_e *mockcrawlStore_Expecter) AddRoomMapping(context1 interface{}, s interface{}, s1 interface{}) *mockcrawlStore_AddRoomMapping_Call {
+	return &mockcrawlStore_AddRoomMapping_Call{Call: _e.mock.On("AddRoomMapping", context1, s, s1)}
 }
 
-func (_c *MockBlocklistService_ByID_Call) Run(run func(matrixID string)) *MockBlocklistService_ByID_Call {
+func (_c *mockcrawlStore_AddRoomMapping_Call) Run(run func(context1 context.Context, s string, s1 string)) *mockcrawlStore_AddRoomMapping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockBlocklistService_ByID_Call) Return(b bool) *MockBlocklistService_ByID_Call {
-	_c.Call.Return(b)
+func (_c *mockcrawlStore_AddRoomMapping_Call) Return(err error) *mockcrawlStore_AddRoomMapping_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockBlocklistService_ByID_Call) RunAndReturn(run func(matrixID string) bool) *MockBlocklistService_ByID_Call {
+func (_c *mockcrawlStore_AddRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string) error) *mockcrawlStore_AddRoomMapping_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ByServer provides a mock function for the type MockBlocklistService
-func (_mock *MockBlocklistService) ByServer(server string) bool {
-	ret := _mock.Called(server)
+// AddServer provides a mock function for the type mockcrawlStore
+func (_mock *mockcrawlStore) AddServer(context1 context.Context, matrixServer *model.MatrixServer) error {
+	ret := _mock.Called(context1, matrixServer)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ByServer")
+		panic("no return value specified for AddServer")
 	}
 
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = returnFunc(server)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.MatrixServer) error); ok {
+		r0 = returnFunc(context1, matrixServer)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockBlocklistService_ByServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ByServer'
-type MockBlocklistService_ByServer_Call struct {
+// mockcrawlStore_AddServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddServer'
+type mockcrawlStore_AddServer_Call struct {
 	*mock.Call
 }
 
-// ByServer is a helper method to define mock.On call
-//   - server string
-func (_e *MockBlocklistService_Expecter) ByServer(server interface{}) *MockBlocklistService_ByServer_Call {
-	return &MockBlocklistService_ByServer_Call{Call: _e.mock.On("ByServer", server)}
+// AddServer is a helper method to define mock.On call
+//   - context1 context.Context
+//   - matrixServer *model.MatrixServer
+func (_e *mockcrawlStore_Expecter) AddServer(context1 interface{}, matrixServer interface{}) *mockcrawlStore_AddServer_Call {
+	return &mockcrawlStore_AddServer_Call{Call: _e.mock.On("AddServer", context1, matrixServer)}
 }
 
-func (_c *MockBlocklistService_ByServer_Call) Run(run func(server string)) *MockBlocklistService_ByServer_Call {
+func (_c *mockcrawlStore_AddServer_Call) Run(run func(context1 context.Context, matrixServer *model.MatrixServer)) *mockcrawlStore_AddServer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.MatrixServer
+		if args[1] != nil {
+			arg1 = args[1].(*model.MatrixServer)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockBlocklistService_ByServer_Call) Return(b bool) *MockBlocklistService_ByServer_Call {
-	_c.Call.Return(b)
+func (_c *mockcrawlStore_AddServer_Call) Return(err error) *mockcrawlStore_AddServer_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockBlocklistService_ByServer_Call) RunAndReturn(run func(server string) bool) *MockBlocklistService_ByServer_Call {
+func (_c *mockcrawlStore_AddServer_Call) RunAndReturn(run func(context1 context.Context, matrixServer *model.MatrixServer) error) *mockcrawlStore_AddServer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Reset provides a mock function for the type MockBlocklistService
-func (_mock *MockBlocklistService) Reset() {
-	_mock.Called()
-	return
+// SetSpaceChildren provides a mock function for the type mockcrawlStore
+func (_mock *mockcrawlStore) SetSpaceChildren(context1 context.Context, s string, matrixSpaceChilds []*model.MatrixSpaceChild) error {
+	ret := _mock.Called(context1, s, matrixSpaceChilds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSpaceChildren")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []*model.MatrixSpaceChild) error); ok {
+		r0 = returnFunc(context1, s, matrixSpaceChilds)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockBlocklistService_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
-type MockBlocklistService_Reset_Call struct {
+// mockcrawlStore_SetSpaceChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSpaceChildren'
+type mockcrawlStore_SetSpaceChildren_Call struct {
 	*mock.Call
 }
 
-// Reset is a helper method to define mock.On call
-func (_e *MockBlocklistService_Expecter) Reset() *MockBlocklistService_Reset_Call {
-	return &MockBlocklistService_Reset_Call{Call: _e.mock.On("Reset")}
+// SetSpaceChildren is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - matrixSpaceChilds []*model.MatrixSpaceChild
+func (_e *mockcrawlStore_Expecter) SetSpaceChildren(context1 interface{}, s interface{}, matrixSpaceChilds interface{}) *mockcrawlStore_SetSpaceChildren_Call {
+	return &mockcrawlStore_SetSpaceChildren_Call{Call: _e.mock.On("SetSpaceChildren", context1, s, matrixSpaceChilds)}
 }
 
-func (_c *MockBlocklistService_Reset_Call) Run(run func()) *MockBlocklistService_Reset_Call {
+func (_c *mockcrawlStore_SetSpaceChildren_Call) Run(run func(context1 context.Context, s string, matrixSpaceChilds []*model.MatrixSpaceChild)) *mockcrawlStore_SetSpaceChildren_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []*model.MatrixSpaceChild
+		if args[2] != nil {
+			arg2 = args[2].([]*model.MatrixSpaceChild)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
 	})
 	return _c
 }
 
-func (_c *MockBlocklistService_Reset_Call) Return() *MockBlocklistService_Reset_Call {
-	_c.Call.Return()
+func (_c *mockcrawlStore_SetSpaceChildren_Call) Return(err error) *mockcrawlStore_SetSpaceChildren_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockBlocklistService_Reset_Call) RunAndReturn(run func()) *MockBlocklistService_Reset_Call {
-	_c.Run(run)
+func (_c *mockcrawlStore_SetSpaceChildren_Call) RunAndReturn(run func(context1 context.Context, s string, matrixSpaceChilds []*model.MatrixSpaceChild) error) *mockcrawlStore_SetSpaceChildren_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockDataRepository creates a new instance of MockDataRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockBlocklistService creates a new instance of MockBlocklistService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockDataRepository(t interface {
+func NewMockBlocklistService(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockDataRepository {
-	mock := &MockDataRepository{}
+}) *MockBlocklistService {
+	mock := &MockBlocklistService{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -382,280 +543,319 @@ func NewMockDataRepository(t interface {
 	return mock
 }
 
-// MockDataRepository is an autogenerated mock type for the DataRepository type
-type MockDataRepository struct {
+// MockBlocklistService is an autogenerated mock type for the BlocklistService type
+type MockBlocklistService struct {
 	mock.Mock
 }
 
-type MockDataRepository_Expecter struct {
+type MockBlocklistService_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockDataRepository) EXPECT() *MockDataRepository_Expecter {
-	return &MockDataRepository_Expecter{mock: &_m.Mock}
+func (_m *MockBlocklistService) EXPECT() *MockBlocklistService_Expecter {
+	return &MockBlocklistService_Expecter{mock: &_m.Mock}
 }
 
-// AddRoomBatch provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) AddRoomBatch(context1 context.Context, matrixRoom *model.MatrixRoom) {
-	_mock.Called(context1, matrixRoom)
+// Add provides a mock function for the type MockBlocklistService
+func (_mock *MockBlocklistService) Add(server string) {
+	_mock.Called(server)
 	return
 }
 
-// MockDataRepository_AddRoomBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomBatch'
-type MockDataRepository_AddRoomBatch_Call struct {
+// MockBlocklistService_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockBlocklistService_Add_Call struct {
 	*mock.Call
 }
 
-// AddRoomBatch is a helper method to define mock.On call
-//   - context1 context.Context
-//   - matrixRoom *model.MatrixRoom
-func (_e *MockDataRepository_Expecter) AddRoomBatch(context1 interface{}, matrixRoom interface{}) *MockDataRepository_AddRoomBatch_Call {
-	return &MockDataRepository_AddRoomBatch_Call{Call: _e.mock.On("AddRoomBatch", context1, matrixRoom)}
+// Add is a helper method to define mock.On call
+//   - server string
+func (_e *MockBlocklistService_Expecter) Add(server interface{}) *MockBlocklistService_Add_Call {
+	return &MockBlocklistService_Add_Call{Call: _e.mock.On("Add", server)}
 }
 
-func (_c *MockDataRepository_AddRoomBatch_Call) Run(run func(context1 context.Context, matrixRoom *model.MatrixRoom)) *MockDataRepository_AddRoomBatch_Call {
+func (_c *MockBlocklistService_Add_Call) Run(run func(server string)) *MockBlocklistService_Add_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 *model.MatrixRoom
-		if args[1] != nil {
-			arg1 = args[1].(*model.MatrixRoom)
+			arg0 = args[0].(string)
 		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_AddRoomBatch_Call) Return() *MockDataRepository_AddRoomBatch_Call {
+func (_c *MockBlocklistService_Add_Call) Return() *MockBlocklistService_Add_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockDataRepository_AddRoomBatch_Call) RunAndReturn(run func(context1 context.Context, matrixRoom *model.MatrixRoom)) *MockDataRepository_AddRoomBatch_Call {
+func (_c *MockBlocklistService_Add_Call) RunAndReturn(run func(server string)) *MockBlocklistService_Add_Call {
 	_c.Run(run)
 	return _c
 }
 
-// AddRoomMapping provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) AddRoomMapping(context1 context.Context, s string, s1 string) error {
-	ret := _mock.Called(context1, s, s1)
+// ByID provides a mock function for the type MockBlocklistService
+func (_mock *MockBlocklistService) ByID(matrixID string) bool {
+	ret := _mock.Called(matrixID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddRoomMapping")
+		panic("no return value specified for ByID")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(context1, s, s1)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(matrixID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 	return r0
 }
 
-// MockDataRepository_AddRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomMapping'
-type MockDataRepository_AddRoomMapping_Call struct {
+// MockBlocklistService_ByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ByID'
+type MockBlocklistService_ByID_Call struct {
 	*mock.Call
 }
 
-// AddRoomMapping is a helper method to define mock.On call
-//   - context1 context.Context
-//   - s string
-//   - s1 string
-func (_e *MockDataRepository_Expecter) AddRoomMapping(context1 interface{}, s interface{}, s1 interface{}) *MockDataRepository_AddRoomMapping_Call {
-	return &MockDataRepository_AddRoomMapping_Call{Call: _e.mock.On("AddRoomMapping", context1, s, s1)}
+// ByID is a helper method to define mock.On call
+//   - matrixID string
+func (_e *MockBlocklistService_Expecter) ByID(matrixID interface{}) *MockBlocklistService_ByID_Call {
+	return &MockBlocklistService_ByID_Call{Call: _e.mock.On("ByID", matrixID)}
 }
 
-func (_c *MockDataRepository_AddRoomMapping_Call) Run(run func(context1 context.Context, s string, s1 string)) *MockDataRepository_AddRoomMapping_Call {
+func (_c *MockBlocklistService_ByID_Call) Run(run func(matrixID string)) *MockBlocklistService_ByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg0 = args[0].(string)
 		}
 		run(
 			arg0,
-			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_AddRoomMapping_Call) Return(err error) *MockDataRepository_AddRoomMapping_Call {
-	_c.Call.Return(err)
+func (_c *MockBlocklistService_ByID_Call) Return(b bool) *MockBlocklistService_ByID_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockDataRepository_AddRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string) error) *MockDataRepository_AddRoomMapping_Call {
+func (_c *MockBlocklistService_ByID_Call) RunAndReturn(run func(matrixID string) bool) *MockBlocklistService_ByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AddServer provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) AddServer(context1 context.Context, matrixServer *model.MatrixServer) error {
-	ret := _mock.Called(context1, matrixServer)
+// ByServer provides a mock function for the type MockBlocklistService
+func (_mock *MockBlocklistService) ByServer(server string) bool {
+	ret := _mock.Called(server)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddServer")
+		panic("no return value specified for ByServer")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.MatrixServer) error); ok {
-		r0 = returnFunc(context1, matrixServer)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(server)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 	return r0
 }
 
-// MockDataRepository_AddServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddServer'
-type MockDataRepository_AddServer_Call struct {
+// MockBlocklistService_ByServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ByServer'
+type MockBlocklistService_ByServer_Call struct {
 	*mock.Call
 }
 
-// AddServer is a helper method to define mock.On call
-//   - context1 context.Context
-//   - matrixServer *model.MatrixServer
-func (_e *MockDataRepository_Expecter) AddServer(context1 interface{}, matrixServer interface{}) *MockDataRepository_AddServer_Call {
-	return &MockDataRepository_AddServer_Call{Call: _e.mock.On("AddServer", context1, matrixServer)}
+// ByServer is a helper method to define mock.On call
+//   - server string
+func (_e *MockBlocklistService_Expecter) ByServer(server interface{}) *MockBlocklistService_ByServer_Call {
+	return &MockBlocklistService_ByServer_Call{Call: _e.mock.On("ByServer", server)}
 }
 
-func (_c *MockDataRepository_AddServer_Call) Run(run func(context1 context.Context, matrixServer *model.MatrixServer)) *MockDataRepository_AddServer_Call {
+func (_c *MockBlocklistService_ByServer_Call) Run(run func(server string)) *MockBlocklistService_ByServer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 *model.MatrixServer
-		if args[1] != nil {
-			arg1 = args[1].(*model.MatrixServer)
+			arg0 = args[0].(string)
 		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_AddServer_Call) Return(err error) *MockDataRepository_AddServer_Call {
-	_c.Call.Return(err)
+func (_c *MockBlocklistService_ByServer_Call) Return(b bool) *MockBlocklistService_ByServer_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockDataRepository_AddServer_Call) RunAndReturn(run func(context1 context.Context, matrixServer *model.MatrixServer) error) *MockDataRepository_AddServer_Call {
+func (_c *MockBlocklistService_ByServer_Call) RunAndReturn(run func(server string) bool) *MockBlocklistService_ByServer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// BanRoom provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) BanRoom(context1 context.Context, s string) error {
-	ret := _mock.Called(context1, s)
+// ByText provides a mock function for the type MockBlocklistService
+func (_mock *MockBlocklistService) ByText(texts ...string) bool {
+	var tmpRet mock.Arguments
+	if len(texts) > 0 {
+		tmpRet = _mock.Called(texts)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for BanRoom")
+		panic("no return value specified for ByText")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(context1, s)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(...string) bool); ok {
+		r0 = returnFunc(texts...)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 	return r0
 }
 
-// MockDataRepository_BanRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanRoom'
-type MockDataRepository_BanRoom_Call struct {
+// MockBlocklistService_ByText_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ByText'
+type MockBlocklistService_ByText_Call struct {
 	*mock.Call
 }
 
-// BanRoom is a helper method to define mock.On call
-//   - context1 context.Context
-//   - s string
-func (_e *MockDataRepository_Expecter) BanRoom(context1 interface{}, s interface{}) *MockDataRepository_BanRoom_Call {
-	return &MockDataRepository_BanRoom_Call{Call: _e.mock.On("BanRoom", context1, s)}
+// ByText is a helper method to define mock.On call
+//   - texts ...string
+func (_e *MockBlocklistService_Expecter) ByText(texts ...interface{}) *MockBlocklistService_ByText_Call {
+	return &MockBlocklistService_ByText_Call{Call: _e.mock.On("ByText",
+		append([]interface{}{}, texts...)...)}
 }
 
-func (_c *MockDataRepository_BanRoom_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_BanRoom_Call {
+func (_c *MockBlocklistService_ByText_Call) Run(run func(texts ...string)) *MockBlocklistService_ByText_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
+		var arg0 []string
+		var variadicArgs []string
+		if len(args) > 0 {
+			variadicArgs = args[0].([]string)
 		}
+		arg0 = variadicArgs
 		run(
-			arg0,
-			arg1,
+			arg0...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_BanRoom_Call) Return(err error) *MockDataRepository_BanRoom_Call {
-	_c.Call.Return(err)
+func (_c *MockBlocklistService_ByText_Call) Return(b bool) *MockBlocklistService_ByText_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockDataRepository_BanRoom_Call) RunAndReturn(run func(context1 context.Context, s string) error) *MockDataRepository_BanRoom_Call {
+func (_c *MockBlocklistService_ByText_Call) RunAndReturn(run func(texts ...string) bool) *MockBlocklistService_ByText_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// BatchServers provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) BatchServers(context1 context.Context, strings []string) error {
-	ret := _mock.Called(context1, strings)
+// Reset provides a mock function for the type MockBlocklistService
+func (_mock *MockBlocklistService) Reset() {
+	_mock.Called()
+	return
+}
+
+// MockBlocklistService_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type MockBlocklistService_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+func (_e *MockBlocklistService_Expecter) Reset() *MockBlocklistService_Reset_Call {
+	return &MockBlocklistService_Reset_Call{Call: _e.mock.On("Reset")}
+}
+
+func (_c *MockBlocklistService_Reset_Call) Run(run func()) *MockBlocklistService_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBlocklistService_Reset_Call) Return() *MockBlocklistService_Reset_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockBlocklistService_Reset_Call) RunAndReturn(run func()) *MockBlocklistService_Reset_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockDataRepository creates a new instance of MockDataRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDataRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDataRepository {
+	mock := &MockDataRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDataRepository is an autogenerated mock type for the DataRepository type
+type MockDataRepository struct {
+	mock.Mock
+}
+
+type MockDataRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDataRepository) EXPECT() *MockDataRepository_Expecter {
+	return &MockDataRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddAuditEntry provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) AddAuditEntry(context1 context.Context, auditEntry *model.AuditEntry) error {
+	ret := _mock.Called(context1, auditEntry)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BatchServers")
+		panic("no return value specified for AddAuditEntry")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
-		r0 = returnFunc(context1, strings)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.AuditEntry) error); ok {
+		r0 = returnFunc(context1, auditEntry)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockDataRepository_BatchServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchServers'
-type MockDataRepository_BatchServers_Call struct {
+// MockDataRepository_AddAuditEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditEntry'
+type MockDataRepository_AddAuditEntry_Call struct {
 	*mock.Call
 }
 
-// BatchServers is a helper method to define mock.On call
+// AddAuditEntry is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings []string
-func (_e *MockDataRepository_Expecter) BatchServers(context1 interface{}, strings interface{}) *MockDataRepository_BatchServers_Call {
-	return &MockDataRepository_BatchServers_Call{Call: _e.mock.On("BatchServers", context1, strings)}
+//   - auditEntry *model.AuditEntry
+func (_e *MockDataRepository_Expecter) AddAuditEntry(context1 interface{}, auditEntry interface{}) *MockDataRepository_AddAuditEntry_Call {
+	return &MockDataRepository_AddAuditEntry_Call{Call: _e.mock.On("AddAuditEntry", context1, auditEntry)}
 }
 
-func (_c *MockDataRepository_BatchServers_Call) Run(run func(context1 context.Context, strings []string)) *MockDataRepository_BatchServers_Call {
+func (_c *MockDataRepository_AddAuditEntry_Call) Run(run func(context1 context.Context, auditEntry *model.AuditEntry)) *MockDataRepository_AddAuditEntry_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 *model.AuditEntry
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(*model.AuditEntry)
 		}
 		run(
 			arg0,
@@ -665,43 +865,54 @@ func (_c *MockDataRepository_BatchServers_Call) Run(run func(context1 context.Co
 	return _c
 }
 
-func (_c *MockDataRepository_BatchServers_Call) Return(err error) *MockDataRepository_BatchServers_Call {
+func (_c *MockDataRepository_AddAuditEntry_Call) Return(err error) *MockDataRepository_AddAuditEntry_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDataRepository_BatchServers_Call) RunAndReturn(run func(context1 context.Context, strings []string) error) *MockDataRepository_BatchServers_Call {
+func (_c *MockDataRepository_AddAuditEntry_Call) RunAndReturn(run func(context1 context.Context, auditEntry *model.AuditEntry) error) *MockDataRepository_AddAuditEntry_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EachRoom provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) EachRoom(context1 context.Context, fn func(string, *model.MatrixRoom) bool) {
-	_mock.Called(context1, fn)
-	return
+// AddBanRule provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) AddBanRule(context1 context.Context, banRule *model.BanRule) error {
+	ret := _mock.Called(context1, banRule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBanRule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.BanRule) error); ok {
+		r0 = returnFunc(context1, banRule)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockDataRepository_EachRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachRoom'
-type MockDataRepository_EachRoom_Call struct {
+// MockDataRepository_AddBanRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddBanRule'
+type MockDataRepository_AddBanRule_Call struct {
 	*mock.Call
 }
 
-// EachRoom is a helper method to define mock.On call
+// AddBanRule is a helper method to define mock.On call
 //   - context1 context.Context
-//   - fn func(string, *model.MatrixRoom) bool
-func (_e *MockDataRepository_Expecter) EachRoom(context1 interface{}, fn interface{}) *MockDataRepository_EachRoom_Call {
-	return &MockDataRepository_EachRoom_Call{Call: _e.mock.On("EachRoom", context1, fn)}
+//   - banRule *model.BanRule
+func (_e *MockDataRepository_Expecter) AddBanRule(context1 interface{}, banRule interface{}) *MockDataRepository_AddBanRule_Call {
+	return &MockDataRepository_AddBanRule_Call{Call: _e.mock.On("AddBanRule", context1, banRule)}
 }
 
-func (_c *MockDataRepository_EachRoom_Call) Run(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *MockDataRepository_EachRoom_Call {
+func (_c *MockDataRepository_AddBanRule_Call) Run(run func(context1 context.Context, banRule *model.BanRule)) *MockDataRepository_AddBanRule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 func(string, *model.MatrixRoom) bool
+		var arg1 *model.BanRule
 		if args[1] != nil {
-			arg1 = args[1].(func(string, *model.MatrixRoom) bool)
+			arg1 = args[1].(*model.BanRule)
 		}
 		run(
 			arg0,
@@ -711,56 +922,43 @@ func (_c *MockDataRepository_EachRoom_Call) Run(run func(context1 context.Contex
 	return _c
 }
 
-func (_c *MockDataRepository_EachRoom_Call) Return() *MockDataRepository_EachRoom_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_AddBanRule_Call) Return(err error) *MockDataRepository_AddBanRule_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDataRepository_EachRoom_Call) RunAndReturn(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *MockDataRepository_EachRoom_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_AddBanRule_Call) RunAndReturn(run func(context1 context.Context, banRule *model.BanRule) error) *MockDataRepository_AddBanRule_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// FilterServers provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) FilterServers(context1 context.Context, fn func(server *model.MatrixServer) bool) map[string]*model.MatrixServer {
-	ret := _mock.Called(context1, fn)
-
-	if len(ret) == 0 {
-		panic("no return value specified for FilterServers")
-	}
-
-	var r0 map[string]*model.MatrixServer
-	if returnFunc, ok := ret.Get(0).(func(context.Context, func(server *model.MatrixServer) bool) map[string]*model.MatrixServer); ok {
-		r0 = returnFunc(context1, fn)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]*model.MatrixServer)
-		}
-	}
-	return r0
+// AddRoomBatch provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) AddRoomBatch(context1 context.Context, matrixRoom *model.MatrixRoom) {
+	_mock.Called(context1, matrixRoom)
+	return
 }
 
-// MockDataRepository_FilterServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterServers'
-type MockDataRepository_FilterServers_Call struct {
+// MockDataRepository_AddRoomBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomBatch'
+type MockDataRepository_AddRoomBatch_Call struct {
 	*mock.Call
 }
 
-// FilterServers is a helper method to define mock.On call
+// AddRoomBatch is a helper method to define mock.On call
 //   - context1 context.Context
-//   - fn func(server *model.MatrixServer) bool
-func (_e *MockDataRepository_Expecter) FilterServers(context1 interface{}, fn interface{}) *MockDataRepository_FilterServers_Call {
-	return &MockDataRepository_FilterServers_Call{Call: _e.mock.On("FilterServers", context1, fn)}
+//   - matrixRoom *model.MatrixRoom
+func (_e *MockDataRepository_Expecter) AddRoomBatch(context1 interface{}, matrixRoom interface{}) *MockDataRepository_AddRoomBatch_Call {
+	return &MockDataRepository_AddRoomBatch_Call{Call: _e.mock.On("AddRoomBatch", context1, matrixRoom)}
 }
 
-func (_c *MockDataRepository_FilterServers_Call) Run(run func(context1 context.Context, fn func(server *model.MatrixServer) bool)) *MockDataRepository_FilterServers_Call {
+func (_c *MockDataRepository_AddRoomBatch_Call) Run(run func(context1 context.Context, matrixRoom *model.MatrixRoom)) *MockDataRepository_AddRoomBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 func(server *model.MatrixServer) bool
+		var arg1 *model.MatrixRoom
 		if args[1] != nil {
-			arg1 = args[1].(func(server *model.MatrixServer) bool)
+			arg1 = args[1].(*model.MatrixRoom)
 		}
 		run(
 			arg0,
@@ -770,259 +968,231 @@ func (_c *MockDataRepository_FilterServers_Call) Run(run func(context1 context.C
 	return _c
 }
 
-func (_c *MockDataRepository_FilterServers_Call) Return(stringToMatrixServer map[string]*model.MatrixServer) *MockDataRepository_FilterServers_Call {
-	_c.Call.Return(stringToMatrixServer)
+func (_c *MockDataRepository_AddRoomBatch_Call) Return() *MockDataRepository_AddRoomBatch_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockDataRepository_FilterServers_Call) RunAndReturn(run func(context1 context.Context, fn func(server *model.MatrixServer) bool) map[string]*model.MatrixServer) *MockDataRepository_FilterServers_Call {
-	_c.Call.Return(run)
+func (_c *MockDataRepository_AddRoomBatch_Call) RunAndReturn(run func(context1 context.Context, matrixRoom *model.MatrixRoom)) *MockDataRepository_AddRoomBatch_Call {
+	_c.Run(run)
 	return _c
 }
 
-// FlushRoomBatch provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) FlushRoomBatch(context1 context.Context) {
-	_mock.Called(context1)
-	return
+// AddRoomMapping provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) AddRoomMapping(context1 context.Context, s string, s1 string) error {
+	ret := _mock.Called(context1, s, s1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRoomMapping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(context1, s, s1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockDataRepository_FlushRoomBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushRoomBatch'
-type MockDataRepository_FlushRoomBatch_Call struct {
+// MockDataRepository_AddRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomMapping'
+type MockDataRepository_AddRoomMapping_Call struct {
 	*mock.Call
 }
 
-// FlushRoomBatch is a helper method to define mock.On call
+// AddRoomMapping is a helper method to define mock.On call
 //   - context1 context.Context
-func (_e *MockDataRepository_Expecter) FlushRoomBatch(context1 interface{}) *MockDataRepository_FlushRoomBatch_Call {
-	return &MockDataRepository_FlushRoomBatch_Call{Call: _e.mock.On("FlushRoomBatch", context1)}
+//   - s string
+//   - s1 string
+func (_e *MockDataRepository_Expecter) AddRoomMapping(context1 interface{}, s interface{}, s1 interface{}) *MockDataRepository_AddRoomMapping_Call {
+	return &MockDataRepository_AddRoomMapping_Call{Call: _e.mock.On("AddRoomMapping", context1, s, s1)}
 }
 
-func (_c *MockDataRepository_FlushRoomBatch_Call) Run(run func(context1 context.Context)) *MockDataRepository_FlushRoomBatch_Call {
+func (_c *MockDataRepository_AddRoomMapping_Call) Run(run func(context1 context.Context, s string, s1 string)) *MockDataRepository_AddRoomMapping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_FlushRoomBatch_Call) Return() *MockDataRepository_FlushRoomBatch_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_AddRoomMapping_Call) Return(err error) *MockDataRepository_AddRoomMapping_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDataRepository_FlushRoomBatch_Call) RunAndReturn(run func(context1 context.Context)) *MockDataRepository_FlushRoomBatch_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_AddRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string) error) *MockDataRepository_AddRoomMapping_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// GetBannedRooms provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) GetBannedRooms(context1 context.Context, strings ...string) ([]string, error) {
-	var tmpRet mock.Arguments
-	if len(strings) > 0 {
-		tmpRet = _mock.Called(context1, strings)
-	} else {
-		tmpRet = _mock.Called(context1)
-	}
-	ret := tmpRet
+// AddServer provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) AddServer(context1 context.Context, matrixServer *model.MatrixServer) error {
+	ret := _mock.Called(context1, matrixServer)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBannedRooms")
+		panic("no return value specified for AddServer")
 	}
 
-	var r0 []string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) ([]string, error)); ok {
-		return returnFunc(context1, strings...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) []string); ok {
-		r0 = returnFunc(context1, strings...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
-		r1 = returnFunc(context1, strings...)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.MatrixServer) error); ok {
+		r0 = returnFunc(context1, matrixServer)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDataRepository_GetBannedRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBannedRooms'
-type MockDataRepository_GetBannedRooms_Call struct {
+// MockDataRepository_AddServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddServer'
+type MockDataRepository_AddServer_Call struct {
 	*mock.Call
 }
 
-// GetBannedRooms is a helper method to define mock.On call
+// AddServer is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings ...string
-func (_e *MockDataRepository_Expecter) GetBannedRooms(context1 interface{}, strings ...interface{}) *MockDataRepository_GetBannedRooms_Call {
-	return &MockDataRepository_GetBannedRooms_Call{Call: _e.mock.On("GetBannedRooms",
-		append([]interface{}{context1}, strings...)...)}
+//   - matrixServer *model.MatrixServer
+func (_e *MockDataRepository_Expecter) AddServer(context1 interface{}, matrixServer interface{}) *MockDataRepository_AddServer_Call {
+	return &MockDataRepository_AddServer_Call{Call: _e.mock.On("AddServer", context1, matrixServer)}
 }
 
-func (_c *MockDataRepository_GetBannedRooms_Call) Run(run func(context1 context.Context, strings ...string)) *MockDataRepository_GetBannedRooms_Call {
+func (_c *MockDataRepository_AddServer_Call) Run(run func(context1 context.Context, matrixServer *model.MatrixServer)) *MockDataRepository_AddServer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
-		var variadicArgs []string
-		if len(args) > 1 {
-			variadicArgs = args[1].([]string)
+		var arg1 *model.MatrixServer
+		if args[1] != nil {
+			arg1 = args[1].(*model.MatrixServer)
 		}
-		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1...,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_GetBannedRooms_Call) Return(strings1 []string, err error) *MockDataRepository_GetBannedRooms_Call {
-	_c.Call.Return(strings1, err)
+func (_c *MockDataRepository_AddServer_Call) Return(err error) *MockDataRepository_AddServer_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDataRepository_GetBannedRooms_Call) RunAndReturn(run func(context1 context.Context, strings ...string) ([]string, error)) *MockDataRepository_GetBannedRooms_Call {
+func (_c *MockDataRepository_AddServer_Call) RunAndReturn(run func(context1 context.Context, matrixServer *model.MatrixServer) error) *MockDataRepository_AddServer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReportedRooms provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) GetReportedRooms(context1 context.Context, strings ...string) (map[string]string, error) {
-	var tmpRet mock.Arguments
-	if len(strings) > 0 {
-		tmpRet = _mock.Called(context1, strings)
-	} else {
-		tmpRet = _mock.Called(context1)
-	}
-	ret := tmpRet
+// BanRoom provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) BanRoom(context1 context.Context, roomBan *model.RoomBan) error {
+	ret := _mock.Called(context1, roomBan)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReportedRooms")
+		panic("no return value specified for BanRoom")
 	}
 
-	var r0 map[string]string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) (map[string]string, error)); ok {
-		return returnFunc(context1, strings...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) map[string]string); ok {
-		r0 = returnFunc(context1, strings...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]string)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
-		r1 = returnFunc(context1, strings...)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.RoomBan) error); ok {
+		r0 = returnFunc(context1, roomBan)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDataRepository_GetReportedRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReportedRooms'
-type MockDataRepository_GetReportedRooms_Call struct {
+// MockDataRepository_BanRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanRoom'
+type MockDataRepository_BanRoom_Call struct {
 	*mock.Call
 }
 
-// GetReportedRooms is a helper method to define mock.On call
+// BanRoom is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings ...string
-func (_e *MockDataRepository_Expecter) GetReportedRooms(context1 interface{}, strings ...interface{}) *MockDataRepository_GetReportedRooms_Call {
-	return &MockDataRepository_GetReportedRooms_Call{Call: _e.mock.On("GetReportedRooms",
-		append([]interface{}{context1}, strings...)...)}
+//   - roomBan *model.RoomBan
+func (_e *MockDataRepository_Expecter) BanRoom(context1 interface{}, roomBan interface{}) *MockDataRepository_BanRoom_Call {
+	return &MockDataRepository_BanRoom_Call{Call: _e.mock.On("BanRoom", context1, roomBan)}
 }
 
-func (_c *MockDataRepository_GetReportedRooms_Call) Run(run func(context1 context.Context, strings ...string)) *MockDataRepository_GetReportedRooms_Call {
+func (_c *MockDataRepository_BanRoom_Call) Run(run func(context1 context.Context, roomBan *model.RoomBan)) *MockDataRepository_BanRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
-		var variadicArgs []string
-		if len(args) > 1 {
-			variadicArgs = args[1].([]string)
+		var arg1 *model.RoomBan
+		if args[1] != nil {
+			arg1 = args[1].(*model.RoomBan)
 		}
-		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1...,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_GetReportedRooms_Call) Return(stringToString map[string]string, err error) *MockDataRepository_GetReportedRooms_Call {
-	_c.Call.Return(stringToString, err)
+func (_c *MockDataRepository_BanRoom_Call) Return(err error) *MockDataRepository_BanRoom_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDataRepository_GetReportedRooms_Call) RunAndReturn(run func(context1 context.Context, strings ...string) (map[string]string, error)) *MockDataRepository_GetReportedRooms_Call {
+func (_c *MockDataRepository_BanRoom_Call) RunAndReturn(run func(context1 context.Context, roomBan *model.RoomBan) error) *MockDataRepository_BanRoom_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRoom provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) GetRoom(context1 context.Context, s string) (*model.MatrixRoom, error) {
-	ret := _mock.Called(context1, s)
+// BatchServers provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) BatchServers(context1 context.Context, strings []string) error {
+	ret := _mock.Called(context1, strings)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoom")
+		panic("no return value specified for BatchServers")
 	}
 
-	var r0 *model.MatrixRoom
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixRoom, error)); ok {
-		return returnFunc(context1, s)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixRoom); ok {
-		r0 = returnFunc(context1, s)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.MatrixRoom)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(context1, s)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = returnFunc(context1, strings)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDataRepository_GetRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoom'
-type MockDataRepository_GetRoom_Call struct {
+// MockDataRepository_BatchServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchServers'
+type MockDataRepository_BatchServers_Call struct {
 	*mock.Call
 }
 
-// GetRoom is a helper method to define mock.On call
+// BatchServers is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-func (_e *MockDataRepository_Expecter) GetRoom(context1 interface{}, s interface{}) *MockDataRepository_GetRoom_Call {
-	return &MockDataRepository_GetRoom_Call{Call: _e.mock.On("GetRoom", context1, s)}
+//   - strings []string
+func (_e *MockDataRepository_Expecter) BatchServers(context1 interface{}, strings interface{}) *MockDataRepository_BatchServers_Call {
+	return &MockDataRepository_BatchServers_Call{Call: _e.mock.On("BatchServers", context1, strings)}
 }
 
-func (_c *MockDataRepository_GetRoom_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetRoom_Call {
+func (_c *MockDataRepository_BatchServers_Call) Run(run func(context1 context.Context, strings []string)) *MockDataRepository_BatchServers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -1032,54 +1202,43 @@ func (_c *MockDataRepository_GetRoom_Call) Run(run func(context1 context.Context
 	return _c
 }
 
-func (_c *MockDataRepository_GetRoom_Call) Return(matrixRoom *model.MatrixRoom, err error) *MockDataRepository_GetRoom_Call {
-	_c.Call.Return(matrixRoom, err)
+func (_c *MockDataRepository_BatchServers_Call) Return(err error) *MockDataRepository_BatchServers_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDataRepository_GetRoom_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.MatrixRoom, error)) *MockDataRepository_GetRoom_Call {
+func (_c *MockDataRepository_BatchServers_Call) RunAndReturn(run func(context1 context.Context, strings []string) error) *MockDataRepository_BatchServers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRoomMapping provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) GetRoomMapping(context1 context.Context, s string) string {
-	ret := _mock.Called(context1, s)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetRoomMapping")
-	}
-
-	var r0 string
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(context1, s)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	return r0
+// EachRoom provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) EachRoom(context1 context.Context, fn func(string, *model.MatrixRoom) bool) {
+	_mock.Called(context1, fn)
+	return
 }
 
-// MockDataRepository_GetRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomMapping'
-type MockDataRepository_GetRoomMapping_Call struct {
+// MockDataRepository_EachRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachRoom'
+type MockDataRepository_EachRoom_Call struct {
 	*mock.Call
 }
 
-// GetRoomMapping is a helper method to define mock.On call
+// EachRoom is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-func (_e *MockDataRepository_Expecter) GetRoomMapping(context1 interface{}, s interface{}) *MockDataRepository_GetRoomMapping_Call {
-	return &MockDataRepository_GetRoomMapping_Call{Call: _e.mock.On("GetRoomMapping", context1, s)}
+//   - fn func(string, *model.MatrixRoom) bool
+func (_e *MockDataRepository_Expecter) EachRoom(context1 interface{}, fn interface{}) *MockDataRepository_EachRoom_Call {
+	return &MockDataRepository_EachRoom_Call{Call: _e.mock.On("EachRoom", context1, fn)}
 }
 
-func (_c *MockDataRepository_GetRoomMapping_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetRoomMapping_Call {
+func (_c *MockDataRepository_EachRoom_Call) Run(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *MockDataRepository_EachRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 func(string, *model.MatrixRoom) bool
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(func(string, *model.MatrixRoom) bool)
 		}
 		run(
 			arg0,
@@ -1089,65 +1248,43 @@ func (_c *MockDataRepository_GetRoomMapping_Call) Run(run func(context1 context.
 	return _c
 }
 
-func (_c *MockDataRepository_GetRoomMapping_Call) Return(s1 string) *MockDataRepository_GetRoomMapping_Call {
-	_c.Call.Return(s1)
+func (_c *MockDataRepository_EachRoom_Call) Return() *MockDataRepository_EachRoom_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockDataRepository_GetRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string) string) *MockDataRepository_GetRoomMapping_Call {
-	_c.Call.Return(run)
+func (_c *MockDataRepository_EachRoom_Call) RunAndReturn(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *MockDataRepository_EachRoom_Call {
+	_c.Run(run)
 	return _c
 }
 
-// GetServerInfo provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) GetServerInfo(context1 context.Context, s string) (*model.MatrixServer, error) {
-	ret := _mock.Called(context1, s)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetServerInfo")
-	}
-
-	var r0 *model.MatrixServer
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixServer, error)); ok {
-		return returnFunc(context1, s)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixServer); ok {
-		r0 = returnFunc(context1, s)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.MatrixServer)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(context1, s)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
+// EachRoomHistory provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) EachRoomHistory(context1 context.Context, fn func(string, *model.RoomHistory) bool) {
+	_mock.Called(context1, fn)
+	return
 }
 
-// MockDataRepository_GetServerInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerInfo'
-type MockDataRepository_GetServerInfo_Call struct {
+// MockDataRepository_EachRoomHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachRoomHistory'
+type MockDataRepository_EachRoomHistory_Call struct {
 	*mock.Call
 }
 
-// GetServerInfo is a helper method to define mock.On call
+// EachRoomHistory is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-func (_e *MockDataRepository_Expecter) GetServerInfo(context1 interface{}, s interface{}) *MockDataRepository_GetServerInfo_Call {
-	return &MockDataRepository_GetServerInfo_Call{Call: _e.mock.On("GetServerInfo", context1, s)}
+//   - fn func(string, *model.RoomHistory) bool
+func (_e *MockDataRepository_Expecter) EachRoomHistory(context1 interface{}, fn interface{}) *MockDataRepository_EachRoomHistory_Call {
+	return &MockDataRepository_EachRoomHistory_Call{Call: _e.mock.On("EachRoomHistory", context1, fn)}
 }
 
-func (_c *MockDataRepository_GetServerInfo_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetServerInfo_Call {
+func (_c *MockDataRepository_EachRoomHistory_Call) Run(run func(context1 context.Context, fn func(string, *model.RoomHistory) bool)) *MockDataRepository_EachRoomHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 func(string, *model.RoomHistory) bool
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(func(string, *model.RoomHistory) bool)
 		}
 		run(
 			arg0,
@@ -1157,54 +1294,56 @@ func (_c *MockDataRepository_GetServerInfo_Call) Run(run func(context1 context.C
 	return _c
 }
 
-func (_c *MockDataRepository_GetServerInfo_Call) Return(matrixServer *model.MatrixServer, err error) *MockDataRepository_GetServerInfo_Call {
-	_c.Call.Return(matrixServer, err)
+func (_c *MockDataRepository_EachRoomHistory_Call) Return() *MockDataRepository_EachRoomHistory_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockDataRepository_GetServerInfo_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.MatrixServer, error)) *MockDataRepository_GetServerInfo_Call {
-	_c.Call.Return(run)
+func (_c *MockDataRepository_EachRoomHistory_Call) RunAndReturn(run func(context1 context.Context, fn func(string, *model.RoomHistory) bool)) *MockDataRepository_EachRoomHistory_Call {
+	_c.Run(run)
 	return _c
 }
 
-// HasServer provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) HasServer(context1 context.Context, s string) bool {
-	ret := _mock.Called(context1, s)
+// FilterServers provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) FilterServers(context1 context.Context, fn func(server *model.MatrixServer) bool) map[string]*model.MatrixServer {
+	ret := _mock.Called(context1, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HasServer")
+		panic("no return value specified for FilterServers")
 	}
 
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = returnFunc(context1, s)
+	var r0 map[string]*model.MatrixServer
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(server *model.MatrixServer) bool) map[string]*model.MatrixServer); ok {
+		r0 = returnFunc(context1, fn)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*model.MatrixServer)
+		}
 	}
 	return r0
 }
 
-// MockDataRepository_HasServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasServer'
-type MockDataRepository_HasServer_Call struct {
+// MockDataRepository_FilterServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterServers'
+type MockDataRepository_FilterServers_Call struct {
 	*mock.Call
 }
 
-// HasServer is a helper method to define mock.On call
+// FilterServers is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-func (_e *MockDataRepository_Expecter) HasServer(context1 interface{}, s interface{}) *MockDataRepository_HasServer_Call {
-	return &MockDataRepository_HasServer_Call{Call: _e.mock.On("HasServer", context1, s)}
+//   - fn func(server *model.MatrixServer) bool
+func (_e *MockDataRepository_Expecter) FilterServers(context1 interface{}, fn interface{}) *MockDataRepository_FilterServers_Call {
+	return &MockDataRepository_FilterServers_Call{Call: _e.mock.On("FilterServers", context1, fn)}
 }
 
-func (_c *MockDataRepository_HasServer_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_HasServer_Call {
+func (_c *MockDataRepository_FilterServers_Call) Run(run func(context1 context.Context, fn func(server *model.MatrixServer) bool)) *MockDataRepository_FilterServers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 func(server *model.MatrixServer) bool
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(func(server *model.MatrixServer) bool)
 		}
 		run(
 			arg0,
@@ -1214,100 +1353,105 @@ func (_c *MockDataRepository_HasServer_Call) Run(run func(context1 context.Conte
 	return _c
 }
 
-func (_c *MockDataRepository_HasServer_Call) Return(b bool) *MockDataRepository_HasServer_Call {
-	_c.Call.Return(b)
+func (_c *MockDataRepository_FilterServers_Call) Return(stringToMatrixServer map[string]*model.MatrixServer) *MockDataRepository_FilterServers_Call {
+	_c.Call.Return(stringToMatrixServer)
 	return _c
 }
 
-func (_c *MockDataRepository_HasServer_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockDataRepository_HasServer_Call {
+func (_c *MockDataRepository_FilterServers_Call) RunAndReturn(run func(context1 context.Context, fn func(server *model.MatrixServer) bool) map[string]*model.MatrixServer) *MockDataRepository_FilterServers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsReported provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) IsReported(context1 context.Context, s string) bool {
-	ret := _mock.Called(context1, s)
-
-	if len(ret) == 0 {
-		panic("no return value specified for IsReported")
-	}
-
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = returnFunc(context1, s)
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-	return r0
+// FlushRoomBatch provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) FlushRoomBatch(context1 context.Context) {
+	_mock.Called(context1)
+	return
 }
 
-// MockDataRepository_IsReported_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsReported'
-type MockDataRepository_IsReported_Call struct {
+// MockDataRepository_FlushRoomBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushRoomBatch'
+type MockDataRepository_FlushRoomBatch_Call struct {
 	*mock.Call
 }
 
-// IsReported is a helper method to define mock.On call
+// FlushRoomBatch is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-func (_e *MockDataRepository_Expecter) IsReported(context1 interface{}, s interface{}) *MockDataRepository_IsReported_Call {
-	return &MockDataRepository_IsReported_Call{Call: _e.mock.On("IsReported", context1, s)}
+func (_e *MockDataRepository_Expecter) FlushRoomBatch(context1 interface{}) *MockDataRepository_FlushRoomBatch_Call {
+	return &MockDataRepository_FlushRoomBatch_Call{Call: _e.mock.On("FlushRoomBatch", context1)}
 }
 
-func (_c *MockDataRepository_IsReported_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_IsReported_Call {
+func (_c *MockDataRepository_FlushRoomBatch_Call) Run(run func(context1 context.Context)) *MockDataRepository_FlushRoomBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_IsReported_Call) Return(b bool) *MockDataRepository_IsReported_Call {
-	_c.Call.Return(b)
+func (_c *MockDataRepository_FlushRoomBatch_Call) Return() *MockDataRepository_FlushRoomBatch_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockDataRepository_IsReported_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockDataRepository_IsReported_Call {
-	_c.Call.Return(run)
+func (_c *MockDataRepository_FlushRoomBatch_Call) RunAndReturn(run func(context1 context.Context)) *MockDataRepository_FlushRoomBatch_Call {
+	_c.Run(run)
 	return _c
 }
 
-// MarkServersOffline provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) MarkServersOffline(context1 context.Context, strings []string) {
-	_mock.Called(context1, strings)
-	return
+// GetAppeal provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetAppeal(context1 context.Context, s string) (*model.Appeal, error) {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppeal")
+	}
+
+	var r0 *model.Appeal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Appeal, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Appeal); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Appeal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_MarkServersOffline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkServersOffline'
-type MockDataRepository_MarkServersOffline_Call struct {
+// MockDataRepository_GetAppeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppeal'
+type MockDataRepository_GetAppeal_Call struct {
 	*mock.Call
 }
 
-// MarkServersOffline is a helper method to define mock.On call
+// GetAppeal is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings []string
-func (_e *MockDataRepository_Expecter) MarkServersOffline(context1 interface{}, strings interface{}) *MockDataRepository_MarkServersOffline_Call {
-	return &MockDataRepository_MarkServersOffline_Call{Call: _e.mock.On("MarkServersOffline", context1, strings)}
+//   - s string
+func (_e *MockDataRepository_Expecter) GetAppeal(context1 interface{}, s interface{}) *MockDataRepository_GetAppeal_Call {
+	return &MockDataRepository_GetAppeal_Call{Call: _e.mock.On("GetAppeal", context1, s)}
 }
 
-func (_c *MockDataRepository_MarkServersOffline_Call) Run(run func(context1 context.Context, strings []string)) *MockDataRepository_MarkServersOffline_Call {
+func (_c *MockDataRepository_GetAppeal_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetAppeal_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -1317,381 +1461,503 @@ func (_c *MockDataRepository_MarkServersOffline_Call) Run(run func(context1 cont
 	return _c
 }
 
-func (_c *MockDataRepository_MarkServersOffline_Call) Return() *MockDataRepository_MarkServersOffline_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_GetAppeal_Call) Return(appeal *model.Appeal, err error) *MockDataRepository_GetAppeal_Call {
+	_c.Call.Return(appeal, err)
 	return _c
 }
 
-func (_c *MockDataRepository_MarkServersOffline_Call) RunAndReturn(run func(context1 context.Context, strings []string)) *MockDataRepository_MarkServersOffline_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_GetAppeal_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.Appeal, error)) *MockDataRepository_GetAppeal_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// RecreateRoomMapping provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) RecreateRoomMapping(context1 context.Context, stringToString map[string]string) error {
-	ret := _mock.Called(context1, stringToString)
+// GetAppeals provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetAppeals(context1 context.Context, strings ...string) ([]*model.Appeal, error) {
+	var tmpRet mock.Arguments
+	if len(strings) > 0 {
+		tmpRet = _mock.Called(context1, strings)
+	} else {
+		tmpRet = _mock.Called(context1)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for RecreateRoomMapping")
+		panic("no return value specified for GetAppeals")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]string) error); ok {
-		r0 = returnFunc(context1, stringToString)
+	var r0 []*model.Appeal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) ([]*model.Appeal, error)); ok {
+		return returnFunc(context1, strings...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) []*model.Appeal); ok {
+		r0 = returnFunc(context1, strings...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Appeal)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(context1, strings...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_RecreateRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecreateRoomMapping'
-type MockDataRepository_RecreateRoomMapping_Call struct {
+// MockDataRepository_GetAppeals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppeals'
+type MockDataRepository_GetAppeals_Call struct {
 	*mock.Call
 }
 
-// RecreateRoomMapping is a helper method to define mock.On call
+// GetAppeals is a helper method to define mock.On call
 //   - context1 context.Context
-//   - stringToString map[string]string
-func (_e *MockDataRepository_Expecter) RecreateRoomMapping(context1 interface{}, stringToString interface{}) *MockDataRepository_RecreateRoomMapping_Call {
-	return &MockDataRepository_RecreateRoomMapping_Call{Call: _e.mock.On("RecreateRoomMapping", context1, stringToString)}
+//   - strings ...string
+func (_e *MockDataRepository_Expecter) GetAppeals(context1 interface{}, strings ...interface{}) *MockDataRepository_GetAppeals_Call {
+	return &MockDataRepository_GetAppeals_Call{Call: _e.mock.On("GetAppeals",
+		append([]interface{}{context1}, strings...)...)}
 }
 
-func (_c *MockDataRepository_RecreateRoomMapping_Call) Run(run func(context1 context.Context, stringToString map[string]string)) *MockDataRepository_RecreateRoomMapping_Call {
+func (_c *MockDataRepository_GetAppeals_Call) Run(run func(context1 context.Context, strings ...string)) *MockDataRepository_GetAppeals_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 map[string]string
-		if args[1] != nil {
-			arg1 = args[1].(map[string]string)
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
 		}
+		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_RecreateRoomMapping_Call) Return(err error) *MockDataRepository_RecreateRoomMapping_Call {
-	_c.Call.Return(err)
+func (_c *MockDataRepository_GetAppeals_Call) Return(appeals []*model.Appeal, err error) *MockDataRepository_GetAppeals_Call {
+	_c.Call.Return(appeals, err)
 	return _c
 }
 
-func (_c *MockDataRepository_RecreateRoomMapping_Call) RunAndReturn(run func(context1 context.Context, stringToString map[string]string) error) *MockDataRepository_RecreateRoomMapping_Call {
+func (_c *MockDataRepository_GetAppeals_Call) RunAndReturn(run func(context1 context.Context, strings ...string) ([]*model.Appeal, error)) *MockDataRepository_GetAppeals_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveRoomMapping provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) RemoveRoomMapping(context1 context.Context, s string, s1 string) {
-	_mock.Called(context1, s, s1)
-	return
+// GetAuditLog provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetAuditLog(context1 context.Context, auditFilter *model.AuditFilter) ([]*model.AuditEntry, error) {
+	ret := _mock.Called(context1, auditFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuditLog")
+	}
+
+	var r0 []*model.AuditEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.AuditFilter) ([]*model.AuditEntry, error)); ok {
+		return returnFunc(context1, auditFilter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.AuditFilter) []*model.AuditEntry); ok {
+		r0 = returnFunc(context1, auditFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AuditEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.AuditFilter) error); ok {
+		r1 = returnFunc(context1, auditFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_RemoveRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveRoomMapping'
-type MockDataRepository_RemoveRoomMapping_Call struct {
+// MockDataRepository_GetAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLog'
+type MockDataRepository_GetAuditLog_Call struct {
 	*mock.Call
 }
 
-// RemoveRoomMapping is a helper method to define mock.On call
+// GetAuditLog is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-//   - s1 string
-func (_e *MockDataRepository_Expecter) RemoveRoomMapping(context1 interface{}, s interface{}, s1 interface{}) *MockDataRepository_RemoveRoomMapping_Call {
-	return &MockDataRepository_RemoveRoomMapping_Call{Call: _e.mock.On("RemoveRoomMapping", context1, s, s1)}
+//   - auditFilter *model.AuditFilter
+func (_e *MockDataRepository_Expecter) GetAuditLog(context1 interface{}, auditFilter interface{}) *MockDataRepository_GetAuditLog_Call {
+	return &MockDataRepository_GetAuditLog_Call{Call: _e.mock.On("GetAuditLog", context1, auditFilter)}
 }
 
-func (_c *MockDataRepository_RemoveRoomMapping_Call) Run(run func(context1 context.Context, s string, s1 string)) *MockDataRepository_RemoveRoomMapping_Call {
+func (_c *MockDataRepository_GetAuditLog_Call) Run(run func(context1 context.Context, auditFilter *model.AuditFilter)) *MockDataRepository_GetAuditLog_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *model.AuditFilter
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*model.AuditFilter)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_RemoveRoomMapping_Call) Return() *MockDataRepository_RemoveRoomMapping_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_GetAuditLog_Call) Return(auditEntrys []*model.AuditEntry, err error) *MockDataRepository_GetAuditLog_Call {
+	_c.Call.Return(auditEntrys, err)
 	return _c
 }
 
-func (_c *MockDataRepository_RemoveRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string)) *MockDataRepository_RemoveRoomMapping_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_GetAuditLog_Call) RunAndReturn(run func(context1 context.Context, auditFilter *model.AuditFilter) ([]*model.AuditEntry, error)) *MockDataRepository_GetAuditLog_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveRooms provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) RemoveRooms(context1 context.Context, strings []string) {
-	_mock.Called(context1, strings)
-	return
+// GetBanRules provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetBanRules(context1 context.Context) ([]*model.BanRule, error) {
+	ret := _mock.Called(context1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBanRules")
+	}
+
+	var r0 []*model.BanRule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*model.BanRule, error)); ok {
+		return returnFunc(context1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*model.BanRule); ok {
+		r0 = returnFunc(context1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BanRule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(context1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_RemoveRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveRooms'
-type MockDataRepository_RemoveRooms_Call struct {
+// MockDataRepository_GetBanRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBanRules'
+type MockDataRepository_GetBanRules_Call struct {
 	*mock.Call
 }
 
-// RemoveRooms is a helper method to define mock.On call
+// GetBanRules is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings []string
-func (_e *MockDataRepository_Expecter) RemoveRooms(context1 interface{}, strings interface{}) *MockDataRepository_RemoveRooms_Call {
-	return &MockDataRepository_RemoveRooms_Call{Call: _e.mock.On("RemoveRooms", context1, strings)}
+func (_e *MockDataRepository_Expecter) GetBanRules(context1 interface{}) *MockDataRepository_GetBanRules_Call {
+	return &MockDataRepository_GetBanRules_Call{Call: _e.mock.On("GetBanRules", context1)}
 }
 
-func (_c *MockDataRepository_RemoveRooms_Call) Run(run func(context1 context.Context, strings []string)) *MockDataRepository_RemoveRooms_Call {
+func (_c *MockDataRepository_GetBanRules_Call) Run(run func(context1 context.Context)) *MockDataRepository_GetBanRules_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
-		if args[1] != nil {
-			arg1 = args[1].([]string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_RemoveRooms_Call) Return() *MockDataRepository_RemoveRooms_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_GetBanRules_Call) Return(banRules []*model.BanRule, err error) *MockDataRepository_GetBanRules_Call {
+	_c.Call.Return(banRules, err)
 	return _c
 }
 
-func (_c *MockDataRepository_RemoveRooms_Call) RunAndReturn(run func(context1 context.Context, strings []string)) *MockDataRepository_RemoveRooms_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_GetBanRules_Call) RunAndReturn(run func(context1 context.Context) ([]*model.BanRule, error)) *MockDataRepository_GetBanRules_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveServer provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) RemoveServer(context1 context.Context, s string) error {
-	ret := _mock.Called(context1, s)
+// GetBannedRooms provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetBannedRooms(context1 context.Context, strings ...string) ([]string, error) {
+	var tmpRet mock.Arguments
+	if len(strings) > 0 {
+		tmpRet = _mock.Called(context1, strings)
+	} else {
+		tmpRet = _mock.Called(context1)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveServer")
+		panic("no return value specified for GetBannedRooms")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(context1, s)
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) ([]string, error)); ok {
+		return returnFunc(context1, strings...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) []string); ok {
+		r0 = returnFunc(context1, strings...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(context1, strings...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_RemoveServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveServer'
-type MockDataRepository_RemoveServer_Call struct {
+// MockDataRepository_GetBannedRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBannedRooms'
+type MockDataRepository_GetBannedRooms_Call struct {
 	*mock.Call
 }
 
-// RemoveServer is a helper method to define mock.On call
+// GetBannedRooms is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-func (_e *MockDataRepository_Expecter) RemoveServer(context1 interface{}, s interface{}) *MockDataRepository_RemoveServer_Call {
-	return &MockDataRepository_RemoveServer_Call{Call: _e.mock.On("RemoveServer", context1, s)}
+//   - strings ...string
+func (_e *MockDataRepository_Expecter) GetBannedRooms(context1 interface{}, strings ...interface{}) *MockDataRepository_GetBannedRooms_Call {
+	return &MockDataRepository_GetBannedRooms_Call{Call: _e.mock.On("GetBannedRooms",
+		append([]interface{}{context1}, strings...)...)}
 }
 
-func (_c *MockDataRepository_RemoveServer_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_RemoveServer_Call {
+func (_c *MockDataRepository_GetBannedRooms_Call) Run(run func(context1 context.Context, strings ...string)) *MockDataRepository_GetBannedRooms_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
 		}
+		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_RemoveServer_Call) Return(err error) *MockDataRepository_RemoveServer_Call {
-	_c.Call.Return(err)
+func (_c *MockDataRepository_GetBannedRooms_Call) Return(strings1 []string, err error) *MockDataRepository_GetBannedRooms_Call {
+	_c.Call.Return(strings1, err)
 	return _c
 }
 
-func (_c *MockDataRepository_RemoveServer_Call) RunAndReturn(run func(context1 context.Context, s string) error) *MockDataRepository_RemoveServer_Call {
+func (_c *MockDataRepository_GetBannedRooms_Call) RunAndReturn(run func(context1 context.Context, strings ...string) ([]string, error)) *MockDataRepository_GetBannedRooms_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveServers provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) RemoveServers(context1 context.Context, strings []string) {
-	_mock.Called(context1, strings)
-	return
+// GetQuarantinedRooms provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetQuarantinedRooms(context1 context.Context, strings ...string) ([]*model.RoomQuarantine, error) {
+	var tmpRet mock.Arguments
+	if len(strings) > 0 {
+		tmpRet = _mock.Called(context1, strings)
+	} else {
+		tmpRet = _mock.Called(context1)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuarantinedRooms")
+	}
+
+	var r0 []*model.RoomQuarantine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) ([]*model.RoomQuarantine, error)); ok {
+		return returnFunc(context1, strings...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) []*model.RoomQuarantine); ok {
+		r0 = returnFunc(context1, strings...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RoomQuarantine)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(context1, strings...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_RemoveServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveServers'
-type MockDataRepository_RemoveServers_Call struct {
+// MockDataRepository_GetQuarantinedRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuarantinedRooms'
+type MockDataRepository_GetQuarantinedRooms_Call struct {
 	*mock.Call
 }
 
-// RemoveServers is a helper method to define mock.On call
+// GetQuarantinedRooms is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings []string
-func (_e *MockDataRepository_Expecter) RemoveServers(context1 interface{}, strings interface{}) *MockDataRepository_RemoveServers_Call {
-	return &MockDataRepository_RemoveServers_Call{Call: _e.mock.On("RemoveServers", context1, strings)}
+//   - strings ...string
+func (_e *MockDataRepository_Expecter) GetQuarantinedRooms(context1 interface{}, strings ...interface{}) *MockDataRepository_GetQuarantinedRooms_Call {
+	return &MockDataRepository_GetQuarantinedRooms_Call{Call: _e.mock.On("GetQuarantinedRooms",
+		append([]interface{}{context1}, strings...)...)}
 }
 
-func (_c *MockDataRepository_RemoveServers_Call) Run(run func(context1 context.Context, strings []string)) *MockDataRepository_RemoveServers_Call {
+func (_c *MockDataRepository_GetQuarantinedRooms_Call) Run(run func(context1 context.Context, strings ...string)) *MockDataRepository_GetQuarantinedRooms_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
 		var arg1 []string
-		if args[1] != nil {
-			arg1 = args[1].([]string)
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
 		}
+		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_RemoveServers_Call) Return() *MockDataRepository_RemoveServers_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_GetQuarantinedRooms_Call) Return(roomQuarantines []*model.RoomQuarantine, err error) *MockDataRepository_GetQuarantinedRooms_Call {
+	_c.Call.Return(roomQuarantines, err)
 	return _c
 }
 
-func (_c *MockDataRepository_RemoveServers_Call) RunAndReturn(run func(context1 context.Context, strings []string)) *MockDataRepository_RemoveServers_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_GetQuarantinedRooms_Call) RunAndReturn(run func(context1 context.Context, strings ...string) ([]*model.RoomQuarantine, error)) *MockDataRepository_GetQuarantinedRooms_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// ReportRoom provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) ReportRoom(context1 context.Context, s string, s1 string, s2 string) error {
-	ret := _mock.Called(context1, s, s1, s2)
+// GetReportedRooms provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetReportedRooms(context1 context.Context, strings ...string) (map[string]*model.RoomReports, error) {
+	var tmpRet mock.Arguments
+	if len(strings) > 0 {
+		tmpRet = _mock.Called(context1, strings)
+	} else {
+		tmpRet = _mock.Called(context1)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for ReportRoom")
+		panic("no return value specified for GetReportedRooms")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = returnFunc(context1, s, s1, s2)
+	var r0 map[string]*model.RoomReports
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) (map[string]*model.RoomReports, error)); ok {
+		return returnFunc(context1, strings...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) map[string]*model.RoomReports); ok {
+		r0 = returnFunc(context1, strings...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*model.RoomReports)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(context1, strings...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_ReportRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReportRoom'
-type MockDataRepository_ReportRoom_Call struct {
+// MockDataRepository_GetReportedRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReportedRooms'
+type MockDataRepository_GetReportedRooms_Call struct {
 	*mock.Call
 }
 
-// ReportRoom is a helper method to define mock.On call
+// GetReportedRooms is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-//   - s1 string
-//   - s2 string
-func (_e *MockDataRepository_Expecter) ReportRoom(context1 interface{}, s interface{}, s1 interface{}, s2 interface{}) *MockDataRepository_ReportRoom_Call {
-	return &MockDataRepository_ReportRoom_Call{Call: _e.mock.On("ReportRoom", context1, s, s1, s2)}
+//   - strings ...string
+func (_e *MockDataRepository_Expecter) GetReportedRooms(context1 interface{}, strings ...interface{}) *MockDataRepository_GetReportedRooms_Call {
+	return &MockDataRepository_GetReportedRooms_Call{Call: _e.mock.On("GetReportedRooms",
+		append([]interface{}{context1}, strings...)...)}
 }
 
-func (_c *MockDataRepository_ReportRoom_Call) Run(run func(context1 context.Context, s string, s1 string, s2 string)) *MockDataRepository_ReportRoom_Call {
+func (_c *MockDataRepository_GetReportedRooms_Call) Run(run func(context1 context.Context, strings ...string)) *MockDataRepository_GetReportedRooms_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
 		}
+		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1,
-			arg2,
-			arg3,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_ReportRoom_Call) Return(err error) *MockDataRepository_ReportRoom_Call {
-	_c.Call.Return(err)
+func (_c *MockDataRepository_GetReportedRooms_Call) Return(stringToRoomReports map[string]*model.RoomReports, err error) *MockDataRepository_GetReportedRooms_Call {
+	_c.Call.Return(stringToRoomReports, err)
 	return _c
 }
 
-func (_c *MockDataRepository_ReportRoom_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string, s2 string) error) *MockDataRepository_ReportRoom_Call {
+func (_c *MockDataRepository_GetReportedRooms_Call) RunAndReturn(run func(context1 context.Context, strings ...string) (map[string]*model.RoomReports, error)) *MockDataRepository_GetReportedRooms_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetBiggestRooms provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) SetBiggestRooms(context1 context.Context, strings []string) error {
-	ret := _mock.Called(context1, strings)
+// GetRoom provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetRoom(context1 context.Context, s string) (*model.MatrixRoom, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetBiggestRooms")
+		panic("no return value specified for GetRoom")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
-		r0 = returnFunc(context1, strings)
+	var r0 *model.MatrixRoom
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixRoom, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixRoom); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MatrixRoom)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_SetBiggestRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBiggestRooms'
-type MockDataRepository_SetBiggestRooms_Call struct {
+// MockDataRepository_GetRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoom'
+type MockDataRepository_GetRoom_Call struct {
 	*mock.Call
 }
 
-// SetBiggestRooms is a helper method to define mock.On call
+// GetRoom is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings []string
-func (_e *MockDataRepository_Expecter) SetBiggestRooms(context1 interface{}, strings interface{}) *MockDataRepository_SetBiggestRooms_Call {
-	return &MockDataRepository_SetBiggestRooms_Call{Call: _e.mock.On("SetBiggestRooms", context1, strings)}
+//   - s string
+func (_e *MockDataRepository_Expecter) GetRoom(context1 interface{}, s interface{}) *MockDataRepository_GetRoom_Call {
+	return &MockDataRepository_GetRoom_Call{Call: _e.mock.On("GetRoom", context1, s)}
 }
 
-func (_c *MockDataRepository_SetBiggestRooms_Call) Run(run func(context1 context.Context, strings []string)) *MockDataRepository_SetBiggestRooms_Call {
+func (_c *MockDataRepository_GetRoom_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -1701,46 +1967,57 @@ func (_c *MockDataRepository_SetBiggestRooms_Call) Run(run func(context1 context
 	return _c
 }
 
-func (_c *MockDataRepository_SetBiggestRooms_Call) Return(err error) *MockDataRepository_SetBiggestRooms_Call {
-	_c.Call.Return(err)
+func (_c *MockDataRepository_GetRoom_Call) Return(matrixRoom *model.MatrixRoom, err error) *MockDataRepository_GetRoom_Call {
+	_c.Call.Return(matrixRoom, err)
 	return _c
 }
 
-func (_c *MockDataRepository_SetBiggestRooms_Call) RunAndReturn(run func(context1 context.Context, strings []string) error) *MockDataRepository_SetBiggestRooms_Call {
+func (_c *MockDataRepository_GetRoom_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.MatrixRoom, error)) *MockDataRepository_GetRoom_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UnbanRoom provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) UnbanRoom(context1 context.Context, s string) error {
+// GetRoomBan provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetRoomBan(context1 context.Context, s string) (*model.RoomBan, error) {
 	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UnbanRoom")
+		panic("no return value specified for GetRoomBan")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 *model.RoomBan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.RoomBan, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.RoomBan); ok {
 		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomBan)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_UnbanRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnbanRoom'
-type MockDataRepository_UnbanRoom_Call struct {
+// MockDataRepository_GetRoomBan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomBan'
+type MockDataRepository_GetRoomBan_Call struct {
 	*mock.Call
 }
 
-// UnbanRoom is a helper method to define mock.On call
+// GetRoomBan is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-func (_e *MockDataRepository_Expecter) UnbanRoom(context1 interface{}, s interface{}) *MockDataRepository_UnbanRoom_Call {
-	return &MockDataRepository_UnbanRoom_Call{Call: _e.mock.On("UnbanRoom", context1, s)}
+func (_e *MockDataRepository_Expecter) GetRoomBan(context1 interface{}, s interface{}) *MockDataRepository_GetRoomBan_Call {
+	return &MockDataRepository_GetRoomBan_Call{Call: _e.mock.On("GetRoomBan", context1, s)}
 }
 
-func (_c *MockDataRepository_UnbanRoom_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_UnbanRoom_Call {
+func (_c *MockDataRepository_GetRoomBan_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetRoomBan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1758,97 +2035,134 @@ func (_c *MockDataRepository_UnbanRoom_Call) Run(run func(context1 context.Conte
 	return _c
 }
 
-func (_c *MockDataRepository_UnbanRoom_Call) Return(err error) *MockDataRepository_UnbanRoom_Call {
-	_c.Call.Return(err)
+func (_c *MockDataRepository_GetRoomBan_Call) Return(roomBan *model.RoomBan, err error) *MockDataRepository_GetRoomBan_Call {
+	_c.Call.Return(roomBan, err)
 	return _c
 }
 
-func (_c *MockDataRepository_UnbanRoom_Call) RunAndReturn(run func(context1 context.Context, s string) error) *MockDataRepository_UnbanRoom_Call {
+func (_c *MockDataRepository_GetRoomBan_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.RoomBan, error)) *MockDataRepository_GetRoomBan_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UnreportAll provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) UnreportAll(context1 context.Context) error {
-	ret := _mock.Called(context1)
+// GetRoomBans provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetRoomBans(context1 context.Context, strings ...string) ([]*model.RoomBan, error) {
+	var tmpRet mock.Arguments
+	if len(strings) > 0 {
+		tmpRet = _mock.Called(context1, strings)
+	} else {
+		tmpRet = _mock.Called(context1)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for UnreportAll")
+		panic("no return value specified for GetRoomBans")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(context1)
+	var r0 []*model.RoomBan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) ([]*model.RoomBan, error)); ok {
+		return returnFunc(context1, strings...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) []*model.RoomBan); ok {
+		r0 = returnFunc(context1, strings...)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RoomBan)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(context1, strings...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_UnreportAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnreportAll'
-type MockDataRepository_UnreportAll_Call struct {
+// MockDataRepository_GetRoomBans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomBans'
+type MockDataRepository_GetRoomBans_Call struct {
 	*mock.Call
 }
 
-// UnreportAll is a helper method to define mock.On call
+// GetRoomBans is a helper method to define mock.On call
 //   - context1 context.Context
-func (_e *MockDataRepository_Expecter) UnreportAll(context1 interface{}) *MockDataRepository_UnreportAll_Call {
-	return &MockDataRepository_UnreportAll_Call{Call: _e.mock.On("UnreportAll", context1)}
+//   - strings ...string
+func (_e *MockDataRepository_Expecter) GetRoomBans(context1 interface{}, strings ...interface{}) *MockDataRepository_GetRoomBans_Call {
+	return &MockDataRepository_GetRoomBans_Call{Call: _e.mock.On("GetRoomBans",
+		append([]interface{}{context1}, strings...)...)}
 }
 
-func (_c *MockDataRepository_UnreportAll_Call) Run(run func(context1 context.Context)) *MockDataRepository_UnreportAll_Call {
+func (_c *MockDataRepository_GetRoomBans_Call) Run(run func(context1 context.Context, strings ...string)) *MockDataRepository_GetRoomBans_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
 		run(
 			arg0,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDataRepository_UnreportAll_Call) Return(err error) *MockDataRepository_UnreportAll_Call {
-	_c.Call.Return(err)
+func (_c *MockDataRepository_GetRoomBans_Call) Return(roomBans []*model.RoomBan, err error) *MockDataRepository_GetRoomBans_Call {
+	_c.Call.Return(roomBans, err)
 	return _c
 }
 
-func (_c *MockDataRepository_UnreportAll_Call) RunAndReturn(run func(context1 context.Context) error) *MockDataRepository_UnreportAll_Call {
+func (_c *MockDataRepository_GetRoomBans_Call) RunAndReturn(run func(context1 context.Context, strings ...string) ([]*model.RoomBan, error)) *MockDataRepository_GetRoomBans_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UnreportRoom provides a mock function for the type MockDataRepository
-func (_mock *MockDataRepository) UnreportRoom(context1 context.Context, s string) error {
+// GetRoomHistory provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetRoomHistory(context1 context.Context, s string) (*model.RoomHistory, error) {
 	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UnreportRoom")
+		panic("no return value specified for GetRoomHistory")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 *model.RoomHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.RoomHistory, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.RoomHistory); ok {
 		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomHistory)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDataRepository_UnreportRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnreportRoom'
-type MockDataRepository_UnreportRoom_Call struct {
+// MockDataRepository_GetRoomHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomHistory'
+type MockDataRepository_GetRoomHistory_Call struct {
 	*mock.Call
 }
 
-// UnreportRoom is a helper method to define mock.On call
+// GetRoomHistory is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-func (_e *MockDataRepository_Expecter) UnreportRoom(context1 interface{}, s interface{}) *MockDataRepository_UnreportRoom_Call {
-	return &MockDataRepository_UnreportRoom_Call{Call: _e.mock.On("UnreportRoom", context1, s)}
+func (_e *MockDataRepository_Expecter) GetRoomHistory(context1 interface{}, s interface{}) *MockDataRepository_GetRoomHistory_Call {
+	return &MockDataRepository_GetRoomHistory_Call{Call: _e.mock.On("GetRoomHistory", context1, s)}
 }
 
-func (_c *MockDataRepository_UnreportRoom_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_UnreportRoom_Call {
+func (_c *MockDataRepository_GetRoomHistory_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetRoomHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1866,124 +2180,114 @@ func (_c *MockDataRepository_UnreportRoom_Call) Run(run func(context1 context.Co
 	return _c
 }
 
-func (_c *MockDataRepository_UnreportRoom_Call) Return(err error) *MockDataRepository_UnreportRoom_Call {
-	_c.Call.Return(err)
+func (_c *MockDataRepository_GetRoomHistory_Call) Return(roomHistory *model.RoomHistory, err error) *MockDataRepository_GetRoomHistory_Call {
+	_c.Call.Return(roomHistory, err)
 	return _c
 }
 
-func (_c *MockDataRepository_UnreportRoom_Call) RunAndReturn(run func(context1 context.Context, s string) error) *MockDataRepository_UnreportRoom_Call {
+func (_c *MockDataRepository_GetRoomHistory_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.RoomHistory, error)) *MockDataRepository_GetRoomHistory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockValidatorService creates a new instance of MockValidatorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockValidatorService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockValidatorService {
-	mock := &MockValidatorService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockValidatorService is an autogenerated mock type for the ValidatorService type
-type MockValidatorService struct {
-	mock.Mock
-}
-
-type MockValidatorService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockValidatorService) EXPECT() *MockValidatorService_Expecter {
-	return &MockValidatorService_Expecter{mock: &_m.Mock}
-}
-
-// Domain provides a mock function for the type MockValidatorService
-func (_mock *MockValidatorService) Domain(server string) bool {
-	ret := _mock.Called(server)
+// GetRoomMapping provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetRoomMapping(context1 context.Context, s string) string {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Domain")
+		panic("no return value specified for GetRoomMapping")
 	}
 
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = returnFunc(server)
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(string)
 	}
 	return r0
 }
 
-// MockValidatorService_Domain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Domain'
-type MockValidatorService_Domain_Call struct {
+// MockDataRepository_GetRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomMapping'
+type MockDataRepository_GetRoomMapping_Call struct {
 	*mock.Call
 }
 
-// Domain is a helper method to define mock.On call
-//   - server string
-func (_e *MockValidatorService_Expecter) Domain(server interface{}) *MockValidatorService_Domain_Call {
-	return &MockValidatorService_Domain_Call{Call: _e.mock.On("Domain", server)}
+// GetRoomMapping is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) GetRoomMapping(context1 interface{}, s interface{}) *MockDataRepository_GetRoomMapping_Call {
+	return &MockDataRepository_GetRoomMapping_Call{Call: _e.mock.On("GetRoomMapping", context1, s)}
 }
 
-func (_c *MockValidatorService_Domain_Call) Run(run func(server string)) *MockValidatorService_Domain_Call {
+func (_c *MockDataRepository_GetRoomMapping_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetRoomMapping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockValidatorService_Domain_Call) Return(b bool) *MockValidatorService_Domain_Call {
-	_c.Call.Return(b)
+func (_c *MockDataRepository_GetRoomMapping_Call) Return(s1 string) *MockDataRepository_GetRoomMapping_Call {
+	_c.Call.Return(s1)
 	return _c
 }
 
-func (_c *MockValidatorService_Domain_Call) RunAndReturn(run func(server string) bool) *MockValidatorService_Domain_Call {
+func (_c *MockDataRepository_GetRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string) string) *MockDataRepository_GetRoomMapping_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsIndexable provides a mock function for the type MockValidatorService
-func (_mock *MockValidatorService) IsIndexable(ctx context.Context, server string) bool {
-	ret := _mock.Called(ctx, server)
+// GetRoomParents provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetRoomParents(context1 context.Context, s string) ([]string, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsIndexable")
+		panic("no return value specified for GetRoomParents")
 	}
 
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = returnFunc(ctx, server)
-	} else {
-		r0 = ret.Get(0).(bool)
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(context1, s)
 	}
-	return r0
-}
-
-// MockValidatorService_IsIndexable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsIndexable'
-type MockValidatorService_IsIndexable_Call struct {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDataRepository_GetRoomParents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomParents'
+type MockDataRepository_GetRoomParents_Call struct {
 	*mock.Call
 }
 
-// IsIndexable is a helper method to define mock.On call
-//   - ctx context.Context
-//   - server string
-func (_e *MockValidatorService_Expecter) IsIndexable(ctx interface{}, server interface{}) *MockValidatorService_IsIndexable_Call {
-	return &MockValidatorService_IsIndexable_Call{Call: _e.mock.On("IsIndexable", ctx, server)}
+// GetRoomParents is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) GetRoomParents(context1 interface{}, s interface{}) *MockDataRepository_GetRoomParents_Call {
+	return &MockDataRepository_GetRoomParents_Call{Call: _e.mock.On("GetRoomParents", context1, s)}
 }
 
-func (_c *MockValidatorService_IsIndexable_Call) Run(run func(ctx context.Context, server string)) *MockValidatorService_IsIndexable_Call {
+func (_c *MockDataRepository_GetRoomParents_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetRoomParents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2001,67 +2305,57 @@ func (_c *MockValidatorService_IsIndexable_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockValidatorService_IsIndexable_Call) Return(b bool) *MockValidatorService_IsIndexable_Call {
-	_c.Call.Return(b)
+func (_c *MockDataRepository_GetRoomParents_Call) Return(strings []string, err error) *MockDataRepository_GetRoomParents_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockValidatorService_IsIndexable_Call) RunAndReturn(run func(ctx context.Context, server string) bool) *MockValidatorService_IsIndexable_Call {
+func (_c *MockDataRepository_GetRoomParents_Call) RunAndReturn(run func(context1 context.Context, s string) ([]string, error)) *MockDataRepository_GetRoomParents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsOnline provides a mock function for the type MockValidatorService
-func (_mock *MockValidatorService) IsOnline(ctx context.Context, server string) (string, string, string, bool) {
-	ret := _mock.Called(ctx, server)
+// GetRoomQuarantine provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetRoomQuarantine(context1 context.Context, s string) (*model.RoomQuarantine, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsOnline")
+		panic("no return value specified for GetRoomQuarantine")
 	}
 
-	var r0 string
-	var r1 string
-	var r2 string
-	var r3 bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, string, string, bool)); ok {
-		return returnFunc(ctx, server)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(ctx, server)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) string); ok {
-		r1 = returnFunc(ctx, server)
-	} else {
-		r1 = ret.Get(1).(string)
+	var r0 *model.RoomQuarantine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.RoomQuarantine, error)); ok {
+		return returnFunc(context1, s)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string) string); ok {
-		r2 = returnFunc(ctx, server)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.RoomQuarantine); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r2 = ret.Get(2).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomQuarantine)
+		}
 	}
-	if returnFunc, ok := ret.Get(3).(func(context.Context, string) bool); ok {
-		r3 = returnFunc(ctx, server)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
 	} else {
-		r3 = ret.Get(3).(bool)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2, r3
+	return r0, r1
 }
 
-// MockValidatorService_IsOnline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsOnline'
-type MockValidatorService_IsOnline_Call struct {
+// MockDataRepository_GetRoomQuarantine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomQuarantine'
+type MockDataRepository_GetRoomQuarantine_Call struct {
 	*mock.Call
 }
 
-// IsOnline is a helper method to define mock.On call
-//   - ctx context.Context
-//   - server string
-func (_e *MockValidatorService_Expecter) IsOnline(ctx interface{}, server interface{}) *MockValidatorService_IsOnline_Call {
-	return &MockValidatorService_IsOnline_Call{Call: _e.mock.On("IsOnline", ctx, server)}
+// GetRoomQuarantine is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) GetRoomQuarantine(context1 interface{}, s interface{}) *MockDataRepository_GetRoomQuarantine_Call {
+	return &MockDataRepository_GetRoomQuarantine_Call{Call: _e.mock.On("GetRoomQuarantine", context1, s)}
 }
 
-func (_c *MockValidatorService_IsOnline_Call) Run(run func(ctx context.Context, server string)) *MockValidatorService_IsOnline_Call {
+func (_c *MockDataRepository_GetRoomQuarantine_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetRoomQuarantine_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2079,124 +2373,125 @@ func (_c *MockValidatorService_IsOnline_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockValidatorService_IsOnline_Call) Return(serverName string, serverSoftware string, serverVersion string, isOnline bool) *MockValidatorService_IsOnline_Call {
-	_c.Call.Return(serverName, serverSoftware, serverVersion, isOnline)
+func (_c *MockDataRepository_GetRoomQuarantine_Call) Return(roomQuarantine *model.RoomQuarantine, err error) *MockDataRepository_GetRoomQuarantine_Call {
+	_c.Call.Return(roomQuarantine, err)
 	return _c
 }
 
-func (_c *MockValidatorService_IsOnline_Call) RunAndReturn(run func(ctx context.Context, server string) (string, string, string, bool)) *MockValidatorService_IsOnline_Call {
+func (_c *MockDataRepository_GetRoomQuarantine_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.RoomQuarantine, error)) *MockDataRepository_GetRoomQuarantine_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsRoomAllowed provides a mock function for the type MockValidatorService
-func (_mock *MockValidatorService) IsRoomAllowed(room *model.MatrixRoom) bool {
-	ret := _mock.Called(room)
+// GetRoomReports provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetRoomReports(context1 context.Context, s string) (*model.RoomReports, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsRoomAllowed")
+		panic("no return value specified for GetRoomReports")
 	}
 
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(*model.MatrixRoom) bool); ok {
-		r0 = returnFunc(room)
+	var r0 *model.RoomReports
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.RoomReports, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.RoomReports); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomReports)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockValidatorService_IsRoomAllowed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRoomAllowed'
-type MockValidatorService_IsRoomAllowed_Call struct {
+// MockDataRepository_GetRoomReports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomReports'
+type MockDataRepository_GetRoomReports_Call struct {
 	*mock.Call
 }
 
-// IsRoomAllowed is a helper method to define mock.On call
-//   - room *model.MatrixRoom
-func (_e *MockValidatorService_Expecter) IsRoomAllowed(room interface{}) *MockValidatorService_IsRoomAllowed_Call {
-	return &MockValidatorService_IsRoomAllowed_Call{Call: _e.mock.On("IsRoomAllowed", room)}
+// GetRoomReports is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) GetRoomReports(context1 interface{}, s interface{}) *MockDataRepository_GetRoomReports_Call {
+	return &MockDataRepository_GetRoomReports_Call{Call: _e.mock.On("GetRoomReports", context1, s)}
 }
 
-func (_c *MockValidatorService_IsRoomAllowed_Call) Run(run func(room *model.MatrixRoom)) *MockValidatorService_IsRoomAllowed_Call {
+func (_c *MockDataRepository_GetRoomReports_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetRoomReports_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *model.MatrixRoom
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*model.MatrixRoom)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockValidatorService_IsRoomAllowed_Call) Return(b bool) *MockValidatorService_IsRoomAllowed_Call {
-	_c.Call.Return(b)
+func (_c *MockDataRepository_GetRoomReports_Call) Return(roomReports *model.RoomReports, err error) *MockDataRepository_GetRoomReports_Call {
+	_c.Call.Return(roomReports, err)
 	return _c
 }
 
-func (_c *MockValidatorService_IsRoomAllowed_Call) RunAndReturn(run func(room *model.MatrixRoom) bool) *MockValidatorService_IsRoomAllowed_Call {
+func (_c *MockDataRepository_GetRoomReports_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.RoomReports, error)) *MockDataRepository_GetRoomReports_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockFederationService creates a new instance of MockFederationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockFederationService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockFederationService {
-	mock := &MockFederationService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockFederationService is an autogenerated mock type for the FederationService type
-type MockFederationService struct {
-	mock.Mock
-}
-
-type MockFederationService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockFederationService) EXPECT() *MockFederationService_Expecter {
-	return &MockFederationService_Expecter{mock: &_m.Mock}
-}
-
-// QueryCSURL provides a mock function for the type MockFederationService
-func (_mock *MockFederationService) QueryCSURL(ctx context.Context, serverName string) string {
-	ret := _mock.Called(ctx, serverName)
+// GetServerInfo provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetServerInfo(context1 context.Context, s string) (*model.MatrixServer, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryCSURL")
+		panic("no return value specified for GetServerInfo")
 	}
 
-	var r0 string
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(ctx, serverName)
+	var r0 *model.MatrixServer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixServer, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixServer); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MatrixServer)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFederationService_QueryCSURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryCSURL'
-type MockFederationService_QueryCSURL_Call struct {
+// MockDataRepository_GetServerInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerInfo'
+type MockDataRepository_GetServerInfo_Call struct {
 	*mock.Call
 }
 
-// QueryCSURL is a helper method to define mock.On call
-//   - ctx context.Context
-//   - serverName string
-func (_e *MockFederationService_Expecter) QueryCSURL(ctx interface{}, serverName interface{}) *MockFederationService_QueryCSURL_Call {
-	return &MockFederationService_QueryCSURL_Call{Call: _e.mock.On("QueryCSURL", ctx, serverName)}
+// GetServerInfo is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) GetServerInfo(context1 interface{}, s interface{}) *MockDataRepository_GetServerInfo_Call {
+	return &MockDataRepository_GetServerInfo_Call{Call: _e.mock.On("GetServerInfo", context1, s)}
 }
 
-func (_c *MockFederationService_QueryCSURL_Call) Run(run func(ctx context.Context, serverName string)) *MockFederationService_QueryCSURL_Call {
+func (_c *MockDataRepository_GetServerInfo_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetServerInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2214,57 +2509,57 @@ func (_c *MockFederationService_QueryCSURL_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockFederationService_QueryCSURL_Call) Return(s string) *MockFederationService_QueryCSURL_Call {
-	_c.Call.Return(s)
+func (_c *MockDataRepository_GetServerInfo_Call) Return(matrixServer *model.MatrixServer, err error) *MockDataRepository_GetServerInfo_Call {
+	_c.Call.Return(matrixServer, err)
 	return _c
 }
 
-func (_c *MockFederationService_QueryCSURL_Call) RunAndReturn(run func(ctx context.Context, serverName string) string) *MockFederationService_QueryCSURL_Call {
+func (_c *MockDataRepository_GetServerInfo_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.MatrixServer, error)) *MockDataRepository_GetServerInfo_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryDirectoryExternal provides a mock function for the type MockFederationService
-func (_mock *MockFederationService) QueryDirectoryExternal(ctx context.Context, roomAlias string) (*model.QueryDirectoryResponse, error) {
-	ret := _mock.Called(ctx, roomAlias)
+// GetSpaceChildren provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetSpaceChildren(context1 context.Context, s string) ([]*model.MatrixSpaceChild, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryDirectoryExternal")
+		panic("no return value specified for GetSpaceChildren")
 	}
 
-	var r0 *model.QueryDirectoryResponse
+	var r0 []*model.MatrixSpaceChild
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.QueryDirectoryResponse, error)); ok {
-		return returnFunc(ctx, roomAlias)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.MatrixSpaceChild, error)); ok {
+		return returnFunc(context1, s)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.QueryDirectoryResponse); ok {
-		r0 = returnFunc(ctx, roomAlias)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.MatrixSpaceChild); ok {
+		r0 = returnFunc(context1, s)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.QueryDirectoryResponse)
+			r0 = ret.Get(0).([]*model.MatrixSpaceChild)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, roomAlias)
+		r1 = returnFunc(context1, s)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFederationService_QueryDirectoryExternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryDirectoryExternal'
-type MockFederationService_QueryDirectoryExternal_Call struct {
+// MockDataRepository_GetSpaceChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSpaceChildren'
+type MockDataRepository_GetSpaceChildren_Call struct {
 	*mock.Call
 }
 
-// QueryDirectoryExternal is a helper method to define mock.On call
-//   - ctx context.Context
-//   - roomAlias string
-func (_e *MockFederationService_Expecter) QueryDirectoryExternal(ctx interface{}, roomAlias interface{}) *MockFederationService_QueryDirectoryExternal_Call {
-	return &MockFederationService_QueryDirectoryExternal_Call{Call: _e.mock.On("QueryDirectoryExternal", ctx, roomAlias)}
+// GetSpaceChildren is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) GetSpaceChildren(context1 interface{}, s interface{}) *MockDataRepository_GetSpaceChildren_Call {
+	return &MockDataRepository_GetSpaceChildren_Call{Call: _e.mock.On("GetSpaceChildren", context1, s)}
 }
 
-func (_c *MockFederationService_QueryDirectoryExternal_Call) Run(run func(ctx context.Context, roomAlias string)) *MockFederationService_QueryDirectoryExternal_Call {
+func (_c *MockDataRepository_GetSpaceChildren_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_GetSpaceChildren_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2282,135 +2577,108 @@ func (_c *MockFederationService_QueryDirectoryExternal_Call) Run(run func(ctx co
 	return _c
 }
 
-func (_c *MockFederationService_QueryDirectoryExternal_Call) Return(queryDirectoryResponse *model.QueryDirectoryResponse, err error) *MockFederationService_QueryDirectoryExternal_Call {
-	_c.Call.Return(queryDirectoryResponse, err)
+func (_c *MockDataRepository_GetSpaceChildren_Call) Return(matrixSpaceChilds []*model.MatrixSpaceChild, err error) *MockDataRepository_GetSpaceChildren_Call {
+	_c.Call.Return(matrixSpaceChilds, err)
 	return _c
 }
 
-func (_c *MockFederationService_QueryDirectoryExternal_Call) RunAndReturn(run func(ctx context.Context, roomAlias string) (*model.QueryDirectoryResponse, error)) *MockFederationService_QueryDirectoryExternal_Call {
+func (_c *MockDataRepository_GetSpaceChildren_Call) RunAndReturn(run func(context1 context.Context, s string) ([]*model.MatrixSpaceChild, error)) *MockDataRepository_GetSpaceChildren_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryPublicRooms provides a mock function for the type MockFederationService
-func (_mock *MockFederationService) QueryPublicRooms(ctx context.Context, serverName string, limit string, since string) (*model.RoomDirectoryResponse, error) {
-	ret := _mock.Called(ctx, serverName, limit, since)
+// GetSpaceParents provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) GetSpaceParents(context1 context.Context) (map[string][]string, error) {
+	ret := _mock.Called(context1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryPublicRooms")
+		panic("no return value specified for GetSpaceParents")
 	}
 
-	var r0 *model.RoomDirectoryResponse
+	var r0 map[string][]string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.RoomDirectoryResponse, error)); ok {
-		return returnFunc(ctx, serverName, limit, since)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string][]string, error)); ok {
+		return returnFunc(context1)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *model.RoomDirectoryResponse); ok {
-		r0 = returnFunc(ctx, serverName, limit, since)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string][]string); ok {
+		r0 = returnFunc(context1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.RoomDirectoryResponse)
+			r0 = ret.Get(0).(map[string][]string)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = returnFunc(ctx, serverName, limit, since)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(context1)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFederationService_QueryPublicRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPublicRooms'
-type MockFederationService_QueryPublicRooms_Call struct {
+// MockDataRepository_GetSpaceParents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSpaceParents'
+type MockDataRepository_GetSpaceParents_Call struct {
 	*mock.Call
 }
 
-// QueryPublicRooms is a helper method to define mock.On call
-//   - ctx context.Context
-//   - serverName string
-//   - limit string
-//   - since string
-func (_e *MockFederationService_Expecter) QueryPublicRooms(ctx interface{}, serverName interface{}, limit interface{}, since interface{}) *MockFederationService_QueryPublicRooms_Call {
-	return &MockFederationService_QueryPublicRooms_Call{Call: _e.mock.On("QueryPublicRooms", ctx, serverName, limit, since)}
+// GetSpaceParents is a helper method to define mock.On call
+//   - context1 context.Context
+func (_e *MockDataRepository_Expecter) GetSpaceParents(context1 interface{}) *MockDataRepository_GetSpaceParents_Call {
+	return &MockDataRepository_GetSpaceParents_Call{Call: _e.mock.On("GetSpaceParents", context1)}
 }
 
-func (_c *MockFederationService_QueryPublicRooms_Call) Run(run func(ctx context.Context, serverName string, limit string, since string)) *MockFederationService_QueryPublicRooms_Call {
+func (_c *MockDataRepository_GetSpaceParents_Call) Run(run func(context1 context.Context)) *MockDataRepository_GetSpaceParents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFederationService_QueryPublicRooms_Call) Return(roomDirectoryResponse *model.RoomDirectoryResponse, err error) *MockFederationService_QueryPublicRooms_Call {
-	_c.Call.Return(roomDirectoryResponse, err)
+func (_c *MockDataRepository_GetSpaceParents_Call) Return(stringToStrings map[string][]string, err error) *MockDataRepository_GetSpaceParents_Call {
+	_c.Call.Return(stringToStrings, err)
 	return _c
 }
 
-func (_c *MockFederationService_QueryPublicRooms_Call) RunAndReturn(run func(ctx context.Context, serverName string, limit string, since string) (*model.RoomDirectoryResponse, error)) *MockFederationService_QueryPublicRooms_Call {
+func (_c *MockDataRepository_GetSpaceParents_Call) RunAndReturn(run func(context1 context.Context) (map[string][]string, error)) *MockDataRepository_GetSpaceParents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryServerName provides a mock function for the type MockFederationService
-func (_mock *MockFederationService) QueryServerName(ctx context.Context, serverName string) (string, error) {
-	ret := _mock.Called(ctx, serverName)
+// HasServer provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) HasServer(context1 context.Context, s string) bool {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryServerName")
+		panic("no return value specified for HasServer")
 	}
 
-	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return returnFunc(ctx, serverName)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(ctx, serverName)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, serverName)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockFederationService_QueryServerName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryServerName'
-type MockFederationService_QueryServerName_Call struct {
+// MockDataRepository_HasServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasServer'
+type MockDataRepository_HasServer_Call struct {
 	*mock.Call
 }
 
-// QueryServerName is a helper method to define mock.On call
-//   - ctx context.Context
-//   - serverName string
-func (_e *MockFederationService_Expecter) QueryServerName(ctx interface{}, serverName interface{}) *MockFederationService_QueryServerName_Call {
-	return &MockFederationService_QueryServerName_Call{Call: _e.mock.On("QueryServerName", ctx, serverName)}
+// HasServer is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) HasServer(context1 interface{}, s interface{}) *MockDataRepository_HasServer_Call {
+	return &MockDataRepository_HasServer_Call{Call: _e.mock.On("HasServer", context1, s)}
 }
 
-func (_c *MockFederationService_QueryServerName_Call) Run(run func(ctx context.Context, serverName string)) *MockFederationService_QueryServerName_Call {
+func (_c *MockDataRepository_HasServer_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_HasServer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2428,61 +2696,46 @@ func (_c *MockFederationService_QueryServerName_Call) Run(run func(ctx context.C
 	return _c
 }
 
-func (_c *MockFederationService_QueryServerName_Call) Return(s string, err error) *MockFederationService_QueryServerName_Call {
-	_c.Call.Return(s, err)
+func (_c *MockDataRepository_HasServer_Call) Return(b bool) *MockDataRepository_HasServer_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockFederationService_QueryServerName_Call) RunAndReturn(run func(ctx context.Context, serverName string) (string, error)) *MockFederationService_QueryServerName_Call {
+func (_c *MockDataRepository_HasServer_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockDataRepository_HasServer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryVersion provides a mock function for the type MockFederationService
-func (_mock *MockFederationService) QueryVersion(ctx context.Context, serverName string) (string, string, error) {
-	ret := _mock.Called(ctx, serverName)
+// IsActionTokenUsed provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) IsActionTokenUsed(context1 context.Context, s string) bool {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryVersion")
+		panic("no return value specified for IsActionTokenUsed")
 	}
 
-	var r0 string
-	var r1 string
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, string, error)); ok {
-		return returnFunc(ctx, serverName)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(ctx, serverName)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) string); ok {
-		r1 = returnFunc(ctx, serverName)
-	} else {
-		r1 = ret.Get(1).(string)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = returnFunc(ctx, serverName)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0, r1, r2
+	return r0
 }
 
-// MockFederationService_QueryVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryVersion'
-type MockFederationService_QueryVersion_Call struct {
+// MockDataRepository_IsActionTokenUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsActionTokenUsed'
+type MockDataRepository_IsActionTokenUsed_Call struct {
 	*mock.Call
 }
 
-// QueryVersion is a helper method to define mock.On call
-//   - ctx context.Context
-//   - serverName string
-func (_e *MockFederationService_Expecter) QueryVersion(ctx interface{}, serverName interface{}) *MockFederationService_QueryVersion_Call {
-	return &MockFederationService_QueryVersion_Call{Call: _e.mock.On("QueryVersion", ctx, serverName)}
+// IsActionTokenUsed is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) IsActionTokenUsed(context1 interface{}, s interface{}) *MockDataRepository_IsActionTokenUsed_Call {
+	return &MockDataRepository_IsActionTokenUsed_Call{Call: _e.mock.On("IsActionTokenUsed", context1, s)}
 }
 
-func (_c *MockFederationService_QueryVersion_Call) Run(run func(ctx context.Context, serverName string)) *MockFederationService_QueryVersion_Call {
+func (_c *MockDataRepository_IsActionTokenUsed_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_IsActionTokenUsed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2500,73 +2753,46 @@ func (_c *MockFederationService_QueryVersion_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockFederationService_QueryVersion_Call) Return(s string, s1 string, err error) *MockFederationService_QueryVersion_Call {
-	_c.Call.Return(s, s1, err)
+func (_c *MockDataRepository_IsActionTokenUsed_Call) Return(b bool) *MockDataRepository_IsActionTokenUsed_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockFederationService_QueryVersion_Call) RunAndReturn(run func(ctx context.Context, serverName string) (string, string, error)) *MockFederationService_QueryVersion_Call {
+func (_c *MockDataRepository_IsActionTokenUsed_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockDataRepository_IsActionTokenUsed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// newMockdataCrawlerService creates a new instance of mockdataCrawlerService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func newMockdataCrawlerService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *mockdataCrawlerService {
-	mock := &mockdataCrawlerService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// mockdataCrawlerService is an autogenerated mock type for the dataCrawlerService type
-type mockdataCrawlerService struct {
-	mock.Mock
-}
-
-type mockdataCrawlerService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *mockdataCrawlerService) EXPECT() *mockdataCrawlerService_Expecter {
-	return &mockdataCrawlerService_Expecter{mock: &_m.Mock}
-}
-
-// AddServer provides a mock function for the type mockdataCrawlerService
-func (_mock *mockdataCrawlerService) AddServer(context1 context.Context, s string) int {
+// IsBanned provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) IsBanned(context1 context.Context, s string) bool {
 	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddServer")
+		panic("no return value specified for IsBanned")
 	}
 
-	var r0 int
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
 		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(bool)
 	}
 	return r0
 }
 
-// mockdataCrawlerService_AddServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddServer'
-type mockdataCrawlerService_AddServer_Call struct {
+// MockDataRepository_IsBanned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBanned'
+type MockDataRepository_IsBanned_Call struct {
 	*mock.Call
 }
 
-// AddServer is a helper method to define mock.On call
+// IsBanned is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-func (_e *mockdataCrawlerService_Expecter) AddServer(context1 interface{}, s interface{}) *mockdataCrawlerService_AddServer_Call {
-	return &mockdataCrawlerService_AddServer_Call{Call: _e.mock.On("AddServer", context1, s)}
+func (_e *MockDataRepository_Expecter) IsBanned(context1 interface{}, s interface{}) *MockDataRepository_IsBanned_Call {
+	return &MockDataRepository_IsBanned_Call{Call: _e.mock.On("IsBanned", context1, s)}
 }
 
-func (_c *mockdataCrawlerService_AddServer_Call) Run(run func(context1 context.Context, s string)) *mockdataCrawlerService_AddServer_Call {
+func (_c *MockDataRepository_IsBanned_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_IsBanned_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2584,155 +2810,157 @@ func (_c *mockdataCrawlerService_AddServer_Call) Run(run func(context1 context.C
 	return _c
 }
 
-func (_c *mockdataCrawlerService_AddServer_Call) Return(n int) *mockdataCrawlerService_AddServer_Call {
-	_c.Call.Return(n)
+func (_c *MockDataRepository_IsBanned_Call) Return(b bool) *MockDataRepository_IsBanned_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *mockdataCrawlerService_AddServer_Call) RunAndReturn(run func(context1 context.Context, s string) int) *mockdataCrawlerService_AddServer_Call {
+func (_c *MockDataRepository_IsBanned_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockDataRepository_IsBanned_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AddServers provides a mock function for the type mockdataCrawlerService
-func (_mock *mockdataCrawlerService) AddServers(context1 context.Context, strings []string, n int) {
-	_mock.Called(context1, strings, n)
-	return
+// IsQuarantined provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) IsQuarantined(context1 context.Context, s string) bool {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsQuarantined")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
 }
 
-// mockdataCrawlerService_AddServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddServers'
-type mockdataCrawlerService_AddServers_Call struct {
+// MockDataRepository_IsQuarantined_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsQuarantined'
+type MockDataRepository_IsQuarantined_Call struct {
 	*mock.Call
 }
 
-// AddServers is a helper method to define mock.On call
+// IsQuarantined is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings []string
-//   - n int
-func (_e *mockdataCrawlerService_Expecter) AddServers(context1 interface{}, strings interface{}, n interface{}) *mockdataCrawlerService_AddServers_Call {
-	return &mockdataCrawlerService_AddServers_Call{Call: _e.mock.On("AddServers", context1, strings, n)}
+//   - s string
+func (_e *MockDataRepository_Expecter) IsQuarantined(context1 interface{}, s interface{}) *MockDataRepository_IsQuarantined_Call {
+	return &MockDataRepository_IsQuarantined_Call{Call: _e.mock.On("IsQuarantined", context1, s)}
 }
 
-func (_c *mockdataCrawlerService_AddServers_Call) Run(run func(context1 context.Context, strings []string, n int)) *mockdataCrawlerService_AddServers_Call {
+func (_c *MockDataRepository_IsQuarantined_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_IsQuarantined_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]string)
-		}
-		var arg2 int
-		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *mockdataCrawlerService_AddServers_Call) Return() *mockdataCrawlerService_AddServers_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_IsQuarantined_Call) Return(b bool) *MockDataRepository_IsQuarantined_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *mockdataCrawlerService_AddServers_Call) RunAndReturn(run func(context1 context.Context, strings []string, n int)) *mockdataCrawlerService_AddServers_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_IsQuarantined_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockDataRepository_IsQuarantined_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// DiscoverServers provides a mock function for the type mockdataCrawlerService
-func (_mock *mockdataCrawlerService) DiscoverServers(context1 context.Context, n int, lists ...*kit.List[string, string]) {
-	if len(lists) > 0 {
-		_mock.Called(context1, n, lists)
-	} else {
-		_mock.Called(context1, n)
+// IsReported provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) IsReported(context1 context.Context, s string) bool {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsReported")
 	}
 
-	return
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
 }
 
-// mockdataCrawlerService_DiscoverServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiscoverServers'
-type mockdataCrawlerService_DiscoverServers_Call struct {
+// MockDataRepository_IsReported_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsReported'
+type MockDataRepository_IsReported_Call struct {
 	*mock.Call
 }
 
-// DiscoverServers is a helper method to define mock.On call
+// IsReported is a helper method to define mock.On call
 //   - context1 context.Context
-//   - n int
-//   - lists ...*kit.List[string, string]
-func (_e *mockdataCrawlerService_Expecter) DiscoverServers(context1 interface{}, n interface{}, lists ...interface{}) *mockdataCrawlerService_DiscoverServers_Call {
-	return &mockdataCrawlerService_DiscoverServers_Call{Call: _e.mock.On("DiscoverServers",
-		append([]interface{}{context1, n}, lists...)...)}
+//   - s string
+func (_e *MockDataRepository_Expecter) IsReported(context1 interface{}, s interface{}) *MockDataRepository_IsReported_Call {
+	return &MockDataRepository_IsReported_Call{Call: _e.mock.On("IsReported", context1, s)}
 }
 
-func (_c *mockdataCrawlerService_DiscoverServers_Call) Run(run func(context1 context.Context, n int, lists ...*kit.List[string, string])) *mockdataCrawlerService_DiscoverServers_Call {
+func (_c *MockDataRepository_IsReported_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_IsReported_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 []*kit.List[string, string]
-		var variadicArgs []*kit.List[string, string]
-		if len(args) > 2 {
-			variadicArgs = args[2].([]*kit.List[string, string])
+			arg1 = args[1].(string)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *mockdataCrawlerService_DiscoverServers_Call) Return() *mockdataCrawlerService_DiscoverServers_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_IsReported_Call) Return(b bool) *MockDataRepository_IsReported_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *mockdataCrawlerService_DiscoverServers_Call) RunAndReturn(run func(context1 context.Context, n int, lists ...*kit.List[string, string])) *mockdataCrawlerService_DiscoverServers_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_IsReported_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockDataRepository_IsReported_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// EachRoom provides a mock function for the type mockdataCrawlerService
-func (_mock *mockdataCrawlerService) EachRoom(context1 context.Context, fn func(string, *model.MatrixRoom) bool) {
-	_mock.Called(context1, fn)
+// MarkServersOffline provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) MarkServersOffline(context1 context.Context, strings []string) {
+	_mock.Called(context1, strings)
 	return
 }
 
-// mockdataCrawlerService_EachRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachRoom'
-type mockdataCrawlerService_EachRoom_Call struct {
+// MockDataRepository_MarkServersOffline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkServersOffline'
+type MockDataRepository_MarkServersOffline_Call struct {
 	*mock.Call
 }
 
-// EachRoom is a helper method to define mock.On call
+// MarkServersOffline is a helper method to define mock.On call
 //   - context1 context.Context
-//   - fn func(string, *model.MatrixRoom) bool
-func (_e *mockdataCrawlerService_Expecter) EachRoom(context1 interface{}, fn interface{}) *mockdataCrawlerService_EachRoom_Call {
-	return &mockdataCrawlerService_EachRoom_Call{Call: _e.mock.On("EachRoom", context1, fn)}
+//   - strings []string
+func (_e *MockDataRepository_Expecter) MarkServersOffline(context1 interface{}, strings interface{}) *MockDataRepository_MarkServersOffline_Call {
+	return &MockDataRepository_MarkServersOffline_Call{Call: _e.mock.On("MarkServersOffline", context1, strings)}
 }
 
-func (_c *mockdataCrawlerService_EachRoom_Call) Run(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *mockdataCrawlerService_EachRoom_Call {
+func (_c *MockDataRepository_MarkServersOffline_Call) Run(run func(context1 context.Context, strings []string)) *MockDataRepository_MarkServersOffline_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 func(string, *model.MatrixRoom) bool
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(func(string, *model.MatrixRoom) bool)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -2742,65 +2970,54 @@ func (_c *mockdataCrawlerService_EachRoom_Call) Run(run func(context1 context.Co
 	return _c
 }
 
-func (_c *mockdataCrawlerService_EachRoom_Call) Return() *mockdataCrawlerService_EachRoom_Call {
+func (_c *MockDataRepository_MarkServersOffline_Call) Return() *MockDataRepository_MarkServersOffline_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *mockdataCrawlerService_EachRoom_Call) RunAndReturn(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *mockdataCrawlerService_EachRoom_Call {
+func (_c *MockDataRepository_MarkServersOffline_Call) RunAndReturn(run func(context1 context.Context, strings []string)) *MockDataRepository_MarkServersOffline_Call {
 	_c.Run(run)
 	return _c
 }
 
-// GetRoom provides a mock function for the type mockdataCrawlerService
-func (_mock *mockdataCrawlerService) GetRoom(ctx context.Context, roomID string) (*model.MatrixRoom, error) {
-	ret := _mock.Called(ctx, roomID)
+// QuarantineRoom provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) QuarantineRoom(context1 context.Context, roomQuarantine *model.RoomQuarantine) error {
+	ret := _mock.Called(context1, roomQuarantine)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoom")
+		panic("no return value specified for QuarantineRoom")
 	}
 
-	var r0 *model.MatrixRoom
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixRoom, error)); ok {
-		return returnFunc(ctx, roomID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixRoom); ok {
-		r0 = returnFunc(ctx, roomID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.MatrixRoom)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, roomID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.RoomQuarantine) error); ok {
+		r0 = returnFunc(context1, roomQuarantine)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// mockdataCrawlerService_GetRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoom'
-type mockdataCrawlerService_GetRoom_Call struct {
+// MockDataRepository_QuarantineRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QuarantineRoom'
+type MockDataRepository_QuarantineRoom_Call struct {
 	*mock.Call
 }
 
-// GetRoom is a helper method to define mock.On call
-//   - ctx context.Context
-//   - roomID string
-func (_e *mockdataCrawlerService_Expecter) GetRoom(ctx interface{}, roomID interface{}) *mockdataCrawlerService_GetRoom_Call {
-	return &mockdataCrawlerService_GetRoom_Call{Call: _e.mock.On("GetRoom", ctx, roomID)}
+// QuarantineRoom is a helper method to define mock.On call
+//   - context1 context.Context
+//   - roomQuarantine *model.RoomQuarantine
+func (_e *MockDataRepository_Expecter) QuarantineRoom(context1 interface{}, roomQuarantine interface{}) *MockDataRepository_QuarantineRoom_Call {
+	return &MockDataRepository_QuarantineRoom_Call{Call: _e.mock.On("QuarantineRoom", context1, roomQuarantine)}
 }
 
-func (_c *mockdataCrawlerService_GetRoom_Call) Run(run func(ctx context.Context, roomID string)) *mockdataCrawlerService_GetRoom_Call {
+func (_c *MockDataRepository_QuarantineRoom_Call) Run(run func(context1 context.Context, roomQuarantine *model.RoomQuarantine)) *MockDataRepository_QuarantineRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *model.RoomQuarantine
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*model.RoomQuarantine)
 		}
 		run(
 			arg0,
@@ -2810,43 +3027,54 @@ func (_c *mockdataCrawlerService_GetRoom_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *mockdataCrawlerService_GetRoom_Call) Return(matrixRoom *model.MatrixRoom, err error) *mockdataCrawlerService_GetRoom_Call {
-	_c.Call.Return(matrixRoom, err)
+func (_c *MockDataRepository_QuarantineRoom_Call) Return(err error) *MockDataRepository_QuarantineRoom_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *mockdataCrawlerService_GetRoom_Call) RunAndReturn(run func(ctx context.Context, roomID string) (*model.MatrixRoom, error)) *mockdataCrawlerService_GetRoom_Call {
+func (_c *MockDataRepository_QuarantineRoom_Call) RunAndReturn(run func(context1 context.Context, roomQuarantine *model.RoomQuarantine) error) *MockDataRepository_QuarantineRoom_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ParseRooms provides a mock function for the type mockdataCrawlerService
-func (_mock *mockdataCrawlerService) ParseRooms(context1 context.Context, n int) {
-	_mock.Called(context1, n)
-	return
+// RecreateRoomMapping provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) RecreateRoomMapping(context1 context.Context, stringToString map[string]string) error {
+	ret := _mock.Called(context1, stringToString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecreateRoomMapping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]string) error); ok {
+		r0 = returnFunc(context1, stringToString)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// mockdataCrawlerService_ParseRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseRooms'
-type mockdataCrawlerService_ParseRooms_Call struct {
+// MockDataRepository_RecreateRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecreateRoomMapping'
+type MockDataRepository_RecreateRoomMapping_Call struct {
 	*mock.Call
 }
 
-// ParseRooms is a helper method to define mock.On call
+// RecreateRoomMapping is a helper method to define mock.On call
 //   - context1 context.Context
-//   - n int
-func (_e *mockdataCrawlerService_Expecter) ParseRooms(context1 interface{}, n interface{}) *mockdataCrawlerService_ParseRooms_Call {
-	return &mockdataCrawlerService_ParseRooms_Call{Call: _e.mock.On("ParseRooms", context1, n)}
+//   - stringToString map[string]string
+func (_e *MockDataRepository_Expecter) RecreateRoomMapping(context1 interface{}, stringToString interface{}) *MockDataRepository_RecreateRoomMapping_Call {
+	return &MockDataRepository_RecreateRoomMapping_Call{Call: _e.mock.On("RecreateRoomMapping", context1, stringToString)}
 }
 
-func (_c *mockdataCrawlerService_ParseRooms_Call) Run(run func(context1 context.Context, n int)) *mockdataCrawlerService_ParseRooms_Call {
+func (_c *MockDataRepository_RecreateRoomMapping_Call) Run(run func(context1 context.Context, stringToString map[string]string)) *MockDataRepository_RecreateRoomMapping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 map[string]string
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(map[string]string)
 		}
 		run(
 			arg0,
@@ -2856,302 +3084,286 @@ func (_c *mockdataCrawlerService_ParseRooms_Call) Run(run func(context1 context.
 	return _c
 }
 
-func (_c *mockdataCrawlerService_ParseRooms_Call) Return() *mockdataCrawlerService_ParseRooms_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_RecreateRoomMapping_Call) Return(err error) *MockDataRepository_RecreateRoomMapping_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *mockdataCrawlerService_ParseRooms_Call) RunAndReturn(run func(context1 context.Context, n int)) *mockdataCrawlerService_ParseRooms_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_RecreateRoomMapping_Call) RunAndReturn(run func(context1 context.Context, stringToString map[string]string) error) *MockDataRepository_RecreateRoomMapping_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// newMockdataIndexService creates a new instance of mockdataIndexService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func newMockdataIndexService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *mockdataIndexService {
-	mock := &mockdataIndexService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// mockdataIndexService is an autogenerated mock type for the dataIndexService type
-type mockdataIndexService struct {
-	mock.Mock
-}
-
-type mockdataIndexService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *mockdataIndexService) EXPECT() *mockdataIndexService_Expecter {
-	return &mockdataIndexService_Expecter{mock: &_m.Mock}
-}
-
-// EmptyIndex provides a mock function for the type mockdataIndexService
-func (_mock *mockdataIndexService) EmptyIndex(ctx context.Context) error {
-	ret := _mock.Called(ctx)
+// ReleaseRoom provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) ReleaseRoom(context1 context.Context, s string) error {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EmptyIndex")
+		panic("no return value specified for ReleaseRoom")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(context1, s)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// mockdataIndexService_EmptyIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EmptyIndex'
-type mockdataIndexService_EmptyIndex_Call struct {
+// MockDataRepository_ReleaseRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseRoom'
+type MockDataRepository_ReleaseRoom_Call struct {
 	*mock.Call
 }
 
-// EmptyIndex is a helper method to define mock.On call
-//   - ctx context.Context
-func (_e *mockdataIndexService_Expecter) EmptyIndex(ctx interface{}) *mockdataIndexService_EmptyIndex_Call {
-	return &mockdataIndexService_EmptyIndex_Call{Call: _e.mock.On("EmptyIndex", ctx)}
+// ReleaseRoom is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) ReleaseRoom(context1 interface{}, s interface{}) *MockDataRepository_ReleaseRoom_Call {
+	return &MockDataRepository_ReleaseRoom_Call{Call: _e.mock.On("ReleaseRoom", context1, s)}
 }
 
-func (_c *mockdataIndexService_EmptyIndex_Call) Run(run func(ctx context.Context)) *mockdataIndexService_EmptyIndex_Call {
+func (_c *MockDataRepository_ReleaseRoom_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_ReleaseRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *mockdataIndexService_EmptyIndex_Call) Return(err error) *mockdataIndexService_EmptyIndex_Call {
+func (_c *MockDataRepository_ReleaseRoom_Call) Return(err error) *MockDataRepository_ReleaseRoom_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *mockdataIndexService_EmptyIndex_Call) RunAndReturn(run func(ctx context.Context) error) *mockdataIndexService_EmptyIndex_Call {
+func (_c *MockDataRepository_ReleaseRoom_Call) RunAndReturn(run func(context1 context.Context, s string) error) *MockDataRepository_ReleaseRoom_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IndexBatch provides a mock function for the type mockdataIndexService
-func (_mock *mockdataIndexService) IndexBatch(ctx context.Context) error {
-	ret := _mock.Called(ctx)
+// RemoveBanRule provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) RemoveBanRule(context1 context.Context, s string) (*model.BanRule, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IndexBatch")
+		panic("no return value specified for RemoveBanRule")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(ctx)
+	var r0 *model.BanRule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.BanRule, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.BanRule); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BanRule)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// mockdataIndexService_IndexBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexBatch'
-type mockdataIndexService_IndexBatch_Call struct {
+// MockDataRepository_RemoveBanRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveBanRule'
+type MockDataRepository_RemoveBanRule_Call struct {
 	*mock.Call
 }
 
-// IndexBatch is a helper method to define mock.On call
-//   - ctx context.Context
-func (_e *mockdataIndexService_Expecter) IndexBatch(ctx interface{}) *mockdataIndexService_IndexBatch_Call {
-	return &mockdataIndexService_IndexBatch_Call{Call: _e.mock.On("IndexBatch", ctx)}
+// RemoveBanRule is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) RemoveBanRule(context1 interface{}, s interface{}) *MockDataRepository_RemoveBanRule_Call {
+	return &MockDataRepository_RemoveBanRule_Call{Call: _e.mock.On("RemoveBanRule", context1, s)}
 }
 
-func (_c *mockdataIndexService_IndexBatch_Call) Run(run func(ctx context.Context)) *mockdataIndexService_IndexBatch_Call {
+func (_c *MockDataRepository_RemoveBanRule_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_RemoveBanRule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *mockdataIndexService_IndexBatch_Call) Return(err error) *mockdataIndexService_IndexBatch_Call {
-	_c.Call.Return(err)
+func (_c *MockDataRepository_RemoveBanRule_Call) Return(banRule *model.BanRule, err error) *MockDataRepository_RemoveBanRule_Call {
+	_c.Call.Return(banRule, err)
 	return _c
 }
 
-func (_c *mockdataIndexService_IndexBatch_Call) RunAndReturn(run func(ctx context.Context) error) *mockdataIndexService_IndexBatch_Call {
+func (_c *MockDataRepository_RemoveBanRule_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.BanRule, error)) *MockDataRepository_RemoveBanRule_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RoomsBatch provides a mock function for the type mockdataIndexService
-func (_mock *mockdataIndexService) RoomsBatch(ctx context.Context, roomID string, data *model.Entry) error {
-	ret := _mock.Called(ctx, roomID, data)
+// RemoveExpiredActionTokens provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) RemoveExpiredActionTokens(context1 context.Context, time1 time.Time) (int, error) {
+	ret := _mock.Called(context1, time1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RoomsBatch")
+		panic("no return value specified for RemoveExpiredActionTokens")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.Entry) error); ok {
-		r0 = returnFunc(ctx, roomID, data)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return returnFunc(context1, time1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = returnFunc(context1, time1)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(context1, time1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// mockdataIndexService_RoomsBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RoomsBatch'
-type mockdataIndexService_RoomsBatch_Call struct {
+// MockDataRepository_RemoveExpiredActionTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveExpiredActionTokens'
+type MockDataRepository_RemoveExpiredActionTokens_Call struct {
 	*mock.Call
 }
 
-// RoomsBatch is a helper method to define mock.On call
-//   - ctx context.Context
-//   - roomID string
-//   - data *model.Entry
-func (_e *mockdataIndexService_Expecter) RoomsBatch(ctx interface{}, roomID interface{}, data interface{}) *mockdataIndexService_RoomsBatch_Call {
-	return &mockdataIndexService_RoomsBatch_Call{Call: _e.mock.On("RoomsBatch", ctx, roomID, data)}
+// RemoveExpiredActionTokens is a helper method to define mock.On call
+//   - context1 context.Context
+//   - time1 time.Time
+func (_e *MockDataRepository_Expecter) RemoveExpiredActionTokens(context1 interface{}, time1 interface{}) *MockDataRepository_RemoveExpiredActionTokens_Call {
+	return &MockDataRepository_RemoveExpiredActionTokens_Call{Call: _e.mock.On("RemoveExpiredActionTokens", context1, time1)}
 }
 
-func (_c *mockdataIndexService_RoomsBatch_Call) Run(run func(ctx context.Context, roomID string, data *model.Entry)) *mockdataIndexService_RoomsBatch_Call {
+func (_c *MockDataRepository_RemoveExpiredActionTokens_Call) Run(run func(context1 context.Context, time1 time.Time)) *MockDataRepository_RemoveExpiredActionTokens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 *model.Entry
-		if args[2] != nil {
-			arg2 = args[2].(*model.Entry)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *mockdataIndexService_RoomsBatch_Call) Return(err error) *mockdataIndexService_RoomsBatch_Call {
-	_c.Call.Return(err)
+func (_c *MockDataRepository_RemoveExpiredActionTokens_Call) Return(n int, err error) *MockDataRepository_RemoveExpiredActionTokens_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *mockdataIndexService_RoomsBatch_Call) RunAndReturn(run func(ctx context.Context, roomID string, data *model.Entry) error) *mockdataIndexService_RoomsBatch_Call {
+func (_c *MockDataRepository_RemoveExpiredActionTokens_Call) RunAndReturn(run func(context1 context.Context, time1 time.Time) (int, error)) *MockDataRepository_RemoveExpiredActionTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// newMockdataStatsService creates a new instance of mockdataStatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func newMockdataStatsService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *mockdataStatsService {
-	mock := &mockdataStatsService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// mockdataStatsService is an autogenerated mock type for the dataStatsService type
-type mockdataStatsService struct {
-	mock.Mock
+// RemoveRoomMapping provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) RemoveRoomMapping(context1 context.Context, s string, s1 string) {
+	_mock.Called(context1, s, s1)
+	return
 }
 
-type mockdataStatsService_Expecter struct {
-	mock *mock.Mock
+// MockDataRepository_RemoveRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveRoomMapping'
+type MockDataRepository_RemoveRoomMapping_Call struct {
+	*mock.Call
 }
 
-func (_m *mockdataStatsService) EXPECT() *mockdataStatsService_Expecter {
-	return &mockdataStatsService_Expecter{mock: &_m.Mock}
+// RemoveRoomMapping is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - s1 string
+func (_e *MockDataRepository_Expecter) RemoveRoomMapping(context1 interface{}, s interface{}, s1 interface{}) *MockDataRepository_RemoveRoomMapping_Call {
+	return &MockDataRepository_RemoveRoomMapping_Call{Call: _e.mock.On("RemoveRoomMapping", context1, s, s1)}
 }
 
-// Collect provides a mock function for the type mockdataStatsService
-func (_mock *mockdataStatsService) Collect(context1 context.Context) {
-	_mock.Called(context1)
-	return
-}
-
-// mockdataStatsService_Collect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Collect'
-type mockdataStatsService_Collect_Call struct {
-	*mock.Call
-}
-
-// Collect is a helper method to define mock.On call
-//   - context1 context.Context
-func (_e *mockdataStatsService_Expecter) Collect(context1 interface{}) *mockdataStatsService_Collect_Call {
-	return &mockdataStatsService_Collect_Call{Call: _e.mock.On("Collect", context1)}
-}
-
-func (_c *mockdataStatsService_Collect_Call) Run(run func(context1 context.Context)) *mockdataStatsService_Collect_Call {
+func (_c *MockDataRepository_RemoveRoomMapping_Call) Run(run func(context1 context.Context, s string, s1 string)) *MockDataRepository_RemoveRoomMapping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *mockdataStatsService_Collect_Call) Return() *mockdataStatsService_Collect_Call {
+func (_c *MockDataRepository_RemoveRoomMapping_Call) Return() *MockDataRepository_RemoveRoomMapping_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *mockdataStatsService_Collect_Call) RunAndReturn(run func(context1 context.Context)) *mockdataStatsService_Collect_Call {
+func (_c *MockDataRepository_RemoveRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string)) *MockDataRepository_RemoveRoomMapping_Call {
 	_c.Run(run)
 	return _c
 }
 
-// CollectServers provides a mock function for the type mockdataStatsService
-func (_mock *mockdataStatsService) CollectServers(context1 context.Context, b bool) {
-	_mock.Called(context1, b)
+// RemoveRooms provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) RemoveRooms(context1 context.Context, strings []string) {
+	_mock.Called(context1, strings)
 	return
 }
 
-// mockdataStatsService_CollectServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CollectServers'
-type mockdataStatsService_CollectServers_Call struct {
+// MockDataRepository_RemoveRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveRooms'
+type MockDataRepository_RemoveRooms_Call struct {
 	*mock.Call
 }
 
-// CollectServers is a helper method to define mock.On call
+// RemoveRooms is a helper method to define mock.On call
 //   - context1 context.Context
-//   - b bool
-func (_e *mockdataStatsService_Expecter) CollectServers(context1 interface{}, b interface{}) *mockdataStatsService_CollectServers_Call {
-	return &mockdataStatsService_CollectServers_Call{Call: _e.mock.On("CollectServers", context1, b)}
+//   - strings []string
+func (_e *MockDataRepository_Expecter) RemoveRooms(context1 interface{}, strings interface{}) *MockDataRepository_RemoveRooms_Call {
+	return &MockDataRepository_RemoveRooms_Call{Call: _e.mock.On("RemoveRooms", context1, strings)}
 }
 
-func (_c *mockdataStatsService_CollectServers_Call) Run(run func(context1 context.Context, b bool)) *mockdataStatsService_CollectServers_Call {
+func (_c *MockDataRepository_RemoveRooms_Call) Run(run func(context1 context.Context, strings []string)) *MockDataRepository_RemoveRooms_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 bool
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(bool)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -3161,134 +3373,167 @@ func (_c *mockdataStatsService_CollectServers_Call) Run(run func(context1 contex
 	return _c
 }
 
-func (_c *mockdataStatsService_CollectServers_Call) Return() *mockdataStatsService_CollectServers_Call {
+func (_c *MockDataRepository_RemoveRooms_Call) Return() *MockDataRepository_RemoveRooms_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *mockdataStatsService_CollectServers_Call) RunAndReturn(run func(context1 context.Context, b bool)) *mockdataStatsService_CollectServers_Call {
+func (_c *MockDataRepository_RemoveRooms_Call) RunAndReturn(run func(context1 context.Context, strings []string)) *MockDataRepository_RemoveRooms_Call {
 	_c.Run(run)
 	return _c
 }
 
-// Get provides a mock function for the type mockdataStatsService
-func (_mock *mockdataStatsService) Get() *model.IndexStats {
-	ret := _mock.Called()
+// RemoveServer provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) RemoveServer(context1 context.Context, s string) error {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for RemoveServer")
 	}
 
-	var r0 *model.IndexStats
-	if returnFunc, ok := ret.Get(0).(func() *model.IndexStats); ok {
-		r0 = returnFunc()
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.IndexStats)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// mockdataStatsService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type mockdataStatsService_Get_Call struct {
+// MockDataRepository_RemoveServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveServer'
+type MockDataRepository_RemoveServer_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
-func (_e *mockdataStatsService_Expecter) Get() *mockdataStatsService_Get_Call {
-	return &mockdataStatsService_Get_Call{Call: _e.mock.On("Get")}
+// RemoveServer is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) RemoveServer(context1 interface{}, s interface{}) *MockDataRepository_RemoveServer_Call {
+	return &MockDataRepository_RemoveServer_Call{Call: _e.mock.On("RemoveServer", context1, s)}
 }
 
-func (_c *mockdataStatsService_Get_Call) Run(run func()) *mockdataStatsService_Get_Call {
+func (_c *MockDataRepository_RemoveServer_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_RemoveServer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
 	})
 	return _c
 }
 
-func (_c *mockdataStatsService_Get_Call) Return(indexStats *model.IndexStats) *mockdataStatsService_Get_Call {
-	_c.Call.Return(indexStats)
+func (_c *MockDataRepository_RemoveServer_Call) Return(err error) *MockDataRepository_RemoveServer_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *mockdataStatsService_Get_Call) RunAndReturn(run func() *model.IndexStats) *mockdataStatsService_Get_Call {
+func (_c *MockDataRepository_RemoveServer_Call) RunAndReturn(run func(context1 context.Context, s string) error) *MockDataRepository_RemoveServer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetFinishedAt provides a mock function for the type mockdataStatsService
-func (_mock *mockdataStatsService) SetFinishedAt(context1 context.Context, s string, time1 time.Time) {
-	_mock.Called(context1, s, time1)
+// RemoveServers provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) RemoveServers(context1 context.Context, strings []string) {
+	_mock.Called(context1, strings)
 	return
 }
 
-// mockdataStatsService_SetFinishedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFinishedAt'
-type mockdataStatsService_SetFinishedAt_Call struct {
+// MockDataRepository_RemoveServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveServers'
+type MockDataRepository_RemoveServers_Call struct {
 	*mock.Call
 }
 
-// SetFinishedAt is a helper method to define mock.On call
+// RemoveServers is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-//   - time1 time.Time
-func (_e *mockdataStatsService_Expecter) SetFinishedAt(context1 interface{}, s interface{}, time1 interface{}) *mockdataStatsService_SetFinishedAt_Call {
-	return &mockdataStatsService_SetFinishedAt_Call{Call: _e.mock.On("SetFinishedAt", context1, s, time1)}
+//   - strings []string
+func (_e *MockDataRepository_Expecter) RemoveServers(context1 interface{}, strings interface{}) *MockDataRepository_RemoveServers_Call {
+	return &MockDataRepository_RemoveServers_Call{Call: _e.mock.On("RemoveServers", context1, strings)}
 }
 
-func (_c *mockdataStatsService_SetFinishedAt_Call) Run(run func(context1 context.Context, s string, time1 time.Time)) *mockdataStatsService_SetFinishedAt_Call {
+func (_c *MockDataRepository_RemoveServers_Call) Run(run func(context1 context.Context, strings []string)) *MockDataRepository_RemoveServers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 time.Time
-		if args[2] != nil {
-			arg2 = args[2].(time.Time)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *mockdataStatsService_SetFinishedAt_Call) Return() *mockdataStatsService_SetFinishedAt_Call {
+func (_c *MockDataRepository_RemoveServers_Call) Return() *MockDataRepository_RemoveServers_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *mockdataStatsService_SetFinishedAt_Call) RunAndReturn(run func(context1 context.Context, s string, time1 time.Time)) *mockdataStatsService_SetFinishedAt_Call {
+func (_c *MockDataRepository_RemoveServers_Call) RunAndReturn(run func(context1 context.Context, strings []string)) *MockDataRepository_RemoveServers_Call {
 	_c.Run(run)
 	return _c
 }
 
-// SetStartedAt provides a mock function for the type mockdataStatsService
-func (_mock *mockdataStatsService) SetStartedAt(context1 context.Context, s string, time1 time.Time) {
-	_mock.Called(context1, s, time1)
-	return
+// ReportRoom provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) ReportRoom(context1 context.Context, s string, roomReport *model.RoomReport) (*model.RoomReports, bool, error) {
+	ret := _mock.Called(context1, s, roomReport)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportRoom")
+	}
+
+	var r0 *model.RoomReports
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.RoomReport) (*model.RoomReports, bool, error)); ok {
+		return returnFunc(context1, s, roomReport)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.RoomReport) *model.RoomReports); ok {
+		r0 = returnFunc(context1, s, roomReport)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomReports)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *model.RoomReport) bool); ok {
+		r1 = returnFunc(context1, s, roomReport)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, *model.RoomReport) error); ok {
+		r2 = returnFunc(context1, s, roomReport)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// mockdataStatsService_SetStartedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStartedAt'
-type mockdataStatsService_SetStartedAt_Call struct {
+// MockDataRepository_ReportRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReportRoom'
+type MockDataRepository_ReportRoom_Call struct {
 	*mock.Call
 }
 
-// SetStartedAt is a helper method to define mock.On call
+// ReportRoom is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-//   - time1 time.Time
-func (_e *mockdataStatsService_Expecter) SetStartedAt(context1 interface{}, s interface{}, time1 interface{}) *mockdataStatsService_SetStartedAt_Call {
-	return &mockdataStatsService_SetStartedAt_Call{Call: _e.mock.On("SetStartedAt", context1, s, time1)}
+//   - roomReport *model.RoomReport
+func (_e *MockDataRepository_Expecter) ReportRoom(context1 interface{}, s interface{}, roomReport interface{}) *MockDataRepository_ReportRoom_Call {
+	return &MockDataRepository_ReportRoom_Call{Call: _e.mock.On("ReportRoom", context1, s, roomReport)}
 }
 
-func (_c *mockdataStatsService_SetStartedAt_Call) Run(run func(context1 context.Context, s string, time1 time.Time)) *mockdataStatsService_SetStartedAt_Call {
+func (_c *MockDataRepository_ReportRoom_Call) Run(run func(context1 context.Context, s string, roomReport *model.RoomReport)) *MockDataRepository_ReportRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3298,9 +3543,9 @@ func (_c *mockdataStatsService_SetStartedAt_Call) Run(run func(context1 context.
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 time.Time
+		var arg2 *model.RoomReport
 		if args[2] != nil {
-			arg2 = args[2].(time.Time)
+			arg2 = args[2].(*model.RoomReport)
 		}
 		run(
 			arg0,
@@ -3311,132 +3556,111 @@ func (_c *mockdataStatsService_SetStartedAt_Call) Run(run func(context1 context.
 	return _c
 }
 
-func (_c *mockdataStatsService_SetStartedAt_Call) Return() *mockdataStatsService_SetStartedAt_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_ReportRoom_Call) Return(roomReports *model.RoomReports, b bool, err error) *MockDataRepository_ReportRoom_Call {
+	_c.Call.Return(roomReports, b, err)
 	return _c
 }
 
-func (_c *mockdataStatsService_SetStartedAt_Call) RunAndReturn(run func(context1 context.Context, s string, time1 time.Time)) *mockdataStatsService_SetStartedAt_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_ReportRoom_Call) RunAndReturn(run func(context1 context.Context, s string, roomReport *model.RoomReport) (*model.RoomReports, bool, error)) *MockDataRepository_ReportRoom_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockIndexRepository creates a new instance of MockIndexRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockIndexRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockIndexRepository {
-	mock := &MockIndexRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockIndexRepository is an autogenerated mock type for the IndexRepository type
-type MockIndexRepository struct {
-	mock.Mock
-}
-
-type MockIndexRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockIndexRepository) EXPECT() *MockIndexRepository_Expecter {
-	return &MockIndexRepository_Expecter{mock: &_m.Mock}
-}
-
-// Delete provides a mock function for the type MockIndexRepository
-func (_mock *MockIndexRepository) Delete(roomID string) error {
-	ret := _mock.Called(roomID)
+// SaveAppeal provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) SaveAppeal(context1 context.Context, appeal *model.Appeal) error {
+	ret := _mock.Called(context1, appeal)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for SaveAppeal")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(roomID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Appeal) error); ok {
+		r0 = returnFunc(context1, appeal)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIndexRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockIndexRepository_Delete_Call struct {
+// MockDataRepository_SaveAppeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveAppeal'
+type MockDataRepository_SaveAppeal_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
-//   - roomID string
-func (_e *MockIndexRepository_Expecter) Delete(roomID interface{}) *MockIndexRepository_Delete_Call {
-	return &MockIndexRepository_Delete_Call{Call: _e.mock.On("Delete", roomID)}
+// SaveAppeal is a helper method to define mock.On call
+//   - context1 context.Context
+//   - appeal *model.Appeal
+func (_e *MockDataRepository_Expecter) SaveAppeal(context1 interface{}, appeal interface{}) *MockDataRepository_SaveAppeal_Call {
+	return &MockDataRepository_SaveAppeal_Call{Call: _e.mock.On("SaveAppeal", context1, appeal)}
 }
 
-func (_c *MockIndexRepository_Delete_Call) Run(run func(roomID string)) *MockIndexRepository_Delete_Call {
+func (_c *MockDataRepository_SaveAppeal_Call) Run(run func(context1 context.Context, appeal *model.Appeal)) *MockDataRepository_SaveAppeal_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Appeal
+		if args[1] != nil {
+			arg1 = args[1].(*model.Appeal)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockIndexRepository_Delete_Call) Return(err error) *MockIndexRepository_Delete_Call {
+func (_c *MockDataRepository_SaveAppeal_Call) Return(err error) *MockDataRepository_SaveAppeal_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIndexRepository_Delete_Call) RunAndReturn(run func(roomID string) error) *MockIndexRepository_Delete_Call {
+func (_c *MockDataRepository_SaveAppeal_Call) RunAndReturn(run func(context1 context.Context, appeal *model.Appeal) error) *MockDataRepository_SaveAppeal_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Index provides a mock function for the type MockIndexRepository
-func (_mock *MockIndexRepository) Index(roomID string, data *model.Entry) error {
-	ret := _mock.Called(roomID, data)
+// SetBiggestRooms provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) SetBiggestRooms(context1 context.Context, strings []string) error {
+	ret := _mock.Called(context1, strings)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Index")
+		panic("no return value specified for SetBiggestRooms")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, *model.Entry) error); ok {
-		r0 = returnFunc(roomID, data)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = returnFunc(context1, strings)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIndexRepository_Index_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Index'
-type MockIndexRepository_Index_Call struct {
+// MockDataRepository_SetBiggestRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBiggestRooms'
+type MockDataRepository_SetBiggestRooms_Call struct {
 	*mock.Call
 }
 
-// Index is a helper method to define mock.On call
-//   - roomID string
-//   - data *model.Entry
-func (_e *MockIndexRepository_Expecter) Index(roomID interface{}, data interface{}) *MockIndexRepository_Index_Call {
-	return &MockIndexRepository_Index_Call{Call: _e.mock.On("Index", roomID, data)}
-}
+// SetBiggestRooms is a helper method to define mock.On call
+//   - context1 context.Context
+//   - strings []string
+func (_e *MockDataRepository_Expecter) SetBiggestRooms(context1 interface{}, strings interface{}) *MockDataRepository_SetBiggestRooms_Call {
+	return &MockDataRepository_SetBiggestRooms_Call{Call: _e.mock.On("SetBiggestRooms", context1, strings)}
+}
 
-func (_c *MockIndexRepository_Index_Call) Run(run func(roomID string, data *model.Entry)) *MockIndexRepository_Index_Call {
+func (_c *MockDataRepository_SetBiggestRooms_Call) Run(run func(context1 context.Context, strings []string)) *MockDataRepository_SetBiggestRooms_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.Entry
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(*model.Entry)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -3446,275 +3670,274 @@ func (_c *MockIndexRepository_Index_Call) Run(run func(roomID string, data *mode
 	return _c
 }
 
-func (_c *MockIndexRepository_Index_Call) Return(err error) *MockIndexRepository_Index_Call {
+func (_c *MockDataRepository_SetBiggestRooms_Call) Return(err error) *MockDataRepository_SetBiggestRooms_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIndexRepository_Index_Call) RunAndReturn(run func(roomID string, data *model.Entry) error) *MockIndexRepository_Index_Call {
+func (_c *MockDataRepository_SetBiggestRooms_Call) RunAndReturn(run func(context1 context.Context, strings []string) error) *MockDataRepository_SetBiggestRooms_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IndexBatch provides a mock function for the type MockIndexRepository
-func (_mock *MockIndexRepository) IndexBatch(batch *bleve.Batch) error {
-	ret := _mock.Called(batch)
+// SetSpaceChildren provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) SetSpaceChildren(context1 context.Context, s string, matrixSpaceChilds []*model.MatrixSpaceChild) error {
+	ret := _mock.Called(context1, s, matrixSpaceChilds)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IndexBatch")
+		panic("no return value specified for SetSpaceChildren")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*bleve.Batch) error); ok {
-		r0 = returnFunc(batch)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []*model.MatrixSpaceChild) error); ok {
+		r0 = returnFunc(context1, s, matrixSpaceChilds)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIndexRepository_IndexBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexBatch'
-type MockIndexRepository_IndexBatch_Call struct {
+// MockDataRepository_SetSpaceChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSpaceChildren'
+type MockDataRepository_SetSpaceChildren_Call struct {
 	*mock.Call
 }
 
-// IndexBatch is a helper method to define mock.On call
-//   - batch *bleve.Batch
-func (_e *MockIndexRepository_Expecter) IndexBatch(batch interface{}) *MockIndexRepository_IndexBatch_Call {
-	return &MockIndexRepository_IndexBatch_Call{Call: _e.mock.On("IndexBatch", batch)}
+// SetSpaceChildren is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - matrixSpaceChilds []*model.MatrixSpaceChild
+func (_e *MockDataRepository_Expecter) SetSpaceChildren(context1 interface{}, s interface{}, matrixSpaceChilds interface{}) *MockDataRepository_SetSpaceChildren_Call {
+	return &MockDataRepository_SetSpaceChildren_Call{Call: _e.mock.On("SetSpaceChildren", context1, s, matrixSpaceChilds)}
 }
 
-func (_c *MockIndexRepository_IndexBatch_Call) Run(run func(batch *bleve.Batch)) *MockIndexRepository_IndexBatch_Call {
+func (_c *MockDataRepository_SetSpaceChildren_Call) Run(run func(context1 context.Context, s string, matrixSpaceChilds []*model.MatrixSpaceChild)) *MockDataRepository_SetSpaceChildren_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *bleve.Batch
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*bleve.Batch)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []*model.MatrixSpaceChild
+		if args[2] != nil {
+			arg2 = args[2].([]*model.MatrixSpaceChild)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockIndexRepository_IndexBatch_Call) Return(err error) *MockIndexRepository_IndexBatch_Call {
+func (_c *MockDataRepository_SetSpaceChildren_Call) Return(err error) *MockDataRepository_SetSpaceChildren_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIndexRepository_IndexBatch_Call) RunAndReturn(run func(batch *bleve.Batch) error) *MockIndexRepository_IndexBatch_Call {
+func (_c *MockDataRepository_SetSpaceChildren_Call) RunAndReturn(run func(context1 context.Context, s string, matrixSpaceChilds []*model.MatrixSpaceChild) error) *MockDataRepository_SetSpaceChildren_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewBatch provides a mock function for the type MockIndexRepository
-func (_mock *MockIndexRepository) NewBatch() *bleve.Batch {
-	ret := _mock.Called()
+// SetTrendingRooms provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) SetTrendingRooms(context1 context.Context, intToTrendingRooms map[int][]*model.TrendingRoom) error {
+	ret := _mock.Called(context1, intToTrendingRooms)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NewBatch")
+		panic("no return value specified for SetTrendingRooms")
 	}
 
-	var r0 *bleve.Batch
-	if returnFunc, ok := ret.Get(0).(func() *bleve.Batch); ok {
-		r0 = returnFunc()
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[int][]*model.TrendingRoom) error); ok {
+		r0 = returnFunc(context1, intToTrendingRooms)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bleve.Batch)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIndexRepository_NewBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewBatch'
-type MockIndexRepository_NewBatch_Call struct {
+// MockDataRepository_SetTrendingRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTrendingRooms'
+type MockDataRepository_SetTrendingRooms_Call struct {
 	*mock.Call
 }
 
-// NewBatch is a helper method to define mock.On call
-func (_e *MockIndexRepository_Expecter) NewBatch() *MockIndexRepository_NewBatch_Call {
-	return &MockIndexRepository_NewBatch_Call{Call: _e.mock.On("NewBatch")}
+// SetTrendingRooms is a helper method to define mock.On call
+//   - context1 context.Context
+//   - intToTrendingRooms map[int][]*model.TrendingRoom
+func (_e *MockDataRepository_Expecter) SetTrendingRooms(context1 interface{}, intToTrendingRooms interface{}) *MockDataRepository_SetTrendingRooms_Call {
+	return &MockDataRepository_SetTrendingRooms_Call{Call: _e.mock.On("SetTrendingRooms", context1, intToTrendingRooms)}
 }
 
-func (_c *MockIndexRepository_NewBatch_Call) Run(run func()) *MockIndexRepository_NewBatch_Call {
+func (_c *MockDataRepository_SetTrendingRooms_Call) Run(run func(context1 context.Context, intToTrendingRooms map[int][]*model.TrendingRoom)) *MockDataRepository_SetTrendingRooms_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[int][]*model.TrendingRoom
+		if args[1] != nil {
+			arg1 = args[1].(map[int][]*model.TrendingRoom)
+		}
+		run(
+			arg0,
+			arg1,
+		)
 	})
 	return _c
 }
 
-func (_c *MockIndexRepository_NewBatch_Call) Return(batch *bleve.Batch) *MockIndexRepository_NewBatch_Call {
-	_c.Call.Return(batch)
+func (_c *MockDataRepository_SetTrendingRooms_Call) Return(err error) *MockDataRepository_SetTrendingRooms_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIndexRepository_NewBatch_Call) RunAndReturn(run func() *bleve.Batch) *MockIndexRepository_NewBatch_Call {
+func (_c *MockDataRepository_SetTrendingRooms_Call) RunAndReturn(run func(context1 context.Context, intToTrendingRooms map[int][]*model.TrendingRoom) error) *MockDataRepository_SetTrendingRooms_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Swap provides a mock function for the type MockIndexRepository
-func (_mock *MockIndexRepository) Swap(ctx context.Context) error {
-	ret := _mock.Called(ctx)
+// UnbanRoom provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) UnbanRoom(context1 context.Context, s string) error {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Swap")
+		panic("no return value specified for UnbanRoom")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(context1, s)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIndexRepository_Swap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Swap'
-type MockIndexRepository_Swap_Call struct {
+// MockDataRepository_UnbanRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnbanRoom'
+type MockDataRepository_UnbanRoom_Call struct {
 	*mock.Call
 }
 
-// Swap is a helper method to define mock.On call
-//   - ctx context.Context
-func (_e *MockIndexRepository_Expecter) Swap(ctx interface{}) *MockIndexRepository_Swap_Call {
-	return &MockIndexRepository_Swap_Call{Call: _e.mock.On("Swap", ctx)}
+// UnbanRoom is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) UnbanRoom(context1 interface{}, s interface{}) *MockDataRepository_UnbanRoom_Call {
+	return &MockDataRepository_UnbanRoom_Call{Call: _e.mock.On("UnbanRoom", context1, s)}
 }
 
-func (_c *MockIndexRepository_Swap_Call) Run(run func(ctx context.Context)) *MockIndexRepository_Swap_Call {
+func (_c *MockDataRepository_UnbanRoom_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_UnbanRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockIndexRepository_Swap_Call) Return(err error) *MockIndexRepository_Swap_Call {
+func (_c *MockDataRepository_UnbanRoom_Call) Return(err error) *MockDataRepository_UnbanRoom_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIndexRepository_Swap_Call) RunAndReturn(run func(ctx context.Context) error) *MockIndexRepository_Swap_Call {
+func (_c *MockDataRepository_UnbanRoom_Call) RunAndReturn(run func(context1 context.Context, s string) error) *MockDataRepository_UnbanRoom_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockMediaService creates a new instance of MockMediaService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockMediaService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockMediaService {
-	mock := &MockMediaService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockMediaService is an autogenerated mock type for the MediaService type
-type MockMediaService struct {
-	mock.Mock
-}
-
-type MockMediaService_Expecter struct {
-	mock *mock.Mock
-}
+// UnreportAll provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) UnreportAll(context1 context.Context) error {
+	ret := _mock.Called(context1)
 
-func (_m *MockMediaService) EXPECT() *MockMediaService_Expecter {
-	return &MockMediaService_Expecter{mock: &_m.Mock}
-}
+	if len(ret) == 0 {
+		panic("no return value specified for UnreportAll")
+	}
 
-// Add provides a mock function for the type MockMediaService
-func (_mock *MockMediaService) Add(ctx context.Context, serverName string, mediaID string, params url.Values, content []byte) {
-	_mock.Called(ctx, serverName, mediaID, params, content)
-	return
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(context1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockMediaService_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
-type MockMediaService_Add_Call struct {
+// MockDataRepository_UnreportAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnreportAll'
+type MockDataRepository_UnreportAll_Call struct {
 	*mock.Call
 }
 
-// Add is a helper method to define mock.On call
-//   - ctx context.Context
-//   - serverName string
-//   - mediaID string
-//   - params url.Values
-//   - content []byte
-func (_e *MockMediaService_Expecter) Add(ctx interface{}, serverName interface{}, mediaID interface{}, params interface{}, content interface{}) *MockMediaService_Add_Call {
-	return &MockMediaService_Add_Call{Call: _e.mock.On("Add", ctx, serverName, mediaID, params, content)}
+// UnreportAll is a helper method to define mock.On call
+//   - context1 context.Context
+func (_e *MockDataRepository_Expecter) UnreportAll(context1 interface{}) *MockDataRepository_UnreportAll_Call {
+	return &MockDataRepository_UnreportAll_Call{Call: _e.mock.On("UnreportAll", context1)}
 }
 
-func (_c *MockMediaService_Add_Call) Run(run func(ctx context.Context, serverName string, mediaID string, params url.Values, content []byte)) *MockMediaService_Add_Call {
+func (_c *MockDataRepository_UnreportAll_Call) Run(run func(context1 context.Context)) *MockDataRepository_UnreportAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 url.Values
-		if args[3] != nil {
-			arg3 = args[3].(url.Values)
-		}
-		var arg4 []byte
-		if args[4] != nil {
-			arg4 = args[4].([]byte)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMediaService_Add_Call) Return() *MockMediaService_Add_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_UnreportAll_Call) Return(err error) *MockDataRepository_UnreportAll_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMediaService_Add_Call) RunAndReturn(run func(ctx context.Context, serverName string, mediaID string, params url.Values, content []byte)) *MockMediaService_Add_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_UnreportAll_Call) RunAndReturn(run func(context1 context.Context) error) *MockDataRepository_UnreportAll_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function for the type MockMediaService
-func (_mock *MockMediaService) Delete(ctx context.Context, serverName string, mediaID string) {
-	_mock.Called(ctx, serverName, mediaID)
-	return
+// UnreportRoom provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) UnreportRoom(context1 context.Context, s string) error {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnreportRoom")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockMediaService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockMediaService_Delete_Call struct {
+// MockDataRepository_UnreportRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnreportRoom'
+type MockDataRepository_UnreportRoom_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
-//   - ctx context.Context
-//   - serverName string
-//   - mediaID string
-func (_e *MockMediaService_Expecter) Delete(ctx interface{}, serverName interface{}, mediaID interface{}) *MockMediaService_Delete_Call {
-	return &MockMediaService_Delete_Call{Call: _e.mock.On("Delete", ctx, serverName, mediaID)}
+// UnreportRoom is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDataRepository_Expecter) UnreportRoom(context1 interface{}, s interface{}) *MockDataRepository_UnreportRoom_Call {
+	return &MockDataRepository_UnreportRoom_Call{Call: _e.mock.On("UnreportRoom", context1, s)}
 }
 
-func (_c *MockMediaService_Delete_Call) Run(run func(ctx context.Context, serverName string, mediaID string)) *MockMediaService_Delete_Call {
+func (_c *MockDataRepository_UnreportRoom_Call) Run(run func(context1 context.Context, s string)) *MockDataRepository_UnreportRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3724,72 +3947,76 @@ func (_c *MockMediaService_Delete_Call) Run(run func(ctx context.Context, server
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMediaService_Delete_Call) Return() *MockMediaService_Delete_Call {
-	_c.Call.Return()
+func (_c *MockDataRepository_UnreportRoom_Call) Return(err error) *MockDataRepository_UnreportRoom_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMediaService_Delete_Call) RunAndReturn(run func(ctx context.Context, serverName string, mediaID string)) *MockMediaService_Delete_Call {
-	_c.Run(run)
+func (_c *MockDataRepository_UnreportRoom_Call) RunAndReturn(run func(context1 context.Context, s string) error) *MockDataRepository_UnreportRoom_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// Exists provides a mock function for the type MockMediaService
-func (_mock *MockMediaService) Exists(serverName string, mediaID string, params url.Values) bool {
-	ret := _mock.Called(serverName, mediaID, params)
+// UseActionToken provides a mock function for the type MockDataRepository
+func (_mock *MockDataRepository) UseActionToken(context1 context.Context, s string, time1 time.Time) (bool, error) {
+	ret := _mock.Called(context1, s, time1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Exists")
+		panic("no return value specified for UseActionToken")
 	}
 
 	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(string, string, url.Values) bool); ok {
-		r0 = returnFunc(serverName, mediaID, params)
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return returnFunc(context1, s, time1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = returnFunc(context1, s, time1)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(context1, s, time1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockMediaService_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
-type MockMediaService_Exists_Call struct {
+// MockDataRepository_UseActionToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseActionToken'
+type MockDataRepository_UseActionToken_Call struct {
 	*mock.Call
 }
 
-// Exists is a helper method to define mock.On call
-//   - serverName string
-//   - mediaID string
-//   - params url.Values
-func (_e *MockMediaService_Expecter) Exists(serverName interface{}, mediaID interface{}, params interface{}) *MockMediaService_Exists_Call {
-	return &MockMediaService_Exists_Call{Call: _e.mock.On("Exists", serverName, mediaID, params)}
+// UseActionToken is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - time1 time.Time
+func (_e *MockDataRepository_Expecter) UseActionToken(context1 interface{}, s interface{}, time1 interface{}) *MockDataRepository_UseActionToken_Call {
+	return &MockDataRepository_UseActionToken_Call{Call: _e.mock.On("UseActionToken", context1, s, time1)}
 }
 
-func (_c *MockMediaService_Exists_Call) Run(run func(serverName string, mediaID string, params url.Values)) *MockMediaService_Exists_Call {
+func (_c *MockDataRepository_UseActionToken_Call) Run(run func(context1 context.Context, s string, time1 time.Time)) *MockDataRepository_UseActionToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 url.Values
+		var arg2 time.Time
 		if args[2] != nil {
-			arg2 = args[2].(url.Values)
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
@@ -3800,214 +4027,128 @@ func (_c *MockMediaService_Exists_Call) Run(run func(serverName string, mediaID
 	return _c
 }
 
-func (_c *MockMediaService_Exists_Call) Return(b bool) *MockMediaService_Exists_Call {
-	_c.Call.Return(b)
+func (_c *MockDataRepository_UseActionToken_Call) Return(b bool, err error) *MockDataRepository_UseActionToken_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockMediaService_Exists_Call) RunAndReturn(run func(serverName string, mediaID string, params url.Values) bool) *MockMediaService_Exists_Call {
+func (_c *MockDataRepository_UseActionToken_Call) RunAndReturn(run func(context1 context.Context, s string, time1 time.Time) (bool, error)) *MockDataRepository_UseActionToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function for the type MockMediaService
-func (_mock *MockMediaService) Get(ctx context.Context, serverName string, mediaID string, params url.Values) (io.Reader, string) {
-	ret := _mock.Called(ctx, serverName, mediaID, params)
-
-	if len(ret) == 0 {
-		panic("no return value specified for Get")
-	}
-
-	var r0 io.Reader
-	var r1 string
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, url.Values) (io.Reader, string)); ok {
-		return returnFunc(ctx, serverName, mediaID, params)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, url.Values) io.Reader); ok {
-		r0 = returnFunc(ctx, serverName, mediaID, params)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(io.Reader)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, url.Values) string); ok {
-		r1 = returnFunc(ctx, serverName, mediaID, params)
-	} else {
-		r1 = ret.Get(1).(string)
-	}
-	return r0, r1
-}
+// NewMockValidatorService creates a new instance of MockValidatorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockValidatorService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockValidatorService {
+	mock := &MockValidatorService{}
+	mock.Mock.Test(t)
 
-// MockMediaService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockMediaService_Get_Call struct {
-	*mock.Call
-}
+	t.Cleanup(func() { mock.AssertExpectations(t) })
 
-// Get is a helper method to define mock.On call
-//   - ctx context.Context
-//   - serverName string
-//   - mediaID string
-//   - params url.Values
-func (_e *MockMediaService_Expecter) Get(ctx interface{}, serverName interface{}, mediaID interface{}, params interface{}) *MockMediaService_Get_Call {
-	return &MockMediaService_Get_Call{Call: _e.mock.On("Get", ctx, serverName, mediaID, params)}
+	return mock
 }
 
-func (_c *MockMediaService_Get_Call) Run(run func(ctx context.Context, serverName string, mediaID string, params url.Values)) *MockMediaService_Get_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 url.Values
-		if args[3] != nil {
-			arg3 = args[3].(url.Values)
-		}
-		run(
-			arg0,
-			arg1,
-			arg2,
-			arg3,
-		)
-	})
-	return _c
+// MockValidatorService is an autogenerated mock type for the ValidatorService type
+type MockValidatorService struct {
+	mock.Mock
 }
 
-func (_c *MockMediaService_Get_Call) Return(content io.Reader, contentType string) *MockMediaService_Get_Call {
-	_c.Call.Return(content, contentType)
-	return _c
+type MockValidatorService_Expecter struct {
+	mock *mock.Mock
 }
 
-func (_c *MockMediaService_Get_Call) RunAndReturn(run func(ctx context.Context, serverName string, mediaID string, params url.Values) (io.Reader, string)) *MockMediaService_Get_Call {
-	_c.Call.Return(run)
-	return _c
+func (_m *MockValidatorService) EXPECT() *MockValidatorService_Expecter {
+	return &MockValidatorService_Expecter{mock: &_m.Mock}
 }
 
-// GetURL provides a mock function for the type MockMediaService
-func (_mock *MockMediaService) GetURL(serverName string, mediaID string) string {
-	ret := _mock.Called(serverName, mediaID)
+// Domain provides a mock function for the type MockValidatorService
+func (_mock *MockValidatorService) Domain(server string) bool {
+	ret := _mock.Called(server)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetURL")
+		panic("no return value specified for Domain")
 	}
 
-	var r0 string
-	if returnFunc, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = returnFunc(serverName, mediaID)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(server)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(bool)
 	}
 	return r0
 }
 
-// MockMediaService_GetURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURL'
-type MockMediaService_GetURL_Call struct {
+// MockValidatorService_Domain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Domain'
+type MockValidatorService_Domain_Call struct {
 	*mock.Call
 }
 
-// GetURL is a helper method to define mock.On call
-//   - serverName string
-//   - mediaID string
-func (_e *MockMediaService_Expecter) GetURL(serverName interface{}, mediaID interface{}) *MockMediaService_GetURL_Call {
-	return &MockMediaService_GetURL_Call{Call: _e.mock.On("GetURL", serverName, mediaID)}
+// Domain is a helper method to define mock.On call
+//   - server string
+func (_e *MockValidatorService_Expecter) Domain(server interface{}) *MockValidatorService_Domain_Call {
+	return &MockValidatorService_Domain_Call{Call: _e.mock.On("Domain", server)}
 }
 
-func (_c *MockMediaService_GetURL_Call) Run(run func(serverName string, mediaID string)) *MockMediaService_GetURL_Call {
+func (_c *MockValidatorService_Domain_Call) Run(run func(server string)) *MockValidatorService_Domain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMediaService_GetURL_Call) Return(s string) *MockMediaService_GetURL_Call {
-	_c.Call.Return(s)
+func (_c *MockValidatorService_Domain_Call) Return(b bool) *MockValidatorService_Domain_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockMediaService_GetURL_Call) RunAndReturn(run func(serverName string, mediaID string) string) *MockMediaService_GetURL_Call {
+func (_c *MockValidatorService_Domain_Call) RunAndReturn(run func(server string) bool) *MockValidatorService_Domain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockEmailService creates a new instance of MockEmailService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockEmailService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockEmailService {
-	mock := &MockEmailService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockEmailService is an autogenerated mock type for the EmailService type
-type MockEmailService struct {
-	mock.Mock
-}
-
-type MockEmailService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockEmailService) EXPECT() *MockEmailService_Expecter {
-	return &MockEmailService_Expecter{mock: &_m.Mock}
-}
-
-// SendModReport provides a mock function for the type MockEmailService
-func (_mock *MockEmailService) SendModReport(text string, email string) error {
-	ret := _mock.Called(text, email)
+// IsIndexable provides a mock function for the type MockValidatorService
+func (_mock *MockValidatorService) IsIndexable(ctx context.Context, server string) bool {
+	ret := _mock.Called(ctx, server)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendModReport")
+		panic("no return value specified for IsIndexable")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = returnFunc(text, email)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, server)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 	return r0
 }
 
-// MockEmailService_SendModReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendModReport'
-type MockEmailService_SendModReport_Call struct {
+// MockValidatorService_IsIndexable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsIndexable'
+type MockValidatorService_IsIndexable_Call struct {
 	*mock.Call
 }
 
-// SendModReport is a helper method to define mock.On call
-//   - text string
-//   - email string
-func (_e *MockEmailService_Expecter) SendModReport(text interface{}, email interface{}) *MockEmailService_SendModReport_Call {
-	return &MockEmailService_SendModReport_Call{Call: _e.mock.On("SendModReport", text, email)}
+// IsIndexable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - server string
+func (_e *MockValidatorService_Expecter) IsIndexable(ctx interface{}, server interface{}) *MockValidatorService_IsIndexable_Call {
+	return &MockValidatorService_IsIndexable_Call{Call: _e.mock.On("IsIndexable", ctx, server)}
 }
 
-func (_c *MockEmailService_SendModReport_Call) Run(run func(text string, email string)) *MockEmailService_SendModReport_Call {
+func (_c *MockValidatorService_IsIndexable_Call) Run(run func(ctx context.Context, server string)) *MockValidatorService_IsIndexable_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
@@ -4021,98 +4162,152 @@ func (_c *MockEmailService_SendModReport_Call) Run(run func(text string, email s
 	return _c
 }
 
-func (_c *MockEmailService_SendModReport_Call) Return(err error) *MockEmailService_SendModReport_Call {
-	_c.Call.Return(err)
+func (_c *MockValidatorService_IsIndexable_Call) Return(b bool) *MockValidatorService_IsIndexable_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockEmailService_SendModReport_Call) RunAndReturn(run func(text string, email string) error) *MockEmailService_SendModReport_Call {
+func (_c *MockValidatorService_IsIndexable_Call) RunAndReturn(run func(ctx context.Context, server string) bool) *MockValidatorService_IsIndexable_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SendReport provides a mock function for the type MockEmailService
-func (_mock *MockEmailService) SendReport(ctx context.Context, room *model.MatrixRoom, server *model.MatrixServer, reason string, emails []string) error {
-	ret := _mock.Called(ctx, room, server, reason, emails)
+// IsOnline provides a mock function for the type MockValidatorService
+func (_mock *MockValidatorService) IsOnline(ctx context.Context, server string) (string, string, string, bool) {
+	ret := _mock.Called(ctx, server)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendReport")
+		panic("no return value specified for IsOnline")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.MatrixRoom, *model.MatrixServer, string, []string) error); ok {
-		r0 = returnFunc(ctx, room, server, reason, emails)
+	var r0 string
+	var r1 string
+	var r2 string
+	var r3 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, string, string, bool)); ok {
+		return returnFunc(ctx, server)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, server)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = returnFunc(ctx, server)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) string); ok {
+		r2 = returnFunc(ctx, server)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, string) bool); ok {
+		r3 = returnFunc(ctx, server)
+	} else {
+		r3 = ret.Get(3).(bool)
+	}
+	return r0, r1, r2, r3
 }
 
-// MockEmailService_SendReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendReport'
-type MockEmailService_SendReport_Call struct {
+// MockValidatorService_IsOnline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsOnline'
+type MockValidatorService_IsOnline_Call struct {
 	*mock.Call
 }
 
-// SendReport is a helper method to define mock.On call
+// IsOnline is a helper method to define mock.On call
 //   - ctx context.Context
-//   - room *model.MatrixRoom
-//   - server *model.MatrixServer
-//   - reason string
-//   - emails []string
-func (_e *MockEmailService_Expecter) SendReport(ctx interface{}, room interface{}, server interface{}, reason interface{}, emails interface{}) *MockEmailService_SendReport_Call {
-	return &MockEmailService_SendReport_Call{Call: _e.mock.On("SendReport", ctx, room, server, reason, emails)}
+//   - server string
+func (_e *MockValidatorService_Expecter) IsOnline(ctx interface{}, server interface{}) *MockValidatorService_IsOnline_Call {
+	return &MockValidatorService_IsOnline_Call{Call: _e.mock.On("IsOnline", ctx, server)}
 }
 
-func (_c *MockEmailService_SendReport_Call) Run(run func(ctx context.Context, room *model.MatrixRoom, server *model.MatrixServer, reason string, emails []string)) *MockEmailService_SendReport_Call {
+func (_c *MockValidatorService_IsOnline_Call) Run(run func(ctx context.Context, server string)) *MockValidatorService_IsOnline_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.MatrixRoom
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.MatrixRoom)
-		}
-		var arg2 *model.MatrixServer
-		if args[2] != nil {
-			arg2 = args[2].(*model.MatrixServer)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 []string
-		if args[4] != nil {
-			arg4 = args[4].([]string)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEmailService_SendReport_Call) Return(err error) *MockEmailService_SendReport_Call {
-	_c.Call.Return(err)
+func (_c *MockValidatorService_IsOnline_Call) Return(serverName string, serverSoftware string, serverVersion string, isOnline bool) *MockValidatorService_IsOnline_Call {
+	_c.Call.Return(serverName, serverSoftware, serverVersion, isOnline)
 	return _c
 }
 
-func (_c *MockEmailService_SendReport_Call) RunAndReturn(run func(ctx context.Context, room *model.MatrixRoom, server *model.MatrixServer, reason string, emails []string) error) *MockEmailService_SendReport_Call {
+func (_c *MockValidatorService_IsOnline_Call) RunAndReturn(run func(ctx context.Context, server string) (string, string, string, bool)) *MockValidatorService_IsOnline_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// newMockmatrixService creates a new instance of mockmatrixService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// IsRoomAllowed provides a mock function for the type MockValidatorService
+func (_mock *MockValidatorService) IsRoomAllowed(room *model.MatrixRoom) bool {
+	ret := _mock.Called(room)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRoomAllowed")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(*model.MatrixRoom) bool); ok {
+		r0 = returnFunc(room)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockValidatorService_IsRoomAllowed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRoomAllowed'
+type MockValidatorService_IsRoomAllowed_Call struct {
+	*mock.Call
+}
+
+// IsRoomAllowed is a helper method to define mock.On call
+//   - room *model.MatrixRoom
+func (_e *MockValidatorService_Expecter) IsRoomAllowed(room interface{}) *MockValidatorService_IsRoomAllowed_Call {
+	return &MockValidatorService_IsRoomAllowed_Call{Call: _e.mock.On("IsRoomAllowed", room)}
+}
+
+func (_c *MockValidatorService_IsRoomAllowed_Call) Run(run func(room *model.MatrixRoom)) *MockValidatorService_IsRoomAllowed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *model.MatrixRoom
+		if args[0] != nil {
+			arg0 = args[0].(*model.MatrixRoom)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockValidatorService_IsRoomAllowed_Call) Return(b bool) *MockValidatorService_IsRoomAllowed_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockValidatorService_IsRoomAllowed_Call) RunAndReturn(run func(room *model.MatrixRoom) bool) *MockValidatorService_IsRoomAllowed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFederationService creates a new instance of MockFederationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func newMockmatrixService(t interface {
+func NewMockFederationService(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *mockmatrixService {
-	mock := &mockmatrixService{}
+}) *MockFederationService {
+	mock := &MockFederationService{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -4120,21 +4315,95 @@ func newMockmatrixService(t interface {
 	return mock
 }
 
-// mockmatrixService is an autogenerated mock type for the matrixService type
-type mockmatrixService struct {
+// MockFederationService is an autogenerated mock type for the FederationService type
+type MockFederationService struct {
 	mock.Mock
 }
 
-type mockmatrixService_Expecter struct {
+type MockFederationService_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *mockmatrixService) EXPECT() *mockmatrixService_Expecter {
-	return &mockmatrixService_Expecter{mock: &_m.Mock}
+func (_m *MockFederationService) EXPECT() *MockFederationService_Expecter {
+	return &MockFederationService_Expecter{mock: &_m.Mock}
 }
 
-// GetClientRoomSummary provides a mock function for the type mockmatrixService
-func (_mock *mockmatrixService) GetClientRoomSummary(ctx context.Context, aliasOrID string, via string, onlyMSC3266 bool) (int, *model.RoomDirectoryRoom) {
+// GetAdminRoomSummary provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) GetAdminRoomSummary(ctx context.Context, aliasOrID string, via string) (int, *model.RoomDirectoryRoom) {
+	ret := _mock.Called(ctx, aliasOrID, via)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdminRoomSummary")
+	}
+
+	var r0 int
+	var r1 *model.RoomDirectoryRoom
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (int, *model.RoomDirectoryRoom)); ok {
+		return returnFunc(ctx, aliasOrID, via)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = returnFunc(ctx, aliasOrID, via)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) *model.RoomDirectoryRoom); ok {
+		r1 = returnFunc(ctx, aliasOrID, via)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.RoomDirectoryRoom)
+		}
+	}
+	return r0, r1
+}
+
+// MockFederationService_GetAdminRoomSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdminRoomSummary'
+type MockFederationService_GetAdminRoomSummary_Call struct {
+	*mock.Call
+}
+
+// GetAdminRoomSummary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - aliasOrID string
+//   - via string
+func (_e *MockFederationService_Expecter) GetAdminRoomSummary(ctx interface{}, aliasOrID interface{}, via interface{}) *MockFederationService_GetAdminRoomSummary_Call {
+	return &MockFederationService_GetAdminRoomSummary_Call{Call: _e.mock.On("GetAdminRoomSummary", ctx, aliasOrID, via)}
+}
+
+func (_c *MockFederationService_GetAdminRoomSummary_Call) Run(run func(ctx context.Context, aliasOrID string, via string)) *MockFederationService_GetAdminRoomSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFederationService_GetAdminRoomSummary_Call) Return(n int, roomDirectoryRoom *model.RoomDirectoryRoom) *MockFederationService_GetAdminRoomSummary_Call {
+	_c.Call.Return(n, roomDirectoryRoom)
+	return _c
+}
+
+func (_c *MockFederationService_GetAdminRoomSummary_Call) RunAndReturn(run func(ctx context.Context, aliasOrID string, via string) (int, *model.RoomDirectoryRoom)) *MockFederationService_GetAdminRoomSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClientRoomSummary provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) GetClientRoomSummary(ctx context.Context, aliasOrID string, via string, onlyMSC3266 bool) (int, *model.RoomDirectoryRoom) {
 	ret := _mock.Called(ctx, aliasOrID, via, onlyMSC3266)
 
 	if len(ret) == 0 {
@@ -4161,8 +4430,8 @@ func (_mock *mockmatrixService) GetClientRoomSummary(ctx context.Context, aliasO
 	return r0, r1
 }
 
-// mockmatrixService_GetClientRoomSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClientRoomSummary'
-type mockmatrixService_GetClientRoomSummary_Call struct {
+// MockFederationService_GetClientRoomSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClientRoomSummary'
+type MockFederationService_GetClientRoomSummary_Call struct {
 	*mock.Call
 }
 
@@ -4171,11 +4440,11 @@ type mockmatrixService_GetClientRoomSummary_Call struct {
 //   - aliasOrID string
 //   - via string
 //   - onlyMSC3266 bool
-func (_e *mockmatrixService_Expecter) GetClientRoomSummary(ctx interface{}, aliasOrID interface{}, via interface{}, onlyMSC3266 interface{}) *mockmatrixService_GetClientRoomSummary_Call {
-	return &mockmatrixService_GetClientRoomSummary_Call{Call: _e.mock.On("GetClientRoomSummary", ctx, aliasOrID, via, onlyMSC3266)}
+func (_e *MockFederationService_Expecter) GetClientRoomSummary(ctx interface{}, aliasOrID interface{}, via interface{}, onlyMSC3266 interface{}) *MockFederationService_GetClientRoomSummary_Call {
+	return &MockFederationService_GetClientRoomSummary_Call{Call: _e.mock.On("GetClientRoomSummary", ctx, aliasOrID, via, onlyMSC3266)}
 }
 
-func (_c *mockmatrixService_GetClientRoomSummary_Call) Run(run func(ctx context.Context, aliasOrID string, via string, onlyMSC3266 bool)) *mockmatrixService_GetClientRoomSummary_Call {
+func (_c *MockFederationService_GetClientRoomSummary_Call) Run(run func(ctx context.Context, aliasOrID string, via string, onlyMSC3266 bool)) *MockFederationService_GetClientRoomSummary_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4203,70 +4472,54 @@ func (_c *mockmatrixService_GetClientRoomSummary_Call) Run(run func(ctx context.
 	return _c
 }
 
-func (_c *mockmatrixService_GetClientRoomSummary_Call) Return(statusCode int, directoryRoom *model.RoomDirectoryRoom) *mockmatrixService_GetClientRoomSummary_Call {
-	_c.Call.Return(statusCode, directoryRoom)
+func (_c *MockFederationService_GetClientRoomSummary_Call) Return(n int, roomDirectoryRoom *model.RoomDirectoryRoom) *MockFederationService_GetClientRoomSummary_Call {
+	_c.Call.Return(n, roomDirectoryRoom)
 	return _c
 }
 
-func (_c *mockmatrixService_GetClientRoomSummary_Call) RunAndReturn(run func(ctx context.Context, aliasOrID string, via string, onlyMSC3266 bool) (int, *model.RoomDirectoryRoom)) *mockmatrixService_GetClientRoomSummary_Call {
+func (_c *MockFederationService_GetClientRoomSummary_Call) RunAndReturn(run func(ctx context.Context, aliasOrID string, via string, onlyMSC3266 bool) (int, *model.RoomDirectoryRoom)) *MockFederationService_GetClientRoomSummary_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockPlausibleService creates a new instance of MockPlausibleService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockPlausibleService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockPlausibleService {
-	mock := &MockPlausibleService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockPlausibleService is an autogenerated mock type for the PlausibleService type
-type MockPlausibleService struct {
-	mock.Mock
-}
-
-type MockPlausibleService_Expecter struct {
-	mock *mock.Mock
-}
+// QueryCSURL provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) QueryCSURL(ctx context.Context, serverName string) string {
+	ret := _mock.Called(ctx, serverName)
 
-func (_m *MockPlausibleService) EXPECT() *MockPlausibleService_Expecter {
-	return &MockPlausibleService_Expecter{mock: &_m.Mock}
-}
+	if len(ret) == 0 {
+		panic("no return value specified for QueryCSURL")
+	}
 
-// Track provides a mock function for the type MockPlausibleService
-func (_mock *MockPlausibleService) Track(ctx context.Context, evt *model.AnalyticsEvent) {
-	_mock.Called(ctx, evt)
-	return
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, serverName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
 }
 
-// MockPlausibleService_Track_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Track'
-type MockPlausibleService_Track_Call struct {
+// MockFederationService_QueryCSURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryCSURL'
+type MockFederationService_QueryCSURL_Call struct {
 	*mock.Call
 }
 
-// Track is a helper method to define mock.On call
+// QueryCSURL is a helper method to define mock.On call
 //   - ctx context.Context
-//   - evt *model.AnalyticsEvent
-func (_e *MockPlausibleService_Expecter) Track(ctx interface{}, evt interface{}) *MockPlausibleService_Track_Call {
-	return &MockPlausibleService_Track_Call{Call: _e.mock.On("Track", ctx, evt)}
+//   - serverName string
+func (_e *MockFederationService_Expecter) QueryCSURL(ctx interface{}, serverName interface{}) *MockFederationService_QueryCSURL_Call {
+	return &MockFederationService_QueryCSURL_Call{Call: _e.mock.On("QueryCSURL", ctx, serverName)}
 }
 
-func (_c *MockPlausibleService_Track_Call) Run(run func(ctx context.Context, evt *model.AnalyticsEvent)) *MockPlausibleService_Track_Call {
+func (_c *MockFederationService_QueryCSURL_Call) Run(run func(ctx context.Context, serverName string)) *MockFederationService_QueryCSURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.AnalyticsEvent
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.AnalyticsEvent)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -4276,541 +4529,5110 @@ func (_c *MockPlausibleService_Track_Call) Run(run func(ctx context.Context, evt
 	return _c
 }
 
-func (_c *MockPlausibleService_Track_Call) Return() *MockPlausibleService_Track_Call {
-	_c.Call.Return()
-	return _c
-}
-
-func (_c *MockPlausibleService_Track_Call) RunAndReturn(run func(ctx context.Context, evt *model.AnalyticsEvent)) *MockPlausibleService_Track_Call {
-	_c.Run(run)
+func (_c *MockFederationService_QueryCSURL_Call) Return(s string) *MockFederationService_QueryCSURL_Call {
+	_c.Call.Return(s)
 	return _c
 }
 
-// newMocksearchDataRepository creates a new instance of mocksearchDataRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func newMocksearchDataRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *mocksearchDataRepository {
-	mock := &mocksearchDataRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// mocksearchDataRepository is an autogenerated mock type for the searchDataRepository type
-type mocksearchDataRepository struct {
-	mock.Mock
-}
-
-type mocksearchDataRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *mocksearchDataRepository) EXPECT() *mocksearchDataRepository_Expecter {
-	return &mocksearchDataRepository_Expecter{mock: &_m.Mock}
+func (_c *MockFederationService_QueryCSURL_Call) RunAndReturn(run func(ctx context.Context, serverName string) string) *MockFederationService_QueryCSURL_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
-// GetBiggestRooms provides a mock function for the type mocksearchDataRepository
-func (_mock *mocksearchDataRepository) GetBiggestRooms(ctx context.Context, limit int, offset int) []*model.MatrixRoom {
-	ret := _mock.Called(ctx, limit, offset)
+// QueryDirectoryExternal provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) QueryDirectoryExternal(ctx context.Context, roomAlias string) (*model.QueryDirectoryResponse, error) {
+	ret := _mock.Called(ctx, roomAlias)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBiggestRooms")
+		panic("no return value specified for QueryDirectoryExternal")
 	}
 
-	var r0 []*model.MatrixRoom
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []*model.MatrixRoom); ok {
-		r0 = returnFunc(ctx, limit, offset)
+	var r0 *model.QueryDirectoryResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.QueryDirectoryResponse, error)); ok {
+		return returnFunc(ctx, roomAlias)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.QueryDirectoryResponse); ok {
+		r0 = returnFunc(ctx, roomAlias)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.MatrixRoom)
+			r0 = ret.Get(0).(*model.QueryDirectoryResponse)
 		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, roomAlias)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// mocksearchDataRepository_GetBiggestRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBiggestRooms'
-type mocksearchDataRepository_GetBiggestRooms_Call struct {
+// MockFederationService_QueryDirectoryExternal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryDirectoryExternal'
+type MockFederationService_QueryDirectoryExternal_Call struct {
 	*mock.Call
 }
 
-// GetBiggestRooms is a helper method to define mock.On call
+// QueryDirectoryExternal is a helper method to define mock.On call
 //   - ctx context.Context
-//   - limit int
-//   - offset int
-func (_e *mocksearchDataRepository_Expecter) GetBiggestRooms(ctx interface{}, limit interface{}, offset interface{}) *mocksearchDataRepository_GetBiggestRooms_Call {
-	return &mocksearchDataRepository_GetBiggestRooms_Call{Call: _e.mock.On("GetBiggestRooms", ctx, limit, offset)}
+//   - roomAlias string
+func (_e *MockFederationService_Expecter) QueryDirectoryExternal(ctx interface{}, roomAlias interface{}) *MockFederationService_QueryDirectoryExternal_Call {
+	return &MockFederationService_QueryDirectoryExternal_Call{Call: _e.mock.On("QueryDirectoryExternal", ctx, roomAlias)}
 }
 
-func (_c *mocksearchDataRepository_GetBiggestRooms_Call) Run(run func(ctx context.Context, limit int, offset int)) *mocksearchDataRepository_GetBiggestRooms_Call {
+func (_c *MockFederationService_QueryDirectoryExternal_Call) Run(run func(ctx context.Context, roomAlias string)) *MockFederationService_QueryDirectoryExternal_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 int
-		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *mocksearchDataRepository_GetBiggestRooms_Call) Return(matrixRooms []*model.MatrixRoom) *mocksearchDataRepository_GetBiggestRooms_Call {
-	_c.Call.Return(matrixRooms)
+func (_c *MockFederationService_QueryDirectoryExternal_Call) Return(queryDirectoryResponse *model.QueryDirectoryResponse, err error) *MockFederationService_QueryDirectoryExternal_Call {
+	_c.Call.Return(queryDirectoryResponse, err)
 	return _c
 }
 
-func (_c *mocksearchDataRepository_GetBiggestRooms_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) []*model.MatrixRoom) *mocksearchDataRepository_GetBiggestRooms_Call {
+func (_c *MockFederationService_QueryDirectoryExternal_Call) RunAndReturn(run func(ctx context.Context, roomAlias string) (*model.QueryDirectoryResponse, error)) *MockFederationService_QueryDirectoryExternal_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockSearchRepository creates a new instance of MockSearchRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockSearchRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockSearchRepository {
-	mock := &MockSearchRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockSearchRepository is an autogenerated mock type for the SearchRepository type
-type MockSearchRepository struct {
-	mock.Mock
-}
-
-type MockSearchRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockSearchRepository) EXPECT() *MockSearchRepository_Expecter {
-	return &MockSearchRepository_Expecter{mock: &_m.Mock}
-}
-
-// Search provides a mock function for the type MockSearchRepository
-func (_mock *MockSearchRepository) Search(ctx context.Context, searchQuery query.Query, limit int, offset int, sortBy []string) ([]*model.Entry, int, error) {
-	ret := _mock.Called(ctx, searchQuery, limit, offset, sortBy)
+// QueryPeerRooms provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) QueryPeerRooms(ctx context.Context, serverName string, since time.Time, from string) (*model.PeerRoomsResponse, error) {
+	ret := _mock.Called(ctx, serverName, since, from)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Search")
+		panic("no return value specified for QueryPeerRooms")
 	}
 
-	var r0 []*model.Entry
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, query.Query, int, int, []string) ([]*model.Entry, int, error)); ok {
-		return returnFunc(ctx, searchQuery, limit, offset, sortBy)
+	var r0 *model.PeerRoomsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, string) (*model.PeerRoomsResponse, error)); ok {
+		return returnFunc(ctx, serverName, since, from)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, query.Query, int, int, []string) []*model.Entry); ok {
-		r0 = returnFunc(ctx, searchQuery, limit, offset, sortBy)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, string) *model.PeerRoomsResponse); ok {
+		r0 = returnFunc(ctx, serverName, since, from)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Entry)
+			r0 = ret.Get(0).(*model.PeerRoomsResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, query.Query, int, int, []string) int); ok {
-		r1 = returnFunc(ctx, searchQuery, limit, offset, sortBy)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, query.Query, int, int, []string) error); ok {
-		r2 = returnFunc(ctx, searchQuery, limit, offset, sortBy)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, string) error); ok {
+		r1 = returnFunc(ctx, serverName, since, from)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockSearchRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
-type MockSearchRepository_Search_Call struct {
+// MockFederationService_QueryPeerRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPeerRooms'
+type MockFederationService_QueryPeerRooms_Call struct {
 	*mock.Call
 }
 
-// Search is a helper method to define mock.On call
+// QueryPeerRooms is a helper method to define mock.On call
 //   - ctx context.Context
-//   - searchQuery query.Query
-//   - limit int
-//   - offset int
-//   - sortBy []string
-func (_e *MockSearchRepository_Expecter) Search(ctx interface{}, searchQuery interface{}, limit interface{}, offset interface{}, sortBy interface{}) *MockSearchRepository_Search_Call {
-	return &MockSearchRepository_Search_Call{Call: _e.mock.On("Search", ctx, searchQuery, limit, offset, sortBy)}
+//   - serverName string
+//   - since time.Time
+//   - from string
+func (_e *MockFederationService_Expecter) QueryPeerRooms(ctx interface{}, serverName interface{}, since interface{}, from interface{}) *MockFederationService_QueryPeerRooms_Call {
+	return &MockFederationService_QueryPeerRooms_Call{Call: _e.mock.On("QueryPeerRooms", ctx, serverName, since, from)}
 }
 
-func (_c *MockSearchRepository_Search_Call) Run(run func(ctx context.Context, searchQuery query.Query, limit int, offset int, sortBy []string)) *MockSearchRepository_Search_Call {
+func (_c *MockFederationService_QueryPeerRooms_Call) Run(run func(ctx context.Context, serverName string, since time.Time, from string)) *MockFederationService_QueryPeerRooms_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 query.Query
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(query.Query)
+			arg1 = args[1].(string)
 		}
-		var arg2 int
+		var arg2 time.Time
 		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg2 = args[2].(time.Time)
 		}
-		var arg3 int
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(int)
-		}
-		var arg4 []string
-		if args[4] != nil {
-			arg4 = args[4].([]string)
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSearchRepository_Search_Call) Return(entrys []*model.Entry, n int, err error) *MockSearchRepository_Search_Call {
-	_c.Call.Return(entrys, n, err)
+func (_c *MockFederationService_QueryPeerRooms_Call) Return(peerRoomsResponse *model.PeerRoomsResponse, err error) *MockFederationService_QueryPeerRooms_Call {
+	_c.Call.Return(peerRoomsResponse, err)
 	return _c
 }
 
-func (_c *MockSearchRepository_Search_Call) RunAndReturn(run func(ctx context.Context, searchQuery query.Query, limit int, offset int, sortBy []string) ([]*model.Entry, int, error)) *MockSearchRepository_Search_Call {
+func (_c *MockFederationService_QueryPeerRooms_Call) RunAndReturn(run func(ctx context.Context, serverName string, since time.Time, from string) (*model.PeerRoomsResponse, error)) *MockFederationService_QueryPeerRooms_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockStatsService creates a new instance of MockStatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockStatsService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockStatsService {
-	mock := &MockStatsService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockStatsService is an autogenerated mock type for the StatsService type
-type MockStatsService struct {
-	mock.Mock
-}
-
-type MockStatsService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockStatsService) EXPECT() *MockStatsService_Expecter {
-	return &MockStatsService_Expecter{mock: &_m.Mock}
-}
-
-// Get provides a mock function for the type MockStatsService
-func (_mock *MockStatsService) Get() *model.IndexStats {
-	ret := _mock.Called()
+// QueryPeerServers provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) QueryPeerServers(ctx context.Context, serverName string) ([]string, error) {
+	ret := _mock.Called(ctx, serverName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for QueryPeerServers")
 	}
 
-	var r0 *model.IndexStats
-	if returnFunc, ok := ret.Get(0).(func() *model.IndexStats); ok {
-		r0 = returnFunc()
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, serverName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, serverName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.IndexStats)
+			r0 = ret.Get(0).([]string)
 		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, serverName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStatsService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockStatsService_Get_Call struct {
+// MockFederationService_QueryPeerServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPeerServers'
+type MockFederationService_QueryPeerServers_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
-func (_e *MockStatsService_Expecter) Get() *MockStatsService_Get_Call {
-	return &MockStatsService_Get_Call{Call: _e.mock.On("Get")}
+// QueryPeerServers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverName string
+func (_e *MockFederationService_Expecter) QueryPeerServers(ctx interface{}, serverName interface{}) *MockFederationService_QueryPeerServers_Call {
+	return &MockFederationService_QueryPeerServers_Call{Call: _e.mock.On("QueryPeerServers", ctx, serverName)}
 }
 
-func (_c *MockStatsService_Get_Call) Run(run func()) *MockStatsService_Get_Call {
+func (_c *MockFederationService_QueryPeerServers_Call) Run(run func(ctx context.Context, serverName string)) *MockFederationService_QueryPeerServers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
 	})
 	return _c
 }
 
-func (_c *MockStatsService_Get_Call) Return(indexStats *model.IndexStats) *MockStatsService_Get_Call {
-	_c.Call.Return(indexStats)
+func (_c *MockFederationService_QueryPeerServers_Call) Return(strings []string, err error) *MockFederationService_QueryPeerServers_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockStatsService_Get_Call) RunAndReturn(run func() *model.IndexStats) *MockStatsService_Get_Call {
+func (_c *MockFederationService_QueryPeerServers_Call) RunAndReturn(run func(ctx context.Context, serverName string) ([]string, error)) *MockFederationService_QueryPeerServers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// newMockbleveQuery creates a new instance of mockbleveQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func newMockbleveQuery(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *mockbleveQuery {
-	mock := &mockbleveQuery{}
-	mock.Mock.Test(t)
+// QueryPublicRooms provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) QueryPublicRooms(ctx context.Context, serverName string, limit string, since string, network model.RoomDirectoryNetwork) (*model.RoomDirectoryResponse, error) {
+	ret := _mock.Called(ctx, serverName, limit, since, network)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for QueryPublicRooms")
+	}
+
+	var r0 *model.RoomDirectoryResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, model.RoomDirectoryNetwork) (*model.RoomDirectoryResponse, error)); ok {
+		return returnFunc(ctx, serverName, limit, since, network)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, model.RoomDirectoryNetwork) *model.RoomDirectoryResponse); ok {
+		r0 = returnFunc(ctx, serverName, limit, since, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomDirectoryResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, model.RoomDirectoryNetwork) error); ok {
+		r1 = returnFunc(ctx, serverName, limit, since, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFederationService_QueryPublicRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPublicRooms'
+type MockFederationService_QueryPublicRooms_Call struct {
+	*mock.Call
+}
+
+// QueryPublicRooms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverName string
+//   - limit string
+//   - since string
+//   - network model.RoomDirectoryNetwork
+func (_e *MockFederationService_Expecter) QueryPublicRooms(ctx interface{}, serverName interface{}, limit interface{}, since interface{}, network interface{}) *MockFederationService_QueryPublicRooms_Call {
+	return &MockFederationService_QueryPublicRooms_Call{Call: _e.mock.On("QueryPublicRooms", ctx, serverName, limit, since, network)}
+}
+
+func (_c *MockFederationService_QueryPublicRooms_Call) Run(run func(ctx context.Context, serverName string, limit string, since string, network model.RoomDirectoryNetwork)) *MockFederationService_QueryPublicRooms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 model.RoomDirectoryNetwork
+		if args[4] != nil {
+			arg4 = args[4].(model.RoomDirectoryNetwork)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFederationService_QueryPublicRooms_Call) Return(roomDirectoryResponse *model.RoomDirectoryResponse, err error) *MockFederationService_QueryPublicRooms_Call {
+	_c.Call.Return(roomDirectoryResponse, err)
+	return _c
+}
+
+func (_c *MockFederationService_QueryPublicRooms_Call) RunAndReturn(run func(ctx context.Context, serverName string, limit string, since string, network model.RoomDirectoryNetwork) (*model.RoomDirectoryResponse, error)) *MockFederationService_QueryPublicRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryServerIP provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) QueryServerIP(ctx context.Context, serverName string) string {
+	ret := _mock.Called(ctx, serverName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryServerIP")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, serverName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockFederationService_QueryServerIP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryServerIP'
+type MockFederationService_QueryServerIP_Call struct {
+	*mock.Call
+}
+
+// QueryServerIP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverName string
+func (_e *MockFederationService_Expecter) QueryServerIP(ctx interface{}, serverName interface{}) *MockFederationService_QueryServerIP_Call {
+	return &MockFederationService_QueryServerIP_Call{Call: _e.mock.On("QueryServerIP", ctx, serverName)}
+}
+
+func (_c *MockFederationService_QueryServerIP_Call) Run(run func(ctx context.Context, serverName string)) *MockFederationService_QueryServerIP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFederationService_QueryServerIP_Call) Return(s string) *MockFederationService_QueryServerIP_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockFederationService_QueryServerIP_Call) RunAndReturn(run func(ctx context.Context, serverName string) string) *MockFederationService_QueryServerIP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryServerName provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) QueryServerName(ctx context.Context, serverName string) (string, error) {
+	ret := _mock.Called(ctx, serverName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryServerName")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, serverName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, serverName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, serverName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFederationService_QueryServerName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryServerName'
+type MockFederationService_QueryServerName_Call struct {
+	*mock.Call
+}
+
+// QueryServerName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverName string
+func (_e *MockFederationService_Expecter) QueryServerName(ctx interface{}, serverName interface{}) *MockFederationService_QueryServerName_Call {
+	return &MockFederationService_QueryServerName_Call{Call: _e.mock.On("QueryServerName", ctx, serverName)}
+}
+
+func (_c *MockFederationService_QueryServerName_Call) Run(run func(ctx context.Context, serverName string)) *MockFederationService_QueryServerName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFederationService_QueryServerName_Call) Return(s string, err error) *MockFederationService_QueryServerName_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockFederationService_QueryServerName_Call) RunAndReturn(run func(ctx context.Context, serverName string) (string, error)) *MockFederationService_QueryServerName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryServerPolicy provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) QueryServerPolicy(ctx context.Context, serverName string) (*model.MatrixServerPolicy, error) {
+	ret := _mock.Called(ctx, serverName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryServerPolicy")
+	}
+
+	var r0 *model.MatrixServerPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixServerPolicy, error)); ok {
+		return returnFunc(ctx, serverName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixServerPolicy); ok {
+		r0 = returnFunc(ctx, serverName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MatrixServerPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, serverName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFederationService_QueryServerPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryServerPolicy'
+type MockFederationService_QueryServerPolicy_Call struct {
+	*mock.Call
+}
+
+// QueryServerPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverName string
+func (_e *MockFederationService_Expecter) QueryServerPolicy(ctx interface{}, serverName interface{}) *MockFederationService_QueryServerPolicy_Call {
+	return &MockFederationService_QueryServerPolicy_Call{Call: _e.mock.On("QueryServerPolicy", ctx, serverName)}
+}
+
+func (_c *MockFederationService_QueryServerPolicy_Call) Run(run func(ctx context.Context, serverName string)) *MockFederationService_QueryServerPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFederationService_QueryServerPolicy_Call) Return(matrixServerPolicy *model.MatrixServerPolicy, err error) *MockFederationService_QueryServerPolicy_Call {
+	_c.Call.Return(matrixServerPolicy, err)
+	return _c
+}
+
+func (_c *MockFederationService_QueryServerPolicy_Call) RunAndReturn(run func(ctx context.Context, serverName string) (*model.MatrixServerPolicy, error)) *MockFederationService_QueryServerPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QuerySpaceHierarchy provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) QuerySpaceHierarchy(ctx context.Context, serverName string, roomID string, suggestedOnly bool) (*model.SpaceHierarchyResponse, error) {
+	ret := _mock.Called(ctx, serverName, roomID, suggestedOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuerySpaceHierarchy")
+	}
+
+	var r0 *model.SpaceHierarchyResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool) (*model.SpaceHierarchyResponse, error)); ok {
+		return returnFunc(ctx, serverName, roomID, suggestedOnly)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool) *model.SpaceHierarchyResponse); ok {
+		r0 = returnFunc(ctx, serverName, roomID, suggestedOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SpaceHierarchyResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = returnFunc(ctx, serverName, roomID, suggestedOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFederationService_QuerySpaceHierarchy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QuerySpaceHierarchy'
+type MockFederationService_QuerySpaceHierarchy_Call struct {
+	*mock.Call
+}
+
+// QuerySpaceHierarchy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverName string
+//   - roomID string
+//   - suggestedOnly bool
+func (_e *MockFederationService_Expecter) QuerySpaceHierarchy(ctx interface{}, serverName interface{}, roomID interface{}, suggestedOnly interface{}) *MockFederationService_QuerySpaceHierarchy_Call {
+	return &MockFederationService_QuerySpaceHierarchy_Call{Call: _e.mock.On("QuerySpaceHierarchy", ctx, serverName, roomID, suggestedOnly)}
+}
+
+func (_c *MockFederationService_QuerySpaceHierarchy_Call) Run(run func(ctx context.Context, serverName string, roomID string, suggestedOnly bool)) *MockFederationService_QuerySpaceHierarchy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFederationService_QuerySpaceHierarchy_Call) Return(spaceHierarchyResponse *model.SpaceHierarchyResponse, err error) *MockFederationService_QuerySpaceHierarchy_Call {
+	_c.Call.Return(spaceHierarchyResponse, err)
+	return _c
+}
+
+func (_c *MockFederationService_QuerySpaceHierarchy_Call) RunAndReturn(run func(ctx context.Context, serverName string, roomID string, suggestedOnly bool) (*model.SpaceHierarchyResponse, error)) *MockFederationService_QuerySpaceHierarchy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryVersion provides a mock function for the type MockFederationService
+func (_mock *MockFederationService) QueryVersion(ctx context.Context, serverName string) (string, string, error) {
+	ret := _mock.Called(ctx, serverName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryVersion")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, string, error)); ok {
+		return returnFunc(ctx, serverName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, serverName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = returnFunc(ctx, serverName)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, serverName)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockFederationService_QueryVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryVersion'
+type MockFederationService_QueryVersion_Call struct {
+	*mock.Call
+}
+
+// QueryVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverName string
+func (_e *MockFederationService_Expecter) QueryVersion(ctx interface{}, serverName interface{}) *MockFederationService_QueryVersion_Call {
+	return &MockFederationService_QueryVersion_Call{Call: _e.mock.On("QueryVersion", ctx, serverName)}
+}
+
+func (_c *MockFederationService_QueryVersion_Call) Run(run func(ctx context.Context, serverName string)) *MockFederationService_QueryVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFederationService_QueryVersion_Call) Return(s string, s1 string, err error) *MockFederationService_QueryVersion_Call {
+	_c.Call.Return(s, s1, err)
+	return _c
+}
+
+func (_c *MockFederationService_QueryVersion_Call) RunAndReturn(run func(ctx context.Context, serverName string) (string, string, error)) *MockFederationService_QueryVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockdataCrawlerService creates a new instance of mockdataCrawlerService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockdataCrawlerService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockdataCrawlerService {
+	mock := &mockdataCrawlerService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockdataCrawlerService is an autogenerated mock type for the dataCrawlerService type
+type mockdataCrawlerService struct {
+	mock.Mock
+}
+
+type mockdataCrawlerService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockdataCrawlerService) EXPECT() *mockdataCrawlerService_Expecter {
+	return &mockdataCrawlerService_Expecter{mock: &_m.Mock}
+}
+
+// AddServer provides a mock function for the type mockdataCrawlerService
+func (_mock *mockdataCrawlerService) AddServer(context1 context.Context, s string) int {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddServer")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// mockdataCrawlerService_AddServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddServer'
+type mockdataCrawlerService_AddServer_Call struct {
+	*mock.Call
+}
+
+// AddServer is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *mockdataCrawlerService_Expecter) AddServer(context1 interface{}, s interface{}) *mockdataCrawlerService_AddServer_Call {
+	return &mockdataCrawlerService_AddServer_Call{Call: _e.mock.On("AddServer", context1, s)}
+}
+
+func (_c *mockdataCrawlerService_AddServer_Call) Run(run func(context1 context.Context, s string)) *mockdataCrawlerService_AddServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataCrawlerService_AddServer_Call) Return(n int) *mockdataCrawlerService_AddServer_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *mockdataCrawlerService_AddServer_Call) RunAndReturn(run func(context1 context.Context, s string) int) *mockdataCrawlerService_AddServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddServers provides a mock function for the type mockdataCrawlerService
+func (_mock *mockdataCrawlerService) AddServers(context1 context.Context, strings []string, n int) {
+	_mock.Called(context1, strings, n)
+	return
+}
+
+// mockdataCrawlerService_AddServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddServers'
+type mockdataCrawlerService_AddServers_Call struct {
+	*mock.Call
+}
+
+// AddServers is a helper method to define mock.On call
+//   - context1 context.Context
+//   - strings []string
+//   - n int
+func (_e *mockdataCrawlerService_Expecter) AddServers(context1 interface{}, strings interface{}, n interface{}) *mockdataCrawlerService_AddServers_Call {
+	return &mockdataCrawlerService_AddServers_Call{Call: _e.mock.On("AddServers", context1, strings, n)}
+}
+
+func (_c *mockdataCrawlerService_AddServers_Call) Run(run func(context1 context.Context, strings []string, n int)) *mockdataCrawlerService_AddServers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataCrawlerService_AddServers_Call) Return() *mockdataCrawlerService_AddServers_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockdataCrawlerService_AddServers_Call) RunAndReturn(run func(context1 context.Context, strings []string, n int)) *mockdataCrawlerService_AddServers_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DiscoverServers provides a mock function for the type mockdataCrawlerService
+func (_mock *mockdataCrawlerService) DiscoverServers(context1 context.Context, n int, lists ...*kit.List[string, string]) {
+	if len(lists) > 0 {
+		_mock.Called(context1, n, lists)
+	} else {
+		_mock.Called(context1, n)
+	}
+
+	return
+}
+
+// mockdataCrawlerService_DiscoverServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiscoverServers'
+type mockdataCrawlerService_DiscoverServers_Call struct {
+	*mock.Call
+}
+
+// DiscoverServers is a helper method to define mock.On call
+//   - context1 context.Context
+//   - n int
+//   - lists ...*kit.List[string, string]
+func (_e *mockdataCrawlerService_Expecter) DiscoverServers(context1 interface{}, n interface{}, lists ...interface{}) *mockdataCrawlerService_DiscoverServers_Call {
+	return &mockdataCrawlerService_DiscoverServers_Call{Call: _e.mock.On("DiscoverServers",
+		append([]interface{}{context1, n}, lists...)...)}
+}
+
+func (_c *mockdataCrawlerService_DiscoverServers_Call) Run(run func(context1 context.Context, n int, lists ...*kit.List[string, string])) *mockdataCrawlerService_DiscoverServers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []*kit.List[string, string]
+		var variadicArgs []*kit.List[string, string]
+		if len(args) > 2 {
+			variadicArgs = args[2].([]*kit.List[string, string])
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataCrawlerService_DiscoverServers_Call) Return() *mockdataCrawlerService_DiscoverServers_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockdataCrawlerService_DiscoverServers_Call) RunAndReturn(run func(context1 context.Context, n int, lists ...*kit.List[string, string])) *mockdataCrawlerService_DiscoverServers_Call {
+	_c.Run(run)
+	return _c
+}
+
+// EachRoom provides a mock function for the type mockdataCrawlerService
+func (_mock *mockdataCrawlerService) EachRoom(context1 context.Context, fn func(string, *model.MatrixRoom) bool) {
+	_mock.Called(context1, fn)
+	return
+}
+
+// mockdataCrawlerService_EachRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachRoom'
+type mockdataCrawlerService_EachRoom_Call struct {
+	*mock.Call
+}
+
+// EachRoom is a helper method to define mock.On call
+//   - context1 context.Context
+//   - fn func(string, *model.MatrixRoom) bool
+func (_e *mockdataCrawlerService_Expecter) EachRoom(context1 interface{}, fn interface{}) *mockdataCrawlerService_EachRoom_Call {
+	return &mockdataCrawlerService_EachRoom_Call{Call: _e.mock.On("EachRoom", context1, fn)}
+}
+
+func (_c *mockdataCrawlerService_EachRoom_Call) Run(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *mockdataCrawlerService_EachRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(string, *model.MatrixRoom) bool
+		if args[1] != nil {
+			arg1 = args[1].(func(string, *model.MatrixRoom) bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataCrawlerService_EachRoom_Call) Return() *mockdataCrawlerService_EachRoom_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockdataCrawlerService_EachRoom_Call) RunAndReturn(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *mockdataCrawlerService_EachRoom_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetRoom provides a mock function for the type mockdataCrawlerService
+func (_mock *mockdataCrawlerService) GetRoom(ctx context.Context, roomID string) (*model.MatrixRoom, error) {
+	ret := _mock.Called(ctx, roomID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoom")
+	}
+
+	var r0 *model.MatrixRoom
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixRoom, error)); ok {
+		return returnFunc(ctx, roomID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixRoom); ok {
+		r0 = returnFunc(ctx, roomID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MatrixRoom)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, roomID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockdataCrawlerService_GetRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoom'
+type mockdataCrawlerService_GetRoom_Call struct {
+	*mock.Call
+}
+
+// GetRoom is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roomID string
+func (_e *mockdataCrawlerService_Expecter) GetRoom(ctx interface{}, roomID interface{}) *mockdataCrawlerService_GetRoom_Call {
+	return &mockdataCrawlerService_GetRoom_Call{Call: _e.mock.On("GetRoom", ctx, roomID)}
+}
+
+func (_c *mockdataCrawlerService_GetRoom_Call) Run(run func(ctx context.Context, roomID string)) *mockdataCrawlerService_GetRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataCrawlerService_GetRoom_Call) Return(matrixRoom *model.MatrixRoom, err error) *mockdataCrawlerService_GetRoom_Call {
+	_c.Call.Return(matrixRoom, err)
+	return _c
+}
+
+func (_c *mockdataCrawlerService_GetRoom_Call) RunAndReturn(run func(ctx context.Context, roomID string) (*model.MatrixRoom, error)) *mockdataCrawlerService_GetRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoomHistory provides a mock function for the type mockdataCrawlerService
+func (_mock *mockdataCrawlerService) GetRoomHistory(ctx context.Context, roomIDorAlias string) (*model.RoomHistory, error) {
+	ret := _mock.Called(ctx, roomIDorAlias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoomHistory")
+	}
+
+	var r0 *model.RoomHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.RoomHistory, error)); ok {
+		return returnFunc(ctx, roomIDorAlias)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.RoomHistory); ok {
+		r0 = returnFunc(ctx, roomIDorAlias)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, roomIDorAlias)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockdataCrawlerService_GetRoomHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomHistory'
+type mockdataCrawlerService_GetRoomHistory_Call struct {
+	*mock.Call
+}
+
+// GetRoomHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roomIDorAlias string
+func (_e *mockdataCrawlerService_Expecter) GetRoomHistory(ctx interface{}, roomIDorAlias interface{}) *mockdataCrawlerService_GetRoomHistory_Call {
+	return &mockdataCrawlerService_GetRoomHistory_Call{Call: _e.mock.On("GetRoomHistory", ctx, roomIDorAlias)}
+}
+
+func (_c *mockdataCrawlerService_GetRoomHistory_Call) Run(run func(ctx context.Context, roomIDorAlias string)) *mockdataCrawlerService_GetRoomHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataCrawlerService_GetRoomHistory_Call) Return(roomHistory *model.RoomHistory, err error) *mockdataCrawlerService_GetRoomHistory_Call {
+	_c.Call.Return(roomHistory, err)
+	return _c
+}
+
+func (_c *mockdataCrawlerService_GetRoomHistory_Call) RunAndReturn(run func(ctx context.Context, roomIDorAlias string) (*model.RoomHistory, error)) *mockdataCrawlerService_GetRoomHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ParseRooms provides a mock function for the type mockdataCrawlerService
+func (_mock *mockdataCrawlerService) ParseRooms(context1 context.Context, n int) {
+	_mock.Called(context1, n)
+	return
+}
+
+// mockdataCrawlerService_ParseRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseRooms'
+type mockdataCrawlerService_ParseRooms_Call struct {
+	*mock.Call
+}
+
+// ParseRooms is a helper method to define mock.On call
+//   - context1 context.Context
+//   - n int
+func (_e *mockdataCrawlerService_Expecter) ParseRooms(context1 interface{}, n interface{}) *mockdataCrawlerService_ParseRooms_Call {
+	return &mockdataCrawlerService_ParseRooms_Call{Call: _e.mock.On("ParseRooms", context1, n)}
+}
+
+func (_c *mockdataCrawlerService_ParseRooms_Call) Run(run func(context1 context.Context, n int)) *mockdataCrawlerService_ParseRooms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataCrawlerService_ParseRooms_Call) Return() *mockdataCrawlerService_ParseRooms_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockdataCrawlerService_ParseRooms_Call) RunAndReturn(run func(context1 context.Context, n int)) *mockdataCrawlerService_ParseRooms_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RefreshRoom provides a mock function for the type mockdataCrawlerService
+func (_mock *mockdataCrawlerService) RefreshRoom(ctx context.Context, roomIDorAlias string) (int, *model.MatrixRoom) {
+	ret := _mock.Called(ctx, roomIDorAlias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshRoom")
+	}
+
+	var r0 int
+	var r1 *model.MatrixRoom
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int, *model.MatrixRoom)); ok {
+		return returnFunc(ctx, roomIDorAlias)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = returnFunc(ctx, roomIDorAlias)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) *model.MatrixRoom); ok {
+		r1 = returnFunc(ctx, roomIDorAlias)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.MatrixRoom)
+		}
+	}
+	return r0, r1
+}
+
+// mockdataCrawlerService_RefreshRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshRoom'
+type mockdataCrawlerService_RefreshRoom_Call struct {
+	*mock.Call
+}
+
+// RefreshRoom is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roomIDorAlias string
+func (_e *mockdataCrawlerService_Expecter) RefreshRoom(ctx interface{}, roomIDorAlias interface{}) *mockdataCrawlerService_RefreshRoom_Call {
+	return &mockdataCrawlerService_RefreshRoom_Call{Call: _e.mock.On("RefreshRoom", ctx, roomIDorAlias)}
+}
+
+func (_c *mockdataCrawlerService_RefreshRoom_Call) Run(run func(ctx context.Context, roomIDorAlias string)) *mockdataCrawlerService_RefreshRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataCrawlerService_RefreshRoom_Call) Return(n int, matrixRoom *model.MatrixRoom) *mockdataCrawlerService_RefreshRoom_Call {
+	_c.Call.Return(n, matrixRoom)
+	return _c
+}
+
+func (_c *mockdataCrawlerService_RefreshRoom_Call) RunAndReturn(run func(ctx context.Context, roomIDorAlias string) (int, *model.MatrixRoom)) *mockdataCrawlerService_RefreshRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshServer provides a mock function for the type mockdataCrawlerService
+func (_mock *mockdataCrawlerService) RefreshServer(ctx context.Context, name string) (int, *model.MatrixServer, []*model.MatrixRoom) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshServer")
+	}
+
+	var r0 int
+	var r1 *model.MatrixServer
+	var r2 []*model.MatrixRoom
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int, *model.MatrixServer, []*model.MatrixRoom)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) *model.MatrixServer); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.MatrixServer)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) []*model.MatrixRoom); ok {
+		r2 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).([]*model.MatrixRoom)
+		}
+	}
+	return r0, r1, r2
+}
+
+// mockdataCrawlerService_RefreshServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshServer'
+type mockdataCrawlerService_RefreshServer_Call struct {
+	*mock.Call
+}
+
+// RefreshServer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *mockdataCrawlerService_Expecter) RefreshServer(ctx interface{}, name interface{}) *mockdataCrawlerService_RefreshServer_Call {
+	return &mockdataCrawlerService_RefreshServer_Call{Call: _e.mock.On("RefreshServer", ctx, name)}
+}
+
+func (_c *mockdataCrawlerService_RefreshServer_Call) Run(run func(ctx context.Context, name string)) *mockdataCrawlerService_RefreshServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataCrawlerService_RefreshServer_Call) Return(n int, matrixServer *model.MatrixServer, matrixRooms []*model.MatrixRoom) *mockdataCrawlerService_RefreshServer_Call {
+	_c.Call.Return(n, matrixServer, matrixRooms)
+	return _c
+}
+
+func (_c *mockdataCrawlerService_RefreshServer_Call) RunAndReturn(run func(ctx context.Context, name string) (int, *model.MatrixServer, []*model.MatrixRoom)) *mockdataCrawlerService_RefreshServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockdataIndexService creates a new instance of mockdataIndexService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockdataIndexService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockdataIndexService {
+	mock := &mockdataIndexService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockdataIndexService is an autogenerated mock type for the dataIndexService type
+type mockdataIndexService struct {
+	mock.Mock
+}
+
+type mockdataIndexService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockdataIndexService) EXPECT() *mockdataIndexService_Expecter {
+	return &mockdataIndexService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteRoom provides a mock function for the type mockdataIndexService
+func (_mock *mockdataIndexService) DeleteRoom(roomID string) error {
+	ret := _mock.Called(roomID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoom")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(roomID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockdataIndexService_DeleteRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRoom'
+type mockdataIndexService_DeleteRoom_Call struct {
+	*mock.Call
+}
+
+// DeleteRoom is a helper method to define mock.On call
+//   - roomID string
+func (_e *mockdataIndexService_Expecter) DeleteRoom(roomID interface{}) *mockdataIndexService_DeleteRoom_Call {
+	return &mockdataIndexService_DeleteRoom_Call{Call: _e.mock.On("DeleteRoom", roomID)}
+}
+
+func (_c *mockdataIndexService_DeleteRoom_Call) Run(run func(roomID string)) *mockdataIndexService_DeleteRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataIndexService_DeleteRoom_Call) Return(err error) *mockdataIndexService_DeleteRoom_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockdataIndexService_DeleteRoom_Call) RunAndReturn(run func(roomID string) error) *mockdataIndexService_DeleteRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EmptyIndex provides a mock function for the type mockdataIndexService
+func (_mock *mockdataIndexService) EmptyIndex(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmptyIndex")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockdataIndexService_EmptyIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EmptyIndex'
+type mockdataIndexService_EmptyIndex_Call struct {
+	*mock.Call
+}
+
+// EmptyIndex is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockdataIndexService_Expecter) EmptyIndex(ctx interface{}) *mockdataIndexService_EmptyIndex_Call {
+	return &mockdataIndexService_EmptyIndex_Call{Call: _e.mock.On("EmptyIndex", ctx)}
+}
+
+func (_c *mockdataIndexService_EmptyIndex_Call) Run(run func(ctx context.Context)) *mockdataIndexService_EmptyIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataIndexService_EmptyIndex_Call) Return(err error) *mockdataIndexService_EmptyIndex_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockdataIndexService_EmptyIndex_Call) RunAndReturn(run func(ctx context.Context) error) *mockdataIndexService_EmptyIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IndexBatch provides a mock function for the type mockdataIndexService
+func (_mock *mockdataIndexService) IndexBatch(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IndexBatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockdataIndexService_IndexBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexBatch'
+type mockdataIndexService_IndexBatch_Call struct {
+	*mock.Call
+}
+
+// IndexBatch is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockdataIndexService_Expecter) IndexBatch(ctx interface{}) *mockdataIndexService_IndexBatch_Call {
+	return &mockdataIndexService_IndexBatch_Call{Call: _e.mock.On("IndexBatch", ctx)}
+}
+
+func (_c *mockdataIndexService_IndexBatch_Call) Run(run func(ctx context.Context)) *mockdataIndexService_IndexBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataIndexService_IndexBatch_Call) Return(err error) *mockdataIndexService_IndexBatch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockdataIndexService_IndexBatch_Call) RunAndReturn(run func(ctx context.Context) error) *mockdataIndexService_IndexBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IndexRoom provides a mock function for the type mockdataIndexService
+func (_mock *mockdataIndexService) IndexRoom(roomID string, data *model.Entry) error {
+	ret := _mock.Called(roomID, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IndexRoom")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, *model.Entry) error); ok {
+		r0 = returnFunc(roomID, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockdataIndexService_IndexRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexRoom'
+type mockdataIndexService_IndexRoom_Call struct {
+	*mock.Call
+}
+
+// IndexRoom is a helper method to define mock.On call
+//   - roomID string
+//   - data *model.Entry
+func (_e *mockdataIndexService_Expecter) IndexRoom(roomID interface{}, data interface{}) *mockdataIndexService_IndexRoom_Call {
+	return &mockdataIndexService_IndexRoom_Call{Call: _e.mock.On("IndexRoom", roomID, data)}
+}
+
+func (_c *mockdataIndexService_IndexRoom_Call) Run(run func(roomID string, data *model.Entry)) *mockdataIndexService_IndexRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 *model.Entry
+		if args[1] != nil {
+			arg1 = args[1].(*model.Entry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataIndexService_IndexRoom_Call) Return(err error) *mockdataIndexService_IndexRoom_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockdataIndexService_IndexRoom_Call) RunAndReturn(run func(roomID string, data *model.Entry) error) *mockdataIndexService_IndexRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RoomsBatch provides a mock function for the type mockdataIndexService
+func (_mock *mockdataIndexService) RoomsBatch(ctx context.Context, roomID string, data *model.Entry) error {
+	ret := _mock.Called(ctx, roomID, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RoomsBatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.Entry) error); ok {
+		r0 = returnFunc(ctx, roomID, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockdataIndexService_RoomsBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RoomsBatch'
+type mockdataIndexService_RoomsBatch_Call struct {
+	*mock.Call
+}
+
+// RoomsBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roomID string
+//   - data *model.Entry
+func (_e *mockdataIndexService_Expecter) RoomsBatch(ctx interface{}, roomID interface{}, data interface{}) *mockdataIndexService_RoomsBatch_Call {
+	return &mockdataIndexService_RoomsBatch_Call{Call: _e.mock.On("RoomsBatch", ctx, roomID, data)}
+}
+
+func (_c *mockdataIndexService_RoomsBatch_Call) Run(run func(ctx context.Context, roomID string, data *model.Entry)) *mockdataIndexService_RoomsBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *model.Entry
+		if args[2] != nil {
+			arg2 = args[2].(*model.Entry)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataIndexService_RoomsBatch_Call) Return(err error) *mockdataIndexService_RoomsBatch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockdataIndexService_RoomsBatch_Call) RunAndReturn(run func(ctx context.Context, roomID string, data *model.Entry) error) *mockdataIndexService_RoomsBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockdataStatsService creates a new instance of mockdataStatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockdataStatsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockdataStatsService {
+	mock := &mockdataStatsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockdataStatsService is an autogenerated mock type for the dataStatsService type
+type mockdataStatsService struct {
+	mock.Mock
+}
+
+type mockdataStatsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockdataStatsService) EXPECT() *mockdataStatsService_Expecter {
+	return &mockdataStatsService_Expecter{mock: &_m.Mock}
+}
+
+// Collect provides a mock function for the type mockdataStatsService
+func (_mock *mockdataStatsService) Collect(context1 context.Context) {
+	_mock.Called(context1)
+	return
+}
+
+// mockdataStatsService_Collect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Collect'
+type mockdataStatsService_Collect_Call struct {
+	*mock.Call
+}
+
+// Collect is a helper method to define mock.On call
+//   - context1 context.Context
+func (_e *mockdataStatsService_Expecter) Collect(context1 interface{}) *mockdataStatsService_Collect_Call {
+	return &mockdataStatsService_Collect_Call{Call: _e.mock.On("Collect", context1)}
+}
+
+func (_c *mockdataStatsService_Collect_Call) Run(run func(context1 context.Context)) *mockdataStatsService_Collect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataStatsService_Collect_Call) Return() *mockdataStatsService_Collect_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockdataStatsService_Collect_Call) RunAndReturn(run func(context1 context.Context)) *mockdataStatsService_Collect_Call {
+	_c.Run(run)
+	return _c
+}
+
+// CollectServers provides a mock function for the type mockdataStatsService
+func (_mock *mockdataStatsService) CollectServers(context1 context.Context, b bool) {
+	_mock.Called(context1, b)
+	return
+}
+
+// mockdataStatsService_CollectServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CollectServers'
+type mockdataStatsService_CollectServers_Call struct {
+	*mock.Call
+}
+
+// CollectServers is a helper method to define mock.On call
+//   - context1 context.Context
+//   - b bool
+func (_e *mockdataStatsService_Expecter) CollectServers(context1 interface{}, b interface{}) *mockdataStatsService_CollectServers_Call {
+	return &mockdataStatsService_CollectServers_Call{Call: _e.mock.On("CollectServers", context1, b)}
+}
+
+func (_c *mockdataStatsService_CollectServers_Call) Run(run func(context1 context.Context, b bool)) *mockdataStatsService_CollectServers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataStatsService_CollectServers_Call) Return() *mockdataStatsService_CollectServers_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockdataStatsService_CollectServers_Call) RunAndReturn(run func(context1 context.Context, b bool)) *mockdataStatsService_CollectServers_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Get provides a mock function for the type mockdataStatsService
+func (_mock *mockdataStatsService) Get() *model.IndexStats {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.IndexStats
+	if returnFunc, ok := ret.Get(0).(func() *model.IndexStats); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IndexStats)
+		}
+	}
+	return r0
+}
+
+// mockdataStatsService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type mockdataStatsService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+func (_e *mockdataStatsService_Expecter) Get() *mockdataStatsService_Get_Call {
+	return &mockdataStatsService_Get_Call{Call: _e.mock.On("Get")}
+}
+
+func (_c *mockdataStatsService_Get_Call) Run(run func()) *mockdataStatsService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockdataStatsService_Get_Call) Return(indexStats *model.IndexStats) *mockdataStatsService_Get_Call {
+	_c.Call.Return(indexStats)
+	return _c
+}
+
+func (_c *mockdataStatsService_Get_Call) RunAndReturn(run func() *model.IndexStats) *mockdataStatsService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFinishedAt provides a mock function for the type mockdataStatsService
+func (_mock *mockdataStatsService) SetFinishedAt(context1 context.Context, s string, time1 time.Time) {
+	_mock.Called(context1, s, time1)
+	return
+}
+
+// mockdataStatsService_SetFinishedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFinishedAt'
+type mockdataStatsService_SetFinishedAt_Call struct {
+	*mock.Call
+}
+
+// SetFinishedAt is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - time1 time.Time
+func (_e *mockdataStatsService_Expecter) SetFinishedAt(context1 interface{}, s interface{}, time1 interface{}) *mockdataStatsService_SetFinishedAt_Call {
+	return &mockdataStatsService_SetFinishedAt_Call{Call: _e.mock.On("SetFinishedAt", context1, s, time1)}
+}
+
+func (_c *mockdataStatsService_SetFinishedAt_Call) Run(run func(context1 context.Context, s string, time1 time.Time)) *mockdataStatsService_SetFinishedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataStatsService_SetFinishedAt_Call) Return() *mockdataStatsService_SetFinishedAt_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockdataStatsService_SetFinishedAt_Call) RunAndReturn(run func(context1 context.Context, s string, time1 time.Time)) *mockdataStatsService_SetFinishedAt_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetStartedAt provides a mock function for the type mockdataStatsService
+func (_mock *mockdataStatsService) SetStartedAt(context1 context.Context, s string, time1 time.Time) {
+	_mock.Called(context1, s, time1)
+	return
+}
+
+// mockdataStatsService_SetStartedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStartedAt'
+type mockdataStatsService_SetStartedAt_Call struct {
+	*mock.Call
+}
+
+// SetStartedAt is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - time1 time.Time
+func (_e *mockdataStatsService_Expecter) SetStartedAt(context1 interface{}, s interface{}, time1 interface{}) *mockdataStatsService_SetStartedAt_Call {
+	return &mockdataStatsService_SetStartedAt_Call{Call: _e.mock.On("SetStartedAt", context1, s, time1)}
+}
+
+func (_c *mockdataStatsService_SetStartedAt_Call) Run(run func(context1 context.Context, s string, time1 time.Time)) *mockdataStatsService_SetStartedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataStatsService_SetStartedAt_Call) Return() *mockdataStatsService_SetStartedAt_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockdataStatsService_SetStartedAt_Call) RunAndReturn(run func(context1 context.Context, s string, time1 time.Time)) *mockdataStatsService_SetStartedAt_Call {
+	_c.Run(run)
+	return _c
+}
+
+// newMockdataFlagService creates a new instance of mockdataFlagService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockdataFlagService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockdataFlagService {
+	mock := &mockdataFlagService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockdataFlagService is an autogenerated mock type for the dataFlagService type
+type mockdataFlagService struct {
+	mock.Mock
+}
+
+type mockdataFlagService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockdataFlagService) EXPECT() *mockdataFlagService_Expecter {
+	return &mockdataFlagService_Expecter{mock: &_m.Mock}
+}
+
+// NewFlagRun provides a mock function for the type mockdataFlagService
+func (_mock *mockdataFlagService) NewFlagRun(ctx context.Context) *FlagRun {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewFlagRun")
+	}
+
+	var r0 *FlagRun
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *FlagRun); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*FlagRun)
+		}
+	}
+	return r0
+}
+
+// mockdataFlagService_NewFlagRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewFlagRun'
+type mockdataFlagService_NewFlagRun_Call struct {
+	*mock.Call
+}
+
+// NewFlagRun is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockdataFlagService_Expecter) NewFlagRun(ctx interface{}) *mockdataFlagService_NewFlagRun_Call {
+	return &mockdataFlagService_NewFlagRun_Call{Call: _e.mock.On("NewFlagRun", ctx)}
+}
+
+func (_c *mockdataFlagService_NewFlagRun_Call) Run(run func(ctx context.Context)) *mockdataFlagService_NewFlagRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataFlagService_NewFlagRun_Call) Return(flagRun *FlagRun) *mockdataFlagService_NewFlagRun_Call {
+	_c.Call.Return(flagRun)
+	return _c
+}
+
+func (_c *mockdataFlagService_NewFlagRun_Call) RunAndReturn(run func(ctx context.Context) *FlagRun) *mockdataFlagService_NewFlagRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRefreshFlagRun provides a mock function for the type mockdataFlagService
+func (_mock *mockdataFlagService) NewRefreshFlagRun(ctx context.Context) *FlagRun {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewRefreshFlagRun")
+	}
+
+	var r0 *FlagRun
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *FlagRun); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*FlagRun)
+		}
+	}
+	return r0
+}
+
+// mockdataFlagService_NewRefreshFlagRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewRefreshFlagRun'
+type mockdataFlagService_NewRefreshFlagRun_Call struct {
+	*mock.Call
+}
+
+// NewRefreshFlagRun is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockdataFlagService_Expecter) NewRefreshFlagRun(ctx interface{}) *mockdataFlagService_NewRefreshFlagRun_Call {
+	return &mockdataFlagService_NewRefreshFlagRun_Call{Call: _e.mock.On("NewRefreshFlagRun", ctx)}
+}
+
+func (_c *mockdataFlagService_NewRefreshFlagRun_Call) Run(run func(ctx context.Context)) *mockdataFlagService_NewRefreshFlagRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockdataFlagService_NewRefreshFlagRun_Call) Return(flagRun *FlagRun) *mockdataFlagService_NewRefreshFlagRun_Call {
+	_c.Call.Return(flagRun)
+	return _c
+}
+
+func (_c *mockdataFlagService_NewRefreshFlagRun_Call) RunAndReturn(run func(ctx context.Context) *FlagRun) *mockdataFlagService_NewRefreshFlagRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIndexRepository creates a new instance of MockIndexRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIndexRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIndexRepository {
+	mock := &MockIndexRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIndexRepository is an autogenerated mock type for the IndexRepository type
+type MockIndexRepository struct {
+	mock.Mock
+}
+
+type MockIndexRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIndexRepository) EXPECT() *MockIndexRepository_Expecter {
+	return &MockIndexRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockIndexRepository
+func (_mock *MockIndexRepository) Delete(roomID string) error {
+	ret := _mock.Called(roomID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(roomID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIndexRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIndexRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - roomID string
+func (_e *MockIndexRepository_Expecter) Delete(roomID interface{}) *MockIndexRepository_Delete_Call {
+	return &MockIndexRepository_Delete_Call{Call: _e.mock.On("Delete", roomID)}
+}
+
+func (_c *MockIndexRepository_Delete_Call) Run(run func(roomID string)) *MockIndexRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIndexRepository_Delete_Call) Return(err error) *MockIndexRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIndexRepository_Delete_Call) RunAndReturn(run func(roomID string) error) *MockIndexRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Index provides a mock function for the type MockIndexRepository
+func (_mock *MockIndexRepository) Index(roomID string, data *model.Entry) error {
+	ret := _mock.Called(roomID, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Index")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, *model.Entry) error); ok {
+		r0 = returnFunc(roomID, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIndexRepository_Index_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Index'
+type MockIndexRepository_Index_Call struct {
+	*mock.Call
+}
+
+// Index is a helper method to define mock.On call
+//   - roomID string
+//   - data *model.Entry
+func (_e *MockIndexRepository_Expecter) Index(roomID interface{}, data interface{}) *MockIndexRepository_Index_Call {
+	return &MockIndexRepository_Index_Call{Call: _e.mock.On("Index", roomID, data)}
+}
+
+func (_c *MockIndexRepository_Index_Call) Run(run func(roomID string, data *model.Entry)) *MockIndexRepository_Index_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 *model.Entry
+		if args[1] != nil {
+			arg1 = args[1].(*model.Entry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIndexRepository_Index_Call) Return(err error) *MockIndexRepository_Index_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIndexRepository_Index_Call) RunAndReturn(run func(roomID string, data *model.Entry) error) *MockIndexRepository_Index_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IndexBatch provides a mock function for the type MockIndexRepository
+func (_mock *MockIndexRepository) IndexBatch(batch *bleve.Batch) error {
+	ret := _mock.Called(batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IndexBatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*bleve.Batch) error); ok {
+		r0 = returnFunc(batch)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIndexRepository_IndexBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexBatch'
+type MockIndexRepository_IndexBatch_Call struct {
+	*mock.Call
+}
+
+// IndexBatch is a helper method to define mock.On call
+//   - batch *bleve.Batch
+func (_e *MockIndexRepository_Expecter) IndexBatch(batch interface{}) *MockIndexRepository_IndexBatch_Call {
+	return &MockIndexRepository_IndexBatch_Call{Call: _e.mock.On("IndexBatch", batch)}
+}
+
+func (_c *MockIndexRepository_IndexBatch_Call) Run(run func(batch *bleve.Batch)) *MockIndexRepository_IndexBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *bleve.Batch
+		if args[0] != nil {
+			arg0 = args[0].(*bleve.Batch)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIndexRepository_IndexBatch_Call) Return(err error) *MockIndexRepository_IndexBatch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIndexRepository_IndexBatch_Call) RunAndReturn(run func(batch *bleve.Batch) error) *MockIndexRepository_IndexBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBatch provides a mock function for the type MockIndexRepository
+func (_mock *MockIndexRepository) NewBatch() *bleve.Batch {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewBatch")
+	}
+
+	var r0 *bleve.Batch
+	if returnFunc, ok := ret.Get(0).(func() *bleve.Batch); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bleve.Batch)
+		}
+	}
+	return r0
+}
+
+// MockIndexRepository_NewBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewBatch'
+type MockIndexRepository_NewBatch_Call struct {
+	*mock.Call
+}
+
+// NewBatch is a helper method to define mock.On call
+func (_e *MockIndexRepository_Expecter) NewBatch() *MockIndexRepository_NewBatch_Call {
+	return &MockIndexRepository_NewBatch_Call{Call: _e.mock.On("NewBatch")}
+}
+
+func (_c *MockIndexRepository_NewBatch_Call) Run(run func()) *MockIndexRepository_NewBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockIndexRepository_NewBatch_Call) Return(batch *bleve.Batch) *MockIndexRepository_NewBatch_Call {
+	_c.Call.Return(batch)
+	return _c
+}
+
+func (_c *MockIndexRepository_NewBatch_Call) RunAndReturn(run func() *bleve.Batch) *MockIndexRepository_NewBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Swap provides a mock function for the type MockIndexRepository
+func (_mock *MockIndexRepository) Swap(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Swap")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIndexRepository_Swap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Swap'
+type MockIndexRepository_Swap_Call struct {
+	*mock.Call
+}
+
+// Swap is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIndexRepository_Expecter) Swap(ctx interface{}) *MockIndexRepository_Swap_Call {
+	return &MockIndexRepository_Swap_Call{Call: _e.mock.On("Swap", ctx)}
+}
+
+func (_c *MockIndexRepository_Swap_Call) Run(run func(ctx context.Context)) *MockIndexRepository_Swap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIndexRepository_Swap_Call) Return(err error) *MockIndexRepository_Swap_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIndexRepository_Swap_Call) RunAndReturn(run func(ctx context.Context) error) *MockIndexRepository_Swap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMediaService creates a new instance of MockMediaService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMediaService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMediaService {
+	mock := &MockMediaService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMediaService is an autogenerated mock type for the MediaService type
+type MockMediaService struct {
+	mock.Mock
+}
+
+type MockMediaService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMediaService) EXPECT() *MockMediaService_Expecter {
+	return &MockMediaService_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function for the type MockMediaService
+func (_mock *MockMediaService) Add(ctx context.Context, serverName string, mediaID string, params url.Values, content []byte) {
+	_mock.Called(ctx, serverName, mediaID, params, content)
+	return
+}
+
+// MockMediaService_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockMediaService_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverName string
+//   - mediaID string
+//   - params url.Values
+//   - content []byte
+func (_e *MockMediaService_Expecter) Add(ctx interface{}, serverName interface{}, mediaID interface{}, params interface{}, content interface{}) *MockMediaService_Add_Call {
+	return &MockMediaService_Add_Call{Call: _e.mock.On("Add", ctx, serverName, mediaID, params, content)}
+}
+
+func (_c *MockMediaService_Add_Call) Run(run func(ctx context.Context, serverName string, mediaID string, params url.Values, content []byte)) *MockMediaService_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 url.Values
+		if args[3] != nil {
+			arg3 = args[3].(url.Values)
+		}
+		var arg4 []byte
+		if args[4] != nil {
+			arg4 = args[4].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMediaService_Add_Call) Return() *MockMediaService_Add_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMediaService_Add_Call) RunAndReturn(run func(ctx context.Context, serverName string, mediaID string, params url.Values, content []byte)) *MockMediaService_Add_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockMediaService
+func (_mock *MockMediaService) Delete(ctx context.Context, serverName string, mediaID string) {
+	_mock.Called(ctx, serverName, mediaID)
+	return
+}
+
+// MockMediaService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockMediaService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverName string
+//   - mediaID string
+func (_e *MockMediaService_Expecter) Delete(ctx interface{}, serverName interface{}, mediaID interface{}) *MockMediaService_Delete_Call {
+	return &MockMediaService_Delete_Call{Call: _e.mock.On("Delete", ctx, serverName, mediaID)}
+}
+
+func (_c *MockMediaService_Delete_Call) Run(run func(ctx context.Context, serverName string, mediaID string)) *MockMediaService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMediaService_Delete_Call) Return() *MockMediaService_Delete_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMediaService_Delete_Call) RunAndReturn(run func(ctx context.Context, serverName string, mediaID string)) *MockMediaService_Delete_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Exists provides a mock function for the type MockMediaService
+func (_mock *MockMediaService) Exists(serverName string, mediaID string, params url.Values) bool {
+	ret := _mock.Called(serverName, mediaID, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string, string, url.Values) bool); ok {
+		r0 = returnFunc(serverName, mediaID, params)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockMediaService_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type MockMediaService_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - serverName string
+//   - mediaID string
+//   - params url.Values
+func (_e *MockMediaService_Expecter) Exists(serverName interface{}, mediaID interface{}, params interface{}) *MockMediaService_Exists_Call {
+	return &MockMediaService_Exists_Call{Call: _e.mock.On("Exists", serverName, mediaID, params)}
+}
+
+func (_c *MockMediaService_Exists_Call) Run(run func(serverName string, mediaID string, params url.Values)) *MockMediaService_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 url.Values
+		if args[2] != nil {
+			arg2 = args[2].(url.Values)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMediaService_Exists_Call) Return(b bool) *MockMediaService_Exists_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockMediaService_Exists_Call) RunAndReturn(run func(serverName string, mediaID string, params url.Values) bool) *MockMediaService_Exists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockMediaService
+func (_mock *MockMediaService) Get(ctx context.Context, serverName string, mediaID string, params url.Values) (io.Reader, string) {
+	ret := _mock.Called(ctx, serverName, mediaID, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 io.Reader
+	var r1 string
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, url.Values) (io.Reader, string)); ok {
+		return returnFunc(ctx, serverName, mediaID, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, url.Values) io.Reader); ok {
+		r0 = returnFunc(ctx, serverName, mediaID, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.Reader)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, url.Values) string); ok {
+		r1 = returnFunc(ctx, serverName, mediaID, params)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	return r0, r1
+}
+
+// MockMediaService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockMediaService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverName string
+//   - mediaID string
+//   - params url.Values
+func (_e *MockMediaService_Expecter) Get(ctx interface{}, serverName interface{}, mediaID interface{}, params interface{}) *MockMediaService_Get_Call {
+	return &MockMediaService_Get_Call{Call: _e.mock.On("Get", ctx, serverName, mediaID, params)}
+}
+
+func (_c *MockMediaService_Get_Call) Run(run func(ctx context.Context, serverName string, mediaID string, params url.Values)) *MockMediaService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 url.Values
+		if args[3] != nil {
+			arg3 = args[3].(url.Values)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMediaService_Get_Call) Return(content io.Reader, contentType string) *MockMediaService_Get_Call {
+	_c.Call.Return(content, contentType)
+	return _c
+}
+
+func (_c *MockMediaService_Get_Call) RunAndReturn(run func(ctx context.Context, serverName string, mediaID string, params url.Values) (io.Reader, string)) *MockMediaService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURL provides a mock function for the type MockMediaService
+func (_mock *MockMediaService) GetURL(serverName string, mediaID string) string {
+	ret := _mock.Called(serverName, mediaID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURL")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = returnFunc(serverName, mediaID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockMediaService_GetURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURL'
+type MockMediaService_GetURL_Call struct {
+	*mock.Call
+}
+
+// GetURL is a helper method to define mock.On call
+//   - serverName string
+//   - mediaID string
+func (_e *MockMediaService_Expecter) GetURL(serverName interface{}, mediaID interface{}) *MockMediaService_GetURL_Call {
+	return &MockMediaService_GetURL_Call{Call: _e.mock.On("GetURL", serverName, mediaID)}
+}
+
+func (_c *MockMediaService_GetURL_Call) Run(run func(serverName string, mediaID string)) *MockMediaService_GetURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMediaService_GetURL_Call) Return(s string) *MockMediaService_GetURL_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockMediaService_GetURL_Call) RunAndReturn(run func(serverName string, mediaID string) string) *MockMediaService_GetURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEmailService creates a new instance of MockEmailService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEmailService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEmailService {
+	mock := &MockEmailService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEmailService is an autogenerated mock type for the EmailService type
+type MockEmailService struct {
+	mock.Mock
+}
+
+type MockEmailService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEmailService) EXPECT() *MockEmailService_Expecter {
+	return &MockEmailService_Expecter{mock: &_m.Mock}
+}
+
+// SendAppealDecision provides a mock function for the type MockEmailService
+func (_mock *MockEmailService) SendAppealDecision(ctx context.Context, appeal *model.Appeal) error {
+	ret := _mock.Called(ctx, appeal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendAppealDecision")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Appeal) error); ok {
+		r0 = returnFunc(ctx, appeal)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEmailService_SendAppealDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendAppealDecision'
+type MockEmailService_SendAppealDecision_Call struct {
+	*mock.Call
+}
+
+// SendAppealDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - appeal *model.Appeal
+func (_e *MockEmailService_Expecter) SendAppealDecision(ctx interface{}, appeal interface{}) *MockEmailService_SendAppealDecision_Call {
+	return &MockEmailService_SendAppealDecision_Call{Call: _e.mock.On("SendAppealDecision", ctx, appeal)}
+}
+
+func (_c *MockEmailService_SendAppealDecision_Call) Run(run func(ctx context.Context, appeal *model.Appeal)) *MockEmailService_SendAppealDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Appeal
+		if args[1] != nil {
+			arg1 = args[1].(*model.Appeal)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmailService_SendAppealDecision_Call) Return(err error) *MockEmailService_SendAppealDecision_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEmailService_SendAppealDecision_Call) RunAndReturn(run func(ctx context.Context, appeal *model.Appeal) error) *MockEmailService_SendAppealDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendAppealVerification provides a mock function for the type MockEmailService
+func (_mock *MockEmailService) SendAppealVerification(ctx context.Context, appeal *model.Appeal, link string) error {
+	ret := _mock.Called(ctx, appeal, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendAppealVerification")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Appeal, string) error); ok {
+		r0 = returnFunc(ctx, appeal, link)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEmailService_SendAppealVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendAppealVerification'
+type MockEmailService_SendAppealVerification_Call struct {
+	*mock.Call
+}
+
+// SendAppealVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - appeal *model.Appeal
+//   - link string
+func (_e *MockEmailService_Expecter) SendAppealVerification(ctx interface{}, appeal interface{}, link interface{}) *MockEmailService_SendAppealVerification_Call {
+	return &MockEmailService_SendAppealVerification_Call{Call: _e.mock.On("SendAppealVerification", ctx, appeal, link)}
+}
+
+func (_c *MockEmailService_SendAppealVerification_Call) Run(run func(ctx context.Context, appeal *model.Appeal, link string)) *MockEmailService_SendAppealVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Appeal
+		if args[1] != nil {
+			arg1 = args[1].(*model.Appeal)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmailService_SendAppealVerification_Call) Return(err error) *MockEmailService_SendAppealVerification_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEmailService_SendAppealVerification_Call) RunAndReturn(run func(ctx context.Context, appeal *model.Appeal, link string) error) *MockEmailService_SendAppealVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendModAppeal provides a mock function for the type MockEmailService
+func (_mock *MockEmailService) SendModAppeal(text string, email string) error {
+	ret := _mock.Called(text, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendModAppeal")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(text, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEmailService_SendModAppeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendModAppeal'
+type MockEmailService_SendModAppeal_Call struct {
+	*mock.Call
+}
+
+// SendModAppeal is a helper method to define mock.On call
+//   - text string
+//   - email string
+func (_e *MockEmailService_Expecter) SendModAppeal(text interface{}, email interface{}) *MockEmailService_SendModAppeal_Call {
+	return &MockEmailService_SendModAppeal_Call{Call: _e.mock.On("SendModAppeal", text, email)}
+}
+
+func (_c *MockEmailService_SendModAppeal_Call) Run(run func(text string, email string)) *MockEmailService_SendModAppeal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmailService_SendModAppeal_Call) Return(err error) *MockEmailService_SendModAppeal_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEmailService_SendModAppeal_Call) RunAndReturn(run func(text string, email string) error) *MockEmailService_SendModAppeal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendModReport provides a mock function for the type MockEmailService
+func (_mock *MockEmailService) SendModReport(text string, email string) error {
+	ret := _mock.Called(text, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendModReport")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(text, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEmailService_SendModReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendModReport'
+type MockEmailService_SendModReport_Call struct {
+	*mock.Call
+}
+
+// SendModReport is a helper method to define mock.On call
+//   - text string
+//   - email string
+func (_e *MockEmailService_Expecter) SendModReport(text interface{}, email interface{}) *MockEmailService_SendModReport_Call {
+	return &MockEmailService_SendModReport_Call{Call: _e.mock.On("SendModReport", text, email)}
+}
+
+func (_c *MockEmailService_SendModReport_Call) Run(run func(text string, email string)) *MockEmailService_SendModReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmailService_SendModReport_Call) Return(err error) *MockEmailService_SendModReport_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEmailService_SendModReport_Call) RunAndReturn(run func(text string, email string) error) *MockEmailService_SendModReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendReport provides a mock function for the type MockEmailService
+func (_mock *MockEmailService) SendReport(ctx context.Context, room *model.MatrixRoom, server *model.MatrixServer, reason string, emails []string) error {
+	ret := _mock.Called(ctx, room, server, reason, emails)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendReport")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.MatrixRoom, *model.MatrixServer, string, []string) error); ok {
+		r0 = returnFunc(ctx, room, server, reason, emails)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEmailService_SendReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendReport'
+type MockEmailService_SendReport_Call struct {
+	*mock.Call
+}
+
+// SendReport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - room *model.MatrixRoom
+//   - server *model.MatrixServer
+//   - reason string
+//   - emails []string
+func (_e *MockEmailService_Expecter) SendReport(ctx interface{}, room interface{}, server interface{}, reason interface{}, emails interface{}) *MockEmailService_SendReport_Call {
+	return &MockEmailService_SendReport_Call{Call: _e.mock.On("SendReport", ctx, room, server, reason, emails)}
+}
+
+func (_c *MockEmailService_SendReport_Call) Run(run func(ctx context.Context, room *model.MatrixRoom, server *model.MatrixServer, reason string, emails []string)) *MockEmailService_SendReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.MatrixRoom
+		if args[1] != nil {
+			arg1 = args[1].(*model.MatrixRoom)
+		}
+		var arg2 *model.MatrixServer
+		if args[2] != nil {
+			arg2 = args[2].(*model.MatrixServer)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []string
+		if args[4] != nil {
+			arg4 = args[4].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmailService_SendReport_Call) Return(err error) *MockEmailService_SendReport_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEmailService_SendReport_Call) RunAndReturn(run func(ctx context.Context, room *model.MatrixRoom, server *model.MatrixServer, reason string, emails []string) error) *MockEmailService_SendReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockmatrixService creates a new instance of mockmatrixService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockmatrixService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockmatrixService {
+	mock := &mockmatrixService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockmatrixService is an autogenerated mock type for the matrixService type
+type mockmatrixService struct {
+	mock.Mock
+}
+
+type mockmatrixService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockmatrixService) EXPECT() *mockmatrixService_Expecter {
+	return &mockmatrixService_Expecter{mock: &_m.Mock}
+}
+
+// GetClientRoomSummary provides a mock function for the type mockmatrixService
+func (_mock *mockmatrixService) GetClientRoomSummary(ctx context.Context, aliasOrID string, via string, onlyMSC3266 bool) (int, *model.RoomDirectoryRoom) {
+	ret := _mock.Called(ctx, aliasOrID, via, onlyMSC3266)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClientRoomSummary")
+	}
+
+	var r0 int
+	var r1 *model.RoomDirectoryRoom
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool) (int, *model.RoomDirectoryRoom)); ok {
+		return returnFunc(ctx, aliasOrID, via, onlyMSC3266)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool) int); ok {
+		r0 = returnFunc(ctx, aliasOrID, via, onlyMSC3266)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, bool) *model.RoomDirectoryRoom); ok {
+		r1 = returnFunc(ctx, aliasOrID, via, onlyMSC3266)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.RoomDirectoryRoom)
+		}
+	}
+	return r0, r1
+}
+
+// mockmatrixService_GetClientRoomSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClientRoomSummary'
+type mockmatrixService_GetClientRoomSummary_Call struct {
+	*mock.Call
+}
+
+// GetClientRoomSummary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - aliasOrID string
+//   - via string
+//   - onlyMSC3266 bool
+func (_e *mockmatrixService_Expecter) GetClientRoomSummary(ctx interface{}, aliasOrID interface{}, via interface{}, onlyMSC3266 interface{}) *mockmatrixService_GetClientRoomSummary_Call {
+	return &mockmatrixService_GetClientRoomSummary_Call{Call: _e.mock.On("GetClientRoomSummary", ctx, aliasOrID, via, onlyMSC3266)}
+}
+
+func (_c *mockmatrixService_GetClientRoomSummary_Call) Run(run func(ctx context.Context, aliasOrID string, via string, onlyMSC3266 bool)) *mockmatrixService_GetClientRoomSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *mockmatrixService_GetClientRoomSummary_Call) Return(statusCode int, directoryRoom *model.RoomDirectoryRoom) *mockmatrixService_GetClientRoomSummary_Call {
+	_c.Call.Return(statusCode, directoryRoom)
+	return _c
+}
+
+func (_c *mockmatrixService_GetClientRoomSummary_Call) RunAndReturn(run func(ctx context.Context, aliasOrID string, via string, onlyMSC3266 bool) (int, *model.RoomDirectoryRoom)) *mockmatrixService_GetClientRoomSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPlausibleService creates a new instance of MockPlausibleService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPlausibleService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPlausibleService {
+	mock := &MockPlausibleService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPlausibleService is an autogenerated mock type for the PlausibleService type
+type MockPlausibleService struct {
+	mock.Mock
+}
+
+type MockPlausibleService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPlausibleService) EXPECT() *MockPlausibleService_Expecter {
+	return &MockPlausibleService_Expecter{mock: &_m.Mock}
+}
+
+// Track provides a mock function for the type MockPlausibleService
+func (_mock *MockPlausibleService) Track(ctx context.Context, evt *model.AnalyticsEvent) {
+	_mock.Called(ctx, evt)
+	return
+}
+
+// MockPlausibleService_Track_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Track'
+type MockPlausibleService_Track_Call struct {
+	*mock.Call
+}
+
+// Track is a helper method to define mock.On call
+//   - ctx context.Context
+//   - evt *model.AnalyticsEvent
+func (_e *MockPlausibleService_Expecter) Track(ctx interface{}, evt interface{}) *MockPlausibleService_Track_Call {
+	return &MockPlausibleService_Track_Call{Call: _e.mock.On("Track", ctx, evt)}
+}
+
+func (_c *MockPlausibleService_Track_Call) Run(run func(ctx context.Context, evt *model.AnalyticsEvent)) *MockPlausibleService_Track_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.AnalyticsEvent
+		if args[1] != nil {
+			arg1 = args[1].(*model.AnalyticsEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPlausibleService_Track_Call) Return() *MockPlausibleService_Track_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockPlausibleService_Track_Call) RunAndReturn(run func(ctx context.Context, evt *model.AnalyticsEvent)) *MockPlausibleService_Track_Call {
+	_c.Run(run)
+	return _c
+}
+
+// newMocksearchDataRepository creates a new instance of mocksearchDataRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMocksearchDataRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mocksearchDataRepository {
+	mock := &mocksearchDataRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mocksearchDataRepository is an autogenerated mock type for the searchDataRepository type
+type mocksearchDataRepository struct {
+	mock.Mock
+}
+
+type mocksearchDataRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mocksearchDataRepository) EXPECT() *mocksearchDataRepository_Expecter {
+	return &mocksearchDataRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetBiggestRooms provides a mock function for the type mocksearchDataRepository
+func (_mock *mocksearchDataRepository) GetBiggestRooms(ctx context.Context, limit int, offset int) []*model.MatrixRoom {
+	ret := _mock.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBiggestRooms")
+	}
+
+	var r0 []*model.MatrixRoom
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []*model.MatrixRoom); ok {
+		r0 = returnFunc(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.MatrixRoom)
+		}
+	}
+	return r0
+}
+
+// mocksearchDataRepository_GetBiggestRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBiggestRooms'
+type mocksearchDataRepository_GetBiggestRooms_Call struct {
+	*mock.Call
+}
+
+// GetBiggestRooms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *mocksearchDataRepository_Expecter) GetBiggestRooms(ctx interface{}, limit interface{}, offset interface{}) *mocksearchDataRepository_GetBiggestRooms_Call {
+	return &mocksearchDataRepository_GetBiggestRooms_Call{Call: _e.mock.On("GetBiggestRooms", ctx, limit, offset)}
+}
+
+func (_c *mocksearchDataRepository_GetBiggestRooms_Call) Run(run func(ctx context.Context, limit int, offset int)) *mocksearchDataRepository_GetBiggestRooms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mocksearchDataRepository_GetBiggestRooms_Call) Return(matrixRooms []*model.MatrixRoom) *mocksearchDataRepository_GetBiggestRooms_Call {
+	_c.Call.Return(matrixRooms)
+	return _c
+}
+
+func (_c *mocksearchDataRepository_GetBiggestRooms_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) []*model.MatrixRoom) *mocksearchDataRepository_GetBiggestRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrendingRooms provides a mock function for the type mocksearchDataRepository
+func (_mock *mocksearchDataRepository) GetTrendingRooms(ctx context.Context, window int, limit int, offset int) []*model.TrendingRoom {
+	ret := _mock.Called(ctx, window, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrendingRooms")
+	}
+
+	var r0 []*model.TrendingRoom
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) []*model.TrendingRoom); ok {
+		r0 = returnFunc(ctx, window, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TrendingRoom)
+		}
+	}
+	return r0
+}
+
+// mocksearchDataRepository_GetTrendingRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrendingRooms'
+type mocksearchDataRepository_GetTrendingRooms_Call struct {
+	*mock.Call
+}
+
+// GetTrendingRooms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - window int
+//   - limit int
+//   - offset int
+func (_e *mocksearchDataRepository_Expecter) GetTrendingRooms(ctx interface{}, window interface{}, limit interface{}, offset interface{}) *mocksearchDataRepository_GetTrendingRooms_Call {
+	return &mocksearchDataRepository_GetTrendingRooms_Call{Call: _e.mock.On("GetTrendingRooms", ctx, window, limit, offset)}
+}
+
+func (_c *mocksearchDataRepository_GetTrendingRooms_Call) Run(run func(ctx context.Context, window int, limit int, offset int)) *mocksearchDataRepository_GetTrendingRooms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *mocksearchDataRepository_GetTrendingRooms_Call) Return(trendingRooms []*model.TrendingRoom) *mocksearchDataRepository_GetTrendingRooms_Call {
+	_c.Call.Return(trendingRooms)
+	return _c
+}
+
+func (_c *mocksearchDataRepository_GetTrendingRooms_Call) RunAndReturn(run func(ctx context.Context, window int, limit int, offset int) []*model.TrendingRoom) *mocksearchDataRepository_GetTrendingRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSearchRepository creates a new instance of MockSearchRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSearchRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSearchRepository {
+	mock := &MockSearchRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSearchRepository is an autogenerated mock type for the SearchRepository type
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+type MockSearchRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSearchRepository) EXPECT() *MockSearchRepository_Expecter {
+	return &MockSearchRepository_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function for the type MockSearchRepository
+func (_mock *MockSearchRepository) Search(ctx context.Context, searchQuery query.Query, limit int, offset int, sortBy []string) ([]*model.Entry, int, error) {
+	ret := _mock.Called(ctx, searchQuery, limit, offset, sortBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*model.Entry
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, query.Query, int, int, []string) ([]*model.Entry, int, error)); ok {
+		return returnFunc(ctx, searchQuery, limit, offset, sortBy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, query.Query, int, int, []string) []*model.Entry); ok {
+		r0 = returnFunc(ctx, searchQuery, limit, offset, sortBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Entry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, query.Query, int, int, []string) int); ok {
+		r1 = returnFunc(ctx, searchQuery, limit, offset, sortBy)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, query.Query, int, int, []string) error); ok {
+		r2 = returnFunc(ctx, searchQuery, limit, offset, sortBy)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockSearchRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockSearchRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - searchQuery query.Query
+//   - limit int
+//   - offset int
+//   - sortBy []string
+func (_e *MockSearchRepository_Expecter) Search(ctx interface{}, searchQuery interface{}, limit interface{}, offset interface{}, sortBy interface{}) *MockSearchRepository_Search_Call {
+	return &MockSearchRepository_Search_Call{Call: _e.mock.On("Search", ctx, searchQuery, limit, offset, sortBy)}
+}
+
+func (_c *MockSearchRepository_Search_Call) Run(run func(ctx context.Context, searchQuery query.Query, limit int, offset int, sortBy []string)) *MockSearchRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 query.Query
+		if args[1] != nil {
+			arg1 = args[1].(query.Query)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 []string
+		if args[4] != nil {
+			arg4 = args[4].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSearchRepository_Search_Call) Return(entrys []*model.Entry, n int, err error) *MockSearchRepository_Search_Call {
+	_c.Call.Return(entrys, n, err)
+	return _c
+}
+
+func (_c *MockSearchRepository_Search_Call) RunAndReturn(run func(ctx context.Context, searchQuery query.Query, limit int, offset int, sortBy []string) ([]*model.Entry, int, error)) *MockSearchRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStatsService creates a new instance of MockStatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatsService {
+	mock := &MockStatsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStatsService is an autogenerated mock type for the StatsService type
+type MockStatsService struct {
+	mock.Mock
+}
+
+type MockStatsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatsService) EXPECT() *MockStatsService_Expecter {
+	return &MockStatsService_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockStatsService
+func (_mock *MockStatsService) Get() *model.IndexStats {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.IndexStats
+	if returnFunc, ok := ret.Get(0).(func() *model.IndexStats); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IndexStats)
+		}
+	}
+	return r0
+}
+
+// MockStatsService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockStatsService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+func (_e *MockStatsService_Expecter) Get() *MockStatsService_Get_Call {
+	return &MockStatsService_Get_Call{Call: _e.mock.On("Get")}
+}
+
+func (_c *MockStatsService_Get_Call) Run(run func()) *MockStatsService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStatsService_Get_Call) Return(indexStats *model.IndexStats) *MockStatsService_Get_Call {
+	_c.Call.Return(indexStats)
+	return _c
+}
+
+func (_c *MockStatsService_Get_Call) RunAndReturn(run func() *model.IndexStats) *MockStatsService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockbleveQuery creates a new instance of mockbleveQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockbleveQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockbleveQuery {
+	mock := &mockbleveQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockbleveQuery is an autogenerated mock type for the bleveQuery type
+type mockbleveQuery struct {
+	mock.Mock
+}
+
+type mockbleveQuery_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockbleveQuery) EXPECT() *mockbleveQuery_Expecter {
+	return &mockbleveQuery_Expecter{mock: &_m.Mock}
+}
+
+// Searcher provides a mock function for the type mockbleveQuery
+func (_mock *mockbleveQuery) Searcher(ctx context.Context, i index.IndexReader, m mapping.IndexMapping, options search.SearcherOptions) (search.Searcher, error) {
+	ret := _mock.Called(ctx, i, m, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Searcher")
+	}
+
+	var r0 search.Searcher
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, index.IndexReader, mapping.IndexMapping, search.SearcherOptions) (search.Searcher, error)); ok {
+		return returnFunc(ctx, i, m, options)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, index.IndexReader, mapping.IndexMapping, search.SearcherOptions) search.Searcher); ok {
+		r0 = returnFunc(ctx, i, m, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(search.Searcher)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, index.IndexReader, mapping.IndexMapping, search.SearcherOptions) error); ok {
+		r1 = returnFunc(ctx, i, m, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockbleveQuery_Searcher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Searcher'
+type mockbleveQuery_Searcher_Call struct {
+	*mock.Call
+}
+
+// Searcher is a helper method to define mock.On call
+//   - ctx context.Context
+//   - i index.IndexReader
+//   - m mapping.IndexMapping
+//   - options search.SearcherOptions
+func (_e *mockbleveQuery_Expecter) Searcher(ctx interface{}, i interface{}, m interface{}, options interface{}) *mockbleveQuery_Searcher_Call {
+	return &mockbleveQuery_Searcher_Call{Call: _e.mock.On("Searcher", ctx, i, m, options)}
+}
+
+func (_c *mockbleveQuery_Searcher_Call) Run(run func(ctx context.Context, i index.IndexReader, m mapping.IndexMapping, options search.SearcherOptions)) *mockbleveQuery_Searcher_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 index.IndexReader
+		if args[1] != nil {
+			arg1 = args[1].(index.IndexReader)
+		}
+		var arg2 mapping.IndexMapping
+		if args[2] != nil {
+			arg2 = args[2].(mapping.IndexMapping)
+		}
+		var arg3 search.SearcherOptions
+		if args[3] != nil {
+			arg3 = args[3].(search.SearcherOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *mockbleveQuery_Searcher_Call) Return(searcher search.Searcher, err error) *mockbleveQuery_Searcher_Call {
+	_c.Call.Return(searcher, err)
+	return _c
+}
+
+func (_c *mockbleveQuery_Searcher_Call) RunAndReturn(run func(ctx context.Context, i index.IndexReader, m mapping.IndexMapping, options search.SearcherOptions) (search.Searcher, error)) *mockbleveQuery_Searcher_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetBoost provides a mock function for the type mockbleveQuery
+func (_mock *mockbleveQuery) SetBoost(f float64) {
+	_mock.Called(f)
+	return
+}
+
+// mockbleveQuery_SetBoost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBoost'
+type mockbleveQuery_SetBoost_Call struct {
+	*mock.Call
+}
+
+// SetBoost is a helper method to define mock.On call
+//   - f float64
+func (_e *mockbleveQuery_Expecter) SetBoost(f interface{}) *mockbleveQuery_SetBoost_Call {
+	return &mockbleveQuery_SetBoost_Call{Call: _e.mock.On("SetBoost", f)}
+}
+
+func (_c *mockbleveQuery_SetBoost_Call) Run(run func(f float64)) *mockbleveQuery_SetBoost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 float64
+		if args[0] != nil {
+			arg0 = args[0].(float64)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockbleveQuery_SetBoost_Call) Return() *mockbleveQuery_SetBoost_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockbleveQuery_SetBoost_Call) RunAndReturn(run func(f float64)) *mockbleveQuery_SetBoost_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetField provides a mock function for the type mockbleveQuery
+func (_mock *mockbleveQuery) SetField(s string) {
+	_mock.Called(s)
+	return
+}
+
+// mockbleveQuery_SetField_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetField'
+type mockbleveQuery_SetField_Call struct {
+	*mock.Call
+}
+
+// SetField is a helper method to define mock.On call
+//   - s string
+func (_e *mockbleveQuery_Expecter) SetField(s interface{}) *mockbleveQuery_SetField_Call {
+	return &mockbleveQuery_SetField_Call{Call: _e.mock.On("SetField", s)}
+}
+
+func (_c *mockbleveQuery_SetField_Call) Run(run func(s string)) *mockbleveQuery_SetField_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockbleveQuery_SetField_Call) Return() *mockbleveQuery_SetField_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockbleveQuery_SetField_Call) RunAndReturn(run func(s string)) *mockbleveQuery_SetField_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockStatsRepository creates a new instance of MockStatsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatsRepository {
+	mock := &MockStatsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStatsRepository is an autogenerated mock type for the StatsRepository type
+type MockStatsRepository struct {
+	mock.Mock
+}
+
+type MockStatsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatsRepository) EXPECT() *MockStatsRepository_Expecter {
+	return &MockStatsRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddAuditEntry provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) AddAuditEntry(context1 context.Context, auditEntry *model.AuditEntry) error {
+	ret := _mock.Called(context1, auditEntry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAuditEntry")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.AuditEntry) error); ok {
+		r0 = returnFunc(context1, auditEntry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatsRepository_AddAuditEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditEntry'
+type MockStatsRepository_AddAuditEntry_Call struct {
+	*mock.Call
+}
+
+// AddAuditEntry is a helper method to define mock.On call
+//   - context1 context.Context
+//   - auditEntry *model.AuditEntry
+func (_e *MockStatsRepository_Expecter) AddAuditEntry(context1 interface{}, auditEntry interface{}) *MockStatsRepository_AddAuditEntry_Call {
+	return &MockStatsRepository_AddAuditEntry_Call{Call: _e.mock.On("AddAuditEntry", context1, auditEntry)}
+}
+
+func (_c *MockStatsRepository_AddAuditEntry_Call) Run(run func(context1 context.Context, auditEntry *model.AuditEntry)) *MockStatsRepository_AddAuditEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.AuditEntry
+		if args[1] != nil {
+			arg1 = args[1].(*model.AuditEntry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_AddAuditEntry_Call) Return(err error) *MockStatsRepository_AddAuditEntry_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStatsRepository_AddAuditEntry_Call) RunAndReturn(run func(context1 context.Context, auditEntry *model.AuditEntry) error) *MockStatsRepository_AddAuditEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddBanRule provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) AddBanRule(context1 context.Context, banRule *model.BanRule) error {
+	ret := _mock.Called(context1, banRule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBanRule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.BanRule) error); ok {
+		r0 = returnFunc(context1, banRule)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatsRepository_AddBanRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddBanRule'
+type MockStatsRepository_AddBanRule_Call struct {
+	*mock.Call
+}
+
+// AddBanRule is a helper method to define mock.On call
+//   - context1 context.Context
+//   - banRule *model.BanRule
+func (_e *MockStatsRepository_Expecter) AddBanRule(context1 interface{}, banRule interface{}) *MockStatsRepository_AddBanRule_Call {
+	return &MockStatsRepository_AddBanRule_Call{Call: _e.mock.On("AddBanRule", context1, banRule)}
+}
+
+func (_c *MockStatsRepository_AddBanRule_Call) Run(run func(context1 context.Context, banRule *model.BanRule)) *MockStatsRepository_AddBanRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.BanRule
+		if args[1] != nil {
+			arg1 = args[1].(*model.BanRule)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_AddBanRule_Call) Return(err error) *MockStatsRepository_AddBanRule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStatsRepository_AddBanRule_Call) RunAndReturn(run func(context1 context.Context, banRule *model.BanRule) error) *MockStatsRepository_AddBanRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddRoomBatch provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) AddRoomBatch(context1 context.Context, matrixRoom *model.MatrixRoom) {
+	_mock.Called(context1, matrixRoom)
+	return
+}
+
+// MockStatsRepository_AddRoomBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomBatch'
+type MockStatsRepository_AddRoomBatch_Call struct {
+	*mock.Call
+}
+
+// AddRoomBatch is a helper method to define mock.On call
+//   - context1 context.Context
+//   - matrixRoom *model.MatrixRoom
+func (_e *MockStatsRepository_Expecter) AddRoomBatch(context1 interface{}, matrixRoom interface{}) *MockStatsRepository_AddRoomBatch_Call {
+	return &MockStatsRepository_AddRoomBatch_Call{Call: _e.mock.On("AddRoomBatch", context1, matrixRoom)}
+}
+
+func (_c *MockStatsRepository_AddRoomBatch_Call) Run(run func(context1 context.Context, matrixRoom *model.MatrixRoom)) *MockStatsRepository_AddRoomBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.MatrixRoom
+		if args[1] != nil {
+			arg1 = args[1].(*model.MatrixRoom)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_AddRoomBatch_Call) Return() *MockStatsRepository_AddRoomBatch_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRepository_AddRoomBatch_Call) RunAndReturn(run func(context1 context.Context, matrixRoom *model.MatrixRoom)) *MockStatsRepository_AddRoomBatch_Call {
+	_c.Run(run)
+	return _c
+}
+
+// AddRoomMapping provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) AddRoomMapping(context1 context.Context, s string, s1 string) error {
+	ret := _mock.Called(context1, s, s1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRoomMapping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(context1, s, s1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatsRepository_AddRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomMapping'
+type MockStatsRepository_AddRoomMapping_Call struct {
+	*mock.Call
+}
+
+// AddRoomMapping is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - s1 string
+func (_e *MockStatsRepository_Expecter) AddRoomMapping(context1 interface{}, s interface{}, s1 interface{}) *MockStatsRepository_AddRoomMapping_Call {
+	return &MockStatsRepository_AddRoomMapping_Call{Call: _e.mock.On("AddRoomMapping", context1, s, s1)}
+}
+
+func (_c *MockStatsRepository_AddRoomMapping_Call) Run(run func(context1 context.Context, s string, s1 string)) *MockStatsRepository_AddRoomMapping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_AddRoomMapping_Call) Return(err error) *MockStatsRepository_AddRoomMapping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStatsRepository_AddRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string) error) *MockStatsRepository_AddRoomMapping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddServer provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) AddServer(context1 context.Context, matrixServer *model.MatrixServer) error {
+	ret := _mock.Called(context1, matrixServer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddServer")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.MatrixServer) error); ok {
+		r0 = returnFunc(context1, matrixServer)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatsRepository_AddServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddServer'
+type MockStatsRepository_AddServer_Call struct {
+	*mock.Call
+}
+
+// AddServer is a helper method to define mock.On call
+//   - context1 context.Context
+//   - matrixServer *model.MatrixServer
+func (_e *MockStatsRepository_Expecter) AddServer(context1 interface{}, matrixServer interface{}) *MockStatsRepository_AddServer_Call {
+	return &MockStatsRepository_AddServer_Call{Call: _e.mock.On("AddServer", context1, matrixServer)}
+}
+
+func (_c *MockStatsRepository_AddServer_Call) Run(run func(context1 context.Context, matrixServer *model.MatrixServer)) *MockStatsRepository_AddServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.MatrixServer
+		if args[1] != nil {
+			arg1 = args[1].(*model.MatrixServer)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_AddServer_Call) Return(err error) *MockStatsRepository_AddServer_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStatsRepository_AddServer_Call) RunAndReturn(run func(context1 context.Context, matrixServer *model.MatrixServer) error) *MockStatsRepository_AddServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BanRoom provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) BanRoom(context1 context.Context, roomBan *model.RoomBan) error {
+	ret := _mock.Called(context1, roomBan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BanRoom")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.RoomBan) error); ok {
+		r0 = returnFunc(context1, roomBan)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatsRepository_BanRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanRoom'
+type MockStatsRepository_BanRoom_Call struct {
+	*mock.Call
+}
+
+// BanRoom is a helper method to define mock.On call
+//   - context1 context.Context
+//   - roomBan *model.RoomBan
+func (_e *MockStatsRepository_Expecter) BanRoom(context1 interface{}, roomBan interface{}) *MockStatsRepository_BanRoom_Call {
+	return &MockStatsRepository_BanRoom_Call{Call: _e.mock.On("BanRoom", context1, roomBan)}
+}
+
+func (_c *MockStatsRepository_BanRoom_Call) Run(run func(context1 context.Context, roomBan *model.RoomBan)) *MockStatsRepository_BanRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.RoomBan
+		if args[1] != nil {
+			arg1 = args[1].(*model.RoomBan)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_BanRoom_Call) Return(err error) *MockStatsRepository_BanRoom_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStatsRepository_BanRoom_Call) RunAndReturn(run func(context1 context.Context, roomBan *model.RoomBan) error) *MockStatsRepository_BanRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BatchServers provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) BatchServers(context1 context.Context, strings []string) error {
+	ret := _mock.Called(context1, strings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchServers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = returnFunc(context1, strings)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatsRepository_BatchServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchServers'
+type MockStatsRepository_BatchServers_Call struct {
+	*mock.Call
+}
+
+// BatchServers is a helper method to define mock.On call
+//   - context1 context.Context
+//   - strings []string
+func (_e *MockStatsRepository_Expecter) BatchServers(context1 interface{}, strings interface{}) *MockStatsRepository_BatchServers_Call {
+	return &MockStatsRepository_BatchServers_Call{Call: _e.mock.On("BatchServers", context1, strings)}
+}
+
+func (_c *MockStatsRepository_BatchServers_Call) Run(run func(context1 context.Context, strings []string)) *MockStatsRepository_BatchServers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_BatchServers_Call) Return(err error) *MockStatsRepository_BatchServers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStatsRepository_BatchServers_Call) RunAndReturn(run func(context1 context.Context, strings []string) error) *MockStatsRepository_BatchServers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachRoom provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) EachRoom(context1 context.Context, fn func(string, *model.MatrixRoom) bool) {
+	_mock.Called(context1, fn)
+	return
+}
+
+// MockStatsRepository_EachRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachRoom'
+type MockStatsRepository_EachRoom_Call struct {
+	*mock.Call
+}
+
+// EachRoom is a helper method to define mock.On call
+//   - context1 context.Context
+//   - fn func(string, *model.MatrixRoom) bool
+func (_e *MockStatsRepository_Expecter) EachRoom(context1 interface{}, fn interface{}) *MockStatsRepository_EachRoom_Call {
+	return &MockStatsRepository_EachRoom_Call{Call: _e.mock.On("EachRoom", context1, fn)}
+}
+
+func (_c *MockStatsRepository_EachRoom_Call) Run(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *MockStatsRepository_EachRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(string, *model.MatrixRoom) bool
+		if args[1] != nil {
+			arg1 = args[1].(func(string, *model.MatrixRoom) bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_EachRoom_Call) Return() *MockStatsRepository_EachRoom_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRepository_EachRoom_Call) RunAndReturn(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *MockStatsRepository_EachRoom_Call {
+	_c.Run(run)
+	return _c
+}
+
+// EachRoomHistory provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) EachRoomHistory(context1 context.Context, fn func(string, *model.RoomHistory) bool) {
+	_mock.Called(context1, fn)
+	return
+}
+
+// MockStatsRepository_EachRoomHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachRoomHistory'
+type MockStatsRepository_EachRoomHistory_Call struct {
+	*mock.Call
+}
+
+// EachRoomHistory is a helper method to define mock.On call
+//   - context1 context.Context
+//   - fn func(string, *model.RoomHistory) bool
+func (_e *MockStatsRepository_Expecter) EachRoomHistory(context1 interface{}, fn interface{}) *MockStatsRepository_EachRoomHistory_Call {
+	return &MockStatsRepository_EachRoomHistory_Call{Call: _e.mock.On("EachRoomHistory", context1, fn)}
+}
+
+func (_c *MockStatsRepository_EachRoomHistory_Call) Run(run func(context1 context.Context, fn func(string, *model.RoomHistory) bool)) *MockStatsRepository_EachRoomHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(string, *model.RoomHistory) bool
+		if args[1] != nil {
+			arg1 = args[1].(func(string, *model.RoomHistory) bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_EachRoomHistory_Call) Return() *MockStatsRepository_EachRoomHistory_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRepository_EachRoomHistory_Call) RunAndReturn(run func(context1 context.Context, fn func(string, *model.RoomHistory) bool)) *MockStatsRepository_EachRoomHistory_Call {
+	_c.Run(run)
+	return _c
+}
+
+// FilterServers provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) FilterServers(context1 context.Context, fn func(server *model.MatrixServer) bool) map[string]*model.MatrixServer {
+	ret := _mock.Called(context1, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilterServers")
+	}
+
+	var r0 map[string]*model.MatrixServer
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(server *model.MatrixServer) bool) map[string]*model.MatrixServer); ok {
+		r0 = returnFunc(context1, fn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*model.MatrixServer)
+		}
+	}
+	return r0
+}
+
+// MockStatsRepository_FilterServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterServers'
+type MockStatsRepository_FilterServers_Call struct {
+	*mock.Call
+}
+
+// FilterServers is a helper method to define mock.On call
+//   - context1 context.Context
+//   - fn func(server *model.MatrixServer) bool
+func (_e *MockStatsRepository_Expecter) FilterServers(context1 interface{}, fn interface{}) *MockStatsRepository_FilterServers_Call {
+	return &MockStatsRepository_FilterServers_Call{Call: _e.mock.On("FilterServers", context1, fn)}
+}
+
+func (_c *MockStatsRepository_FilterServers_Call) Run(run func(context1 context.Context, fn func(server *model.MatrixServer) bool)) *MockStatsRepository_FilterServers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(server *model.MatrixServer) bool
+		if args[1] != nil {
+			arg1 = args[1].(func(server *model.MatrixServer) bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_FilterServers_Call) Return(stringToMatrixServer map[string]*model.MatrixServer) *MockStatsRepository_FilterServers_Call {
+	_c.Call.Return(stringToMatrixServer)
+	return _c
+}
+
+func (_c *MockStatsRepository_FilterServers_Call) RunAndReturn(run func(context1 context.Context, fn func(server *model.MatrixServer) bool) map[string]*model.MatrixServer) *MockStatsRepository_FilterServers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FlushRoomBatch provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) FlushRoomBatch(context1 context.Context) {
+	_mock.Called(context1)
+	return
+}
+
+// MockStatsRepository_FlushRoomBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushRoomBatch'
+type MockStatsRepository_FlushRoomBatch_Call struct {
+	*mock.Call
+}
+
+// FlushRoomBatch is a helper method to define mock.On call
+//   - context1 context.Context
+func (_e *MockStatsRepository_Expecter) FlushRoomBatch(context1 interface{}) *MockStatsRepository_FlushRoomBatch_Call {
+	return &MockStatsRepository_FlushRoomBatch_Call{Call: _e.mock.On("FlushRoomBatch", context1)}
+}
+
+func (_c *MockStatsRepository_FlushRoomBatch_Call) Run(run func(context1 context.Context)) *MockStatsRepository_FlushRoomBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_FlushRoomBatch_Call) Return() *MockStatsRepository_FlushRoomBatch_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRepository_FlushRoomBatch_Call) RunAndReturn(run func(context1 context.Context)) *MockStatsRepository_FlushRoomBatch_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetAppeal provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetAppeal(context1 context.Context, s string) (*model.Appeal, error) {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppeal")
+	}
+
+	var r0 *model.Appeal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Appeal, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Appeal); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Appeal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatsRepository_GetAppeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppeal'
+type MockStatsRepository_GetAppeal_Call struct {
+	*mock.Call
+}
+
+// GetAppeal is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockStatsRepository_Expecter) GetAppeal(context1 interface{}, s interface{}) *MockStatsRepository_GetAppeal_Call {
+	return &MockStatsRepository_GetAppeal_Call{Call: _e.mock.On("GetAppeal", context1, s)}
+}
+
+func (_c *MockStatsRepository_GetAppeal_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetAppeal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_GetAppeal_Call) Return(appeal *model.Appeal, err error) *MockStatsRepository_GetAppeal_Call {
+	_c.Call.Return(appeal, err)
+	return _c
+}
+
+func (_c *MockStatsRepository_GetAppeal_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.Appeal, error)) *MockStatsRepository_GetAppeal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAppeals provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetAppeals(context1 context.Context, strings ...string) ([]*model.Appeal, error) {
+	var tmpRet mock.Arguments
+	if len(strings) > 0 {
+		tmpRet = _mock.Called(context1, strings)
+	} else {
+		tmpRet = _mock.Called(context1)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppeals")
+	}
+
+	var r0 []*model.Appeal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) ([]*model.Appeal, error)); ok {
+		return returnFunc(context1, strings...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) []*model.Appeal); ok {
+		r0 = returnFunc(context1, strings...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Appeal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(context1, strings...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatsRepository_GetAppeals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppeals'
+type MockStatsRepository_GetAppeals_Call struct {
+	*mock.Call
+}
+
+// GetAppeals is a helper method to define mock.On call
+//   - context1 context.Context
+//   - strings ...string
+func (_e *MockStatsRepository_Expecter) GetAppeals(context1 interface{}, strings ...interface{}) *MockStatsRepository_GetAppeals_Call {
+	return &MockStatsRepository_GetAppeals_Call{Call: _e.mock.On("GetAppeals",
+		append([]interface{}{context1}, strings...)...)}
+}
+
+func (_c *MockStatsRepository_GetAppeals_Call) Run(run func(context1 context.Context, strings ...string)) *MockStatsRepository_GetAppeals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_GetAppeals_Call) Return(appeals []*model.Appeal, err error) *MockStatsRepository_GetAppeals_Call {
+	_c.Call.Return(appeals, err)
+	return _c
+}
+
+func (_c *MockStatsRepository_GetAppeals_Call) RunAndReturn(run func(context1 context.Context, strings ...string) ([]*model.Appeal, error)) *MockStatsRepository_GetAppeals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuditLog provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetAuditLog(context1 context.Context, auditFilter *model.AuditFilter) ([]*model.AuditEntry, error) {
+	ret := _mock.Called(context1, auditFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuditLog")
+	}
+
+	var r0 []*model.AuditEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.AuditFilter) ([]*model.AuditEntry, error)); ok {
+		return returnFunc(context1, auditFilter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.AuditFilter) []*model.AuditEntry); ok {
+		r0 = returnFunc(context1, auditFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AuditEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.AuditFilter) error); ok {
+		r1 = returnFunc(context1, auditFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatsRepository_GetAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLog'
+type MockStatsRepository_GetAuditLog_Call struct {
+	*mock.Call
+}
+
+// GetAuditLog is a helper method to define mock.On call
+//   - context1 context.Context
+//   - auditFilter *model.AuditFilter
+func (_e *MockStatsRepository_Expecter) GetAuditLog(context1 interface{}, auditFilter interface{}) *MockStatsRepository_GetAuditLog_Call {
+	return &MockStatsRepository_GetAuditLog_Call{Call: _e.mock.On("GetAuditLog", context1, auditFilter)}
+}
+
+func (_c *MockStatsRepository_GetAuditLog_Call) Run(run func(context1 context.Context, auditFilter *model.AuditFilter)) *MockStatsRepository_GetAuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.AuditFilter
+		if args[1] != nil {
+			arg1 = args[1].(*model.AuditFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_GetAuditLog_Call) Return(auditEntrys []*model.AuditEntry, err error) *MockStatsRepository_GetAuditLog_Call {
+	_c.Call.Return(auditEntrys, err)
+	return _c
+}
+
+func (_c *MockStatsRepository_GetAuditLog_Call) RunAndReturn(run func(context1 context.Context, auditFilter *model.AuditFilter) ([]*model.AuditEntry, error)) *MockStatsRepository_GetAuditLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBanRules provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetBanRules(context1 context.Context) ([]*model.BanRule, error) {
+	ret := _mock.Called(context1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBanRules")
+	}
+
+	var r0 []*model.BanRule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*model.BanRule, error)); ok {
+		return returnFunc(context1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*model.BanRule); ok {
+		r0 = returnFunc(context1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BanRule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(context1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatsRepository_GetBanRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBanRules'
+type MockStatsRepository_GetBanRules_Call struct {
+	*mock.Call
+}
+
+// GetBanRules is a helper method to define mock.On call
+//   - context1 context.Context
+func (_e *MockStatsRepository_Expecter) GetBanRules(context1 interface{}) *MockStatsRepository_GetBanRules_Call {
+	return &MockStatsRepository_GetBanRules_Call{Call: _e.mock.On("GetBanRules", context1)}
+}
+
+func (_c *MockStatsRepository_GetBanRules_Call) Run(run func(context1 context.Context)) *MockStatsRepository_GetBanRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_GetBanRules_Call) Return(banRules []*model.BanRule, err error) *MockStatsRepository_GetBanRules_Call {
+	_c.Call.Return(banRules, err)
+	return _c
+}
+
+func (_c *MockStatsRepository_GetBanRules_Call) RunAndReturn(run func(context1 context.Context) ([]*model.BanRule, error)) *MockStatsRepository_GetBanRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBannedRooms provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetBannedRooms(context1 context.Context, strings ...string) ([]string, error) {
+	var tmpRet mock.Arguments
+	if len(strings) > 0 {
+		tmpRet = _mock.Called(context1, strings)
+	} else {
+		tmpRet = _mock.Called(context1)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBannedRooms")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) ([]string, error)); ok {
+		return returnFunc(context1, strings...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) []string); ok {
+		r0 = returnFunc(context1, strings...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(context1, strings...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatsRepository_GetBannedRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBannedRooms'
+type MockStatsRepository_GetBannedRooms_Call struct {
+	*mock.Call
+}
+
+// GetBannedRooms is a helper method to define mock.On call
+//   - context1 context.Context
+//   - strings ...string
+func (_e *MockStatsRepository_Expecter) GetBannedRooms(context1 interface{}, strings ...interface{}) *MockStatsRepository_GetBannedRooms_Call {
+	return &MockStatsRepository_GetBannedRooms_Call{Call: _e.mock.On("GetBannedRooms",
+		append([]interface{}{context1}, strings...)...)}
+}
+
+func (_c *MockStatsRepository_GetBannedRooms_Call) Run(run func(context1 context.Context, strings ...string)) *MockStatsRepository_GetBannedRooms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_GetBannedRooms_Call) Return(strings1 []string, err error) *MockStatsRepository_GetBannedRooms_Call {
+	_c.Call.Return(strings1, err)
+	return _c
+}
+
+func (_c *MockStatsRepository_GetBannedRooms_Call) RunAndReturn(run func(context1 context.Context, strings ...string) ([]string, error)) *MockStatsRepository_GetBannedRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIndexStats provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetIndexStats(ctx context.Context) *model.IndexStats {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIndexStats")
+	}
+
+	var r0 *model.IndexStats
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *model.IndexStats); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IndexStats)
+		}
+	}
+	return r0
+}
+
+// MockStatsRepository_GetIndexStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIndexStats'
+type MockStatsRepository_GetIndexStats_Call struct {
+	*mock.Call
+}
+
+// GetIndexStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStatsRepository_Expecter) GetIndexStats(ctx interface{}) *MockStatsRepository_GetIndexStats_Call {
+	return &MockStatsRepository_GetIndexStats_Call{Call: _e.mock.On("GetIndexStats", ctx)}
+}
+
+func (_c *MockStatsRepository_GetIndexStats_Call) Run(run func(ctx context.Context)) *MockStatsRepository_GetIndexStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_GetIndexStats_Call) Return(indexStats *model.IndexStats) *MockStatsRepository_GetIndexStats_Call {
+	_c.Call.Return(indexStats)
+	return _c
+}
+
+func (_c *MockStatsRepository_GetIndexStats_Call) RunAndReturn(run func(ctx context.Context) *model.IndexStats) *MockStatsRepository_GetIndexStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIndexStatsTL provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetIndexStatsTL(ctx context.Context, prefix string) (map[time.Time]*model.IndexStats, error) {
+	ret := _mock.Called(ctx, prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIndexStatsTL")
+	}
+
+	var r0 map[time.Time]*model.IndexStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (map[time.Time]*model.IndexStats, error)); ok {
+		return returnFunc(ctx, prefix)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) map[time.Time]*model.IndexStats); ok {
+		r0 = returnFunc(ctx, prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[time.Time]*model.IndexStats)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatsRepository_GetIndexStatsTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIndexStatsTL'
+type MockStatsRepository_GetIndexStatsTL_Call struct {
+	*mock.Call
+}
+
+// GetIndexStatsTL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prefix string
+func (_e *MockStatsRepository_Expecter) GetIndexStatsTL(ctx interface{}, prefix interface{}) *MockStatsRepository_GetIndexStatsTL_Call {
+	return &MockStatsRepository_GetIndexStatsTL_Call{Call: _e.mock.On("GetIndexStatsTL", ctx, prefix)}
+}
+
+func (_c *MockStatsRepository_GetIndexStatsTL_Call) Run(run func(ctx context.Context, prefix string)) *MockStatsRepository_GetIndexStatsTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
 
-	return mock
+func (_c *MockStatsRepository_GetIndexStatsTL_Call) Return(timeToIndexStats map[time.Time]*model.IndexStats, err error) *MockStatsRepository_GetIndexStatsTL_Call {
+	_c.Call.Return(timeToIndexStats, err)
+	return _c
 }
 
-// mockbleveQuery is an autogenerated mock type for the bleveQuery type
-type mockbleveQuery struct {
-	mock.Mock
+func (_c *MockStatsRepository_GetIndexStatsTL_Call) RunAndReturn(run func(ctx context.Context, prefix string) (map[time.Time]*model.IndexStats, error)) *MockStatsRepository_GetIndexStatsTL_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
-type mockbleveQuery_Expecter struct {
-	mock *mock.Mock
+// GetQuarantinedRooms provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetQuarantinedRooms(context1 context.Context, strings ...string) ([]*model.RoomQuarantine, error) {
+	var tmpRet mock.Arguments
+	if len(strings) > 0 {
+		tmpRet = _mock.Called(context1, strings)
+	} else {
+		tmpRet = _mock.Called(context1)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuarantinedRooms")
+	}
+
+	var r0 []*model.RoomQuarantine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) ([]*model.RoomQuarantine, error)); ok {
+		return returnFunc(context1, strings...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) []*model.RoomQuarantine); ok {
+		r0 = returnFunc(context1, strings...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RoomQuarantine)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(context1, strings...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-func (_m *mockbleveQuery) EXPECT() *mockbleveQuery_Expecter {
-	return &mockbleveQuery_Expecter{mock: &_m.Mock}
+// MockStatsRepository_GetQuarantinedRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuarantinedRooms'
+type MockStatsRepository_GetQuarantinedRooms_Call struct {
+	*mock.Call
 }
 
-// Searcher provides a mock function for the type mockbleveQuery
-func (_mock *mockbleveQuery) Searcher(ctx context.Context, i index.IndexReader, m mapping.IndexMapping, options search.SearcherOptions) (search.Searcher, error) {
-	ret := _mock.Called(ctx, i, m, options)
+// GetQuarantinedRooms is a helper method to define mock.On call
+//   - context1 context.Context
+//   - strings ...string
+func (_e *MockStatsRepository_Expecter) GetQuarantinedRooms(context1 interface{}, strings ...interface{}) *MockStatsRepository_GetQuarantinedRooms_Call {
+	return &MockStatsRepository_GetQuarantinedRooms_Call{Call: _e.mock.On("GetQuarantinedRooms",
+		append([]interface{}{context1}, strings...)...)}
+}
+
+func (_c *MockStatsRepository_GetQuarantinedRooms_Call) Run(run func(context1 context.Context, strings ...string)) *MockStatsRepository_GetQuarantinedRooms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_GetQuarantinedRooms_Call) Return(roomQuarantines []*model.RoomQuarantine, err error) *MockStatsRepository_GetQuarantinedRooms_Call {
+	_c.Call.Return(roomQuarantines, err)
+	return _c
+}
+
+func (_c *MockStatsRepository_GetQuarantinedRooms_Call) RunAndReturn(run func(context1 context.Context, strings ...string) ([]*model.RoomQuarantine, error)) *MockStatsRepository_GetQuarantinedRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReportedRooms provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetReportedRooms(context1 context.Context, strings ...string) (map[string]*model.RoomReports, error) {
+	var tmpRet mock.Arguments
+	if len(strings) > 0 {
+		tmpRet = _mock.Called(context1, strings)
+	} else {
+		tmpRet = _mock.Called(context1)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for Searcher")
+		panic("no return value specified for GetReportedRooms")
 	}
 
-	var r0 search.Searcher
+	var r0 map[string]*model.RoomReports
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, index.IndexReader, mapping.IndexMapping, search.SearcherOptions) (search.Searcher, error)); ok {
-		return returnFunc(ctx, i, m, options)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) (map[string]*model.RoomReports, error)); ok {
+		return returnFunc(context1, strings...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, index.IndexReader, mapping.IndexMapping, search.SearcherOptions) search.Searcher); ok {
-		r0 = returnFunc(ctx, i, m, options)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) map[string]*model.RoomReports); ok {
+		r0 = returnFunc(context1, strings...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(search.Searcher)
+			r0 = ret.Get(0).(map[string]*model.RoomReports)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, index.IndexReader, mapping.IndexMapping, search.SearcherOptions) error); ok {
-		r1 = returnFunc(ctx, i, m, options)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(context1, strings...)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockbleveQuery_Searcher_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Searcher'
-type mockbleveQuery_Searcher_Call struct {
+// MockStatsRepository_GetReportedRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReportedRooms'
+type MockStatsRepository_GetReportedRooms_Call struct {
 	*mock.Call
 }
 
-// Searcher is a helper method to define mock.On call
-//   - ctx context.Context
-//   - i index.IndexReader
-//   - m mapping.IndexMapping
-//   - options search.SearcherOptions
-func (_e *mockbleveQuery_Expecter) Searcher(ctx interface{}, i interface{}, m interface{}, options interface{}) *mockbleveQuery_Searcher_Call {
-	return &mockbleveQuery_Searcher_Call{Call: _e.mock.On("Searcher", ctx, i, m, options)}
+// GetReportedRooms is a helper method to define mock.On call
+//   - context1 context.Context
+//   - strings ...string
+func (_e *MockStatsRepository_Expecter) GetReportedRooms(context1 interface{}, strings ...interface{}) *MockStatsRepository_GetReportedRooms_Call {
+	return &MockStatsRepository_GetReportedRooms_Call{Call: _e.mock.On("GetReportedRooms",
+		append([]interface{}{context1}, strings...)...)}
 }
 
-func (_c *mockbleveQuery_Searcher_Call) Run(run func(ctx context.Context, i index.IndexReader, m mapping.IndexMapping, options search.SearcherOptions)) *mockbleveQuery_Searcher_Call {
+func (_c *MockStatsRepository_GetReportedRooms_Call) Run(run func(context1 context.Context, strings ...string)) *MockStatsRepository_GetReportedRooms_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 index.IndexReader
-		if args[1] != nil {
-			arg1 = args[1].(index.IndexReader)
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
 		}
-		var arg2 mapping.IndexMapping
-		if args[2] != nil {
-			arg2 = args[2].(mapping.IndexMapping)
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_GetReportedRooms_Call) Return(stringToRoomReports map[string]*model.RoomReports, err error) *MockStatsRepository_GetReportedRooms_Call {
+	_c.Call.Return(stringToRoomReports, err)
+	return _c
+}
+
+func (_c *MockStatsRepository_GetReportedRooms_Call) RunAndReturn(run func(context1 context.Context, strings ...string) (map[string]*model.RoomReports, error)) *MockStatsRepository_GetReportedRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoom provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetRoom(context1 context.Context, s string) (*model.MatrixRoom, error) {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoom")
+	}
+
+	var r0 *model.MatrixRoom
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixRoom, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixRoom); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MatrixRoom)
 		}
-		var arg3 search.SearcherOptions
-		if args[3] != nil {
-			arg3 = args[3].(search.SearcherOptions)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatsRepository_GetRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoom'
+type MockStatsRepository_GetRoom_Call struct {
+	*mock.Call
+}
+
+// GetRoom is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockStatsRepository_Expecter) GetRoom(context1 interface{}, s interface{}) *MockStatsRepository_GetRoom_Call {
+	return &MockStatsRepository_GetRoom_Call{Call: _e.mock.On("GetRoom", context1, s)}
+}
+
+func (_c *MockStatsRepository_GetRoom_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *mockbleveQuery_Searcher_Call) Return(searcher search.Searcher, err error) *mockbleveQuery_Searcher_Call {
-	_c.Call.Return(searcher, err)
+func (_c *MockStatsRepository_GetRoom_Call) Return(matrixRoom *model.MatrixRoom, err error) *MockStatsRepository_GetRoom_Call {
+	_c.Call.Return(matrixRoom, err)
 	return _c
 }
 
-func (_c *mockbleveQuery_Searcher_Call) RunAndReturn(run func(ctx context.Context, i index.IndexReader, m mapping.IndexMapping, options search.SearcherOptions) (search.Searcher, error)) *mockbleveQuery_Searcher_Call {
+func (_c *MockStatsRepository_GetRoom_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.MatrixRoom, error)) *MockStatsRepository_GetRoom_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetBoost provides a mock function for the type mockbleveQuery
-func (_mock *mockbleveQuery) SetBoost(f float64) {
-	_mock.Called(f)
-	return
+// GetRoomBan provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetRoomBan(context1 context.Context, s string) (*model.RoomBan, error) {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoomBan")
+	}
+
+	var r0 *model.RoomBan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.RoomBan, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.RoomBan); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomBan)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// mockbleveQuery_SetBoost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBoost'
-type mockbleveQuery_SetBoost_Call struct {
+// MockStatsRepository_GetRoomBan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomBan'
+type MockStatsRepository_GetRoomBan_Call struct {
 	*mock.Call
 }
 
-// SetBoost is a helper method to define mock.On call
-//   - f float64
-func (_e *mockbleveQuery_Expecter) SetBoost(f interface{}) *mockbleveQuery_SetBoost_Call {
-	return &mockbleveQuery_SetBoost_Call{Call: _e.mock.On("SetBoost", f)}
+// GetRoomBan is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockStatsRepository_Expecter) GetRoomBan(context1 interface{}, s interface{}) *MockStatsRepository_GetRoomBan_Call {
+	return &MockStatsRepository_GetRoomBan_Call{Call: _e.mock.On("GetRoomBan", context1, s)}
 }
 
-func (_c *mockbleveQuery_SetBoost_Call) Run(run func(f float64)) *mockbleveQuery_SetBoost_Call {
+func (_c *MockStatsRepository_GetRoomBan_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetRoomBan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 float64
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(float64)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *mockbleveQuery_SetBoost_Call) Return() *mockbleveQuery_SetBoost_Call {
-	_c.Call.Return()
+func (_c *MockStatsRepository_GetRoomBan_Call) Return(roomBan *model.RoomBan, err error) *MockStatsRepository_GetRoomBan_Call {
+	_c.Call.Return(roomBan, err)
+	return _c
+}
+
+func (_c *MockStatsRepository_GetRoomBan_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.RoomBan, error)) *MockStatsRepository_GetRoomBan_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-func (_c *mockbleveQuery_SetBoost_Call) RunAndReturn(run func(f float64)) *mockbleveQuery_SetBoost_Call {
-	_c.Run(run)
-	return _c
-}
+// GetRoomBans provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetRoomBans(context1 context.Context, strings ...string) ([]*model.RoomBan, error) {
+	var tmpRet mock.Arguments
+	if len(strings) > 0 {
+		tmpRet = _mock.Called(context1, strings)
+	} else {
+		tmpRet = _mock.Called(context1)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoomBans")
+	}
 
-// SetField provides a mock function for the type mockbleveQuery
-func (_mock *mockbleveQuery) SetField(s string) {
-	_mock.Called(s)
-	return
+	var r0 []*model.RoomBan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) ([]*model.RoomBan, error)); ok {
+		return returnFunc(context1, strings...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) []*model.RoomBan); ok {
+		r0 = returnFunc(context1, strings...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RoomBan)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(context1, strings...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// mockbleveQuery_SetField_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetField'
-type mockbleveQuery_SetField_Call struct {
+// MockStatsRepository_GetRoomBans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomBans'
+type MockStatsRepository_GetRoomBans_Call struct {
 	*mock.Call
 }
 
-// SetField is a helper method to define mock.On call
-//   - s string
-func (_e *mockbleveQuery_Expecter) SetField(s interface{}) *mockbleveQuery_SetField_Call {
-	return &mockbleveQuery_SetField_Call{Call: _e.mock.On("SetField", s)}
+// GetRoomBans is a helper method to define mock.On call
+//   - context1 context.Context
+//   - strings ...string
+func (_e *MockStatsRepository_Expecter) GetRoomBans(context1 interface{}, strings ...interface{}) *MockStatsRepository_GetRoomBans_Call {
+	return &MockStatsRepository_GetRoomBans_Call{Call: _e.mock.On("GetRoomBans",
+		append([]interface{}{context1}, strings...)...)}
 }
 
-func (_c *mockbleveQuery_SetField_Call) Run(run func(s string)) *mockbleveQuery_SetField_Call {
+func (_c *MockStatsRepository_GetRoomBans_Call) Run(run func(context1 context.Context, strings ...string)) *MockStatsRepository_GetRoomBans_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
 		}
+		arg1 = variadicArgs
 		run(
 			arg0,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *mockbleveQuery_SetField_Call) Return() *mockbleveQuery_SetField_Call {
-	_c.Call.Return()
+func (_c *MockStatsRepository_GetRoomBans_Call) Return(roomBans []*model.RoomBan, err error) *MockStatsRepository_GetRoomBans_Call {
+	_c.Call.Return(roomBans, err)
 	return _c
 }
 
-func (_c *mockbleveQuery_SetField_Call) RunAndReturn(run func(s string)) *mockbleveQuery_SetField_Call {
-	_c.Run(run)
+func (_c *MockStatsRepository_GetRoomBans_Call) RunAndReturn(run func(context1 context.Context, strings ...string) ([]*model.RoomBan, error)) *MockStatsRepository_GetRoomBans_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockStatsRepository creates a new instance of MockStatsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockStatsRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockStatsRepository {
-	mock := &MockStatsRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockStatsRepository is an autogenerated mock type for the StatsRepository type
-type MockStatsRepository struct {
-	mock.Mock
-}
-
-type MockStatsRepository_Expecter struct {
-	mock *mock.Mock
-}
+// GetRoomHistory provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetRoomHistory(context1 context.Context, s string) (*model.RoomHistory, error) {
+	ret := _mock.Called(context1, s)
 
-func (_m *MockStatsRepository) EXPECT() *MockStatsRepository_Expecter {
-	return &MockStatsRepository_Expecter{mock: &_m.Mock}
-}
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoomHistory")
+	}
 
-// AddRoomBatch provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) AddRoomBatch(context1 context.Context, matrixRoom *model.MatrixRoom) {
-	_mock.Called(context1, matrixRoom)
-	return
+	var r0 *model.RoomHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.RoomHistory, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.RoomHistory); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStatsRepository_AddRoomBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomBatch'
-type MockStatsRepository_AddRoomBatch_Call struct {
+// MockStatsRepository_GetRoomHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomHistory'
+type MockStatsRepository_GetRoomHistory_Call struct {
 	*mock.Call
 }
 
-// AddRoomBatch is a helper method to define mock.On call
+// GetRoomHistory is a helper method to define mock.On call
 //   - context1 context.Context
-//   - matrixRoom *model.MatrixRoom
-func (_e *MockStatsRepository_Expecter) AddRoomBatch(context1 interface{}, matrixRoom interface{}) *MockStatsRepository_AddRoomBatch_Call {
-	return &MockStatsRepository_AddRoomBatch_Call{Call: _e.mock.On("AddRoomBatch", context1, matrixRoom)}
+//   - s string
+func (_e *MockStatsRepository_Expecter) GetRoomHistory(context1 interface{}, s interface{}) *MockStatsRepository_GetRoomHistory_Call {
+	return &MockStatsRepository_GetRoomHistory_Call{Call: _e.mock.On("GetRoomHistory", context1, s)}
 }
 
-func (_c *MockStatsRepository_AddRoomBatch_Call) Run(run func(context1 context.Context, matrixRoom *model.MatrixRoom)) *MockStatsRepository_AddRoomBatch_Call {
+func (_c *MockStatsRepository_GetRoomHistory_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetRoomHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.MatrixRoom
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.MatrixRoom)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -4820,47 +9642,46 @@ func (_c *MockStatsRepository_AddRoomBatch_Call) Run(run func(context1 context.C
 	return _c
 }
 
-func (_c *MockStatsRepository_AddRoomBatch_Call) Return() *MockStatsRepository_AddRoomBatch_Call {
-	_c.Call.Return()
+func (_c *MockStatsRepository_GetRoomHistory_Call) Return(roomHistory *model.RoomHistory, err error) *MockStatsRepository_GetRoomHistory_Call {
+	_c.Call.Return(roomHistory, err)
 	return _c
 }
 
-func (_c *MockStatsRepository_AddRoomBatch_Call) RunAndReturn(run func(context1 context.Context, matrixRoom *model.MatrixRoom)) *MockStatsRepository_AddRoomBatch_Call {
-	_c.Run(run)
+func (_c *MockStatsRepository_GetRoomHistory_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.RoomHistory, error)) *MockStatsRepository_GetRoomHistory_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// AddRoomMapping provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) AddRoomMapping(context1 context.Context, s string, s1 string) error {
-	ret := _mock.Called(context1, s, s1)
+// GetRoomMapping provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetRoomMapping(context1 context.Context, s string) string {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddRoomMapping")
+		panic("no return value specified for GetRoomMapping")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(context1, s, s1)
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
 	return r0
 }
 
-// MockStatsRepository_AddRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomMapping'
-type MockStatsRepository_AddRoomMapping_Call struct {
+// MockStatsRepository_GetRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomMapping'
+type MockStatsRepository_GetRoomMapping_Call struct {
 	*mock.Call
 }
 
-// AddRoomMapping is a helper method to define mock.On call
+// GetRoomMapping is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-//   - s1 string
-func (_e *MockStatsRepository_Expecter) AddRoomMapping(context1 interface{}, s interface{}, s1 interface{}) *MockStatsRepository_AddRoomMapping_Call {
-	return &MockStatsRepository_AddRoomMapping_Call{Call: _e.mock.On("AddRoomMapping", context1, s, s1)}
+func (_e *MockStatsRepository_Expecter) GetRoomMapping(context1 interface{}, s interface{}) *MockStatsRepository_GetRoomMapping_Call {
+	return &MockStatsRepository_GetRoomMapping_Call{Call: _e.mock.On("GetRoomMapping", context1, s)}
 }
 
-func (_c *MockStatsRepository_AddRoomMapping_Call) Run(run func(context1 context.Context, s string, s1 string)) *MockStatsRepository_AddRoomMapping_Call {
+func (_c *MockStatsRepository_GetRoomMapping_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetRoomMapping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4870,67 +9691,73 @@ func (_c *MockStatsRepository_AddRoomMapping_Call) Run(run func(context1 context
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStatsRepository_AddRoomMapping_Call) Return(err error) *MockStatsRepository_AddRoomMapping_Call {
-	_c.Call.Return(err)
+func (_c *MockStatsRepository_GetRoomMapping_Call) Return(s1 string) *MockStatsRepository_GetRoomMapping_Call {
+	_c.Call.Return(s1)
 	return _c
 }
 
-func (_c *MockStatsRepository_AddRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string) error) *MockStatsRepository_AddRoomMapping_Call {
+func (_c *MockStatsRepository_GetRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string) string) *MockStatsRepository_GetRoomMapping_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AddServer provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) AddServer(context1 context.Context, matrixServer *model.MatrixServer) error {
-	ret := _mock.Called(context1, matrixServer)
+// GetRoomParents provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetRoomParents(context1 context.Context, s string) ([]string, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddServer")
+		panic("no return value specified for GetRoomParents")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.MatrixServer) error); ok {
-		r0 = returnFunc(context1, matrixServer)
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStatsRepository_AddServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddServer'
-type MockStatsRepository_AddServer_Call struct {
+// MockStatsRepository_GetRoomParents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomParents'
+type MockStatsRepository_GetRoomParents_Call struct {
 	*mock.Call
 }
 
-// AddServer is a helper method to define mock.On call
+// GetRoomParents is a helper method to define mock.On call
 //   - context1 context.Context
-//   - matrixServer *model.MatrixServer
-func (_e *MockStatsRepository_Expecter) AddServer(context1 interface{}, matrixServer interface{}) *MockStatsRepository_AddServer_Call {
-	return &MockStatsRepository_AddServer_Call{Call: _e.mock.On("AddServer", context1, matrixServer)}
+//   - s string
+func (_e *MockStatsRepository_Expecter) GetRoomParents(context1 interface{}, s interface{}) *MockStatsRepository_GetRoomParents_Call {
+	return &MockStatsRepository_GetRoomParents_Call{Call: _e.mock.On("GetRoomParents", context1, s)}
 }
 
-func (_c *MockStatsRepository_AddServer_Call) Run(run func(context1 context.Context, matrixServer *model.MatrixServer)) *MockStatsRepository_AddServer_Call {
+func (_c *MockStatsRepository_GetRoomParents_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetRoomParents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.MatrixServer
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.MatrixServer)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -4940,46 +9767,57 @@ func (_c *MockStatsRepository_AddServer_Call) Run(run func(context1 context.Cont
 	return _c
 }
 
-func (_c *MockStatsRepository_AddServer_Call) Return(err error) *MockStatsRepository_AddServer_Call {
-	_c.Call.Return(err)
+func (_c *MockStatsRepository_GetRoomParents_Call) Return(strings []string, err error) *MockStatsRepository_GetRoomParents_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockStatsRepository_AddServer_Call) RunAndReturn(run func(context1 context.Context, matrixServer *model.MatrixServer) error) *MockStatsRepository_AddServer_Call {
+func (_c *MockStatsRepository_GetRoomParents_Call) RunAndReturn(run func(context1 context.Context, s string) ([]string, error)) *MockStatsRepository_GetRoomParents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// BanRoom provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) BanRoom(context1 context.Context, s string) error {
+// GetRoomQuarantine provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetRoomQuarantine(context1 context.Context, s string) (*model.RoomQuarantine, error) {
 	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BanRoom")
+		panic("no return value specified for GetRoomQuarantine")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 *model.RoomQuarantine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.RoomQuarantine, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.RoomQuarantine); ok {
 		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomQuarantine)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStatsRepository_BanRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanRoom'
-type MockStatsRepository_BanRoom_Call struct {
+// MockStatsRepository_GetRoomQuarantine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomQuarantine'
+type MockStatsRepository_GetRoomQuarantine_Call struct {
 	*mock.Call
 }
 
-// BanRoom is a helper method to define mock.On call
+// GetRoomQuarantine is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-func (_e *MockStatsRepository_Expecter) BanRoom(context1 interface{}, s interface{}) *MockStatsRepository_BanRoom_Call {
-	return &MockStatsRepository_BanRoom_Call{Call: _e.mock.On("BanRoom", context1, s)}
+func (_e *MockStatsRepository_Expecter) GetRoomQuarantine(context1 interface{}, s interface{}) *MockStatsRepository_GetRoomQuarantine_Call {
+	return &MockStatsRepository_GetRoomQuarantine_Call{Call: _e.mock.On("GetRoomQuarantine", context1, s)}
 }
 
-func (_c *MockStatsRepository_BanRoom_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_BanRoom_Call {
+func (_c *MockStatsRepository_GetRoomQuarantine_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetRoomQuarantine_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4997,54 +9835,65 @@ func (_c *MockStatsRepository_BanRoom_Call) Run(run func(context1 context.Contex
 	return _c
 }
 
-func (_c *MockStatsRepository_BanRoom_Call) Return(err error) *MockStatsRepository_BanRoom_Call {
-	_c.Call.Return(err)
+func (_c *MockStatsRepository_GetRoomQuarantine_Call) Return(roomQuarantine *model.RoomQuarantine, err error) *MockStatsRepository_GetRoomQuarantine_Call {
+	_c.Call.Return(roomQuarantine, err)
 	return _c
 }
 
-func (_c *MockStatsRepository_BanRoom_Call) RunAndReturn(run func(context1 context.Context, s string) error) *MockStatsRepository_BanRoom_Call {
+func (_c *MockStatsRepository_GetRoomQuarantine_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.RoomQuarantine, error)) *MockStatsRepository_GetRoomQuarantine_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// BatchServers provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) BatchServers(context1 context.Context, strings []string) error {
-	ret := _mock.Called(context1, strings)
+// GetRoomReports provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetRoomReports(context1 context.Context, s string) (*model.RoomReports, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BatchServers")
+		panic("no return value specified for GetRoomReports")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
-		r0 = returnFunc(context1, strings)
+	var r0 *model.RoomReports
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.RoomReports, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.RoomReports); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomReports)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStatsRepository_BatchServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchServers'
-type MockStatsRepository_BatchServers_Call struct {
+// MockStatsRepository_GetRoomReports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomReports'
+type MockStatsRepository_GetRoomReports_Call struct {
 	*mock.Call
 }
 
-// BatchServers is a helper method to define mock.On call
+// GetRoomReports is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings []string
-func (_e *MockStatsRepository_Expecter) BatchServers(context1 interface{}, strings interface{}) *MockStatsRepository_BatchServers_Call {
-	return &MockStatsRepository_BatchServers_Call{Call: _e.mock.On("BatchServers", context1, strings)}
+//   - s string
+func (_e *MockStatsRepository_Expecter) GetRoomReports(context1 interface{}, s interface{}) *MockStatsRepository_GetRoomReports_Call {
+	return &MockStatsRepository_GetRoomReports_Call{Call: _e.mock.On("GetRoomReports", context1, s)}
 }
 
-func (_c *MockStatsRepository_BatchServers_Call) Run(run func(context1 context.Context, strings []string)) *MockStatsRepository_BatchServers_Call {
+func (_c *MockStatsRepository_GetRoomReports_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetRoomReports_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -5054,43 +9903,65 @@ func (_c *MockStatsRepository_BatchServers_Call) Run(run func(context1 context.C
 	return _c
 }
 
-func (_c *MockStatsRepository_BatchServers_Call) Return(err error) *MockStatsRepository_BatchServers_Call {
-	_c.Call.Return(err)
+func (_c *MockStatsRepository_GetRoomReports_Call) Return(roomReports *model.RoomReports, err error) *MockStatsRepository_GetRoomReports_Call {
+	_c.Call.Return(roomReports, err)
 	return _c
 }
 
-func (_c *MockStatsRepository_BatchServers_Call) RunAndReturn(run func(context1 context.Context, strings []string) error) *MockStatsRepository_BatchServers_Call {
+func (_c *MockStatsRepository_GetRoomReports_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.RoomReports, error)) *MockStatsRepository_GetRoomReports_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EachRoom provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) EachRoom(context1 context.Context, fn func(string, *model.MatrixRoom) bool) {
-	_mock.Called(context1, fn)
-	return
+// GetServerInfo provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetServerInfo(context1 context.Context, s string) (*model.MatrixServer, error) {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServerInfo")
+	}
+
+	var r0 *model.MatrixServer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixServer, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixServer); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MatrixServer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStatsRepository_EachRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachRoom'
-type MockStatsRepository_EachRoom_Call struct {
+// MockStatsRepository_GetServerInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerInfo'
+type MockStatsRepository_GetServerInfo_Call struct {
 	*mock.Call
 }
 
-// EachRoom is a helper method to define mock.On call
+// GetServerInfo is a helper method to define mock.On call
 //   - context1 context.Context
-//   - fn func(string, *model.MatrixRoom) bool
-func (_e *MockStatsRepository_Expecter) EachRoom(context1 interface{}, fn interface{}) *MockStatsRepository_EachRoom_Call {
-	return &MockStatsRepository_EachRoom_Call{Call: _e.mock.On("EachRoom", context1, fn)}
+//   - s string
+func (_e *MockStatsRepository_Expecter) GetServerInfo(context1 interface{}, s interface{}) *MockStatsRepository_GetServerInfo_Call {
+	return &MockStatsRepository_GetServerInfo_Call{Call: _e.mock.On("GetServerInfo", context1, s)}
 }
 
-func (_c *MockStatsRepository_EachRoom_Call) Run(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *MockStatsRepository_EachRoom_Call {
+func (_c *MockStatsRepository_GetServerInfo_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetServerInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 func(string, *model.MatrixRoom) bool
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(func(string, *model.MatrixRoom) bool)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -5100,56 +9971,65 @@ func (_c *MockStatsRepository_EachRoom_Call) Run(run func(context1 context.Conte
 	return _c
 }
 
-func (_c *MockStatsRepository_EachRoom_Call) Return() *MockStatsRepository_EachRoom_Call {
-	_c.Call.Return()
+func (_c *MockStatsRepository_GetServerInfo_Call) Return(matrixServer *model.MatrixServer, err error) *MockStatsRepository_GetServerInfo_Call {
+	_c.Call.Return(matrixServer, err)
 	return _c
 }
 
-func (_c *MockStatsRepository_EachRoom_Call) RunAndReturn(run func(context1 context.Context, fn func(string, *model.MatrixRoom) bool)) *MockStatsRepository_EachRoom_Call {
-	_c.Run(run)
+func (_c *MockStatsRepository_GetServerInfo_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.MatrixServer, error)) *MockStatsRepository_GetServerInfo_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// FilterServers provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) FilterServers(context1 context.Context, fn func(server *model.MatrixServer) bool) map[string]*model.MatrixServer {
-	ret := _mock.Called(context1, fn)
+// GetSpaceChildren provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetSpaceChildren(context1 context.Context, s string) ([]*model.MatrixSpaceChild, error) {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FilterServers")
+		panic("no return value specified for GetSpaceChildren")
 	}
 
-	var r0 map[string]*model.MatrixServer
-	if returnFunc, ok := ret.Get(0).(func(context.Context, func(server *model.MatrixServer) bool) map[string]*model.MatrixServer); ok {
-		r0 = returnFunc(context1, fn)
+	var r0 []*model.MatrixSpaceChild
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.MatrixSpaceChild, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.MatrixSpaceChild); ok {
+		r0 = returnFunc(context1, s)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]*model.MatrixServer)
+			r0 = ret.Get(0).([]*model.MatrixSpaceChild)
 		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStatsRepository_FilterServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterServers'
-type MockStatsRepository_FilterServers_Call struct {
+// MockStatsRepository_GetSpaceChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSpaceChildren'
+type MockStatsRepository_GetSpaceChildren_Call struct {
 	*mock.Call
 }
 
-// FilterServers is a helper method to define mock.On call
+// GetSpaceChildren is a helper method to define mock.On call
 //   - context1 context.Context
-//   - fn func(server *model.MatrixServer) bool
-func (_e *MockStatsRepository_Expecter) FilterServers(context1 interface{}, fn interface{}) *MockStatsRepository_FilterServers_Call {
-	return &MockStatsRepository_FilterServers_Call{Call: _e.mock.On("FilterServers", context1, fn)}
+//   - s string
+func (_e *MockStatsRepository_Expecter) GetSpaceChildren(context1 interface{}, s interface{}) *MockStatsRepository_GetSpaceChildren_Call {
+	return &MockStatsRepository_GetSpaceChildren_Call{Call: _e.mock.On("GetSpaceChildren", context1, s)}
 }
 
-func (_c *MockStatsRepository_FilterServers_Call) Run(run func(context1 context.Context, fn func(server *model.MatrixServer) bool)) *MockStatsRepository_FilterServers_Call {
+func (_c *MockStatsRepository_GetSpaceChildren_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetSpaceChildren_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 func(server *model.MatrixServer) bool
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(func(server *model.MatrixServer) bool)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -5159,34 +10039,56 @@ func (_c *MockStatsRepository_FilterServers_Call) Run(run func(context1 context.
 	return _c
 }
 
-func (_c *MockStatsRepository_FilterServers_Call) Return(stringToMatrixServer map[string]*model.MatrixServer) *MockStatsRepository_FilterServers_Call {
-	_c.Call.Return(stringToMatrixServer)
+func (_c *MockStatsRepository_GetSpaceChildren_Call) Return(matrixSpaceChilds []*model.MatrixSpaceChild, err error) *MockStatsRepository_GetSpaceChildren_Call {
+	_c.Call.Return(matrixSpaceChilds, err)
 	return _c
 }
 
-func (_c *MockStatsRepository_FilterServers_Call) RunAndReturn(run func(context1 context.Context, fn func(server *model.MatrixServer) bool) map[string]*model.MatrixServer) *MockStatsRepository_FilterServers_Call {
+func (_c *MockStatsRepository_GetSpaceChildren_Call) RunAndReturn(run func(context1 context.Context, s string) ([]*model.MatrixSpaceChild, error)) *MockStatsRepository_GetSpaceChildren_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FlushRoomBatch provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) FlushRoomBatch(context1 context.Context) {
-	_mock.Called(context1)
-	return
+// GetSpaceParents provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) GetSpaceParents(context1 context.Context) (map[string][]string, error) {
+	ret := _mock.Called(context1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSpaceParents")
+	}
+
+	var r0 map[string][]string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string][]string, error)); ok {
+		return returnFunc(context1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string][]string); ok {
+		r0 = returnFunc(context1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(context1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStatsRepository_FlushRoomBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushRoomBatch'
-type MockStatsRepository_FlushRoomBatch_Call struct {
+// MockStatsRepository_GetSpaceParents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSpaceParents'
+type MockStatsRepository_GetSpaceParents_Call struct {
 	*mock.Call
 }
 
-// FlushRoomBatch is a helper method to define mock.On call
+// GetSpaceParents is a helper method to define mock.On call
 //   - context1 context.Context
-func (_e *MockStatsRepository_Expecter) FlushRoomBatch(context1 interface{}) *MockStatsRepository_FlushRoomBatch_Call {
-	return &MockStatsRepository_FlushRoomBatch_Call{Call: _e.mock.On("FlushRoomBatch", context1)}
+func (_e *MockStatsRepository_Expecter) GetSpaceParents(context1 interface{}) *MockStatsRepository_GetSpaceParents_Call {
+	return &MockStatsRepository_GetSpaceParents_Call{Call: _e.mock.On("GetSpaceParents", context1)}
 }
 
-func (_c *MockStatsRepository_FlushRoomBatch_Call) Run(run func(context1 context.Context)) *MockStatsRepository_FlushRoomBatch_Call {
+func (_c *MockStatsRepository_GetSpaceParents_Call) Run(run func(context1 context.Context)) *MockStatsRepository_GetSpaceParents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5199,187 +10101,160 @@ func (_c *MockStatsRepository_FlushRoomBatch_Call) Run(run func(context1 context
 	return _c
 }
 
-func (_c *MockStatsRepository_FlushRoomBatch_Call) Return() *MockStatsRepository_FlushRoomBatch_Call {
-	_c.Call.Return()
+func (_c *MockStatsRepository_GetSpaceParents_Call) Return(stringToStrings map[string][]string, err error) *MockStatsRepository_GetSpaceParents_Call {
+	_c.Call.Return(stringToStrings, err)
 	return _c
 }
 
-func (_c *MockStatsRepository_FlushRoomBatch_Call) RunAndReturn(run func(context1 context.Context)) *MockStatsRepository_FlushRoomBatch_Call {
-	_c.Run(run)
+func (_c *MockStatsRepository_GetSpaceParents_Call) RunAndReturn(run func(context1 context.Context) (map[string][]string, error)) *MockStatsRepository_GetSpaceParents_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// GetBannedRooms provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) GetBannedRooms(context1 context.Context, strings ...string) ([]string, error) {
-	var tmpRet mock.Arguments
-	if len(strings) > 0 {
-		tmpRet = _mock.Called(context1, strings)
-	} else {
-		tmpRet = _mock.Called(context1)
-	}
-	ret := tmpRet
+// HasServer provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) HasServer(context1 context.Context, s string) bool {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBannedRooms")
+		panic("no return value specified for HasServer")
 	}
 
-	var r0 []string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) ([]string, error)); ok {
-		return returnFunc(context1, strings...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) []string); ok {
-		r0 = returnFunc(context1, strings...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
-		r1 = returnFunc(context1, strings...)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStatsRepository_GetBannedRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBannedRooms'
-type MockStatsRepository_GetBannedRooms_Call struct {
+// MockStatsRepository_HasServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasServer'
+type MockStatsRepository_HasServer_Call struct {
 	*mock.Call
 }
 
-// GetBannedRooms is a helper method to define mock.On call
+// HasServer is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings ...string
-func (_e *MockStatsRepository_Expecter) GetBannedRooms(context1 interface{}, strings ...interface{}) *MockStatsRepository_GetBannedRooms_Call {
-	return &MockStatsRepository_GetBannedRooms_Call{Call: _e.mock.On("GetBannedRooms",
-		append([]interface{}{context1}, strings...)...)}
+//   - s string
+func (_e *MockStatsRepository_Expecter) HasServer(context1 interface{}, s interface{}) *MockStatsRepository_HasServer_Call {
+	return &MockStatsRepository_HasServer_Call{Call: _e.mock.On("HasServer", context1, s)}
 }
 
-func (_c *MockStatsRepository_GetBannedRooms_Call) Run(run func(context1 context.Context, strings ...string)) *MockStatsRepository_GetBannedRooms_Call {
+func (_c *MockStatsRepository_HasServer_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_HasServer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
-		var variadicArgs []string
-		if len(args) > 1 {
-			variadicArgs = args[1].([]string)
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
-		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1...,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStatsRepository_GetBannedRooms_Call) Return(strings1 []string, err error) *MockStatsRepository_GetBannedRooms_Call {
-	_c.Call.Return(strings1, err)
+func (_c *MockStatsRepository_HasServer_Call) Return(b bool) *MockStatsRepository_HasServer_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockStatsRepository_GetBannedRooms_Call) RunAndReturn(run func(context1 context.Context, strings ...string) ([]string, error)) *MockStatsRepository_GetBannedRooms_Call {
+func (_c *MockStatsRepository_HasServer_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockStatsRepository_HasServer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetIndexStats provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) GetIndexStats(ctx context.Context) *model.IndexStats {
-	ret := _mock.Called(ctx)
+// IsActionTokenUsed provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) IsActionTokenUsed(context1 context.Context, s string) bool {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetIndexStats")
+		panic("no return value specified for IsActionTokenUsed")
 	}
 
-	var r0 *model.IndexStats
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *model.IndexStats); ok {
-		r0 = returnFunc(ctx)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.IndexStats)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 	return r0
 }
 
-// MockStatsRepository_GetIndexStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIndexStats'
-type MockStatsRepository_GetIndexStats_Call struct {
+// MockStatsRepository_IsActionTokenUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsActionTokenUsed'
+type MockStatsRepository_IsActionTokenUsed_Call struct {
 	*mock.Call
 }
 
-// GetIndexStats is a helper method to define mock.On call
-//   - ctx context.Context
-func (_e *MockStatsRepository_Expecter) GetIndexStats(ctx interface{}) *MockStatsRepository_GetIndexStats_Call {
-	return &MockStatsRepository_GetIndexStats_Call{Call: _e.mock.On("GetIndexStats", ctx)}
+// IsActionTokenUsed is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockStatsRepository_Expecter) IsActionTokenUsed(context1 interface{}, s interface{}) *MockStatsRepository_IsActionTokenUsed_Call {
+	return &MockStatsRepository_IsActionTokenUsed_Call{Call: _e.mock.On("IsActionTokenUsed", context1, s)}
 }
 
-func (_c *MockStatsRepository_GetIndexStats_Call) Run(run func(ctx context.Context)) *MockStatsRepository_GetIndexStats_Call {
+func (_c *MockStatsRepository_IsActionTokenUsed_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_IsActionTokenUsed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStatsRepository_GetIndexStats_Call) Return(indexStats *model.IndexStats) *MockStatsRepository_GetIndexStats_Call {
-	_c.Call.Return(indexStats)
+func (_c *MockStatsRepository_IsActionTokenUsed_Call) Return(b bool) *MockStatsRepository_IsActionTokenUsed_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockStatsRepository_GetIndexStats_Call) RunAndReturn(run func(ctx context.Context) *model.IndexStats) *MockStatsRepository_GetIndexStats_Call {
+func (_c *MockStatsRepository_IsActionTokenUsed_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockStatsRepository_IsActionTokenUsed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetIndexStatsTL provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) GetIndexStatsTL(ctx context.Context, prefix string) (map[time.Time]*model.IndexStats, error) {
-	ret := _mock.Called(ctx, prefix)
+// IsBanned provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) IsBanned(context1 context.Context, s string) bool {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetIndexStatsTL")
+		panic("no return value specified for IsBanned")
 	}
 
-	var r0 map[time.Time]*model.IndexStats
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (map[time.Time]*model.IndexStats, error)); ok {
-		return returnFunc(ctx, prefix)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) map[time.Time]*model.IndexStats); ok {
-		r0 = returnFunc(ctx, prefix)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[time.Time]*model.IndexStats)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, prefix)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0, r1
-}
-
-// MockStatsRepository_GetIndexStatsTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIndexStatsTL'
-type MockStatsRepository_GetIndexStatsTL_Call struct {
-	*mock.Call
+	return r0
 }
-
-// GetIndexStatsTL is a helper method to define mock.On call
-//   - ctx context.Context
-//   - prefix string
-func (_e *MockStatsRepository_Expecter) GetIndexStatsTL(ctx interface{}, prefix interface{}) *MockStatsRepository_GetIndexStatsTL_Call {
-	return &MockStatsRepository_GetIndexStatsTL_Call{Call: _e.mock.On("GetIndexStatsTL", ctx, prefix)}
+
+// MockStatsRepository_IsBanned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBanned'
+type MockStatsRepository_IsBanned_Call struct {
+	*mock.Call
 }
 
-func (_c *MockStatsRepository_GetIndexStatsTL_Call) Run(run func(ctx context.Context, prefix string)) *MockStatsRepository_GetIndexStatsTL_Call {
+// IsBanned is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockStatsRepository_Expecter) IsBanned(context1 interface{}, s interface{}) *MockStatsRepository_IsBanned_Call {
+	return &MockStatsRepository_IsBanned_Call{Call: _e.mock.On("IsBanned", context1, s)}
+}
+
+func (_c *MockStatsRepository_IsBanned_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_IsBanned_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5397,134 +10272,103 @@ func (_c *MockStatsRepository_GetIndexStatsTL_Call) Run(run func(ctx context.Con
 	return _c
 }
 
-func (_c *MockStatsRepository_GetIndexStatsTL_Call) Return(timeToIndexStats map[time.Time]*model.IndexStats, err error) *MockStatsRepository_GetIndexStatsTL_Call {
-	_c.Call.Return(timeToIndexStats, err)
+func (_c *MockStatsRepository_IsBanned_Call) Return(b bool) *MockStatsRepository_IsBanned_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockStatsRepository_GetIndexStatsTL_Call) RunAndReturn(run func(ctx context.Context, prefix string) (map[time.Time]*model.IndexStats, error)) *MockStatsRepository_GetIndexStatsTL_Call {
+func (_c *MockStatsRepository_IsBanned_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockStatsRepository_IsBanned_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReportedRooms provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) GetReportedRooms(context1 context.Context, strings ...string) (map[string]string, error) {
-	var tmpRet mock.Arguments
-	if len(strings) > 0 {
-		tmpRet = _mock.Called(context1, strings)
-	} else {
-		tmpRet = _mock.Called(context1)
-	}
-	ret := tmpRet
+// IsQuarantined provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) IsQuarantined(context1 context.Context, s string) bool {
+	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReportedRooms")
+		panic("no return value specified for IsQuarantined")
 	}
 
-	var r0 map[string]string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) (map[string]string, error)); ok {
-		return returnFunc(context1, strings...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) map[string]string); ok {
-		r0 = returnFunc(context1, strings...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]string)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
-		r1 = returnFunc(context1, strings...)
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(context1, s)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStatsRepository_GetReportedRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReportedRooms'
-type MockStatsRepository_GetReportedRooms_Call struct {
+// MockStatsRepository_IsQuarantined_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsQuarantined'
+type MockStatsRepository_IsQuarantined_Call struct {
 	*mock.Call
 }
 
-// GetReportedRooms is a helper method to define mock.On call
+// IsQuarantined is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings ...string
-func (_e *MockStatsRepository_Expecter) GetReportedRooms(context1 interface{}, strings ...interface{}) *MockStatsRepository_GetReportedRooms_Call {
-	return &MockStatsRepository_GetReportedRooms_Call{Call: _e.mock.On("GetReportedRooms",
-		append([]interface{}{context1}, strings...)...)}
+//   - s string
+func (_e *MockStatsRepository_Expecter) IsQuarantined(context1 interface{}, s interface{}) *MockStatsRepository_IsQuarantined_Call {
+	return &MockStatsRepository_IsQuarantined_Call{Call: _e.mock.On("IsQuarantined", context1, s)}
 }
 
-func (_c *MockStatsRepository_GetReportedRooms_Call) Run(run func(context1 context.Context, strings ...string)) *MockStatsRepository_GetReportedRooms_Call {
+func (_c *MockStatsRepository_IsQuarantined_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_IsQuarantined_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
-		var variadicArgs []string
-		if len(args) > 1 {
-			variadicArgs = args[1].([]string)
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
-		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1...,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStatsRepository_GetReportedRooms_Call) Return(stringToString map[string]string, err error) *MockStatsRepository_GetReportedRooms_Call {
-	_c.Call.Return(stringToString, err)
+func (_c *MockStatsRepository_IsQuarantined_Call) Return(b bool) *MockStatsRepository_IsQuarantined_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockStatsRepository_GetReportedRooms_Call) RunAndReturn(run func(context1 context.Context, strings ...string) (map[string]string, error)) *MockStatsRepository_GetReportedRooms_Call {
+func (_c *MockStatsRepository_IsQuarantined_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockStatsRepository_IsQuarantined_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRoom provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) GetRoom(context1 context.Context, s string) (*model.MatrixRoom, error) {
+// IsReported provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) IsReported(context1 context.Context, s string) bool {
 	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoom")
+		panic("no return value specified for IsReported")
 	}
 
-	var r0 *model.MatrixRoom
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixRoom, error)); ok {
-		return returnFunc(context1, s)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixRoom); ok {
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
 		r0 = returnFunc(context1, s)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.MatrixRoom)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(context1, s)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStatsRepository_GetRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoom'
-type MockStatsRepository_GetRoom_Call struct {
+// MockStatsRepository_IsReported_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsReported'
+type MockStatsRepository_IsReported_Call struct {
 	*mock.Call
 }
 
-// GetRoom is a helper method to define mock.On call
+// IsReported is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-func (_e *MockStatsRepository_Expecter) GetRoom(context1 interface{}, s interface{}) *MockStatsRepository_GetRoom_Call {
-	return &MockStatsRepository_GetRoom_Call{Call: _e.mock.On("GetRoom", context1, s)}
+func (_e *MockStatsRepository_Expecter) IsReported(context1 interface{}, s interface{}) *MockStatsRepository_IsReported_Call {
+	return &MockStatsRepository_IsReported_Call{Call: _e.mock.On("IsReported", context1, s)}
 }
 
-func (_c *MockStatsRepository_GetRoom_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetRoom_Call {
+func (_c *MockStatsRepository_IsReported_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_IsReported_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5542,54 +10386,43 @@ func (_c *MockStatsRepository_GetRoom_Call) Run(run func(context1 context.Contex
 	return _c
 }
 
-func (_c *MockStatsRepository_GetRoom_Call) Return(matrixRoom *model.MatrixRoom, err error) *MockStatsRepository_GetRoom_Call {
-	_c.Call.Return(matrixRoom, err)
+func (_c *MockStatsRepository_IsReported_Call) Return(b bool) *MockStatsRepository_IsReported_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockStatsRepository_GetRoom_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.MatrixRoom, error)) *MockStatsRepository_GetRoom_Call {
+func (_c *MockStatsRepository_IsReported_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockStatsRepository_IsReported_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRoomMapping provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) GetRoomMapping(context1 context.Context, s string) string {
-	ret := _mock.Called(context1, s)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetRoomMapping")
-	}
-
-	var r0 string
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(context1, s)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	return r0
+// MarkServersOffline provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) MarkServersOffline(context1 context.Context, strings []string) {
+	_mock.Called(context1, strings)
+	return
 }
 
-// MockStatsRepository_GetRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomMapping'
-type MockStatsRepository_GetRoomMapping_Call struct {
+// MockStatsRepository_MarkServersOffline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkServersOffline'
+type MockStatsRepository_MarkServersOffline_Call struct {
 	*mock.Call
 }
 
-// GetRoomMapping is a helper method to define mock.On call
+// MarkServersOffline is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-func (_e *MockStatsRepository_Expecter) GetRoomMapping(context1 interface{}, s interface{}) *MockStatsRepository_GetRoomMapping_Call {
-	return &MockStatsRepository_GetRoomMapping_Call{Call: _e.mock.On("GetRoomMapping", context1, s)}
+//   - strings []string
+func (_e *MockStatsRepository_Expecter) MarkServersOffline(context1 interface{}, strings interface{}) *MockStatsRepository_MarkServersOffline_Call {
+	return &MockStatsRepository_MarkServersOffline_Call{Call: _e.mock.On("MarkServersOffline", context1, strings)}
 }
 
-func (_c *MockStatsRepository_GetRoomMapping_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetRoomMapping_Call {
+func (_c *MockStatsRepository_MarkServersOffline_Call) Run(run func(context1 context.Context, strings []string)) *MockStatsRepository_MarkServersOffline_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -5599,65 +10432,54 @@ func (_c *MockStatsRepository_GetRoomMapping_Call) Run(run func(context1 context
 	return _c
 }
 
-func (_c *MockStatsRepository_GetRoomMapping_Call) Return(s1 string) *MockStatsRepository_GetRoomMapping_Call {
-	_c.Call.Return(s1)
+func (_c *MockStatsRepository_MarkServersOffline_Call) Return() *MockStatsRepository_MarkServersOffline_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockStatsRepository_GetRoomMapping_Call) RunAndReturn(run func(context1 context.Context, s string) string) *MockStatsRepository_GetRoomMapping_Call {
-	_c.Call.Return(run)
+func (_c *MockStatsRepository_MarkServersOffline_Call) RunAndReturn(run func(context1 context.Context, strings []string)) *MockStatsRepository_MarkServersOffline_Call {
+	_c.Run(run)
 	return _c
 }
 
-// GetServerInfo provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) GetServerInfo(context1 context.Context, s string) (*model.MatrixServer, error) {
-	ret := _mock.Called(context1, s)
+// QuarantineRoom provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) QuarantineRoom(context1 context.Context, roomQuarantine *model.RoomQuarantine) error {
+	ret := _mock.Called(context1, roomQuarantine)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetServerInfo")
+		panic("no return value specified for QuarantineRoom")
 	}
 
-	var r0 *model.MatrixServer
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.MatrixServer, error)); ok {
-		return returnFunc(context1, s)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.MatrixServer); ok {
-		r0 = returnFunc(context1, s)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.MatrixServer)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(context1, s)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.RoomQuarantine) error); ok {
+		r0 = returnFunc(context1, roomQuarantine)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStatsRepository_GetServerInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerInfo'
-type MockStatsRepository_GetServerInfo_Call struct {
+// MockStatsRepository_QuarantineRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QuarantineRoom'
+type MockStatsRepository_QuarantineRoom_Call struct {
 	*mock.Call
 }
 
-// GetServerInfo is a helper method to define mock.On call
+// QuarantineRoom is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-func (_e *MockStatsRepository_Expecter) GetServerInfo(context1 interface{}, s interface{}) *MockStatsRepository_GetServerInfo_Call {
-	return &MockStatsRepository_GetServerInfo_Call{Call: _e.mock.On("GetServerInfo", context1, s)}
+//   - roomQuarantine *model.RoomQuarantine
+func (_e *MockStatsRepository_Expecter) QuarantineRoom(context1 interface{}, roomQuarantine interface{}) *MockStatsRepository_QuarantineRoom_Call {
+	return &MockStatsRepository_QuarantineRoom_Call{Call: _e.mock.On("QuarantineRoom", context1, roomQuarantine)}
 }
 
-func (_c *MockStatsRepository_GetServerInfo_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_GetServerInfo_Call {
+func (_c *MockStatsRepository_QuarantineRoom_Call) Run(run func(context1 context.Context, roomQuarantine *model.RoomQuarantine)) *MockStatsRepository_QuarantineRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *model.RoomQuarantine
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*model.RoomQuarantine)
 		}
 		run(
 			arg0,
@@ -5667,54 +10489,54 @@ func (_c *MockStatsRepository_GetServerInfo_Call) Run(run func(context1 context.
 	return _c
 }
 
-func (_c *MockStatsRepository_GetServerInfo_Call) Return(matrixServer *model.MatrixServer, err error) *MockStatsRepository_GetServerInfo_Call {
-	_c.Call.Return(matrixServer, err)
+func (_c *MockStatsRepository_QuarantineRoom_Call) Return(err error) *MockStatsRepository_QuarantineRoom_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStatsRepository_GetServerInfo_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.MatrixServer, error)) *MockStatsRepository_GetServerInfo_Call {
+func (_c *MockStatsRepository_QuarantineRoom_Call) RunAndReturn(run func(context1 context.Context, roomQuarantine *model.RoomQuarantine) error) *MockStatsRepository_QuarantineRoom_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// HasServer provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) HasServer(context1 context.Context, s string) bool {
-	ret := _mock.Called(context1, s)
+// RecreateRoomMapping provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) RecreateRoomMapping(context1 context.Context, stringToString map[string]string) error {
+	ret := _mock.Called(context1, stringToString)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HasServer")
+		panic("no return value specified for RecreateRoomMapping")
 	}
 
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = returnFunc(context1, s)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]string) error); ok {
+		r0 = returnFunc(context1, stringToString)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStatsRepository_HasServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasServer'
-type MockStatsRepository_HasServer_Call struct {
+// MockStatsRepository_RecreateRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecreateRoomMapping'
+type MockStatsRepository_RecreateRoomMapping_Call struct {
 	*mock.Call
 }
 
-// HasServer is a helper method to define mock.On call
+// RecreateRoomMapping is a helper method to define mock.On call
 //   - context1 context.Context
-//   - s string
-func (_e *MockStatsRepository_Expecter) HasServer(context1 interface{}, s interface{}) *MockStatsRepository_HasServer_Call {
-	return &MockStatsRepository_HasServer_Call{Call: _e.mock.On("HasServer", context1, s)}
+//   - stringToString map[string]string
+func (_e *MockStatsRepository_Expecter) RecreateRoomMapping(context1 interface{}, stringToString interface{}) *MockStatsRepository_RecreateRoomMapping_Call {
+	return &MockStatsRepository_RecreateRoomMapping_Call{Call: _e.mock.On("RecreateRoomMapping", context1, stringToString)}
 }
 
-func (_c *MockStatsRepository_HasServer_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_HasServer_Call {
+func (_c *MockStatsRepository_RecreateRoomMapping_Call) Run(run func(context1 context.Context, stringToString map[string]string)) *MockStatsRepository_RecreateRoomMapping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 map[string]string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(map[string]string)
 		}
 		run(
 			arg0,
@@ -5724,46 +10546,46 @@ func (_c *MockStatsRepository_HasServer_Call) Run(run func(context1 context.Cont
 	return _c
 }
 
-func (_c *MockStatsRepository_HasServer_Call) Return(b bool) *MockStatsRepository_HasServer_Call {
-	_c.Call.Return(b)
+func (_c *MockStatsRepository_RecreateRoomMapping_Call) Return(err error) *MockStatsRepository_RecreateRoomMapping_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStatsRepository_HasServer_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockStatsRepository_HasServer_Call {
+func (_c *MockStatsRepository_RecreateRoomMapping_Call) RunAndReturn(run func(context1 context.Context, stringToString map[string]string) error) *MockStatsRepository_RecreateRoomMapping_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsReported provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) IsReported(context1 context.Context, s string) bool {
+// ReleaseRoom provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) ReleaseRoom(context1 context.Context, s string) error {
 	ret := _mock.Called(context1, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsReported")
+		panic("no return value specified for ReleaseRoom")
 	}
 
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
 		r0 = returnFunc(context1, s)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStatsRepository_IsReported_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsReported'
-type MockStatsRepository_IsReported_Call struct {
+// MockStatsRepository_ReleaseRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseRoom'
+type MockStatsRepository_ReleaseRoom_Call struct {
 	*mock.Call
 }
 
-// IsReported is a helper method to define mock.On call
+// ReleaseRoom is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-func (_e *MockStatsRepository_Expecter) IsReported(context1 interface{}, s interface{}) *MockStatsRepository_IsReported_Call {
-	return &MockStatsRepository_IsReported_Call{Call: _e.mock.On("IsReported", context1, s)}
+func (_e *MockStatsRepository_Expecter) ReleaseRoom(context1 interface{}, s interface{}) *MockStatsRepository_ReleaseRoom_Call {
+	return &MockStatsRepository_ReleaseRoom_Call{Call: _e.mock.On("ReleaseRoom", context1, s)}
 }
 
-func (_c *MockStatsRepository_IsReported_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_IsReported_Call {
+func (_c *MockStatsRepository_ReleaseRoom_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_ReleaseRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5781,43 +10603,65 @@ func (_c *MockStatsRepository_IsReported_Call) Run(run func(context1 context.Con
 	return _c
 }
 
-func (_c *MockStatsRepository_IsReported_Call) Return(b bool) *MockStatsRepository_IsReported_Call {
-	_c.Call.Return(b)
+func (_c *MockStatsRepository_ReleaseRoom_Call) Return(err error) *MockStatsRepository_ReleaseRoom_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStatsRepository_IsReported_Call) RunAndReturn(run func(context1 context.Context, s string) bool) *MockStatsRepository_IsReported_Call {
+func (_c *MockStatsRepository_ReleaseRoom_Call) RunAndReturn(run func(context1 context.Context, s string) error) *MockStatsRepository_ReleaseRoom_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MarkServersOffline provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) MarkServersOffline(context1 context.Context, strings []string) {
-	_mock.Called(context1, strings)
-	return
+// RemoveBanRule provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) RemoveBanRule(context1 context.Context, s string) (*model.BanRule, error) {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBanRule")
+	}
+
+	var r0 *model.BanRule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.BanRule, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.BanRule); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BanRule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStatsRepository_MarkServersOffline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkServersOffline'
-type MockStatsRepository_MarkServersOffline_Call struct {
+// MockStatsRepository_RemoveBanRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveBanRule'
+type MockStatsRepository_RemoveBanRule_Call struct {
 	*mock.Call
 }
 
-// MarkServersOffline is a helper method to define mock.On call
+// RemoveBanRule is a helper method to define mock.On call
 //   - context1 context.Context
-//   - strings []string
-func (_e *MockStatsRepository_Expecter) MarkServersOffline(context1 interface{}, strings interface{}) *MockStatsRepository_MarkServersOffline_Call {
-	return &MockStatsRepository_MarkServersOffline_Call{Call: _e.mock.On("MarkServersOffline", context1, strings)}
+//   - s string
+func (_e *MockStatsRepository_Expecter) RemoveBanRule(context1 interface{}, s interface{}) *MockStatsRepository_RemoveBanRule_Call {
+	return &MockStatsRepository_RemoveBanRule_Call{Call: _e.mock.On("RemoveBanRule", context1, s)}
 }
 
-func (_c *MockStatsRepository_MarkServersOffline_Call) Run(run func(context1 context.Context, strings []string)) *MockStatsRepository_MarkServersOffline_Call {
+func (_c *MockStatsRepository_RemoveBanRule_Call) Run(run func(context1 context.Context, s string)) *MockStatsRepository_RemoveBanRule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -5827,54 +10671,63 @@ func (_c *MockStatsRepository_MarkServersOffline_Call) Run(run func(context1 con
 	return _c
 }
 
-func (_c *MockStatsRepository_MarkServersOffline_Call) Return() *MockStatsRepository_MarkServersOffline_Call {
-	_c.Call.Return()
+func (_c *MockStatsRepository_RemoveBanRule_Call) Return(banRule *model.BanRule, err error) *MockStatsRepository_RemoveBanRule_Call {
+	_c.Call.Return(banRule, err)
 	return _c
 }
 
-func (_c *MockStatsRepository_MarkServersOffline_Call) RunAndReturn(run func(context1 context.Context, strings []string)) *MockStatsRepository_MarkServersOffline_Call {
-	_c.Run(run)
+func (_c *MockStatsRepository_RemoveBanRule_Call) RunAndReturn(run func(context1 context.Context, s string) (*model.BanRule, error)) *MockStatsRepository_RemoveBanRule_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// RecreateRoomMapping provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) RecreateRoomMapping(context1 context.Context, stringToString map[string]string) error {
-	ret := _mock.Called(context1, stringToString)
+// RemoveExpiredActionTokens provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) RemoveExpiredActionTokens(context1 context.Context, time1 time.Time) (int, error) {
+	ret := _mock.Called(context1, time1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RecreateRoomMapping")
+		panic("no return value specified for RemoveExpiredActionTokens")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]string) error); ok {
-		r0 = returnFunc(context1, stringToString)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return returnFunc(context1, time1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = returnFunc(context1, time1)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(context1, time1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStatsRepository_RecreateRoomMapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecreateRoomMapping'
-type MockStatsRepository_RecreateRoomMapping_Call struct {
+// MockStatsRepository_RemoveExpiredActionTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveExpiredActionTokens'
+type MockStatsRepository_RemoveExpiredActionTokens_Call struct {
 	*mock.Call
 }
 
-// RecreateRoomMapping is a helper method to define mock.On call
+// RemoveExpiredActionTokens is a helper method to define mock.On call
 //   - context1 context.Context
-//   - stringToString map[string]string
-func (_e *MockStatsRepository_Expecter) RecreateRoomMapping(context1 interface{}, stringToString interface{}) *MockStatsRepository_RecreateRoomMapping_Call {
-	return &MockStatsRepository_RecreateRoomMapping_Call{Call: _e.mock.On("RecreateRoomMapping", context1, stringToString)}
+//   - time1 time.Time
+func (_e *MockStatsRepository_Expecter) RemoveExpiredActionTokens(context1 interface{}, time1 interface{}) *MockStatsRepository_RemoveExpiredActionTokens_Call {
+	return &MockStatsRepository_RemoveExpiredActionTokens_Call{Call: _e.mock.On("RemoveExpiredActionTokens", context1, time1)}
 }
 
-func (_c *MockStatsRepository_RecreateRoomMapping_Call) Run(run func(context1 context.Context, stringToString map[string]string)) *MockStatsRepository_RecreateRoomMapping_Call {
+func (_c *MockStatsRepository_RemoveExpiredActionTokens_Call) Run(run func(context1 context.Context, time1 time.Time)) *MockStatsRepository_RemoveExpiredActionTokens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 map[string]string
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(map[string]string)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
@@ -5884,12 +10737,12 @@ func (_c *MockStatsRepository_RecreateRoomMapping_Call) Run(run func(context1 co
 	return _c
 }
 
-func (_c *MockStatsRepository_RecreateRoomMapping_Call) Return(err error) *MockStatsRepository_RecreateRoomMapping_Call {
-	_c.Call.Return(err)
+func (_c *MockStatsRepository_RemoveExpiredActionTokens_Call) Return(n int, err error) *MockStatsRepository_RemoveExpiredActionTokens_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockStatsRepository_RecreateRoomMapping_Call) RunAndReturn(run func(context1 context.Context, stringToString map[string]string) error) *MockStatsRepository_RecreateRoomMapping_Call {
+func (_c *MockStatsRepository_RemoveExpiredActionTokens_Call) RunAndReturn(run func(context1 context.Context, time1 time.Time) (int, error)) *MockStatsRepository_RemoveExpiredActionTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6096,20 +10949,37 @@ func (_c *MockStatsRepository_RemoveServers_Call) RunAndReturn(run func(context1
 }
 
 // ReportRoom provides a mock function for the type MockStatsRepository
-func (_mock *MockStatsRepository) ReportRoom(context1 context.Context, s string, s1 string, s2 string) error {
-	ret := _mock.Called(context1, s, s1, s2)
+func (_mock *MockStatsRepository) ReportRoom(context1 context.Context, s string, roomReport *model.RoomReport) (*model.RoomReports, bool, error) {
+	ret := _mock.Called(context1, s, roomReport)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReportRoom")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = returnFunc(context1, s, s1, s2)
+	var r0 *model.RoomReports
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.RoomReport) (*model.RoomReports, bool, error)); ok {
+		return returnFunc(context1, s, roomReport)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.RoomReport) *model.RoomReports); ok {
+		r0 = returnFunc(context1, s, roomReport)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RoomReports)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *model.RoomReport) bool); ok {
+		r1 = returnFunc(context1, s, roomReport)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, *model.RoomReport) error); ok {
+		r2 = returnFunc(context1, s, roomReport)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // MockStatsRepository_ReportRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReportRoom'
@@ -6120,13 +10990,12 @@ type MockStatsRepository_ReportRoom_Call struct {
 // ReportRoom is a helper method to define mock.On call
 //   - context1 context.Context
 //   - s string
-//   - s1 string
-//   - s2 string
-func (_e *MockStatsRepository_Expecter) ReportRoom(context1 interface{}, s interface{}, s1 interface{}, s2 interface{}) *MockStatsRepository_ReportRoom_Call {
-	return &MockStatsRepository_ReportRoom_Call{Call: _e.mock.On("ReportRoom", context1, s, s1, s2)}
+//   - roomReport *model.RoomReport
+func (_e *MockStatsRepository_Expecter) ReportRoom(context1 interface{}, s interface{}, roomReport interface{}) *MockStatsRepository_ReportRoom_Call {
+	return &MockStatsRepository_ReportRoom_Call{Call: _e.mock.On("ReportRoom", context1, s, roomReport)}
 }
 
-func (_c *MockStatsRepository_ReportRoom_Call) Run(run func(context1 context.Context, s string, s1 string, s2 string)) *MockStatsRepository_ReportRoom_Call {
+func (_c *MockStatsRepository_ReportRoom_Call) Run(run func(context1 context.Context, s string, roomReport *model.RoomReport)) *MockStatsRepository_ReportRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6136,30 +11005,82 @@ func (_c *MockStatsRepository_ReportRoom_Call) Run(run func(context1 context.Con
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *model.RoomReport
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg2 = args[2].(*model.RoomReport)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStatsRepository_ReportRoom_Call) Return(err error) *MockStatsRepository_ReportRoom_Call {
+func (_c *MockStatsRepository_ReportRoom_Call) Return(roomReports *model.RoomReports, b bool, err error) *MockStatsRepository_ReportRoom_Call {
+	_c.Call.Return(roomReports, b, err)
+	return _c
+}
+
+func (_c *MockStatsRepository_ReportRoom_Call) RunAndReturn(run func(context1 context.Context, s string, roomReport *model.RoomReport) (*model.RoomReports, bool, error)) *MockStatsRepository_ReportRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveAppeal provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) SaveAppeal(context1 context.Context, appeal *model.Appeal) error {
+	ret := _mock.Called(context1, appeal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAppeal")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Appeal) error); ok {
+		r0 = returnFunc(context1, appeal)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatsRepository_SaveAppeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveAppeal'
+type MockStatsRepository_SaveAppeal_Call struct {
+	*mock.Call
+}
+
+// SaveAppeal is a helper method to define mock.On call
+//   - context1 context.Context
+//   - appeal *model.Appeal
+func (_e *MockStatsRepository_Expecter) SaveAppeal(context1 interface{}, appeal interface{}) *MockStatsRepository_SaveAppeal_Call {
+	return &MockStatsRepository_SaveAppeal_Call{Call: _e.mock.On("SaveAppeal", context1, appeal)}
+}
+
+func (_c *MockStatsRepository_SaveAppeal_Call) Run(run func(context1 context.Context, appeal *model.Appeal)) *MockStatsRepository_SaveAppeal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Appeal
+		if args[1] != nil {
+			arg1 = args[1].(*model.Appeal)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_SaveAppeal_Call) Return(err error) *MockStatsRepository_SaveAppeal_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStatsRepository_ReportRoom_Call) RunAndReturn(run func(context1 context.Context, s string, s1 string, s2 string) error) *MockStatsRepository_ReportRoom_Call {
+func (_c *MockStatsRepository_SaveAppeal_Call) RunAndReturn(run func(context1 context.Context, appeal *model.Appeal) error) *MockStatsRepository_SaveAppeal_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6803,6 +11724,69 @@ func (_c *MockStatsRepository_SetIndexStatsTL_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
+// SetSpaceChildren provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) SetSpaceChildren(context1 context.Context, s string, matrixSpaceChilds []*model.MatrixSpaceChild) error {
+	ret := _mock.Called(context1, s, matrixSpaceChilds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSpaceChildren")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []*model.MatrixSpaceChild) error); ok {
+		r0 = returnFunc(context1, s, matrixSpaceChilds)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatsRepository_SetSpaceChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSpaceChildren'
+type MockStatsRepository_SetSpaceChildren_Call struct {
+	*mock.Call
+}
+
+// SetSpaceChildren is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - matrixSpaceChilds []*model.MatrixSpaceChild
+func (_e *MockStatsRepository_Expecter) SetSpaceChildren(context1 interface{}, s interface{}, matrixSpaceChilds interface{}) *MockStatsRepository_SetSpaceChildren_Call {
+	return &MockStatsRepository_SetSpaceChildren_Call{Call: _e.mock.On("SetSpaceChildren", context1, s, matrixSpaceChilds)}
+}
+
+func (_c *MockStatsRepository_SetSpaceChildren_Call) Run(run func(context1 context.Context, s string, matrixSpaceChilds []*model.MatrixSpaceChild)) *MockStatsRepository_SetSpaceChildren_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []*model.MatrixSpaceChild
+		if args[2] != nil {
+			arg2 = args[2].([]*model.MatrixSpaceChild)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_SetSpaceChildren_Call) Return(err error) *MockStatsRepository_SetSpaceChildren_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStatsRepository_SetSpaceChildren_Call) RunAndReturn(run func(context1 context.Context, s string, matrixSpaceChilds []*model.MatrixSpaceChild) error) *MockStatsRepository_SetSpaceChildren_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetStartedAt provides a mock function for the type MockStatsRepository
 func (_mock *MockStatsRepository) SetStartedAt(ctx context.Context, process string, startedAt time.Time) error {
 	ret := _mock.Called(ctx, process, startedAt)
@@ -6866,6 +11850,63 @@ func (_c *MockStatsRepository_SetStartedAt_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// SetTrendingRooms provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) SetTrendingRooms(context1 context.Context, intToTrendingRooms map[int][]*model.TrendingRoom) error {
+	ret := _mock.Called(context1, intToTrendingRooms)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTrendingRooms")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[int][]*model.TrendingRoom) error); ok {
+		r0 = returnFunc(context1, intToTrendingRooms)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatsRepository_SetTrendingRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTrendingRooms'
+type MockStatsRepository_SetTrendingRooms_Call struct {
+	*mock.Call
+}
+
+// SetTrendingRooms is a helper method to define mock.On call
+//   - context1 context.Context
+//   - intToTrendingRooms map[int][]*model.TrendingRoom
+func (_e *MockStatsRepository_Expecter) SetTrendingRooms(context1 interface{}, intToTrendingRooms interface{}) *MockStatsRepository_SetTrendingRooms_Call {
+	return &MockStatsRepository_SetTrendingRooms_Call{Call: _e.mock.On("SetTrendingRooms", context1, intToTrendingRooms)}
+}
+
+func (_c *MockStatsRepository_SetTrendingRooms_Call) Run(run func(context1 context.Context, intToTrendingRooms map[int][]*model.TrendingRoom)) *MockStatsRepository_SetTrendingRooms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[int][]*model.TrendingRoom
+		if args[1] != nil {
+			arg1 = args[1].(map[int][]*model.TrendingRoom)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_SetTrendingRooms_Call) Return(err error) *MockStatsRepository_SetTrendingRooms_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStatsRepository_SetTrendingRooms_Call) RunAndReturn(run func(context1 context.Context, intToTrendingRooms map[int][]*model.TrendingRoom) error) *MockStatsRepository_SetTrendingRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UnbanRoom provides a mock function for the type MockStatsRepository
 func (_mock *MockStatsRepository) UnbanRoom(context1 context.Context, s string) error {
 	ret := _mock.Called(context1, s)
@@ -7031,6 +12072,78 @@ func (_c *MockStatsRepository_UnreportRoom_Call) RunAndReturn(run func(context1
 	return _c
 }
 
+// UseActionToken provides a mock function for the type MockStatsRepository
+func (_mock *MockStatsRepository) UseActionToken(context1 context.Context, s string, time1 time.Time) (bool, error) {
+	ret := _mock.Called(context1, s, time1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseActionToken")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return returnFunc(context1, s, time1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = returnFunc(context1, s, time1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(context1, s, time1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatsRepository_UseActionToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseActionToken'
+type MockStatsRepository_UseActionToken_Call struct {
+	*mock.Call
+}
+
+// UseActionToken is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+//   - time1 time.Time
+func (_e *MockStatsRepository_Expecter) UseActionToken(context1 interface{}, s interface{}, time1 interface{}) *MockStatsRepository_UseActionToken_Call {
+	return &MockStatsRepository_UseActionToken_Call{Call: _e.mock.On("UseActionToken", context1, s, time1)}
+}
+
+func (_c *MockStatsRepository_UseActionToken_Call) Run(run func(context1 context.Context, s string, time1 time.Time)) *MockStatsRepository_UseActionToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRepository_UseActionToken_Call) Return(b bool, err error) *MockStatsRepository_UseActionToken_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockStatsRepository_UseActionToken_Call) RunAndReturn(run func(context1 context.Context, s string, time1 time.Time) (bool, error)) *MockStatsRepository_UseActionToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockLenable creates a new instance of MockLenable. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockLenable(t interface {
@@ -3,11 +3,17 @@ package services
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/etkecc/go-apm"
 	"github.com/etkecc/go-kit"
@@ -20,6 +26,9 @@ import (
 type EmailService interface {
 	SendReport(ctx context.Context, room *model.MatrixRoom, server *model.MatrixServer, reason string, emails []string) error
 	SendModReport(text, email string) error
+	SendModAppeal(text, email string) error
+	SendAppealVerification(ctx context.Context, appeal *model.Appeal, link string) error
+	SendAppealDecision(ctx context.Context, appeal *model.Appeal) error
 }
 
 type matrixService interface {
@@ -33,7 +42,13 @@ type Moderation struct {
 	media  MediaService
 	mail   EmailService
 	index  IndexRepository
+	block  banRulesBlocklist
 	matrix matrixService
+
+	policyRules atomic.Pointer[[]*model.PolicyRule] // imported from policy lists, see ImportPolicies
+	policyStats atomic.Pointer[[]*model.PolicyStats]
+	flags       atomic.Pointer[compiledFlags]
+	flagStats   atomic.Pointer[[]*model.FlagStats]
 }
 
 // webhookPayload for hookshot
@@ -44,18 +59,19 @@ type webhookPayload struct {
 }
 
 // NewModeration service
-func NewModeration(cfg ConfigService, data DataRepository, media MediaService, index IndexRepository, mail EmailService, matrix matrixService) *Moderation {
+func NewModeration(cfg ConfigService, data DataRepository, media MediaService, index IndexRepository, block banRulesBlocklist, mail EmailService, matrix matrixService) *Moderation {
 	return &Moderation{
 		cfg:    cfg,
 		data:   data,
 		mail:   mail,
 		media:  media,
 		index:  index,
+		block:  block,
 		matrix: matrix,
 	}
 }
 
-func (m *Moderation) getReportText(ctx context.Context, roomID, reason, fromIP string, room *model.MatrixRoom, server *model.MatrixServer) string {
+func (m *Moderation) getReportText(ctx context.Context, roomID string, reports *model.RoomReports, fromIP string, room *model.MatrixRoom, server *model.MatrixServer) string {
 	log := apm.Log(ctx)
 	var roomtxt string
 	roomb, err := json.MarshalIndent(room, "", "    ")
@@ -78,8 +94,20 @@ func (m *Moderation) getReportText(ctx context.Context, roomID, reason, fromIP s
 	text.WriteString(fromIP)
 	text.WriteString("\n")
 
+	latest := reports.Latest()
+	text.WriteString("* Category: ")
+	text.WriteString(latest.Category)
+	text.WriteString("\n")
+
 	text.WriteString("* Reason: ")
-	text.WriteString(reason)
+	text.WriteString(latest.Reason)
+	text.WriteString("\n")
+
+	text.WriteString("* Reports: ")
+	text.WriteString(strconv.Itoa(reports.Count))
+	text.WriteString(" (priority ")
+	text.WriteString(strconv.Itoa(reports.Priority))
+	text.WriteString(")")
 
 	text.WriteString("\n\n---\n\n")
 
@@ -97,10 +125,15 @@ func (m *Moderation) getReportText(ctx context.Context, roomID, reason, fromIP s
 		return text.String()
 	}
 
-	text.WriteString("[ban and erase](")
-	text.WriteString(apiURL.JoinPath("/mod/ban", roomID).String())
+	// the action links open a confirmation step, a link previewer following them bans nothing
+	text.WriteString("[quarantine](")
+	text.WriteString(m.actionLink(apiURL, model.AuditQuarantine, roomID))
+	text.WriteString(") | [ban and erase](")
+	text.WriteString(m.actionLink(apiURL, model.AuditBan, roomID))
 	text.WriteString(") | [unban](")
-	text.WriteString(apiURL.JoinPath("/mod/unban", roomID).String())
+	text.WriteString(m.actionLink(apiURL, model.AuditUnban, roomID))
+	text.WriteString(") | [dismiss](")
+	text.WriteString(m.actionLink(apiURL, model.AuditUnreport, roomID))
 	text.WriteString(") | [list banned (all)](")
 	text.WriteString(apiURL.JoinPath("/mod/list").String())
 	text.WriteString(") | [list banned (" + server.Name + ")](")
@@ -135,15 +168,15 @@ func (m *Moderation) getServerContactsText(contacts model.MatrixServerContacts)
 	return text.String()
 }
 
-// sendWebhook sends a report to the configured webhook
-func (m *Moderation) sendWebhook(ctx context.Context, room *model.MatrixRoom, server *model.MatrixServer, fromIP, reason string) error {
+// sendWebhook sends a notification (report or appeal) to the configured webhook
+func (m *Moderation) sendWebhook(ctx context.Context, text string) error {
 	if m.cfg.Get().Webhooks.Moderation == "" {
 		return nil
 	}
 
 	payload, err := json.Marshal(webhookPayload{
 		Username: m.cfg.Get().Matrix.ServerName,
-		Markdown: m.getReportText(ctx, room.ID, reason, fromIP, room, server),
+		Markdown: text,
 	})
 	if err != nil {
 		return err
@@ -167,22 +200,17 @@ func (m *Moderation) sendWebhook(ctx context.Context, room *model.MatrixRoom, se
 }
 
 // sendEmail sends a report to the configured moderators' email
-func (m *Moderation) sendEmail(ctx context.Context, room *model.MatrixRoom, server *model.MatrixServer, reason, fromIP string) error {
+func (m *Moderation) sendEmail(text string) error {
 	if m.cfg.Get().Email.Moderation == "" {
 		return nil
 	}
-	text := m.getReportText(ctx, room.ID, reason, fromIP, room, server)
 	return m.mail.SendModReport(text, m.cfg.Get().Email.Moderation)
 }
 
-// Report a room
-func (m *Moderation) Report(ctx context.Context, fromIP, roomID, reason string, noMSC1929 bool) error {
+// Report a room. Every reporter is counted, but moderators and the room's server are only notified
+// about the first report and reports of a more severe category than the room had so far
+func (m *Moderation) Report(ctx context.Context, fromIP, roomID, category, reason string, noMSC1929 bool) error {
 	log := apm.Log(ctx).With().Str("room", roomID).Str("fromIP", fromIP).Logger()
-	if m.data.IsReported(ctx, roomID) {
-		log.Warn().Msg("room already reported")
-		return nil
-	}
-
 	room, err := m.data.GetRoom(ctx, roomID)
 	if err != nil {
 		log.Warn().Err(err).Msg("cannot get room to report")
@@ -199,6 +227,20 @@ func (m *Moderation) Report(ctx context.Context, fromIP, roomID, reason string,
 		room = entry.Convert("")
 	}
 
+	reports, escalated, err := m.data.ReportRoom(ctx, roomID, &model.RoomReport{
+		At:       time.Now().UTC(),
+		Category: category,
+		Reason:   reason,
+		Reporter: m.reporterHash(fromIP),
+	})
+	if err != nil {
+		return err
+	}
+	if !escalated {
+		log.Info().Int("reports", reports.Count).Msg("room already reported")
+		return nil
+	}
+
 	serverName := room.GetOwnServer()
 	server, err := m.data.GetServerInfo(ctx, serverName)
 	if err != nil {
@@ -208,7 +250,8 @@ func (m *Moderation) Report(ctx context.Context, fromIP, roomID, reason string,
 		server = &model.MatrixServer{Name: serverName}
 	}
 
-	if err := m.sendWebhook(ctx, room, server, reason, fromIP); err != nil {
+	text := m.getReportText(ctx, room.ID, reports, fromIP, room, server)
+	if err := m.sendWebhook(ctx, text); err != nil {
 		log.Error().Err(err).Msg("cannot send moderation webhook")
 	}
 
@@ -224,43 +267,86 @@ func (m *Moderation) Report(ctx context.Context, fromIP, roomID, reason string,
 		}
 	}
 
-	if err := m.sendEmail(ctx, room, server, reason, fromIP); err != nil {
+	if err := m.sendEmail(text); err != nil {
 		log.Error().Err(err).Msg("cannot send moderation email")
 	}
+	return nil
+}
+
+// reporterHash returns a hash of the reporter's IP, enough to tell reporters apart without storing their IPs.
+// The hash is keyed with the instance's secret, so it cannot be reversed by hashing the whole IPv4 space
+func (m *Moderation) reporterHash(fromIP string) string {
+	mac := hmac.New(sha256.New, m.secret())
+	mac.Write([]byte(fromIP))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
 
-	return m.data.ReportRoom(ctx, fromIP, roomID, reason)
+// secret returns the instance's secret behind reporter hashes, action tokens, and appeal tokens.
+// It's a config value of its own: the federation key is shared with workers and rotated for other reasons
+func (m *Moderation) secret() []byte {
+	if cfg := m.cfg.Get(); cfg != nil && cfg.Secret != "" {
+		return []byte(cfg.Secret)
+	}
+	return nil
 }
 
-// Unreport a room
-func (m *Moderation) Unreport(ctx context.Context, roomID string) error {
+// Unreport a room, or all of them if roomID is empty
+func (m *Moderation) Unreport(ctx context.Context, actor, roomID, reason string) error {
 	log := apm.Log(ctx).With().Str("room", roomID).Logger()
 	if roomID == "" {
-		return m.data.UnreportAll(ctx)
+		reports, err := m.data.GetReportedRooms(ctx)
+		if err != nil {
+			return err
+		}
+		if err := m.data.UnreportAll(ctx); err != nil {
+			return err
+		}
+		// one entry per room, "who cleared this report" must be answerable for any room
+		for reportedID, roomReports := range reports {
+			state := &model.AuditState{Banned: m.data.IsBanned(ctx, reportedID)}
+			state.SetReports(roomReports)
+			if err := m.audit(ctx, actor, model.AuditUnreport, reportedID, reason, state); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 
 	if !m.data.IsReported(ctx, roomID) {
 		log.Warn().Msg("room not reported")
 		return nil
 	}
+	state := m.auditState(ctx, roomID)
 	if err := m.data.UnreportRoom(ctx, roomID); err != nil {
 		log.Error().Err(err).Msg("cannot unreport room")
 		return err
 	}
-	return nil
+	return m.audit(ctx, actor, model.AuditUnreport, roomID, reason, state)
 }
 
-// List returns full list of the banned rooms (optionally from specific server)
-func (m *Moderation) List(ctx context.Context, serverName ...string) ([]string, error) {
-	return m.data.GetBannedRooms(ctx, serverName...)
+// List returns ban records of the banned rooms (optionally from specific server)
+func (m *Moderation) List(ctx context.Context, serverName ...string) ([]*model.RoomBan, error) {
+	return m.data.GetRoomBans(ctx, serverName...)
 }
 
-// ListReported returns full list of the reported rooms (optionally from specific server)
-func (m *Moderation) ListReported(ctx context.Context, serverName ...string) (map[string]string, error) {
-	return m.data.GetReportedRooms(ctx, serverName...)
+// ListReported returns reports of the reported rooms (optionally from specific server), most urgent first
+func (m *Moderation) ListReported(ctx context.Context, serverName ...string) ([]*model.RoomReports, error) {
+	reports, err := m.data.GetReportedRooms(ctx, serverName...)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*model.RoomReports, 0, len(reports))
+	for _, roomReports := range reports {
+		list = append(list, roomReports)
+	}
+	model.SortRoomReports(list)
+	return list, nil
 }
 
-// Ban a room
-func (m *Moderation) Ban(ctx context.Context, roomID string) error {
+// Ban a room. The ban's moderator, reason, category, and expiry come from the caller,
+// the category defaults to the most severe category of the room's reports
+func (m *Moderation) Ban(ctx context.Context, ban *model.RoomBan) error {
+	roomID := ban.RoomID
 	log := apm.Log(ctx).With().Str("room", roomID).Logger()
 	room, err := m.data.GetRoom(ctx, roomID)
 	if err != nil {
@@ -278,7 +364,21 @@ func (m *Moderation) Ban(ctx context.Context, roomID string) error {
 	if room == nil {
 		room = &model.MatrixRoom{ID: roomID}
 	}
-	if err := m.data.BanRoom(ctx, roomID); err != nil {
+	state := m.auditState(ctx, roomID)
+	ban.CreatedAt = time.Now().UTC()
+	if ban.Category == "" {
+		ban.Category = state.Category
+	}
+	if ban.Category == "" {
+		ban.Category = model.ReportOther
+	}
+	if ban.Email == "" {
+		ban.Email = room.Email
+	}
+	if err := m.data.BanRoom(ctx, ban); err != nil {
+		return err
+	}
+	if err := m.audit(ctx, ban.Moderator, model.AuditBan, roomID, ban.Reason, state); err != nil {
 		return err
 	}
 	m.data.RemoveRoomMapping(ctx, room.ID, room.Alias)
@@ -298,7 +398,134 @@ func (m *Moderation) Ban(ctx context.Context, roomID string) error {
 	return nil
 }
 
+// removeRooms removes the rooms matched by rules from the db, the room mapping, and the index, the same way Ban does
+func (m *Moderation) removeRooms(ctx context.Context, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	for _, id := range ids { // before RemoveRooms, which drops only the ID -> alias half of the mapping
+		m.data.RemoveRoomMapping(ctx, id, m.data.GetRoomMapping(ctx, id))
+	}
+	m.data.RemoveRooms(ctx, ids)
+	for _, id := range ids {
+		if err := m.index.Delete(id); err != nil {
+			apm.Log(ctx).Warn().Err(err).Str("room", id).Msg("cannot remove room from the index")
+		}
+	}
+}
+
 // Unban a room
-func (m *Moderation) Unban(ctx context.Context, roomID string) error {
-	return m.data.UnbanRoom(ctx, roomID)
+func (m *Moderation) Unban(ctx context.Context, actor, roomID, reason string) error {
+	state := m.auditState(ctx, roomID)
+	if err := m.data.UnbanRoom(ctx, roomID); err != nil {
+		return err
+	}
+	return m.audit(ctx, actor, model.AuditUnban, roomID, reason, state)
+}
+
+// Quarantine hides the room from search and directory results, but keeps its data, so it can be released or banned later
+func (m *Moderation) Quarantine(ctx context.Context, quarantine *model.RoomQuarantine) error {
+	roomID := quarantine.RoomID
+	state := m.auditState(ctx, roomID)
+	if state.Banned {
+		apm.Log(ctx).Warn().Str("room", roomID).Msg("room is banned, nothing to quarantine")
+		return nil
+	}
+	quarantine.CreatedAt = time.Now().UTC()
+	if err := m.data.QuarantineRoom(ctx, quarantine); err != nil {
+		return err
+	}
+	if err := m.audit(ctx, quarantine.Moderator, model.AuditQuarantine, roomID, quarantine.Reason, state); err != nil {
+		return err
+	}
+	return m.index.Delete(roomID)
+}
+
+// Release lifts the quarantine and puts the room back to the index right away, no need to wait for the next parsing
+func (m *Moderation) Release(ctx context.Context, actor, roomID, reason string) error {
+	state := m.auditState(ctx, roomID)
+	if state.Quarantine == nil {
+		apm.Log(ctx).Warn().Str("room", roomID).Msg("room is not quarantined")
+		return nil
+	}
+	if err := m.data.ReleaseRoom(ctx, roomID); err != nil {
+		return err
+	}
+	if err := m.audit(ctx, actor, model.AuditRelease, roomID, reason, state); err != nil {
+		return err
+	}
+
+	room, err := m.data.GetRoom(ctx, roomID)
+	if err != nil || room == nil {
+		return err
+	}
+	return m.index.Index(roomID, room.Entry())
+}
+
+// ListQuarantined returns quarantine records of the quarantined rooms (optionally from specific server)
+func (m *Moderation) ListQuarantined(ctx context.Context, serverName ...string) ([]*model.RoomQuarantine, error) {
+	return m.data.GetQuarantinedRooms(ctx, serverName...)
+}
+
+// UnbanExpired lifts temporary bans whose time is up
+func (m *Moderation) UnbanExpired(ctx context.Context) {
+	log := apm.Log(ctx)
+	bans, err := m.data.GetRoomBans(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("cannot get ban records")
+		return
+	}
+	now := time.Now().UTC()
+	for _, ban := range bans {
+		if !ban.IsExpired(now) {
+			continue
+		}
+		if err := m.Unban(ctx, model.AuditActorSystem, ban.RoomID, "ban expired"); err != nil {
+			log.Error().Err(err).Str("room", ban.RoomID).Msg("cannot lift expired ban")
+			continue
+		}
+		log.Info().Str("room", ban.RoomID).Msg("expired ban lifted")
+	}
+}
+
+// AuditLog returns moderation actions matching the filter, newest first
+func (m *Moderation) AuditLog(ctx context.Context, filter *model.AuditFilter) ([]*model.AuditEntry, error) {
+	return m.data.GetAuditLog(ctx, filter)
+}
+
+// auditState returns the moderation state of the room, to be recorded before an action changes it
+func (m *Moderation) auditState(ctx context.Context, roomID string) *model.AuditState {
+	state := &model.AuditState{}
+	ban, err := m.data.GetRoomBan(ctx, roomID)
+	if err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("room", roomID).Msg("cannot get ban record of the room")
+	}
+	state.Banned, state.Ban = ban != nil, ban
+	if state.Quarantine, err = m.data.GetRoomQuarantine(ctx, roomID); err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("room", roomID).Msg("cannot get quarantine record of the room")
+	}
+	reports, err := m.data.GetRoomReports(ctx, roomID)
+	if err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("room", roomID).Msg("cannot get reports of the room")
+		return state
+	}
+	state.SetReports(reports)
+	return state
+}
+
+// audit records the action in the audit log. The action is already done by then,
+// but a failed audit entry is returned as an error anyway, so the moderator knows the log has a gap
+func (m *Moderation) audit(ctx context.Context, actor, action, target, reason string, state *model.AuditState) error {
+	err := m.data.AddAuditEntry(ctx, &model.AuditEntry{
+		At:       time.Now().UTC(),
+		Actor:    actor,
+		Action:   action,
+		Target:   target,
+		Reason:   reason,
+		Previous: state,
+	})
+	if err != nil {
+		apm.Log(ctx).Error().Err(err).Str("action", action).Str("room", target).Msg("cannot add audit entry")
+	}
+	return err
 }
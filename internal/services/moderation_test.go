@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// clearing all reports at once still leaves a trace per room, with the report that was cleared.
+func TestUnreportAll_Audit(t *testing.T) {
+	data := NewMockDataRepository(t)
+	data.EXPECT().GetReportedRooms(mock.Anything).Return(map[string]*model.RoomReports{
+		"!a:one.example": {Reports: []*model.RoomReport{{Category: model.ReportSpam, Reason: "spam"}}},
+		"!b:two.example": {Reports: []*model.RoomReport{{Category: model.ReportOther, Reason: "scam"}}},
+	}, nil)
+	data.EXPECT().UnreportAll(mock.Anything).Return(nil).Once()
+	data.EXPECT().IsBanned(mock.Anything, mock.Anything).Return(false)
+	entries := []*model.AuditEntry{}
+	data.EXPECT().AddAuditEntry(mock.Anything, mock.Anything).Run(func(_ context.Context, entry *model.AuditEntry) {
+		entries = append(entries, entry)
+	}).Return(nil)
+
+	m := NewModeration(nil, data, nil, nil, nil, nil, nil)
+	if err := m.Unreport(context.Background(), "alice", "", "cleanup"); err != nil {
+		t.Fatalf("Unreport() = %v", err)
+	}
+
+	reports := []string{}
+	for _, entry := range entries {
+		if entry.Actor != "alice" || entry.Action != model.AuditUnreport || entry.Reason != "cleanup" || entry.At.IsZero() || !entry.Previous.Reported {
+			t.Errorf("entry = %+v", entry)
+		}
+		reports = append(reports, entry.Target+" "+entry.Previous.Report)
+	}
+	slices.Sort(reports)
+	if want := []string{"!a:one.example spam", "!b:two.example scam"}; !slices.Equal(reports, want) {
+		t.Errorf("audited %v, want %v", reports, want)
+	}
+}
+
+// only bans whose time is up are lifted, and the audit log says who lifted them and what the ban was.
+func TestUnbanExpired(t *testing.T) {
+	data := NewMockDataRepository(t)
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	expired := &model.RoomBan{RoomID: "!expired:one.example", Reason: "cool down", ExpiresAt: &past}
+	data.EXPECT().GetRoomBans(mock.Anything).Return([]*model.RoomBan{
+		expired,
+		{RoomID: "!temporary:one.example", ExpiresAt: &future},
+		{RoomID: "!permanent:one.example"},
+	}, nil)
+	data.EXPECT().GetRoomBan(mock.Anything, "!expired:one.example").Return(expired, nil)
+	data.EXPECT().GetRoomQuarantine(mock.Anything, "!expired:one.example").Return(nil, nil)
+	data.EXPECT().GetRoomReports(mock.Anything, "!expired:one.example").Return(nil, nil)
+	data.EXPECT().UnbanRoom(mock.Anything, "!expired:one.example").Return(nil).Once()
+	var entry *model.AuditEntry
+	data.EXPECT().AddAuditEntry(mock.Anything, mock.Anything).Run(func(_ context.Context, e *model.AuditEntry) { entry = e }).Return(nil).Once()
+
+	m := NewModeration(nil, data, nil, nil, nil, nil, nil)
+	m.UnbanExpired(context.Background())
+
+	if entry == nil || entry.Actor != model.AuditActorSystem || entry.Action != model.AuditUnban || entry.Previous.Ban != expired {
+		t.Errorf("audit entry = %+v", entry)
+	}
+}
+
+// a quarantined room leaves the index but keeps its data, and comes back to the index the moment it is released.
+func TestQuarantine_Release(t *testing.T) {
+	data := NewMockDataRepository(t)
+	index := NewMockIndexRepository(t)
+	room := &model.MatrixRoom{ID: "!r:one.example", Name: "Room"}
+	var quarantined *model.RoomQuarantine
+	data.EXPECT().GetRoomBan(mock.Anything, room.ID).Return(nil, nil)
+	data.EXPECT().GetRoomQuarantine(mock.Anything, room.ID).RunAndReturn(func(_ context.Context, _ string) (*model.RoomQuarantine, error) {
+		return quarantined, nil
+	})
+	data.EXPECT().GetRoomReports(mock.Anything, room.ID).Return(nil, nil)
+	data.EXPECT().QuarantineRoom(mock.Anything, mock.Anything).Run(func(_ context.Context, q *model.RoomQuarantine) { quarantined = q }).Return(nil).Once()
+	data.EXPECT().ReleaseRoom(mock.Anything, room.ID).Return(nil).Once()
+	data.EXPECT().GetRoom(mock.Anything, room.ID).Return(room, nil).Once()
+	entries := []*model.AuditEntry{}
+	data.EXPECT().AddAuditEntry(mock.Anything, mock.Anything).Run(func(_ context.Context, entry *model.AuditEntry) {
+		entries = append(entries, entry)
+	}).Return(nil)
+	index.EXPECT().Delete(room.ID).Return(nil).Once()
+	index.EXPECT().Index(room.ID, mock.Anything).Return(nil).Once()
+
+	m := NewModeration(nil, data, nil, index, nil, nil, nil)
+	ctx := context.Background()
+	if err := m.Quarantine(ctx, &model.RoomQuarantine{RoomID: room.ID, Reason: "looks odd", Moderator: "alice"}); err != nil {
+		t.Fatalf("Quarantine() = %v", err)
+	}
+	if err := m.Release(ctx, "bob", room.ID, "fine after all"); err != nil {
+		t.Fatalf("Release() = %v", err)
+	}
+
+	if len(entries) != 2 || entries[0].Action != model.AuditQuarantine || entries[1].Action != model.AuditRelease || entries[1].Previous.Quarantine.Moderator != "alice" {
+		t.Errorf("audit entries = %+v", entries)
+	}
+}
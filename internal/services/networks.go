@@ -0,0 +1,52 @@
+package services
+
+import (
+	"slices"
+
+	"github.com/etkecc/go-kit"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// directoryNetwork is a room directory to crawl on a server, and the network to tag the rooms found there with
+type directoryNetwork struct {
+	query model.RoomDirectoryNetwork
+	tag   string // empty for the server's own directory, native matrix rooms
+}
+
+// String is for logs
+func (n directoryNetwork) String() string {
+	if n.tag == "" {
+		return model.NetworkMatrix
+	}
+	return n.tag
+}
+
+// directoryNetworks returns directories to crawl on the server: its own first, then the configured third-party instances,
+// then include_all_networks, so a room listed in several of them keeps the most precise tag
+func directoryNetworks(cfg *model.ConfigNetworks, name string) []directoryNetwork {
+	networks := []directoryNetwork{{}}
+	if !cfg.IsEnabled() {
+		return networks
+	}
+
+	instances := cfg.Instances[name]
+	ids := kit.MapKeys(instances)
+	slices.Sort(ids)
+	for _, id := range ids {
+		if id == "" || instances[id] == "" {
+			continue
+		}
+		networks = append(networks, directoryNetwork{
+			query: model.RoomDirectoryNetwork{ThirdPartyInstanceID: id},
+			tag:   instances[id],
+		})
+	}
+	if cfg.All {
+		networks = append(networks, directoryNetwork{
+			query: model.RoomDirectoryNetwork{IncludeAllNetworks: true},
+			tag:   model.NetworkThirdParty,
+		})
+	}
+	return networks
+}
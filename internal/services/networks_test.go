@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// the server's own directory always goes first and include_all_networks last: whoever lists a room first tags it.
+func TestDirectoryNetworks(t *testing.T) {
+	cfg := &model.ConfigNetworks{
+		All: true,
+		Instances: map[string]map[string]string{
+			"example.com": {"xmpp|jabber": "xmpp", "irc|libera": "irc-libera", "irc|broken": ""},
+		},
+	}
+	tests := []struct {
+		name   string
+		cfg    *model.ConfigNetworks
+		server string
+		want   []string
+	}{
+		{"not configured", nil, "example.com", []string{"matrix"}},
+		{"instances and all", cfg, "example.com", []string{"matrix", "irc-libera", "xmpp", "thirdparty"}},
+		{"all only for others", cfg, "other.com", []string{"matrix", "thirdparty"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, network := range directoryNetworks(tt.cfg, tt.server) {
+				got = append(got, network.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// a bridged directory tags its rooms, but a room the server's own directory already listed stays native.
+func TestGetPublicRooms_TagsNetworkSkipsSeen(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	fed := NewMockFederationService(t)
+	v := NewMockValidatorService(t)
+	data := NewMockDataRepository(t)
+	cfg.EXPECT().Get().Return(&model.Config{Matrix: &model.ConfigMatrix{ServerName: "mrs.example"}}).Maybe()
+
+	network := directoryNetwork{query: model.RoomDirectoryNetwork{ThirdPartyInstanceID: "irc|libera"}, tag: "irc-libera"}
+	resp := &model.RoomDirectoryResponse{
+		Chunk: []*model.RoomDirectoryRoom{
+			{ID: "!native:known.example", Topic: "(MRS-language:EN-MRS)", JoinRule: "public"},
+			{ID: "!bridged:known.example", Topic: "(MRS-language:EN-MRS)", JoinRule: "public"},
+		},
+	}
+	fed.EXPECT().QueryPublicRooms(mock.Anything, "known.example", mock.Anything, "", network.query).Return(resp, nil)
+	v.EXPECT().IsRoomAllowed(mock.Anything).Return(true)
+	var stored []*model.MatrixRoom
+	data.EXPECT().AddRoomBatch(mock.Anything, mock.Anything).Run(func(_ context.Context, room *model.MatrixRoom) {
+		stored = append(stored, room)
+	}).Return()
+
+	m := NewCrawler(cfg, fed, v, nil, nil, data, nil)
+	seen := map[string]struct{}{"!native:known.example": {}}
+	m.getPublicRooms(context.Background(), "known.example", "192.0.2.1", network, seen, nil)
+
+	if len(stored) != 1 || stored[0].ID != "!bridged:known.example" || stored[0].Network != "irc-libera" {
+		t.Fatalf("stored = %+v, want only the bridged room tagged irc-libera", stored)
+	}
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/etkecc/go-apm"
+	"github.com/etkecc/go-kit"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// pullPeerRooms adds rooms the peers have parsed since the last pull to the room batch. Runs before our own crawl,
+// so for servers both instances index our own (later flushed) version wins
+func (m *Crawler) pullPeerRooms(ctx context.Context) {
+	for _, peer := range m.cfg.Get().Peering.GetPeers() {
+		if !peer.Rooms {
+			continue
+		}
+		m.pullPeerRoomsFrom(ctx, peer.ServerName)
+	}
+}
+
+// pullPeerRoomsFrom pulls all pages of the peer's rooms feed. The next pull continues from the latest parsed_at seen
+// (peer's clock, not ours), but only after a complete pull: pages are sorted by room ID, not by time.
+// A peer vouches for nothing but the public directory fields of its rooms, the rest (contact email, language, avatar URL, alias)
+// is parsed and verified here, like for rooms MRS has crawled itself. Rooms of servers this instance doesn't know or may not index
+// are skipped, so a server opting out of this instance can't be indexed through a peer, and the server's policy applies as usual
+func (m *Crawler) pullPeerRoomsFrom(ctx context.Context, peer string) {
+	log := apm.Log(ctx).With().Str("peer", peer).Logger()
+	m.peersMu.Lock()
+	since := m.peersSince[peer]
+	m.peersMu.Unlock()
+
+	latest := since
+	var from string
+	var pulled, added int
+	servers := map[string]*model.MatrixServer{} // server name => stored info, nil if unknown
+	for {
+		resp, err := m.fed.QueryPeerRooms(ctx, peer, since, from)
+		if err != nil {
+			log.Warn().Err(err).Int("pulled", pulled).Msg("cannot pull peer rooms, will retry from the same point next time")
+			return
+		}
+		for _, peerRoom := range resp.Rooms {
+			pulled++
+			if peerRoom.ParsedAt.After(latest) {
+				latest = peerRoom.ParsedAt
+			}
+			room := peerRoom.DirectoryEntry().Convert(peerRoom.GetOwnServer())
+			server := m.peerRoomServer(ctx, servers, room.Server)
+			if server == nil || !server.Indexable || !m.v.IsRoomAllowed(room) {
+				continue
+			}
+			server.Policy.Apply(room)
+			if !room.Parse(m.detector, m.media, m.cfg.Get().Matrix.ServerName) {
+				continue
+			}
+			if aliasServers := m.verifyAlias(ctx, room); len(aliasServers) > 0 {
+				room.Servers = kit.Uniq(append(room.AllServers(), aliasServers...))
+			}
+			m.data.AddRoomBatch(ctx, room)
+			if room.AliasVerification.IsVerified() {
+				m.data.AddRoomMapping(ctx, room.ID, room.Alias) //nolint:errcheck // ignore error
+			}
+			added++
+		}
+		if resp.NextBatch == "" || len(resp.Rooms) == 0 {
+			break
+		}
+		from = resp.NextBatch
+	}
+
+	m.peersMu.Lock()
+	if m.peersSince == nil {
+		m.peersSince = map[string]time.Time{}
+	}
+	m.peersSince[peer] = latest
+	m.peersMu.Unlock()
+	log.Info().Int("pulled", pulled).Int("added", added).Time("since", since).Msg("pulled peer rooms")
+}
+
+// peerRoomServer returns the stored info of the peer room's server, looked up once per pull, nil if the server is unknown here
+func (m *Crawler) peerRoomServer(ctx context.Context, servers map[string]*model.MatrixServer, name string) *model.MatrixServer {
+	if server, ok := servers[name]; ok {
+		return server
+	}
+	server, err := m.data.GetServerInfo(ctx, name)
+	if err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("server", name).Msg("cannot get server info")
+	}
+	servers[name] = server
+	return server
+}
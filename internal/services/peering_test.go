@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// every page is pulled with the same since, the next pull moves to the latest parsed_at,
+// and a failed pull doesn't move it at all. Only the directory fields of the peer's rooms are taken, the rest is parsed here,
+// with the alias verified and the server's policy applied. Rooms of unknown or non-indexable servers are skipped.
+func TestPullPeerRoomsFrom(t *testing.T) {
+	ctx := context.Background()
+	parsed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	allowed := &model.MatrixRoom{
+		ID:                "!a:example.com",
+		Alias:             "#a:example.com",
+		Topic:             "Welcome (MRS-language:EN-MRS)",
+		ParsedAt:          parsed,
+		Email:             "attacker@evil.example",
+		Avatar:            "mxc://example.com/avatar",
+		AliasVerification: &model.MatrixRoomAlias{Alias: "#a:example.com", Status: "verified"},
+	}
+	blocked := &model.MatrixRoom{ID: "!b:blocked.com", Alias: "#b:blocked.com", ParsedAt: parsed.Add(time.Hour)}
+	optedOut := &model.MatrixRoom{ID: "!c:optout.com", Alias: "#c:optout.com", ParsedAt: parsed}
+	unknown := &model.MatrixRoom{ID: "!d:unknown.com", Alias: "#d:unknown.com", ParsedAt: parsed}
+	byID := func(id string) any {
+		return mock.MatchedBy(func(room *model.MatrixRoom) bool { return room.ID == id })
+	}
+
+	fed := NewMockFederationService(t)
+	fed.EXPECT().QueryPeerRooms(ctx, "peer.example.com", time.Time{}, "").
+		Return(&model.PeerRoomsResponse{Rooms: []*model.MatrixRoom{allowed, optedOut, unknown}, NextBatch: "!a:example.com"}, nil).Once()
+	fed.EXPECT().QueryPeerRooms(ctx, "peer.example.com", time.Time{}, "!a:example.com").
+		Return(&model.PeerRoomsResponse{Rooms: []*model.MatrixRoom{blocked}}, nil).Once()
+	fed.EXPECT().QueryDirectoryExternal(ctx, "#a:example.com").
+		Return(&model.QueryDirectoryResponse{RoomID: "!a:example.com", Servers: []string{"alias.example.com"}}, nil).Once()
+	v := NewMockValidatorService(t)
+	v.EXPECT().IsRoomAllowed(byID(allowed.ID)).Return(true)
+	v.EXPECT().IsRoomAllowed(byID(blocked.ID)).Return(false)
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{Matrix: &model.ConfigMatrix{ServerName: "mrs.example.com"}})
+	data := NewMockDataRepository(t)
+	data.EXPECT().GetServerInfo(ctx, "example.com").
+		Return(&model.MatrixServer{Name: "example.com", Indexable: true, Policy: &model.MatrixServerPolicy{NoindexAvatars: true}}, nil).Once()
+	data.EXPECT().GetServerInfo(ctx, "blocked.com").Return(&model.MatrixServer{Name: "blocked.com", Indexable: true}, nil).Once()
+	data.EXPECT().GetServerInfo(ctx, "optout.com").Return(&model.MatrixServer{Name: "optout.com"}, nil).Once()
+	data.EXPECT().GetServerInfo(ctx, "unknown.com").Return(nil, nil).Once()
+	data.EXPECT().AddRoomBatch(ctx, mock.MatchedBy(func(room *model.MatrixRoom) bool {
+		return room.ID == allowed.ID && room.Email == "" && room.AliasVerification.IsVerified() &&
+			room.Language == "EN" && room.Topic == "Welcome" && !room.ParsedAt.Equal(parsed) &&
+			room.Avatar == "" && slices.Contains(room.Servers, "alias.example.com")
+	})).Return().Once()
+	data.EXPECT().AddRoomMapping(ctx, allowed.ID, allowed.Alias).Return(nil).Once()
+	m := &Crawler{cfg: cfg, fed: fed, v: v, data: data}
+
+	m.pullPeerRoomsFrom(ctx, "peer.example.com")
+	if since := m.peersSince["peer.example.com"]; !since.Equal(blocked.ParsedAt) {
+		t.Fatalf("since = %v, want the latest parsed_at %v", since, blocked.ParsedAt)
+	}
+
+	fed.EXPECT().QueryPeerRooms(ctx, "peer.example.com", blocked.ParsedAt, "").Return(nil, errors.New("timeout")).Once()
+	m.pullPeerRoomsFrom(ctx, "peer.example.com")
+	if since := m.peersSince["peer.example.com"]; !since.Equal(blocked.ParsedAt) {
+		t.Errorf("since = %v after a failed pull, want it unchanged", since)
+	}
+	mock.AssertExpectationsForObjects(t, fed, v, data)
+}
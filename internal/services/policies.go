@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/etkecc/go-apm"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// PolicyStats returns stats of the last import of each policy list
+func (m *Moderation) PolicyStats() []*model.PolicyStats {
+	if stats := m.policyStats.Load(); stats != nil {
+		return *stats
+	}
+	return []*model.PolicyStats{}
+}
+
+// ImportPolicies pulls every configured policy list, hands the room and server bans to the blocklist,
+// and removes the rooms they match from the index. A list that cannot be pulled keeps its rules from the previous import.
+// A rule matching more rooms than the retention's max removal allows is skipped, a `*` entity is a typo, not a wish to empty the index
+func (m *Moderation) ImportPolicies(ctx context.Context) {
+	sources := m.cfg.Get().Policies
+	previous := m.policyRules.Load()
+	if len(sources) == 0 && previous == nil {
+		return
+	}
+
+	log := apm.Log(ctx)
+	rules := []*model.PolicyRule{}
+	stats := make([]*model.PolicyStats, 0, len(sources))
+	for _, source := range sources {
+		stat := &model.PolicyStats{Source: source.String(), At: time.Now().UTC()}
+		events, err := m.fetchPolicies(ctx, source)
+		if err != nil {
+			log.Warn().Err(err).Str("policy_list", stat.Source).Msg("cannot pull policy list, keeping its previous rules")
+			stat.Error = err.Error()
+			if previous != nil {
+				for _, rule := range *previous {
+					if rule.Source == stat.Source {
+						rules = append(rules, rule)
+						stat.Rules++
+					}
+				}
+			}
+		}
+		stat.Events = len(events)
+		for _, evt := range events {
+			if rule := model.NewPolicyRule(stat.Source, evt); rule != nil {
+				rules = append(rules, rule)
+				stat.Rules++
+			}
+		}
+		log.Info().Str("policy_list", stat.Source).Int("events", stat.Events).Int("rules", stat.Rules).Msg("pulled policy list")
+		stats = append(stats, stat)
+	}
+
+	rules, skipped, ids := m.limitPolicyRules(ctx, rules)
+	m.auditSkippedPolicyRules(ctx, skipped, stats)
+	m.block.SetPolicies(rules)
+	m.policyRules.Store(&rules)
+	m.policyStats.Store(&stats)
+	if len(ids) == 0 {
+		return
+	}
+
+	m.removeRooms(ctx, ids)
+	log.Info().Int("rules", len(rules)).Int("rooms", len(ids)).Msg("policy lists applied")
+}
+
+// limitPolicyRules splits the rules into the ones to apply and the ones matching too many rooms,
+// and returns the IDs of the rooms matched by the rules to apply
+func (m *Moderation) limitPolicyRules(ctx context.Context, rules []*model.PolicyRule) (applied, skipped []*model.PolicyRule, ids []string) {
+	if len(rules) == 0 {
+		return rules, nil, nil
+	}
+
+	var total int
+	matches := make([][]string, len(rules))
+	m.data.EachRoom(ctx, func(roomID string, room *model.MatrixRoom) bool {
+		total++
+		for i, rule := range rules {
+			if rule.MatchRoom(room) {
+				matches[i] = append(matches[i], roomID)
+			}
+		}
+		return false
+	})
+
+	maxRemoval := m.cfg.Get().Retention.GetMaxRemoval()
+	applied = make([]*model.PolicyRule, 0, len(rules))
+	seen := map[string]bool{}
+	for i, rule := range rules {
+		if exceedsMaxRemoval(len(matches[i]), total, maxRemoval) {
+			apm.Log(ctx).Error().
+				Str("policy_list", rule.Source).
+				Str("rule", rule.String()).
+				Int("count", len(matches[i])).
+				Int("of", total).
+				Int("max_removal", maxRemoval).
+				Msg("skipping policy rule, it matches too many rooms")
+			skipped = append(skipped, rule)
+			continue
+		}
+		applied = append(applied, rule)
+		for _, id := range matches[i] {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return applied, skipped, ids
+}
+
+// auditSkippedPolicyRules records the skipped rules in their sources' stats, and in the audit log
+// when a rule is skipped for the first time, not on every hourly import
+func (m *Moderation) auditSkippedPolicyRules(ctx context.Context, skipped []*model.PolicyRule, stats []*model.PolicyStats) {
+	previous := map[string]bool{}
+	if previousStats := m.policyStats.Load(); previousStats != nil {
+		for _, stat := range *previousStats {
+			for _, rule := range stat.Skipped {
+				previous[stat.Source+" "+rule] = true
+			}
+		}
+	}
+
+	for _, rule := range skipped {
+		for _, stat := range stats {
+			if stat.Source == rule.Source {
+				stat.Skipped = append(stat.Skipped, rule.String())
+			}
+		}
+		if previous[rule.Source+" "+rule.String()] {
+			continue
+		}
+		reason := "policy list " + rule.Source + ": the rule matches too many rooms"
+		m.audit(ctx, model.AuditActorSystem, model.AuditSkipPolicyRule, rule.String(), reason, nil) //nolint:errcheck // logged by audit
+	}
+}
+
+// fetchPolicies reads the policy events from the file or url
+func (m *Moderation) fetchPolicies(ctx context.Context, source *model.ConfigSeed) ([]*model.PolicyEvent, error) {
+	datab, err := readSeed(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	return model.ParsePolicyEvents(datab)
+}
+
+// ExportPolicies returns MRS's own bans as policy events: banned rooms and server ban rules.
+// Alias and text rules are regexes and have no place in a glob world, so they stay home
+func (m *Moderation) ExportPolicies(ctx context.Context) ([]*model.PolicyEvent, error) {
+	bans, err := m.data.GetRoomBans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := m.data.GetBanRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*model.PolicyEvent, 0, len(bans)+len(rules))
+	for _, ban := range bans {
+		events = append(events, model.NewPolicyEvent(model.PolicyRuleRoom, ban.RoomID, ban.Reason))
+	}
+	for _, rule := range rules {
+		if rule.Kind == model.BanRuleServer {
+			events = append(events, model.NewPolicyEvent(model.PolicyRuleServer, rule.Pattern, rule.Reason))
+		}
+	}
+	slices.SortFunc(events, func(a, b *model.PolicyEvent) int {
+		if a.Type != b.Type {
+			return strings.Compare(a.Type, b.Type)
+		}
+		return strings.Compare(a.StateKey, b.StateKey)
+	})
+	return events, nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// imported bans go to the blocklist and take the matching rooms out, and a list gone missing doesn't lift its bans.
+func TestImportPolicies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.json")
+	state := `[{"type": "m.policy.rule.server", "state_key": "rule:*.spam.example", "content": {"entity": "*.spam.example", "recommendation": "m.ban"}}]`
+	if err := os.WriteFile(path, []byte(state), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{
+		Blocklist: &model.ConfigBlocklist{},
+		Policies:  []*model.ConfigSeed{{Name: "community", Path: path}},
+	}).Maybe()
+	data := NewMockDataRepository(t)
+	index := NewMockIndexRepository(t)
+	block := NewBlocklist(cfg)
+
+	data.EXPECT().EachRoom(mock.Anything, mock.Anything).Run(func(_ context.Context, handler func(string, *model.MatrixRoom) bool) {
+		for _, room := range []*model.MatrixRoom{
+			{ID: "!a:chat.spam.example"},
+			{ID: "!b:one.example"},
+			{ID: "!c:one.example"},
+			{ID: "!d:two.example"},
+			{ID: "!e:two.example"},
+		} {
+			if handler(room.ID, room) {
+				return
+			}
+		}
+	}).Return()
+	data.EXPECT().GetRoomMapping(mock.Anything, "!a:chat.spam.example").Return("")
+	data.EXPECT().RemoveRoomMapping(mock.Anything, "!a:chat.spam.example", "").Return()
+	data.EXPECT().RemoveRooms(mock.Anything, []string{"!a:chat.spam.example"}).Return()
+	index.EXPECT().Delete("!a:chat.spam.example").Return(nil)
+
+	m := NewModeration(cfg, data, nil, index, block, nil, nil)
+	m.ImportPolicies(context.Background())
+	if !block.ByServer("chat.spam.example") || block.ByServer("one.example") {
+		t.Error("the policy list is not in the blocklist")
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	m.ImportPolicies(context.Background())
+	stats := m.PolicyStats()
+	if len(stats) != 1 || stats[0].Error == "" || stats[0].Rules != 1 {
+		t.Fatalf("PolicyStats() = %+v, want an error and the previous rule", stats)
+	}
+	if !block.ByID("!c:chat.spam.example") {
+		t.Error("the previous rules are gone")
+	}
+}
+
+// a rule matching most of the index is skipped and audited once, the rest of the list still applies.
+func TestImportPolicies_SkipsBroadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.json")
+	state := `[
+		{"type": "m.policy.rule.server", "state_key": "rule:*", "content": {"entity": "*", "recommendation": "m.ban"}},
+		{"type": "m.policy.rule.room", "state_key": "rule:!a:one.example", "content": {"entity": "!a:one.example", "recommendation": "m.ban"}}
+	]`
+	if err := os.WriteFile(path, []byte(state), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{
+		Blocklist: &model.ConfigBlocklist{},
+		Policies:  []*model.ConfigSeed{{Name: "community", Path: path}},
+	}).Maybe()
+	data := NewMockDataRepository(t)
+	index := NewMockIndexRepository(t)
+	block := NewBlocklist(cfg)
+
+	data.EXPECT().EachRoom(mock.Anything, mock.Anything).Run(func(_ context.Context, handler func(string, *model.MatrixRoom) bool) {
+		for _, id := range []string{"!a:one.example", "!b:one.example", "!c:two.example", "!d:two.example"} {
+			if handler(id, &model.MatrixRoom{ID: id}) {
+				return
+			}
+		}
+	}).Return()
+	data.EXPECT().GetRoomMapping(mock.Anything, "!a:one.example").Return("")
+	data.EXPECT().RemoveRoomMapping(mock.Anything, "!a:one.example", "").Return()
+	data.EXPECT().RemoveRooms(mock.Anything, []string{"!a:one.example"}).Return()
+	index.EXPECT().Delete("!a:one.example").Return(nil)
+	data.EXPECT().AddAuditEntry(mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditSkipPolicyRule && entry.Target == model.PolicyRuleServer+":*"
+	})).Return(nil).Once()
+
+	m := NewModeration(cfg, data, nil, index, block, nil, nil)
+	m.ImportPolicies(context.Background())
+	m.ImportPolicies(context.Background())
+	if block.ByServer("two.example") {
+		t.Error("the skipped rule is in the blocklist")
+	}
+	if stats := m.PolicyStats(); len(stats) != 1 || len(stats[0].Skipped) != 1 {
+		t.Errorf("PolicyStats() = %+v, want a skipped rule", stats)
+	}
+}
+
+func TestExportPolicies(t *testing.T) {
+	data := NewMockDataRepository(t)
+	data.EXPECT().GetRoomBans(mock.Anything).Return([]*model.RoomBan{{RoomID: "!a:one.example", Reason: "spam"}}, nil)
+	data.EXPECT().GetBanRules(mock.Anything).Return([]*model.BanRule{
+		{ID: "1", Kind: model.BanRuleServer, Pattern: "spam.example"},
+		{ID: "2", Kind: model.BanRuleText, Pattern: "(?i)airdrop"},
+	}, nil)
+
+	m := NewModeration(nil, data, nil, nil, nil, nil, nil)
+	events, err := m.ExportPolicies(context.Background())
+	if err != nil || len(events) != 2 {
+		t.Fatalf("ExportPolicies() = %+v, %v, want 2 events", events, err)
+	}
+	if events[0].Type != model.PolicyRuleRoom || events[0].Content.Entity != "!a:one.example" || events[0].Content.Reason != "spam" {
+		t.Errorf("events[0] = %+v", events[0])
+	}
+	if events[1].Type != model.PolicyRuleServer || events[1].StateKey != "rule:spam.example" {
+		t.Errorf("events[1] = %+v", events[1])
+	}
+}
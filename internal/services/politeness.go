@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+const (
+	defaultPerIP       = 4
+	defaultPageSize    = 10000
+	defaultMinPageSize = 100
+	// a page served faster than fastPage earns a bigger next one, a page slower than slowPage (or a failed one) halves it
+	fastPage = 10 * time.Second
+	slowPage = 60 * time.Second
+	// maxRetryAfterWait is the longest Retry-After we sit out within a cycle; anything longer skips the server until it expires
+	maxRetryAfterWait = 2 * time.Minute
+	// defaultRetryAfter is for a 429 that didn't bother to say how long
+	defaultRetryAfter = time.Minute
+	// maxRetryAfter caps what a 429 may ask for, a month-long Retry-After is a typo (or an overflow) and would shelve the server for good
+	maxRetryAfter = 24 * time.Hour
+	// maxLimitedRetries is how many 429s a single directory crawl sits out before it gives up on the server until the next cycle
+	maxLimitedRetries = 3
+)
+
+// pageOutcome is what a single publicRooms request taught us about the destination
+type pageOutcome int
+
+const (
+	pageFast pageOutcome = iota
+	pageOK
+	pageSlow
+	pageFailed
+	pageLimited
+)
+
+// politeness is the per-destination crawl scheduler: it remembers how big a page each server can stomach,
+// when a server told us to come back, and caps in-flight requests per resolved IP.
+// It lives as long as the crawler does, so whatever a server taught us last cycle still holds in the next one.
+type politeness struct {
+	mu    sync.Mutex
+	hosts map[string]*hostPoliteness
+	ips   map[string]*ipPoliteness
+}
+
+type hostPoliteness struct {
+	pageSize   int
+	retryAfter time.Time // set by 429, don't knock before that
+}
+
+type ipPoliteness struct {
+	inflight int
+	limit    int           // adaptive, 1..per_ip
+	wake     chan struct{} // closed and replaced on every release, so waiters re-check
+}
+
+func newPoliteness() *politeness {
+	return &politeness{
+		hosts: map[string]*hostPoliteness{},
+		ips:   map[string]*ipPoliteness{},
+	}
+}
+
+// politenessLimits returns configured per-IP concurrency and page size bounds, with defaults for the unset ones
+func politenessLimits(cfg *model.Config) (perIP, pageSize, minPageSize int) {
+	perIP, pageSize, minPageSize = defaultPerIP, defaultPageSize, defaultMinPageSize
+	if cfg == nil || cfg.Workers == nil {
+		return perIP, pageSize, minPageSize
+	}
+	if cfg.Workers.PerIP > 0 {
+		perIP = cfg.Workers.PerIP
+	}
+	if cfg.Workers.PageSize > 0 {
+		pageSize = cfg.Workers.PageSize
+	}
+	if cfg.Workers.MinPageSize > 0 {
+		minPageSize = cfg.Workers.MinPageSize
+	}
+	return perIP, pageSize, min(minPageSize, pageSize)
+}
+
+// classifyPage turns a request's latency and error into an outcome, with the requested wait for a 429
+func classifyPage(took time.Duration, err error) (outcome pageOutcome, retryAfter time.Duration) {
+	if err != nil {
+		var merr *model.MatrixError
+		if errors.As(err, &merr) && (merr.Code == "M_LIMIT_EXCEEDED" || strings.HasPrefix(merr.HTTP, "429")) {
+			retryAfter = defaultRetryAfter
+			if merr.RetryAfterMs > 0 {
+				retryAfter = time.Duration(min(merr.RetryAfterMs, maxRetryAfter.Milliseconds())) * time.Millisecond
+			}
+			return pageLimited, retryAfter
+		}
+		return pageFailed, 0
+	}
+	switch {
+	case took < fastPage:
+		return pageFast, 0
+	case took > slowPage:
+		return pageSlow, 0
+	default:
+		return pageOK, 0
+	}
+}
+
+func (p *politeness) host(name string, maxSize int) *hostPoliteness {
+	host, ok := p.hosts[name]
+	if !ok {
+		host = &hostPoliteness{pageSize: maxSize}
+		p.hosts[name] = host
+	}
+	return host
+}
+
+func (p *politeness) ip(addr string, maxInflight int) *ipPoliteness {
+	ip, ok := p.ips[addr]
+	if !ok {
+		ip = &ipPoliteness{limit: maxInflight, wake: make(chan struct{})}
+		p.ips[addr] = ip
+	}
+	ip.limit = max(1, min(ip.limit, maxInflight)) // per_ip may have been lowered by a config reload
+	return ip
+}
+
+// pageSize returns the page size to request from the server next
+func (p *politeness) pageSize(name string, maxSize, minSize int) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	host := p.host(name, maxSize)
+	host.pageSize = max(minSize, min(host.pageSize, maxSize))
+	return host.pageSize
+}
+
+// backoff returns how long the server asked us to stay away, 0 when it's fair game
+func (p *politeness) backoff(name string) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	host, ok := p.hosts[name]
+	if !ok {
+		return 0
+	}
+	return max(0, time.Until(host.retryAfter))
+}
+
+// observe adapts the server's page size to the outcome: double on a fast page, halve on a slow or failed one
+func (p *politeness) observe(name string, outcome pageOutcome, retryAfter time.Duration, maxSize, minSize int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	host := p.host(name, maxSize)
+	switch outcome {
+	case pageFast:
+		host.pageSize = min(host.pageSize*2, maxSize)
+	case pageSlow, pageFailed:
+		host.pageSize = max(host.pageSize/2, minSize)
+	case pageLimited:
+		host.pageSize = max(host.pageSize/2, minSize)
+		host.retryAfter = time.Now().Add(retryAfter)
+	}
+}
+
+// acquire blocks until the IP has a free slot, false if ctx is done first
+func (p *politeness) acquire(ctx context.Context, addr string, maxInflight int) bool {
+	for {
+		p.mu.Lock()
+		ip := p.ip(addr, maxInflight)
+		if ip.inflight < ip.limit {
+			ip.inflight++
+			p.mu.Unlock()
+			return true
+		}
+		wake := ip.wake
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-wake:
+		}
+	}
+}
+
+// release frees the IP slot and adapts the IP's concurrency: +1 on a healthy page, halved on errors and 429s
+func (p *politeness) release(addr string, outcome pageOutcome, maxInflight int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ip := p.ip(addr, maxInflight)
+	ip.inflight = max(0, ip.inflight-1)
+	switch outcome {
+	case pageFast, pageOK:
+		ip.limit = min(ip.limit+1, maxInflight)
+	case pageFailed, pageLimited:
+		ip.limit = max(1, ip.limit/2)
+	}
+	close(ip.wake)
+	ip.wake = make(chan struct{})
+}
+
+// sleepCtx waits for d, false if ctx is done first (or would be, its deadline comes before d is up)
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
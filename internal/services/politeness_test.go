@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// a 429 must be told apart from a plain failure, and carry the wait it asked for (or a sane default when it didn't ask).
+func TestClassifyPage(t *testing.T) {
+	tests := []struct {
+		name      string
+		took      time.Duration
+		err       error
+		want      pageOutcome
+		wantAfter time.Duration
+	}{
+		{"fast", time.Second, nil, pageFast, 0},
+		{"ok", 30 * time.Second, nil, pageOK, 0},
+		{"slow", 2 * time.Minute, nil, pageSlow, 0},
+		{"failed", time.Second, errors.New("boom"), pageFailed, 0},
+		{"limited with wait", time.Second, &model.MatrixError{Code: "M_LIMIT_EXCEEDED", RetryAfterMs: 3000}, pageLimited, 3 * time.Second},
+		{"limited for ages", time.Second, &model.MatrixError{Code: "M_LIMIT_EXCEEDED", RetryAfterMs: 1 << 62}, pageLimited, maxRetryAfter},
+		{"limited without wait", time.Second, &model.MatrixError{HTTP: "429 Too Many Requests", Code: "M_UNKNOWN"}, pageLimited, defaultRetryAfter},
+		{"other matrix error", time.Second, &model.MatrixError{HTTP: "403 Forbidden", Code: "M_FORBIDDEN"}, pageFailed, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, after := classifyPage(tt.took, tt.err)
+			if got != tt.want || after != tt.wantAfter {
+				t.Errorf("got (%v, %v), want (%v, %v)", got, after, tt.want, tt.wantAfter)
+			}
+		})
+	}
+}
+
+// page size halves on trouble down to the floor, doubles back on fast pages up to the ceiling, and a 429 sets a do-not-knock deadline.
+func TestPoliteness_PageSizeAdapts(t *testing.T) {
+	p := newPoliteness()
+	const name = "small.example"
+
+	if got := p.pageSize(name, 1000, 100); got != 1000 {
+		t.Fatalf("fresh server must start at the ceiling, got %d", got)
+	}
+	p.observe(name, pageSlow, 0, 1000, 100)
+	p.observe(name, pageFailed, 0, 1000, 100)
+	if got := p.pageSize(name, 1000, 100); got != 250 {
+		t.Errorf("after slow+failed: got %d, want 250", got)
+	}
+	for range 5 {
+		p.observe(name, pageFailed, 0, 1000, 100)
+	}
+	if got := p.pageSize(name, 1000, 100); got != 100 {
+		t.Errorf("must not drop below the floor, got %d", got)
+	}
+	for range 5 {
+		p.observe(name, pageFast, 0, 1000, 100)
+	}
+	if got := p.pageSize(name, 1000, 100); got != 1000 {
+		t.Errorf("must not grow past the ceiling, got %d", got)
+	}
+
+	if p.backoff(name) != 0 {
+		t.Error("no 429 yet, backoff must be zero")
+	}
+	p.observe(name, pageLimited, time.Hour, 1000, 100)
+	if wait := p.backoff(name); wait < 59*time.Minute {
+		t.Errorf("429 must set the backoff deadline, got %v", wait)
+	}
+}
+
+// the per-IP cap holds while slots are taken, halves on errors, and grows back one at a time.
+func TestPoliteness_PerIPCap(t *testing.T) {
+	p := newPoliteness()
+	ctx := context.Background()
+	const ip = "192.0.2.1"
+
+	for range 2 {
+		if !p.acquire(ctx, ip, 2) {
+			t.Fatal("acquire under the cap must succeed")
+		}
+	}
+	blocked, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if p.acquire(blocked, ip, 2) {
+		t.Fatal("acquire over the cap must block until ctx is done")
+	}
+
+	p.release(ip, pageFailed, 2) // limit 2 -> 1, inflight 2 -> 1
+	blocked2, cancel2 := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel2()
+	if p.acquire(blocked2, ip, 2) {
+		t.Fatal("a failure must shrink the cap, the freed slot is not up for grabs")
+	}
+
+	p.release(ip, pageFast, 2) // limit 1 -> 2, inflight 1 -> 0
+	if !p.acquire(ctx, ip, 2) || !p.acquire(ctx, ip, 2) {
+		t.Fatal("healthy pages must grow the cap back")
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/etkecc/go-apm"
+	"github.com/etkecc/go-kit"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// roomCollector stores rooms as usual, but remembers them too
+type roomCollector struct {
+	DataRepository
+	mu    sync.Mutex
+	rooms []*model.MatrixRoom
+}
+
+// AddRoomBatch adds the room to the batch and to the list
+func (c *roomCollector) AddRoomBatch(ctx context.Context, room *model.MatrixRoom) {
+	c.mu.Lock()
+	c.rooms = append(c.rooms, room)
+	c.mu.Unlock()
+	c.DataRepository.AddRoomBatch(ctx, room)
+}
+
+// RefreshServer re-discovers the server and re-parses its public rooms right away, intended for HTTP API.
+// returns http status code to send to the admin, the server and its refreshed rooms (except banned and quarantined)
+func (m *Crawler) RefreshServer(ctx context.Context, name string) (int, *model.MatrixServer, []*model.MatrixRoom) {
+	log := apm.Log(ctx)
+	server := m.discoverServer(ctx, name)
+	if !server.Online {
+		m.data.MarkServersOffline(ctx, []string{server.Name})
+		return http.StatusUnprocessableEntity, server, nil
+	}
+	if !server.Indexable {
+		return http.StatusOK, server, []*model.MatrixRoom{}
+	}
+
+	// same crawler, but the rooms are collected on the way to the repository
+	collector := &roomCollector{DataRepository: m.data}
+	refresher := NewCrawler(m.cfg, m.fed, m.v, m.block, m.media, collector, m.detector)
+	refresher.polite = m.polite
+	discovered := refresher.parseServer(ctx, server.Name, server.Policy)
+	m.data.FlushRoomBatch(ctx)
+	if err := m.data.BatchServers(ctx, discovered.Slice()); err != nil {
+		log.Warn().Err(err).Msg("cannot persist discovered servers for next cycle")
+	}
+
+	rooms := make([]*model.MatrixRoom, 0, len(collector.rooms))
+	for _, room := range collector.rooms {
+		if !m.data.IsBanned(ctx, room.ID) && !m.data.IsQuarantined(ctx, room.ID) {
+			room.Parents = m.roomParents(ctx, room.ID) // as EachRoom does for the indexing
+			rooms = append(rooms, room)
+		}
+	}
+	log.Info().Str("server", server.Name).Int("rooms", len(rooms)).Msg("server has been refreshed")
+	return http.StatusOK, server, rooms
+}
+
+// RefreshRoom re-reads the room's summary (MSC3266) via its server right away, intended for HTTP API.
+// returns http status code to send to the admin and the refreshed room.
+// 410 means the room must not be indexed anymore (e.g., noindex in its topic or its server's policy) and has been removed,
+// 202 means the room is refreshed, but quarantined, so it must stay out of the index
+func (m *Crawler) RefreshRoom(ctx context.Context, roomIDorAlias string) (int, *model.MatrixRoom) {
+	roomID := roomIDorAlias
+	if utils.IsValidAlias(roomIDorAlias) {
+		if mapped := m.data.GetRoomMapping(ctx, roomIDorAlias); mapped != "" {
+			roomID = mapped
+		}
+	}
+	existing, err := m.data.GetRoom(ctx, roomID)
+	if err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("room", roomID).Msg("cannot get room")
+	}
+	var via string
+	if existing != nil {
+		via = existing.GetOwnServer()
+	}
+
+	code, entry := m.fed.GetAdminRoomSummary(ctx, roomID, via) // a quarantined room is refreshed too, see below
+	if entry == nil {
+		return code, nil
+	}
+	if via == "" {
+		via = utils.ServerFrom(entry.ID)
+	}
+	room := entry.Convert(via)
+	if existing != nil {
+		room.Servers = existing.Servers
+		room.Network = existing.Network
+	}
+	server, err := m.data.GetServerInfo(ctx, room.Server)
+	if err != nil {
+		apm.Log(ctx).Warn().Err(err).Str("server", room.Server).Msg("cannot get server info")
+	}
+	var policy *model.MatrixServerPolicy
+	if server != nil {
+		policy = server.Policy // as the crawl applies it, see getPublicRooms
+	}
+	if policy.IsNoindex() || !m.v.IsRoomAllowed(room) {
+		m.data.RemoveRooms(ctx, []string{room.ID})
+		return http.StatusGone, room
+	}
+	policy.Apply(room)
+	if !room.Parse(m.detector, m.media, m.cfg.Get().Matrix.ServerName) {
+		m.data.RemoveRooms(ctx, []string{room.ID})
+		return http.StatusGone, room
+	}
+	if aliasServers := m.verifyAlias(ctx, room); len(aliasServers) > 0 {
+		room.Servers = kit.Uniq(append(room.AllServers(), aliasServers...))
+	}
+
+	m.data.AddRoomBatch(ctx, room)
+	m.data.FlushRoomBatch(ctx)
+	if room.AliasVerification.IsVerified() {
+		m.data.AddRoomMapping(ctx, room.ID, room.Alias) //nolint:errcheck // ignore error
+	}
+	if m.data.IsQuarantined(ctx, room.ID) {
+		return http.StatusAccepted, room
+	}
+	room.Parents = m.roomParents(ctx, room.ID) // as EachRoom does for the indexing
+	return http.StatusOK, room
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// the summary is asked via the room's own server, and what the directory knew about the room survives the refresh.
+func TestRefreshRoom(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	fed := NewMockFederationService(t)
+	v := NewMockValidatorService(t)
+	data := NewMockDataRepository(t)
+	cfg.EXPECT().Get().Return(&model.Config{Matrix: &model.ConfigMatrix{ServerName: "mrs.example"}}).Maybe()
+
+	existing := &model.MatrixRoom{ID: "!r:known.example", Server: "known.example", Servers: []string{"other.example"}, Topic: "old"}
+	data.EXPECT().GetRoom(mock.Anything, "!r:known.example").Return(existing, nil)
+	fed.EXPECT().GetAdminRoomSummary(mock.Anything, "!r:known.example", "known.example").
+		Return(http.StatusOK, &model.RoomDirectoryRoom{ID: "!r:known.example", Name: "Test", Topic: "new (MRS-language:EN-MRS)", Members: 42})
+	data.EXPECT().GetServerInfo(mock.Anything, "known.example").Return(&model.MatrixServer{Name: "known.example"}, nil)
+	v.EXPECT().IsRoomAllowed(mock.Anything).Return(true)
+	var stored *model.MatrixRoom
+	data.EXPECT().AddRoomBatch(mock.Anything, mock.Anything).Run(func(_ context.Context, room *model.MatrixRoom) { stored = room }).Return()
+	data.EXPECT().FlushRoomBatch(mock.Anything).Return()
+	data.EXPECT().IsQuarantined(mock.Anything, "!r:known.example").Return(false)
+	data.EXPECT().GetRoomParents(mock.Anything, "!r:known.example").Return([]string{"!space:known.example"}, nil)
+
+	m := NewCrawler(cfg, fed, v, nil, nil, data, nil)
+	code, room := m.RefreshRoom(context.Background(), "!r:known.example")
+
+	if code != http.StatusOK || room == nil || room != stored {
+		t.Fatalf("RefreshRoom() = %d, %+v, want 200 and the stored room", code, room)
+	}
+	if room.Topic != "new" || room.Members != 42 || room.Server != "known.example" || !slices.Contains(room.Servers, "other.example") {
+		t.Errorf("refreshed room = %+v", room)
+	}
+	if !slices.Equal(room.Parents, []string{"!space:known.example"}) {
+		t.Errorf("refreshed room parents = %v, want the space, as the indexing has them", room.Parents)
+	}
+}
+
+// a quarantined room is refreshed all the same, the summary is read past the quarantine, but it stays out of the index.
+func TestRefreshRoom_Quarantined(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	fed := NewMockFederationService(t)
+	v := NewMockValidatorService(t)
+	data := NewMockDataRepository(t)
+	cfg.EXPECT().Get().Return(&model.Config{Matrix: &model.ConfigMatrix{ServerName: "mrs.example"}}).Maybe()
+
+	data.EXPECT().GetRoom(mock.Anything, "!r:known.example").Return(nil, nil)
+	fed.EXPECT().GetAdminRoomSummary(mock.Anything, "!r:known.example", "").
+		Return(http.StatusOK, &model.RoomDirectoryRoom{ID: "!r:known.example", Name: "Test", Topic: "(MRS-language:EN-MRS)", Members: 42})
+	data.EXPECT().GetServerInfo(mock.Anything, "known.example").Return(&model.MatrixServer{Name: "known.example"}, nil)
+	v.EXPECT().IsRoomAllowed(mock.Anything).Return(true)
+	data.EXPECT().AddRoomBatch(mock.Anything, mock.Anything).Return().Once()
+	data.EXPECT().FlushRoomBatch(mock.Anything).Return()
+	data.EXPECT().IsQuarantined(mock.Anything, "!r:known.example").Return(true)
+
+	m := NewCrawler(cfg, fed, v, nil, nil, data, nil)
+	if code, room := m.RefreshRoom(context.Background(), "!r:known.example"); code != http.StatusAccepted || room == nil || room.Members != 42 {
+		t.Fatalf("RefreshRoom() = %d, %+v, want 202 and the refreshed room", code, room)
+	}
+}
+
+// a refreshed room goes through the flag rules, as it would with the next indexing.
+func TestDataFacade_RefreshRoom_Flagged(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{Flags: []*model.FlagRule{
+		{Name: "crypto", Action: model.FlagActionQuarantine, Words: []string{"airdrop"}},
+	}})
+	data := NewMockDataRepository(t)
+	data.EXPECT().GetAuditLog(mock.Anything, mock.Anything).Return(nil, nil)
+	data.EXPECT().GetRoomBan(mock.Anything, "!r:known.example").Return(nil, nil).Maybe()
+	data.EXPECT().GetRoomQuarantine(mock.Anything, "!r:known.example").Return(nil, nil).Maybe()
+	data.EXPECT().GetRoomReports(mock.Anything, "!r:known.example").Return(nil, nil).Maybe()
+	data.EXPECT().QuarantineRoom(mock.Anything, mock.MatchedBy(func(q *model.RoomQuarantine) bool {
+		return q.RoomID == "!r:known.example" && q.Moderator == "rule:crypto"
+	})).Return(nil).Once()
+	data.EXPECT().AddAuditEntry(mock.Anything, mock.Anything).Return(nil).Maybe()
+	index := NewMockIndexRepository(t)
+	index.EXPECT().Delete(mock.Anything).Return(nil).Maybe()
+	crawler := newMockdataCrawlerService(t)
+	crawler.EXPECT().RefreshRoom(mock.Anything, "!r:known.example").
+		Return(http.StatusOK, &model.MatrixRoom{ID: "!r:known.example", Name: "Free airdrop", Members: 42})
+	dataIndex := newMockdataIndexService(t)
+	dataIndex.EXPECT().DeleteRoom("!r:known.example").Return(nil).Once()
+
+	df := NewDataFacade(crawler, dataIndex, nil, NewModeration(cfg, data, nil, index, nil, nil, nil))
+	if code, _ := df.RefreshRoom(context.Background(), "!r:known.example"); code != http.StatusAccepted {
+		t.Fatalf("RefreshRoom() = %d, want 202", code)
+	}
+}
+
+// a room that opted out since the last parse goes away right now, not with the next full cycle.
+func TestRefreshRoom_Noindex(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	fed := NewMockFederationService(t)
+	v := NewMockValidatorService(t)
+	data := NewMockDataRepository(t)
+	cfg.EXPECT().Get().Return(&model.Config{Matrix: &model.ConfigMatrix{ServerName: "mrs.example"}}).Maybe()
+
+	data.EXPECT().GetRoom(mock.Anything, "!r:known.example").Return(nil, nil)
+	fed.EXPECT().GetAdminRoomSummary(mock.Anything, "!r:known.example", "").
+		Return(http.StatusOK, &model.RoomDirectoryRoom{ID: "!r:known.example", Topic: "(MRS-noindex:true-MRS)"})
+	data.EXPECT().GetServerInfo(mock.Anything, "known.example").Return(nil, nil)
+	v.EXPECT().IsRoomAllowed(mock.Anything).Return(true)
+	data.EXPECT().RemoveRooms(mock.Anything, []string{"!r:known.example"}).Return().Once()
+
+	m := NewCrawler(cfg, fed, v, nil, nil, data, nil)
+	if code, _ := m.RefreshRoom(context.Background(), "!r:known.example"); code != http.StatusGone {
+		t.Fatalf("RefreshRoom() = %d, want 410", code)
+	}
+}
+
+// a refresh applies the server's stored policy as the crawl does: noindex removes the room, the rest shapes it.
+func TestRefreshRoom_ServerPolicy(t *testing.T) {
+	cfg := NewMockConfigService(t)
+	fed := NewMockFederationService(t)
+	v := NewMockValidatorService(t)
+	data := NewMockDataRepository(t)
+	cfg.EXPECT().Get().Return(&model.Config{Matrix: &model.ConfigMatrix{ServerName: "mrs.example"}}).Maybe()
+
+	data.EXPECT().GetRoom(mock.Anything, mock.Anything).Return(nil, nil)
+	fed.EXPECT().GetAdminRoomSummary(mock.Anything, "!r:optout.example", "").
+		Return(http.StatusOK, &model.RoomDirectoryRoom{ID: "!r:optout.example", Name: "Test"})
+	data.EXPECT().GetServerInfo(mock.Anything, "optout.example").
+		Return(&model.MatrixServer{Name: "optout.example", Policy: &model.MatrixServerPolicy{Noindex: true}}, nil)
+	data.EXPECT().RemoveRooms(mock.Anything, []string{"!r:optout.example"}).Return().Once()
+
+	m := NewCrawler(cfg, fed, v, nil, nil, data, nil)
+	if code, _ := m.RefreshRoom(context.Background(), "!r:optout.example"); code != http.StatusGone {
+		t.Fatalf("RefreshRoom() of a noindex server's room = %d, want 410", code)
+	}
+
+	fed.EXPECT().GetAdminRoomSummary(mock.Anything, "!r:shy.example", "").
+		Return(http.StatusOK, &model.RoomDirectoryRoom{ID: "!r:shy.example", Name: "Test", Avatar: "mxc://shy.example/avatar"})
+	data.EXPECT().GetServerInfo(mock.Anything, "shy.example").
+		Return(&model.MatrixServer{Name: "shy.example", Policy: &model.MatrixServerPolicy{NoindexAvatars: true, Language: "DE"}}, nil)
+	v.EXPECT().IsRoomAllowed(mock.Anything).Return(true)
+	data.EXPECT().AddRoomBatch(mock.Anything, mock.Anything).Return()
+	data.EXPECT().FlushRoomBatch(mock.Anything).Return()
+	data.EXPECT().IsQuarantined(mock.Anything, "!r:shy.example").Return(false)
+	data.EXPECT().GetRoomParents(mock.Anything, "!r:shy.example").Return(nil, nil)
+
+	code, room := m.RefreshRoom(context.Background(), "!r:shy.example")
+	if code != http.StatusOK || room.Avatar != "" || room.AvatarURL != "" || room.Language != "DE" {
+		t.Fatalf("RefreshRoom() = %d, %+v, want 200 without the avatar and with the policy's language", code, room)
+	}
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/etkecc/go-apm"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// retentionSample is how many IDs the retention logs show, a mass removal must not become a mass log line
+const retentionSample = 20
+
+// retentionAllows decides whether the stale rooms or servers (kind) may actually be removed:
+// nothing is removed in dry run, and a run that wants to remove too much of everything (total) is most likely
+// a degraded one (network outage, resolver down), not the end of the world
+func (m *Crawler) retentionAllows(ctx context.Context, kind string, ids []string, total int) bool {
+	log := apm.Log(ctx)
+	retention := m.cfg.Get().Retention
+	if retention.IsDryRun() {
+		log.Info().Str("kind", kind).Int("count", len(ids)).Int("of", total).Strs("sample", sampleIDs(ids)).Msg("retention dry run, would remove")
+		return false
+	}
+
+	maxRemoval := retention.GetMaxRemoval()
+	if exceedsMaxRemoval(len(ids), total, maxRemoval) {
+		log.Error().
+			Str("kind", kind).
+			Int("count", len(ids)).
+			Int("of", total).
+			Int("max_removal", maxRemoval).
+			Msg("refusing mass removal, the run looks degraded")
+		return false
+	}
+	return true
+}
+
+// sampleIDs returns the first retentionSample IDs, enough to check what's going on without flooding the logs
+func sampleIDs(ids []string) []string {
+	return ids[:min(len(ids), retentionSample)]
+}
+
+// exceedsMaxRemoval returns true if removing count of total is more than maxRemoval percent of it, nothing is too much of nothing
+func exceedsMaxRemoval(count, total, maxRemoval int) bool {
+	return total > 0 && count*100 > total*maxRemoval
+}
+
+// offlineBackoff is the minimum gap between dials of an offline server, widening the longer it's been dead,
+// following the retention backoff steps (sorted by After). Dialed every run before the first step, to catch a quick recovery
+func offlineBackoff(age time.Duration, steps []*model.ConfigRetentionBackoff) time.Duration {
+	if age < 0 {
+		age = 0 // a backwards clock step is not an invitation to resurrect the whole graveyard for a redial. floor it.
+	}
+	var gap time.Duration
+	for _, step := range steps {
+		if age < time.Duration(step.After)*24*time.Hour {
+			break
+		}
+		gap = time.Duration(step.Every) * 24 * time.Hour
+	}
+	return gap
+}
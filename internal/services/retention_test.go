@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// the safety valve is the only thing between a resolver outage and an empty index.
+func TestRetentionAllows(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention *model.ConfigRetention
+		remove    int
+		total     int
+		want      bool
+	}{
+		{"defaults, a few stale rooms", nil, 3, 100, true},
+		{"defaults, exactly at the limit", nil, 30, 100, true},
+		{"defaults, degraded run", nil, 31, 100, false},
+		{"custom limit", &model.ConfigRetention{MaxRemoval: 10}, 11, 100, false},
+		{"valve disabled", &model.ConfigRetention{MaxRemoval: 100}, 100, 100, true},
+		{"dry run", &model.ConfigRetention{DryRun: true}, 1, 100, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewMockConfigService(t)
+			cfg.EXPECT().Get().Return(&model.Config{Retention: tt.retention})
+			m := &Crawler{cfg: cfg}
+
+			ids := make([]string, tt.remove)
+			if got := m.retentionAllows(context.Background(), "rooms", ids, tt.total); got != tt.want {
+				t.Errorf("retentionAllows(%d of %d) = %v, want %v", tt.remove, tt.total, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSampleIDs(t *testing.T) {
+	if got := sampleIDs([]string{"a", "b"}); len(got) != 2 {
+		t.Errorf("sampleIDs(2) = %v, want all of them", got)
+	}
+	if got := sampleIDs(make([]string, 1000)); len(got) != retentionSample {
+		t.Errorf("sampleIDs(1000) has %d IDs, want %d", len(got), retentionSample)
+	}
+}
+
+// custom steps may come in any order, and an explicitly empty schedule dials offline servers every run.
+func TestOfflineBackoff_CustomSteps(t *testing.T) {
+	day := 24 * time.Hour
+	steps := (&model.ConfigRetention{Backoff: []*model.ConfigRetentionBackoff{{After: 10, Every: 5}, {After: 2, Every: 1}}}).GetBackoff()
+	if got := offlineBackoff(1*day, steps); got != 0 {
+		t.Errorf("1d = %v, want 0", got)
+	}
+	if got := offlineBackoff(3*day, steps); got != day {
+		t.Errorf("3d = %v, want 1d", got)
+	}
+	if got := offlineBackoff(20*day, steps); got != 5*day {
+		t.Errorf("20d = %v, want 5d", got)
+	}
+
+	empty := (&model.ConfigRetention{Backoff: []*model.ConfigRetentionBackoff{}}).GetBackoff()
+	if got := offlineBackoff(20*day, empty); got != 0 {
+		t.Errorf("empty schedule, 20d = %v, want 0", got)
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+// CheckRoomConfig shows how MRS understands a room config: of the topic as is, or of the room's live topic.
+// intended for HTTP API, returns http status code to send to the room admin
+func (m *Crawler) CheckRoomConfig(ctx context.Context, req *model.RoomConfigCheckRequest) (int, *model.RoomConfigCheck) {
+	room := &model.MatrixRoom{Topic: req.Topic}
+	if req.Room != "" {
+		code, entry := m.fed.GetClientRoomSummary(ctx, req.Room, "", true)
+		if entry == nil {
+			return code, nil
+		}
+		room = entry.Convert(utils.ServerFrom(entry.ID))
+	}
+
+	_, cfg, warnings := model.CheckRoomConfig(utils.Sanitize(room.Topic))
+	check := &model.RoomConfigCheck{RoomID: room.ID, Config: cfg, Warnings: warnings}
+	// exactly as the room would be parsed, legacy options and language detection included
+	if room.Parse(m.detector, m.media, m.cfg.Get().Matrix.ServerName) {
+		check.Language = room.Language
+	}
+	check.Topic = room.Topic
+	return http.StatusOK, check
+}
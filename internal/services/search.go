@@ -28,6 +28,7 @@ type Search struct {
 
 type searchDataRepository interface {
 	GetBiggestRooms(ctx context.Context, limit, offset int) []*model.MatrixRoom
+	GetTrendingRooms(ctx context.Context, window, limit, offset int) []*model.TrendingRoom
 }
 
 // SearchRepository interface
@@ -65,9 +66,9 @@ func NewSearch(cfg ConfigService, data searchDataRepository, repo SearchReposito
 	return s
 }
 
-// Search things
+// Search things, narrowed down to the network if set ("matrix" for native rooms), or to the network: filter of the query
 // ref: https://blevesearch.com/docs/Query-String-Query/
-func (s *Search) Search(ctx context.Context, req *http.Request, q, sortBy string, roomTypes []string, limit, offset int) ([]*model.Entry, int, error) {
+func (s *Search) Search(ctx context.Context, req *http.Request, q, network, sortBy string, roomTypes []string, limit, offset int) ([]*model.Entry, int, error) {
 	log := apm.Log(ctx)
 	originServer := mcontext.GetOrigin(ctx)
 	highlights := s.availableHighlights(originServer)
@@ -87,15 +88,21 @@ func (s *Search) Search(ctx context.Context, req *http.Request, q, sortBy string
 	}
 
 	var builtQuery query.Query
-	if q == "" {
+	if q == "" && !strings.Contains(sortBy, "first_seen") {
 		// empty query is a directory listing (biggest rooms); track it as a Search too, or federation
 		// publicRooms browsing without a filter stays invisible, which is most of the directory traffic.
 		s.trackSearch(ctx, req, "")
-		entries, length := s.getEmptyQueryResults(ctx, roomTypes, limit, offset)
+		entries, length := s.getEmptyQueryResults(ctx, roomTypes, network, limit, offset)
 		entries = s.addHighlights(originServer, entries)
 		return entries, length, nil
 	}
 	q, fields, fuzzy := s.matchFields(q)
+	if filter, ok := fields["network"]; ok { // not a field query, see withNetwork
+		if network == "" {
+			network = filter
+		}
+		delete(fields, "network")
+	}
 	q = strings.TrimPrefix(strings.TrimSpace(q), "#")
 	qTrack := strings.TrimSpace(strings.ToLower(q))
 	if qTrack != "" {
@@ -103,6 +110,11 @@ func (s *Search) Search(ctx context.Context, req *http.Request, q, sortBy string
 	}
 
 	builtQuery = s.getSearchQuery(q, fields, roomTypes, fuzzy)
+	if builtQuery == nil && q == "" && len(fields) == 0 {
+		// "new rooms" listing: the biggest rooms shortcut knows one order only, so ask the index for everything
+		builtQuery = bleve.NewMatchAllQuery()
+	}
+	builtQuery = s.withNetwork(builtQuery, network)
 	if builtQuery == nil {
 		return []*model.Entry{}, 0, nil
 	}
@@ -180,15 +192,64 @@ func (s *Search) addHighlights(originServer string, entries []*model.Entry) []*m
 	return entries
 }
 
-func (s *Search) getEmptyQueryResults(ctx context.Context, roomTypes []string, limit, offset int) (entries []*model.Entry, length int) {
+func (s *Search) getEmptyQueryResults(ctx context.Context, roomTypes []string, network string, limit, offset int) (entries []*model.Entry, length int) {
 	total := s.stats.Get().Rooms.Indexed
+	getRooms := s.emptyQueryRooms(ctx)
 
-	if len(roomTypes) == 0 {
-		return s.biggestRoomsEntries(ctx, limit, offset), total
+	if len(roomTypes) == 0 && network == "" {
+		return s.biggestRoomsEntries(ctx, getRooms, limit, offset), total
 	}
 
 	includeRegular, allowed := parseRoomTypeFilter(roomTypes)
-	return s.filteredBiggestRoomsEntries(ctx, includeRegular, allowed, limit, offset), total
+	match := func(room *model.MatrixRoom) bool {
+		if network != "" && !strings.EqualFold(room.GetNetwork(), network) {
+			return false
+		}
+		return len(roomTypes) == 0 || s.roomTypeMatches(room.RoomType, includeRegular, allowed)
+	}
+	return s.filteredBiggestRoomsEntries(ctx, getRooms, match, limit, offset), total
+}
+
+// emptyQueryRooms returns the source of the empty-query directory: the biggest rooms,
+// or the trending list of the configured window for federation requests
+func (s *Search) emptyQueryRooms(ctx context.Context) func(ctx context.Context, limit, offset int) []*model.MatrixRoom {
+	window := s.cfg.Get().Search.Trending.Federation
+	if window == 0 || mcontext.GetOrigin(ctx) == "" {
+		return s.data.GetBiggestRooms
+	}
+
+	return func(ctx context.Context, limit, offset int) []*model.MatrixRoom {
+		trending := s.data.GetTrendingRooms(ctx, window, limit, offset)
+		rooms := make([]*model.MatrixRoom, 0, len(trending))
+		for _, item := range trending {
+			rooms = append(rooms, item.Room)
+		}
+		return rooms
+	}
+}
+
+// Trending returns rooms that gained the most members over the window (in days), 0 = the first configured window
+func (s *Search) Trending(ctx context.Context, window, limit, offset int) []*model.TrendingEntry {
+	if window == 0 {
+		window = s.cfg.Get().Search.Trending.GetWindows()[0]
+	}
+	if limit == 0 {
+		limit = s.cfg.Get().Search.Defaults.Limit
+	}
+
+	trending := s.data.GetTrendingRooms(ctx, window, limit, offset)
+	entries := make([]*model.TrendingEntry, 0, len(trending))
+	for _, item := range trending {
+		if item.Delta <= 0 { // the list is sorted, the rest didn't grow
+			break
+		}
+		entry := item.Entry(window)
+		if entry.IsBlocked(s.block) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
 }
 
 func (s *Search) roomTypeMatches(roomType string, includeRegular bool, allowed map[string]struct{}) bool {
@@ -200,8 +261,8 @@ func (s *Search) roomTypeMatches(roomType string, includeRegular bool, allowed m
 }
 
 // biggestRoomsEntries converts the paginated biggest-rooms slice into search entries.
-func (s *Search) biggestRoomsEntries(ctx context.Context, limit, offset int) []*model.Entry {
-	rooms := s.data.GetBiggestRooms(ctx, limit, offset)
+func (s *Search) biggestRoomsEntries(ctx context.Context, getRooms func(context.Context, int, int) []*model.MatrixRoom, limit, offset int) []*model.Entry {
+	rooms := getRooms(ctx, limit, offset)
 	entries := make([]*model.Entry, 0, len(rooms))
 	for _, room := range rooms {
 		entries = append(entries, room.Entry())
@@ -222,21 +283,21 @@ func parseRoomTypeFilter(roomTypes []string) (includeRegular bool, allowed map[s
 	return includeRegular, allowed
 }
 
-// filteredBiggestRoomsEntries paginates the biggest-rooms list and keeps only rooms matching the room type filter.
+// filteredBiggestRoomsEntries paginates the biggest-rooms list and keeps only rooms matching the room type and network filters.
 func (s *Search) filteredBiggestRoomsEntries(
 	ctx context.Context,
-	includeRegular bool,
-	allowed map[string]struct{},
+	getRooms func(context.Context, int, int) []*model.MatrixRoom,
+	match func(*model.MatrixRoom) bool,
 	limit, offset int,
 ) []*model.Entry {
 	entries := make([]*model.Entry, 0, limit)
 	skipped := 0
 	for fetchOffset := 0; len(entries) < limit; fetchOffset += emptyQueryBatchSize(limit) {
-		rooms := s.data.GetBiggestRooms(ctx, emptyQueryBatchSize(limit), fetchOffset)
+		rooms := getRooms(ctx, emptyQueryBatchSize(limit), fetchOffset)
 		if len(rooms) == 0 {
 			break
 		}
-		entries, skipped = s.appendFilteredRoomEntries(entries, rooms, includeRegular, allowed, limit, offset, skipped)
+		entries, skipped = s.appendFilteredRoomEntries(entries, rooms, match, limit, offset, skipped)
 	}
 	return entries
 }
@@ -250,18 +311,17 @@ func emptyQueryBatchSize(limit int) int {
 	return batchSize
 }
 
-// appendFilteredRoomEntries applies room-type filtering and offset skipping to a fetched room batch.
+// appendFilteredRoomEntries applies room filtering and offset skipping to a fetched room batch.
 func (s *Search) appendFilteredRoomEntries(
 	entries []*model.Entry,
 	rooms []*model.MatrixRoom,
-	includeRegular bool,
-	allowed map[string]struct{},
+	match func(*model.MatrixRoom) bool,
 	limit, offset, skipped int,
 ) (updatedEntries []*model.Entry, updatedSkipped int) {
 	updatedEntries = entries
 	updatedSkipped = skipped
 	for _, room := range rooms {
-		if !s.roomTypeMatches(room.RoomType, includeRegular, allowed) {
+		if !match(room) {
 			continue
 		}
 		if updatedSkipped < offset {
@@ -309,7 +369,7 @@ func (s *Search) matchFields(queryStr string) (sanitizedQuery string, fields map
 		}
 		toRemove = append(toRemove, part)
 
-		pair := strings.Split(strings.TrimSpace(part), ":")
+		pair := strings.SplitN(strings.TrimSpace(part), ":", 2) // values may have colons too, e.g. space:!id:example.com
 		if len(pair) < 2 {
 			continue
 		}
@@ -320,6 +380,10 @@ func (s *Search) matchFields(queryStr string) (sanitizedQuery string, fields map
 		queryStr = strings.ReplaceAll(queryStr, remove, "")
 	}
 	queryStr = strings.TrimSpace(queryStr)
+	if spaceID, ok := fields["space"]; ok { // space:!id is the human name of the parents field
+		fields["parents"] = spaceID
+		delete(fields, "space")
+	}
 	isFuzzy := strings.EqualFold(fields["fuzzy"], "true")
 	delete(fields, "fuzzy") // it's not a real field, but a flag, so remove it to not confuse bleve
 
@@ -411,6 +475,28 @@ func (s *Search) getFieldsQuery(fields map[string]string) query.Query {
 	return boolQ
 }
 
+// withNetwork narrows the query down to the network. Native rooms are the ones not tagged with any crawled network,
+// rather than network:"matrix", because rooms indexed before the tags existed carry none until the next full reindex
+func (s *Search) withNetwork(q query.Query, network string) query.Query {
+	if q == nil || network == "" {
+		return q
+	}
+	if !strings.EqualFold(network, model.NetworkMatrix) {
+		return combineQueries(q, s.newTermQuery(network, "network"))
+	}
+
+	names := s.cfg.Get().Networks.Names()
+	if len(names) == 0 {
+		return q
+	}
+	boolQ := bleve.NewBooleanQuery()
+	boolQ.AddMust(q)
+	for _, name := range names {
+		boolQ.AddMustNot(s.newTermQuery(name, "network"))
+	}
+	return boolQ
+}
+
 // initStopwords initializes the stopwords map from the configuration
 func (s *Search) initStopwords() {
 	s.stopwords = map[string]bool{}
@@ -7,10 +7,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/search/query"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/model/mcontext"
 )
 
 var testRooms = []*model.MatrixRoom{
@@ -138,6 +140,21 @@ func TestMatchFields_OnlyFields(t *testing.T) {
 	}
 }
 
+// room IDs carry a colon of their own, space: must keep it and land on the indexed parents field.
+func TestMatchFields_SpaceFilter(t *testing.T) {
+	env := newTestSearchService(t)
+	q, fields, _ := env.svc.matchFields("space:!abc:example.com bridges")
+	if q != "bridges" {
+		t.Errorf("q = %q, want 'bridges'", q)
+	}
+	if fields["parents"] != "!abc:example.com" {
+		t.Errorf("fields[parents] = %q, want '!abc:example.com'", fields["parents"])
+	}
+	if _, ok := fields["space"]; ok {
+		t.Error("fields[space] must be renamed to parents, bleve has no such field")
+	}
+}
+
 func TestShouldReject_BlockedQuery(t *testing.T) {
 	env := newTestSearchService(t)
 	if !env.svc.shouldReject("badword", nil) {
@@ -215,6 +232,36 @@ func TestGetSearchQuery_QueryWithFields(t *testing.T) {
 	}
 }
 
+// native rooms are "not any crawled network", so rooms indexed before the tags existed stay listed, no regexp scans.
+func TestWithNetwork(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Networks = &model.ConfigNetworks{All: true, Instances: map[string]map[string]string{"example.com": {"libera": "irc-libera"}}}
+	cfgMock := NewMockConfigService(t)
+	cfgMock.EXPECT().Get().Return(cfg).Maybe()
+	svc := NewSearch(cfgMock, newMocksearchDataRepository(t), NewMockSearchRepository(t), NewMockBlocklistService(t), NewMockStatsService(t), NewMockPlausibleService(t))
+	base := bleve.NewMatchQuery("matrix")
+
+	if q := svc.withNetwork(base, ""); q != base {
+		t.Errorf("no network = %T, want the query as is", q)
+	}
+	if _, ok := svc.withNetwork(base, "irc-libera").(*query.ConjunctionQuery); !ok {
+		t.Error("irc-libera is not a term filter on top of the query")
+	}
+	native, ok := svc.withNetwork(base, "matrix").(*query.BooleanQuery)
+	if !ok {
+		t.Fatalf("matrix = %T, want *query.BooleanQuery", native)
+	}
+	mustNot, ok := native.MustNot.(*query.DisjunctionQuery)
+	if !ok || len(mustNot.Disjuncts) != 2 {
+		t.Fatalf("matrix must not = %#v, want irc-libera and thirdparty", native.MustNot)
+	}
+	for _, sub := range mustNot.Disjuncts {
+		if _, ok := sub.(*query.TermQuery); !ok {
+			t.Errorf("matrix must not has a %T, want term queries only", sub)
+		}
+	}
+}
+
 // TestGetSearchQuery_IncludesExactFields guards the real query path, not the bleve schema:
 // the exact-field fix lives or dies on these clauses reaching the disjunction, and the
 // repo-layer tests never walk getSearchQuery. Drop the loop in buildTextSearchQueries and
@@ -244,7 +291,7 @@ func TestGetEmptyQueryResults_NoFilter(t *testing.T) {
 	env := newTestSearchService(t)
 	env.dataMock.EXPECT().GetBiggestRooms(mock.Anything, 5, 0).Return(biggestRoomsPage(5, 0))
 
-	entries, total := env.svc.getEmptyQueryResults(context.Background(), nil, 5, 0)
+	entries, total := env.svc.getEmptyQueryResults(context.Background(), nil, "", 5, 0)
 	if len(entries) != 5 {
 		t.Errorf("len(entries) = %d, want 5", len(entries))
 	}
@@ -260,7 +307,7 @@ func TestGetEmptyQueryResults_SpacesOnly(t *testing.T) {
 
 	// TODO: Revisit filtered totals for empty-query searches.
 	// Current behavior intentionally returns all indexed rooms as total.
-	entries, total := env.svc.getEmptyQueryResults(context.Background(), []string{"m.space"}, 20, 0)
+	entries, total := env.svc.getEmptyQueryResults(context.Background(), []string{"m.space"}, "", 20, 0)
 	for _, e := range entries {
 		if e.RoomType != "m.space" {
 			t.Errorf("expected m.space, got %q for %s", e.RoomType, e.Name)
@@ -282,7 +329,7 @@ func TestGetEmptyQueryResults_RegularRoomsOnly(t *testing.T) {
 	// null in JSON -> "" in Go -> regular rooms
 	// TODO: Revisit filtered totals for empty-query searches.
 	// Current behavior intentionally returns all indexed rooms as total.
-	entries, total := env.svc.getEmptyQueryResults(context.Background(), []string{""}, 20, 0)
+	entries, total := env.svc.getEmptyQueryResults(context.Background(), []string{""}, "", 20, 0)
 	for _, e := range entries {
 		if e.RoomType == "m.space" {
 			t.Errorf("unexpected space %s in regular rooms", e.Name)
@@ -303,7 +350,7 @@ func TestGetEmptyQueryResults_BothTypes(t *testing.T) {
 
 	// [null, "m.space"] -> both regular and spaces = everything
 	// Combined filters match the entire fixture set, so this total is stable either way.
-	entries, total := env.svc.getEmptyQueryResults(context.Background(), []string{"", "m.space"}, 20, 0)
+	entries, total := env.svc.getEmptyQueryResults(context.Background(), []string{"", "m.space"}, "", 20, 0)
 	if len(entries) != len(testRooms) {
 		t.Errorf("len(entries) = %d, want %d (all rooms)", len(entries), len(testRooms))
 	}
@@ -321,8 +368,8 @@ func TestGetEmptyQueryResults_FilteredPagination(t *testing.T) {
 
 	// TODO: Revisit filtered totals for empty-query searches.
 	// Pagination is correct today; total still reflects all indexed rooms.
-	page1, total1 := env.svc.getEmptyQueryResults(context.Background(), []string{""}, 2, 0)
-	page2, total2 := env.svc.getEmptyQueryResults(context.Background(), []string{""}, 2, 2)
+	page1, total1 := env.svc.getEmptyQueryResults(context.Background(), []string{""}, "", 2, 0)
+	page2, total2 := env.svc.getEmptyQueryResults(context.Background(), []string{""}, "", 2, 2)
 
 	if len(page1) != 2 {
 		t.Errorf("page1 len = %d, want 2", len(page1))
@@ -355,7 +402,7 @@ func TestGetEmptyQueryResults_FilteredPaginationExhaust(t *testing.T) {
 
 	// TODO: Revisit filtered totals for empty-query searches.
 	// Current behavior intentionally keeps returning all indexed rooms as total.
-	entries, total := env.svc.getEmptyQueryResults(context.Background(), []string{"m.space"}, 20, 0)
+	entries, total := env.svc.getEmptyQueryResults(context.Background(), []string{"m.space"}, "", 20, 0)
 	if len(entries) != 3 {
 		t.Errorf("len(entries) = %d, want 3", len(entries))
 	}
@@ -364,7 +411,7 @@ func TestGetEmptyQueryResults_FilteredPaginationExhaust(t *testing.T) {
 	}
 
 	// Offset past all spaces -> empty page; total mismatch remains documented but non-fatal for now.
-	entries, total = env.svc.getEmptyQueryResults(context.Background(), []string{"m.space"}, 20, 3)
+	entries, total = env.svc.getEmptyQueryResults(context.Background(), []string{"m.space"}, "", 20, 3)
 	if len(entries) != 0 {
 		t.Errorf("len(entries) = %d, want 0 (past all spaces)", len(entries))
 	}
@@ -416,7 +463,7 @@ func TestSearch_EmptyQuery(t *testing.T) {
 			return biggestRoomsPage(limit, offset)
 		})
 
-	entries, total, err := env.svc.Search(context.Background(), newReq(), "", "", nil, 5, 0)
+	entries, total, err := env.svc.Search(context.Background(), newReq(), "", "", "", nil, 5, 0)
 	if err != nil {
 		t.Fatal("error:", err)
 	}
@@ -436,7 +483,7 @@ func TestSearch_EmptyQueryWithRoomTypes(t *testing.T) {
 		})
 
 	// TODO: Revisit filtered totals for empty-query room-type searches at the service level.
-	entries, total, err := env.svc.Search(context.Background(), newReq(), "", "", []string{"m.space"}, 20, 0)
+	entries, total, err := env.svc.Search(context.Background(), newReq(), "", "", "", []string{"m.space"}, 20, 0)
 	if err != nil {
 		t.Fatal("error:", err)
 	}
@@ -457,10 +504,11 @@ func TestSearch_WithQuery(t *testing.T) {
 	env := newTestSearchService(t)
 	env.blockMock.EXPECT().ByID(mock.Anything).Return(false).Maybe()
 	env.blockMock.EXPECT().ByServer(mock.Anything).Return(false).Maybe()
+	env.blockMock.EXPECT().ByText(mock.Anything, mock.Anything).Return(false).Maybe()
 	env.repoMock.EXPECT().Search(mock.Anything, mock.Anything, 20, 0, mock.Anything).
 		Return([]*model.Entry{{ID: "!test:x", Name: "Test"}}, 1, nil)
 
-	entries, total, err := env.svc.Search(context.Background(), newReq(), "matrix", "", nil, 0, 0)
+	entries, total, err := env.svc.Search(context.Background(), newReq(), "matrix", "", "", nil, 0, 0)
 	if err != nil {
 		t.Fatal("error:", err)
 	}
@@ -474,7 +522,7 @@ func TestSearch_WithQuery(t *testing.T) {
 
 func TestSearch_BlockedQuery(t *testing.T) {
 	env := newTestSearchService(t)
-	entries, _, err := env.svc.Search(context.Background(), newReq(), "badword", "", nil, 0, 0)
+	entries, _, err := env.svc.Search(context.Background(), newReq(), "badword", "", "", nil, 0, 0)
 	if err != nil {
 		t.Fatal("error:", err)
 	}
@@ -487,6 +535,7 @@ func TestSearch_FieldOnlyQuery(t *testing.T) {
 	env := newTestSearchService(t)
 	env.blockMock.EXPECT().ByID(mock.Anything).Return(false).Maybe()
 	env.blockMock.EXPECT().ByServer(mock.Anything).Return(false).Maybe()
+	env.blockMock.EXPECT().ByText(mock.Anything, mock.Anything).Return(false).Maybe()
 
 	var capturedSort []string
 	env.repoMock.EXPECT().Search(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -495,7 +544,7 @@ func TestSearch_FieldOnlyQuery(t *testing.T) {
 			return []*model.Entry{{ID: "!test:x", Name: "Test", Language: "EN"}}, 1, nil
 		})
 
-	_, _, err := env.svc.Search(context.Background(), newReq(), "language:EN", "", nil, 0, 0)
+	_, _, err := env.svc.Search(context.Background(), newReq(), "language:EN", "", "", nil, 0, 0)
 	if err != nil {
 		t.Fatal("error:", err)
 	}
@@ -511,10 +560,41 @@ func TestSearch_FieldOnlyQuery(t *testing.T) {
 	}
 }
 
+// "new rooms" is an empty query too, but the biggest rooms shortcut can't sort by first_seen, so it must reach the index.
+func TestSearch_EmptyQueryNewRooms(t *testing.T) {
+	env := newTestSearchService(t)
+	env.blockMock.EXPECT().ByID(mock.Anything).Return(false).Maybe()
+	env.blockMock.EXPECT().ByServer(mock.Anything).Return(false).Maybe()
+	env.blockMock.EXPECT().ByText(mock.Anything, mock.Anything).Return(false).Maybe()
+
+	var capturedQuery query.Query
+	var capturedSort []string
+	env.repoMock.EXPECT().Search(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, q query.Query, _, _ int, sortBy []string) ([]*model.Entry, int, error) {
+			capturedQuery, capturedSort = q, sortBy
+			return []*model.Entry{{ID: "!new:x", Name: "New"}}, 1, nil
+		})
+
+	entries, _, err := env.svc.Search(context.Background(), newReq(), "", "", "-first_seen", nil, 0, 0)
+	if err != nil {
+		t.Fatal("error:", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("len = %d, want 1", len(entries))
+	}
+	if _, ok := capturedQuery.(*query.MatchAllQuery); !ok {
+		t.Errorf("query = %T, want match all", capturedQuery)
+	}
+	if len(capturedSort) == 0 || capturedSort[0] != "-first_seen" {
+		t.Errorf("sort = %v, want -first_seen first", capturedSort)
+	}
+}
+
 func TestSearch_TextQueryNoMembersTiebreaker(t *testing.T) {
 	env := newTestSearchService(t)
 	env.blockMock.EXPECT().ByID(mock.Anything).Return(false).Maybe()
 	env.blockMock.EXPECT().ByServer(mock.Anything).Return(false).Maybe()
+	env.blockMock.EXPECT().ByText(mock.Anything, mock.Anything).Return(false).Maybe()
 
 	var capturedSort []string
 	env.repoMock.EXPECT().Search(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -523,7 +603,7 @@ func TestSearch_TextQueryNoMembersTiebreaker(t *testing.T) {
 			return []*model.Entry{{ID: "!test:x", Name: "Test"}}, 1, nil
 		})
 
-	_, _, err := env.svc.Search(context.Background(), newReq(), "matrix", "", nil, 0, 0)
+	_, _, err := env.svc.Search(context.Background(), newReq(), "matrix", "", "", nil, 0, 0)
 	if err != nil {
 		t.Fatal("error:", err)
 	}
@@ -538,10 +618,11 @@ func TestSearch_QueryWithRoomTypesPassedToRepo(t *testing.T) {
 	env := newTestSearchService(t)
 	env.blockMock.EXPECT().ByID(mock.Anything).Return(false).Maybe()
 	env.blockMock.EXPECT().ByServer(mock.Anything).Return(false).Maybe()
+	env.blockMock.EXPECT().ByText(mock.Anything, mock.Anything).Return(false).Maybe()
 	env.repoMock.EXPECT().Search(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return([]*model.Entry{{ID: "!test:x", Name: "Test", RoomType: "m.space"}}, 1, nil)
 
-	entries, _, err := env.svc.Search(context.Background(), newReq(), "matrix", "", []string{"m.space"}, 0, 0)
+	entries, _, err := env.svc.Search(context.Background(), newReq(), "matrix", "", "", []string{"m.space"}, 0, 0)
 	if err != nil {
 		t.Fatal("error:", err)
 	}
@@ -554,10 +635,11 @@ func TestSearch_HashPrefixStripped(t *testing.T) {
 	env := newTestSearchService(t)
 	env.blockMock.EXPECT().ByID(mock.Anything).Return(false).Maybe()
 	env.blockMock.EXPECT().ByServer(mock.Anything).Return(false).Maybe()
+	env.blockMock.EXPECT().ByText(mock.Anything, mock.Anything).Return(false).Maybe()
 	env.repoMock.EXPECT().Search(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, 0, nil)
 
-	_, _, err := env.svc.Search(context.Background(), newReq(), "#postmoogle", "", nil, 0, 0)
+	_, _, err := env.svc.Search(context.Background(), newReq(), "#postmoogle", "", "", nil, 0, 0)
 	if err != nil {
 		t.Fatal("error:", err)
 	}
@@ -567,6 +649,7 @@ func TestRemoveBlocked_NoBlocked(t *testing.T) {
 	env := newTestSearchService(t)
 	env.blockMock.EXPECT().ByID(mock.Anything).Return(false).Maybe()
 	env.blockMock.EXPECT().ByServer(mock.Anything).Return(false).Maybe()
+	env.blockMock.EXPECT().ByText(mock.Anything, mock.Anything).Return(false).Maybe()
 
 	entries := []*model.Entry{
 		{ID: "!a:x", Server: "safe.com"},
@@ -670,7 +753,7 @@ func TestSearch_TracksExactlyOneEvent(t *testing.T) {
 			}).Return()
 
 			svc := NewSearch(cfgMock, dataMock, repoMock, blockMock, statsMock, plausibleMock)
-			if _, _, err := svc.Search(context.Background(), newReq(), tc.query, "", nil, 5, 0); err != nil {
+			if _, _, err := svc.Search(context.Background(), newReq(), tc.query, "", "", nil, 5, 0); err != nil {
 				t.Fatalf("Search returned error: %v", err)
 			}
 
@@ -696,3 +779,85 @@ func TestSearch_TracksExactlyOneEvent(t *testing.T) {
 		})
 	}
 }
+
+// federation directory browsing follows the configured trending window, our own UI keeps the biggest rooms.
+func TestSearch_EmptyQueryFederationTrending(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Search.Trending.Federation = 7
+	cfgMock := NewMockConfigService(t)
+	cfgMock.EXPECT().Get().Return(cfg).Maybe()
+	statsMock := NewMockStatsService(t)
+	statsMock.EXPECT().Get().Return(&model.IndexStats{Rooms: model.IndexStatsRooms{Indexed: len(testRooms)}}).Maybe()
+	plausibleMock := NewMockPlausibleService(t)
+	plausibleMock.EXPECT().Track(mock.Anything, mock.Anything).Maybe()
+	dataMock := newMocksearchDataRepository(t)
+	dataMock.EXPECT().GetTrendingRooms(mock.Anything, 7, 5, 0).
+		Return([]*model.TrendingRoom{{ID: testRooms[7].ID, Delta: 30, Room: testRooms[7]}, {ID: testRooms[0].ID, Room: testRooms[0]}})
+	dataMock.EXPECT().GetBiggestRooms(mock.Anything, 5, 0).Return(biggestRoomsPage(5, 0))
+	svc := NewSearch(cfgMock, dataMock, NewMockSearchRepository(t), NewMockBlocklistService(t), statsMock, plausibleMock)
+
+	fedCtx := mcontext.WithOrigin(context.Background(), "example.com")
+	entries, _, err := svc.Search(fedCtx, newReq(), "", "", "", nil, 5, 0)
+	if err != nil {
+		t.Fatal("error:", err)
+	}
+	if len(entries) != 2 || entries[0].ID != testRooms[7].ID {
+		t.Errorf("federation entries = %+v, want the trending list", entries)
+	}
+
+	entries, _, err = svc.Search(context.Background(), newReq(), "", "", "", nil, 5, 0)
+	if err != nil {
+		t.Fatal("error:", err)
+	}
+	if len(entries) != 5 || entries[0].ID != testRooms[0].ID {
+		t.Errorf("local entries = %+v, want the biggest rooms", entries)
+	}
+}
+
+// the network of federation publicRooms filters the trending list, it doesn't turn the empty query into a bleve search.
+func TestSearch_FederationNetworkFastPath(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Search.Trending.Federation = 7
+	cfg.Networks = &model.ConfigNetworks{All: true}
+	cfgMock := NewMockConfigService(t)
+	cfgMock.EXPECT().Get().Return(cfg).Maybe()
+	statsMock := NewMockStatsService(t)
+	statsMock.EXPECT().Get().Return(&model.IndexStats{Rooms: model.IndexStatsRooms{Indexed: 2}}).Maybe()
+	plausibleMock := NewMockPlausibleService(t)
+	plausibleMock.EXPECT().Track(mock.Anything, mock.Anything).Maybe()
+	dataMock := newMocksearchDataRepository(t)
+	native := &model.MatrixRoom{ID: "!native:example.com", Server: "example.com"}
+	bridged := &model.MatrixRoom{ID: "!bridged:example.com", Server: "example.com", Network: model.NetworkThirdParty}
+	dataMock.EXPECT().GetTrendingRooms(mock.Anything, 7, mock.AnythingOfType("int"), 0).
+		Return([]*model.TrendingRoom{{ID: bridged.ID, Delta: 30, Room: bridged}, {ID: native.ID, Delta: 10, Room: native}})
+	dataMock.EXPECT().GetTrendingRooms(mock.Anything, 7, mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(nil).Maybe()
+	svc := NewSearch(cfgMock, dataMock, NewMockSearchRepository(t), NewMockBlocklistService(t), statsMock, plausibleMock)
+
+	fedCtx := mcontext.WithOrigin(context.Background(), "example.com")
+	entries, _, err := svc.Search(fedCtx, newReq(), "", model.NetworkMatrix, "", nil, 5, 0)
+	if err != nil {
+		t.Fatal("error:", err)
+	}
+	if len(entries) != 1 || entries[0].ID != native.ID {
+		t.Errorf("native entries = %+v, want the native room only", entries)
+	}
+}
+
+// only rooms that grew are trending, blocked servers are not.
+func TestSearch_Trending(t *testing.T) {
+	env := newTestSearchService(t)
+	env.blockMock.EXPECT().ByID(mock.Anything).Return(false).Maybe()
+	env.blockMock.EXPECT().ByServer("blocked.com").Return(true).Maybe()
+	env.blockMock.EXPECT().ByServer(mock.Anything).Return(false).Maybe()
+	env.blockMock.EXPECT().ByText(mock.Anything, mock.Anything).Return(false).Maybe()
+	env.dataMock.EXPECT().GetTrendingRooms(mock.Anything, 7, 20, 0).Return([]*model.TrendingRoom{
+		{ID: "!a:etke.cc", Delta: 30, Growth: 0.5, Room: &model.MatrixRoom{ID: "!a:etke.cc", Server: "etke.cc"}},
+		{ID: "!b:blocked.com", Delta: 20, Room: &model.MatrixRoom{ID: "!b:blocked.com", Server: "blocked.com"}},
+		{ID: "!c:etke.cc", Delta: 0, Room: &model.MatrixRoom{ID: "!c:etke.cc", Server: "etke.cc"}},
+	})
+
+	entries := env.svc.Trending(context.Background(), 0, 0, 0)
+	if len(entries) != 1 || entries[0].ID != "!a:etke.cc" || entries[0].Window != 7 || entries[0].Delta != 30 {
+		t.Errorf("entries = %+v, want only !a:etke.cc over 7 days", entries)
+	}
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/etkecc/go-apm"
+	"github.com/etkecc/go-kit"
+	"github.com/goccy/go-json"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+const seedMaxSize = 10 << 20 // 10MiB of server names is a lot of server names
+
+// seedDo sends seed and policy list requests through the shared client, with its dial guard, retries, and User-Agent,
+// like every other outbound call. A var only for tests, as httptest listens on loopback, which the guard refuses
+var seedDo = utils.Do
+
+// SeedStats returns stats of the last pull of each seed source
+func (m *Crawler) SeedStats() []*model.SeedStats {
+	if stats := m.seedStats.Load(); stats != nil {
+		return *stats
+	}
+	return []*model.SeedStats{}
+}
+
+// seedSource is anything that lists server names: a seed file or url, or a peer's catalog
+type seedSource struct {
+	name  string
+	fetch func(ctx context.Context) ([]string, error)
+}
+
+// seedSources returns configured seeds, then peers
+func (m *Crawler) seedSources() []*seedSource {
+	cfg := m.cfg.Get()
+	sources := make([]*seedSource, 0, len(cfg.Seeds))
+	for _, seed := range cfg.Seeds {
+		sources = append(sources, &seedSource{
+			name:  seed.String(),
+			fetch: func(ctx context.Context) ([]string, error) { return m.fetchSeed(ctx, seed) },
+		})
+	}
+	for _, peer := range cfg.Peering.GetPeers() {
+		sources = append(sources, &seedSource{
+			name:  "peer:" + peer.ServerName,
+			fetch: func(ctx context.Context) ([]string, error) { return m.fed.QueryPeerServers(ctx, peer.ServerName) },
+		})
+	}
+	return sources
+}
+
+// addSeeds pulls every seed source into the servers list, counting what each source added on top of the list
+func (m *Crawler) addSeeds(ctx context.Context, servers *kit.List[string, string]) {
+	seeds := m.seedSources()
+	if len(seeds) == 0 {
+		return
+	}
+
+	log := apm.Log(ctx)
+	known := make(map[string]bool, servers.Len())
+	for _, name := range servers.Slice() {
+		known[name] = true
+	}
+	stats := make([]*model.SeedStats, 0, len(seeds))
+	for _, seed := range seeds {
+		stat := &model.SeedStats{Source: seed.name, At: time.Now().UTC()}
+		names, err := seed.fetch(ctx)
+		if err != nil {
+			log.Warn().Err(err).Str("seed", stat.Source).Msg("cannot pull seed source")
+			stat.Error = err.Error()
+		}
+		for _, name := range names {
+			if !m.v.Domain(name) {
+				continue
+			}
+			stat.Fetched++
+			if known[name] {
+				continue
+			}
+			known[name] = true
+			servers.Add(name)
+			stat.New++
+		}
+		log.Info().Str("seed", stat.Source).Int("fetched", stat.Fetched).Int("new", stat.New).Msg("pulled seed source")
+		stats = append(stats, stat)
+	}
+	m.seedStats.Store(&stats)
+}
+
+// fetchSeed reads the seed's list of server names from the file or url
+func (m *Crawler) fetchSeed(ctx context.Context, seed *model.ConfigSeed) ([]string, error) {
+	datab, err := readSeed(ctx, seed)
+	if err != nil {
+		return nil, err
+	}
+	return parseSeed(datab)
+}
+
+// readSeed reads the seed's raw contents from the file or url
+func readSeed(ctx context.Context, seed *model.ConfigSeed) ([]byte, error) {
+	if seed.Path != "" {
+		return os.ReadFile(seed.Path)
+	}
+	if seed.URL == "" {
+		return nil, fmt.Errorf("neither path nor url is set")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, seed.URL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if seed.Login != "" {
+		req.SetBasicAuth(seed.Login, seed.Password)
+	}
+	resp, err := seedDo(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("seed returned HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, seedMaxSize))
+}
+
+// parseSeed parses a JSON array of server names (e.g. /catalog/servers), or one server name per line with # comments
+func parseSeed(datab []byte) ([]string, error) {
+	datab = bytes.TrimSpace(datab)
+	if bytes.HasPrefix(datab, []byte("[")) {
+		var names []string
+		if err := json.Unmarshal(datab, &names); err != nil {
+			return nil, err
+		}
+		for i, name := range names {
+			names[i] = strings.ToLower(strings.TrimSpace(name))
+		}
+		return names, nil
+	}
+
+	names := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(datab))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, strings.ToLower(line))
+		}
+	}
+	return names, scanner.Err()
+}
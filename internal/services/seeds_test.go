@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/etkecc/go-kit"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+	"github.com/etkecc/mrs/internal/utils"
+)
+
+func TestParseSeed(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"lines", "example.com\n\n  Other.com  \n", []string{"example.com", "other.com"}},
+		{"comments", "# seeds\nexample.com # the first one\n", []string{"example.com"}},
+		{"json", ` ["example.com", "Other.com"]`, []string{"example.com", "other.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSeed([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parseSeed: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseSeed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// a source only gets credit for servers nobody listed before it, and a broken source doesn't break the others.
+func TestAddSeeds_PerSourceDedup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "servers.txt")
+	if err := os.WriteFile(path, []byte("known.com\nfile.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if login, password, ok := r.BasicAuth(); !ok || login != "catalog" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`["file.com", "catalog.com", "-invalid-"]`)) //nolint:errcheck // test
+	}))
+	defer srv.Close()
+	seedDo = srv.Client().Do
+	defer func() { seedDo = utils.Do }()
+
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{Seeds: []*model.ConfigSeed{
+		{Path: path},
+		{Name: "mrs", URL: srv.URL, Login: "catalog", Password: "secret"},
+		{Name: "broken", URL: srv.URL},
+	}})
+	v := NewMockValidatorService(t)
+	v.EXPECT().Domain(mock.Anything).RunAndReturn(func(name string) bool { return name != "-invalid-" })
+	m := &Crawler{cfg: cfg, v: v}
+
+	servers := kit.NewListFrom([]string{"known.com"})
+	m.addSeeds(context.Background(), servers)
+
+	if servers.Len() != 3 {
+		t.Errorf("servers = %v, want known.com, file.com, catalog.com", servers.Slice())
+	}
+	stats := m.SeedStats()
+	if len(stats) != 3 {
+		t.Fatalf("stats = %+v, want 3 sources", stats)
+	}
+	if stats[0].Source != path || stats[0].Fetched != 2 || stats[0].New != 1 {
+		t.Errorf("file stats = %+v, want 2 fetched, 1 new", stats[0])
+	}
+	if stats[1].Source != "mrs" || stats[1].Fetched != 2 || stats[1].New != 1 {
+		t.Errorf("catalog stats = %+v, want 2 fetched, 1 new", stats[1])
+	}
+	if stats[2].Error == "" || stats[2].Fetched != 0 {
+		t.Errorf("broken stats = %+v, want an error", stats[2])
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/etkecc/go-apm"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// calculateTrending ranks the rooms (biggest first, as stored) by member count change over each trending window.
+// Every room gets a place, so the list can replace the biggest rooms order of the empty-query directory
+func (m *Crawler) calculateTrending(ctx context.Context, ids []string) {
+	log := apm.Log(ctx)
+	started := time.Now().UTC()
+	var trendingCfg model.ConfigSearchTrending
+	if search := m.cfg.Get().Search; search != nil {
+		trendingCfg = search.Trending
+	}
+	windows := trendingCfg.GetWindows()
+
+	positions := make(map[string]int, len(ids))
+	for i, id := range ids {
+		positions[id] = i
+	}
+	trending := make(map[int][]*model.TrendingRoom, len(windows))
+	for _, window := range windows {
+		trending[window] = make([]*model.TrendingRoom, 0, len(ids))
+	}
+	seen := make(map[string]bool, len(ids))
+	m.data.EachRoomHistory(ctx, func(id string, history *model.RoomHistory) bool {
+		if _, ok := positions[id]; !ok {
+			return false
+		}
+		seen[id] = true
+		for _, window := range windows {
+			delta, growth := history.MembersDelta(started, time.Duration(window)*24*time.Hour)
+			trending[window] = append(trending[window], &model.TrendingRoom{ID: id, Delta: delta, Growth: growth})
+		}
+		return false
+	})
+	// rooms stored before the history was tracked have no samples yet, they keep their biggest rooms place
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		for _, window := range windows {
+			trending[window] = append(trending[window], &model.TrendingRoom{ID: id})
+		}
+	}
+	for _, rooms := range trending {
+		sortTrending(rooms, positions)
+	}
+
+	if err := m.data.SetTrendingRooms(ctx, trending); err != nil {
+		log.Error().Err(err).Msg("cannot set trending rooms")
+		return
+	}
+	log.Info().Str("took", time.Since(started).String()).Ints("windows", windows).Msg("trending rooms have been calculated and stored")
+}
+
+// sortTrending by delta, then growth, and keeps the biggest rooms order for the rest (no change at all is the common case)
+func sortTrending(rooms []*model.TrendingRoom, positions map[string]int) {
+	sort.SliceStable(rooms, func(i, j int) bool {
+		if rooms[i].Delta != rooms[j].Delta {
+			return rooms[i].Delta > rooms[j].Delta
+		}
+		if rooms[i].Growth != rooms[j].Growth {
+			return rooms[i].Growth > rooms[j].Growth
+		}
+		return positions[rooms[i].ID] < positions[rooms[j].ID]
+	})
+}
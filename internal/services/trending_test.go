@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// a room that grew this week beats a bigger one that didn't, a month-old jump only counts in the 30d window,
+// and rooms without history keep their biggest rooms place at the end.
+func TestCalculateTrending(t *testing.T) {
+	now := time.Now().UTC()
+	days := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	histories := map[string]*model.RoomHistory{
+		"!big:example.com":    {Members: []*model.RoomMembersSample{{Members: 1000, At: days(40)}, {Members: 1000, At: now}}},
+		"!week:example.com":   {Members: []*model.RoomMembersSample{{Members: 10, At: days(10)}, {Members: 50, At: days(3)}, {Members: 60, At: now}}},
+		"!month:example.com":  {Members: []*model.RoomMembersSample{{Members: 10, At: days(29)}, {Members: 200, At: days(20)}, {Members: 200, At: now}}},
+		"!banned:example.com": {Members: []*model.RoomMembersSample{{Members: 1, At: days(8)}, {Members: 9999, At: now}}},
+	}
+
+	cfg := NewMockConfigService(t)
+	cfg.EXPECT().Get().Return(&model.Config{Search: &model.ConfigSearch{}}).Maybe()
+	data := NewMockDataRepository(t)
+	data.EXPECT().EachRoomHistory(mock.Anything, mock.Anything).Run(func(_ context.Context, handler func(string, *model.RoomHistory) bool) {
+		for id, history := range histories {
+			handler(id, history)
+		}
+	}).Return()
+	var stored map[int][]*model.TrendingRoom
+	data.EXPECT().SetTrendingRooms(mock.Anything, mock.Anything).Run(func(_ context.Context, trending map[int][]*model.TrendingRoom) {
+		stored = trending
+	}).Return(nil)
+
+	m := &Crawler{cfg: cfg, data: data}
+	m.calculateTrending(context.Background(), []string{"!big:example.com", "!month:example.com", "!legacy:example.com", "!week:example.com"})
+
+	expected := map[int][]string{
+		7:  {"!week:example.com", "!big:example.com", "!month:example.com", "!legacy:example.com"},
+		30: {"!month:example.com", "!week:example.com", "!big:example.com", "!legacy:example.com"},
+	}
+	for window, ids := range expected {
+		rooms := stored[window]
+		if len(rooms) != len(ids) {
+			t.Fatalf("window %d: got %d rooms, want %d", window, len(rooms), len(ids))
+		}
+		for i, id := range ids {
+			if rooms[i].ID != id {
+				t.Errorf("window %d, position %d: got %s, want %s", window, i, rooms[i].ID, id)
+			}
+		}
+	}
+	if week := stored[7][0]; week.Delta != 50 || week.Growth != 5 {
+		t.Errorf("7d delta = %d, growth = %v, want 50 (from the sample at the window start) and 5", week.Delta, week.Growth)
+	}
+}
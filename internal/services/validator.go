@@ -81,7 +81,7 @@ func (v *Validator) IsIndexable(ctx context.Context, server string) bool {
 		log.Info().Str("reason", "blocklist").Msg("not indexable")
 		return false
 	}
-	if _, err := v.matrix.QueryPublicRooms(ctx, server, "1", ""); err != nil {
+	if _, err := v.matrix.QueryPublicRooms(ctx, server, "1", "", model.RoomDirectoryNetwork{}); err != nil {
 		log.Info().Err(err).Str("reason", "publicRooms").Msg("not indexable")
 		return false
 	}
@@ -121,6 +121,9 @@ func (v *Validator) IsRoomAllowed(room *model.MatrixRoom) bool {
 	if v.block.ByServer(room.GetOwnServer()) {
 		return false
 	}
+	if v.block.ByText(room.Name, room.Topic) {
+		return false
+	}
 	if v.isBlockedByTopic(room.Topic) {
 		return false
 	}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etkecc/go-apm"
+	"github.com/etkecc/go-kit"
+	"github.com/etkecc/go-kit/workpool"
+	"github.com/goccy/go-json"
+	"github.com/pemistahl/lingua-go"
+
+	"github.com/etkecc/mrs/internal/model"
+)
+
+// workerPoll is how long an idle worker waits before asking the coordinator for a batch again
+const workerPoll = 30 * time.Second
+
+var workerClient = &http.Client{Timeout: 5 * time.Minute} // results of a big batch take a while to upload
+
+// Worker is a stateless crawler, it processes batches the coordinator hands out and sends the results back
+type Worker struct {
+	cfg     ConfigService
+	crawler *Crawler
+	results *workerResults
+}
+
+// workerResults collects what the crawler would store, to send it to the coordinator instead. It's the crawler's store,
+// the worker has no data repository at all
+type workerResults struct {
+	mu      sync.Mutex
+	results *model.CrawlResults
+}
+
+// NewWorker service
+func NewWorker(cfg ConfigService, fedSvc FederationService, v ValidatorService, block BlocklistService, media MediaService, detector lingua.LanguageDetector) *Worker {
+	results := &workerResults{results: &model.CrawlResults{}}
+	crawler := NewCrawler(cfg, fedSvc, v, block, media, nil, detector)
+	crawler.store = results
+	return &Worker{
+		cfg:     cfg,
+		crawler: crawler,
+		results: results,
+	}
+}
+
+// Run asks the coordinator for batches until the context is canceled
+func (w *Worker) Run(ctx context.Context) {
+	log := apm.Log(ctx)
+	log.Info().Str("coordinator", w.cfg.Get().Coordinator.URL).Msg("worker started")
+	for ctx.Err() == nil {
+		batch, err := w.lease(ctx)
+		if err != nil {
+			log.Warn().Err(err).Msg("cannot lease a batch")
+		}
+		if batch == nil {
+			if !sleepCtx(ctx, workerPoll) {
+				return
+			}
+			continue
+		}
+
+		start := time.Now()
+		results := w.process(ctx, batch)
+		if err := w.submit(ctx, batch.ID, results); err != nil {
+			log.Warn().Err(err).Str("batch", batch.ID).Msg("cannot submit batch results")
+			continue
+		}
+		log.Info().Str("batch", batch.ID).Int("servers", len(batch.Servers)).Str("took", time.Since(start).String()).Msg("batch processed")
+	}
+}
+
+// process crawls servers of the batch
+func (w *Worker) process(ctx context.Context, batch *model.CrawlBatch) *model.CrawlResults {
+	w.results.reset()
+	cfg := w.cfg.Get()
+	workers := cfg.Workers.Discovery
+	if batch.Kind == model.CrawlParsing {
+		workers = cfg.Workers.Parsing
+	}
+
+	wp := workpool.New(min(workers, len(batch.Servers)))
+	discovered := kit.NewList[string, string]()
+	for _, name := range batch.Servers {
+		wp.Do(func() {
+			switch batch.Kind {
+			case model.CrawlDiscovery:
+				w.results.addServer(name, w.crawler.discoverServer(ctx, name))
+			case model.CrawlParsing:
+				discovered.AddSlice(w.crawler.parseServer(ctx, name, batch.Policies[name]).Slice())
+			}
+		})
+	}
+	wp.Run()
+
+	results := w.results.reset()
+	results.Discovered = discovered.Slice()
+	return results
+}
+
+// lease asks the coordinator for a batch, nil if there is nothing to do
+func (w *Worker) lease(ctx context.Context) (*model.CrawlBatch, error) {
+	resp, err := w.call(ctx, "/coordinator/lease", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil //nolint:nilnil // nothing to do is not an error
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("coordinator returned HTTP %d", resp.StatusCode)
+	}
+	var batch *model.CrawlBatch
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// submit sends the batch results to the coordinator
+func (w *Worker) submit(ctx context.Context, batchID string, results *model.CrawlResults) error {
+	datab, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	resp, err := w.call(ctx, "/coordinator/results/"+url.PathEscape(batchID), datab)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck // drain only
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("coordinator returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *Worker) call(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	cfg := w.cfg.Get().Coordinator
+	if cfg == nil || cfg.URL == "" {
+		return nil, fmt.Errorf("coordinator.url is not set")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(cfg.URL, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.Login != "" {
+		req.SetBasicAuth(cfg.Login, cfg.Password)
+	}
+	return workerClient.Do(req)
+}
+
+// reset returns results collected so far and starts from scratch
+func (r *workerResults) reset() *model.CrawlResults {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	results := r.results
+	r.results = &model.CrawlResults{}
+	return results
+}
+
+// addServer collects the server discovered as the batch's name
+func (r *workerResults) addServer(name string, server *model.MatrixServer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results.Servers = append(r.results.Servers, server)
+	r.results.Leased = append(r.results.Leased, name)
+}
+
+// AddServer is a no-op, the worker reports every server of the batch with its outcome, see addServer
+func (r *workerResults) AddServer(_ context.Context, _ *model.MatrixServer) error {
+	return nil
+}
+
+// AddRoomBatch collects the room
+func (r *workerResults) AddRoomBatch(_ context.Context, room *model.MatrixRoom) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results.Rooms = append(r.results.Rooms, room)
+}
+
+// AddRoomMapping is a no-op, the coordinator maps verified aliases of the rooms itself
+func (r *workerResults) AddRoomMapping(_ context.Context, _, _ string) error {
+	return nil
+}
+
+// SetSpaceChildren collects the space children
+func (r *workerResults) SetSpaceChildren(_ context.Context, spaceID string, children []*model.MatrixSpaceChild) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.results.Spaces == nil {
+		r.results.Spaces = map[string][]*model.MatrixSpaceChild{}
+	}
+	r.results.Spaces[spaceID] = children
+	return nil
+}